@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -26,6 +33,20 @@ var (
 	backupWorkers  int
 	backupConfigs  bool
 	backupTags     bool
+	backupBizMeta  bool
+	backupVerPar   int
+	backupMaxVers  int
+	backupSince    string
+	backupArchive  string
+	backupFilter   string
+	backupRegex    bool
+	backupFailFast bool
+	backupRedact   bool
+	backupCkptFile string
+	backupDeleted  bool
+
+	backupExclude        []string
+	backupExcludePattern string
 )
 
 var backupCmd = &cobra.Command{
@@ -38,7 +59,11 @@ Backup scope options:
   • Full registry backup (default)
   • Single context backup (--context)
   • Specific subjects (--subjects)
+  • Subjects matching a glob pattern (--filter, * wildcard, case-insensitive)
+    or a regular expression (--filter with --regex)
   • Backup using schema IDs (--by-id)
+  • Active subjects and versions only (--include-deleted=false); soft-deleted
+    ones are included by default and restored as soft-deleted
 
 The backup includes:
   • All schema versions with their global IDs
@@ -46,8 +71,23 @@ The backup includes:
   • Subject modes
   • Schema references
 
+Incremental backups:
+  • Use --since with a previous backup to only save new or changed versions
+  • The new backup records the backup it builds on; restore applies the chain
+
+Redaction:
+  • Use --redact-docs to strip Avro doc attributes and JSON Schema
+    description/examples from saved schemas, keeping their structure; the
+    manifest records it and restore warns that schemas differ from the source
+
+Archives:
+  • Use --archive tar.gz or --archive zip to write the backup straight into
+    a single compressed file with the same layout; restore reads it directly
+
 Multi-threading:
   • Use --workers to control parallel backup speed (default: 10)
+  • Use --parallel-versions to also fetch each subject's versions concurrently,
+    for registries dominated by a few subjects with many versions
 
 Examples:
   # Full registry backup
@@ -62,26 +102,72 @@ Examples:
   # Backup specific subjects
   srctl backup --subjects user-events,order-events --output ./backup
 
+  # Backup subjects matching a pattern
+  srctl backup --filter "events.*" --output ./backup
+
+  # Backup everything except internal subjects
+  srctl backup --exclude-pattern "_*" --output ./backup
+
   # Backup preserving schema IDs (useful for migration)
   srctl backup --by-id --output ./backup
 
   # Backup with all subject-level configs
-  srctl backup --output ./backup --configs`,
+  srctl backup --output ./backup --configs
+
+  # Backup schema structure without documentation strings
+  srctl backup --output ./backup --redact-docs
+
+  # Backup into a compressed archive
+  srctl backup --output ./backup --archive tar.gz
+
+  # Backup only the latest 5 versions of each subject
+  srctl backup --output ./backup --max-versions 5
+
+  # Backup without soft-deleted subjects and versions
+  srctl backup --output ./backup --include-deleted=false
+
+  # Incremental backup on top of a previous one
+  srctl backup --output ./backup --since ./backup/sr-backup-20240115-120000`,
 	RunE: runBackup,
 }
 
 func init() {
 	backupCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "Output directory for backup (required)")
 	backupCmd.Flags().StringSliceVar(&backupSubjects, "subjects", nil, "Specific subjects to backup (comma-separated)")
+	backupCmd.Flags().StringVar(&backupFilter, "filter", "", "Only back up subjects matching a glob pattern (e.g. \"user-*\"); combines with --subjects and --context")
+	backupCmd.Flags().StringSliceVar(&backupExclude, "exclude", nil, "Subjects to leave out of the backup (comma-separated)")
+	backupCmd.Flags().StringVar(&backupExcludePattern, "exclude-pattern", "", "Leave out subjects matching a glob pattern (a regular expression with --regex)")
+	backupCmd.Flags().BoolVar(&backupFailFast, "fail-fast", false, "Abort the backup at the first subject or version that cannot be fetched")
+	backupCmd.Flags().BoolVar(&backupRegex, "regex", false, "Treat --filter as a regular expression instead of a glob")
 	backupCmd.Flags().BoolVar(&backupByID, "by-id", false, "Include schema ID mapping for exact restoration")
 	backupCmd.Flags().IntVar(&backupWorkers, "workers", 10, "Number of parallel workers for backup")
+	backupCmd.Flags().IntVar(&backupVerPar, "parallel-versions", 1, "Versions of each subject to fetch concurrently (per worker)")
+	backupCmd.Flags().IntVar(&backupMaxVers, "max-versions", 0, "Back up only the newest N versions of each subject (0 = all)")
+	backupCmd.Flags().BoolVar(&backupDeleted, "include-deleted", true, "Include soft-deleted subjects and versions")
 	backupCmd.Flags().BoolVar(&backupConfigs, "configs", true, "Include subject-level configurations")
 	backupCmd.Flags().BoolVar(&backupTags, "tags", true, "Include tag definitions and associations")
+	backupCmd.Flags().BoolVar(&backupBizMeta, "business-metadata", true, "Include business metadata definitions and associations")
+	backupCmd.Flags().StringVar(&backupArchive, "archive", "", "Write the backup into a compressed archive: tar.gz or zip")
+	backupCmd.Flags().StringVar(&backupCkptFile, "checkpoint", "", "Record finished subjects in this file; re-running with it resumes the backup in the same directory")
+	backupCmd.Flags().BoolVar(&backupRedact, "redact-docs", false, "Strip Avro doc and JSON Schema description/examples from saved schemas")
+	addJSONLFlag(backupCmd)
+	backupCmd.Flags().StringVar(&backupSince, "since", "", "Previous backup directory; only save versions that are new or changed since it")
 
 	backupCmd.MarkFlagRequired("output")
+	backupCmd.AddCommand(backupVerifyCmd)
 	rootCmd.AddCommand(backupCmd)
 }
 
+// backupFormatVersion is the manifest version backups are written with.
+// Change it when the layout changes in a way older releases can't restore,
+// and add a migration from the previous version to backupManifestMigrations.
+const backupFormatVersion = "1.0"
+
+// backupManifestMigrations upgrades a manifest read from disk, keyed by the
+// version it upgrades from. Each one updates the manifest to the next format
+// version, until it reaches backupFormatVersion.
+var backupManifestMigrations = map[string]func(m *BackupManifest) error{}
+
 // BackupManifest contains metadata about the backup
 type BackupManifest struct {
 	Version     string    `json:"version"`
@@ -94,9 +180,30 @@ type BackupManifest struct {
 		TotalIDs       int `json:"totalIds"`
 		TagDefinitions int `json:"tagDefinitions,omitempty"`
 		TagAssignments int `json:"tagAssignments,omitempty"`
+
+		BusinessMetadataDefinitions int `json:"businessMetadataDefinitions,omitempty"`
+		BusinessMetadataAssignments int `json:"businessMetadataAssignments,omitempty"`
+
+		// DeletedVersions counts the soft-deleted versions saved
+		DeletedVersions int `json:"deletedVersions,omitempty"`
 	} `json:"statistics"`
 	BySchemaID   bool `json:"bySchemaId"`
 	IncludesTags bool `json:"includesTags,omitempty"`
+	// IncludesBusinessMetadata means business-metadata.json was written
+	IncludesBusinessMetadata bool `json:"includesBusinessMetadata,omitempty"`
+	// ExcludesDeleted means soft-deleted subjects and versions were left out
+	// (--include-deleted=false)
+	ExcludesDeleted bool `json:"excludesDeleted,omitempty"`
+	// MaxVersions is the --max-versions limit: only the newest MaxVersions
+	// versions of each subject were saved (0 means the full history)
+	MaxVersions int `json:"maxVersions,omitempty"`
+	// RedactedDocs means schema documentation was stripped (--redact-docs), so
+	// the saved schemas are not byte-identical to the registry's
+	RedactedDocs bool `json:"redactedDocs,omitempty"`
+	// BaseBackup is the backup an incremental backup builds on, relative to this backup
+	BaseBackup string `json:"baseBackup,omitempty"`
+	// Checksums maps each backup file (relative path) to its SHA-256
+	Checksums map[string]string `json:"checksums,omitempty"`
 }
 
 // TagBackup contains tag definitions and assignments
@@ -110,6 +217,40 @@ type TagAssignmentBackup struct {
 	Subject  string   `json:"subject"`
 	Version  int      `json:"version,omitempty"` // 0 means subject-level
 	TagNames []string `json:"tagNames"`
+	// Attributes holds each tag's attributes, keyed by tag name; tags without
+	// attributes are left out
+	Attributes map[string]map[string]string `json:"attributes,omitempty"`
+}
+
+// newTagAssignmentBackup records the tags assigned to a subject (version 0)
+// or schema version, with their attributes
+func newTagAssignmentBackup(subject string, version int, tags []client.TagAssignment) TagAssignmentBackup {
+	backup := TagAssignmentBackup{Subject: subject, Version: version}
+	for _, t := range tags {
+		backup.TagNames = append(backup.TagNames, t.TypeName)
+		if len(t.Attributes) > 0 {
+			if backup.Attributes == nil {
+				backup.Attributes = make(map[string]map[string]string)
+			}
+			backup.Attributes[t.TypeName] = t.Attributes
+		}
+	}
+	return backup
+}
+
+// BusinessMetadataBackup contains business metadata definitions and assignments
+type BusinessMetadataBackup struct {
+	Definitions []client.BusinessMetadataDef       `json:"definitions"`
+	Assignments []BusinessMetadataAssignmentBackup `json:"assignments"`
+}
+
+// BusinessMetadataAssignmentBackup stores business metadata attached to a
+// subject (version 0) or schema version
+type BusinessMetadataAssignmentBackup struct {
+	Subject    string            `json:"subject"`
+	Version    int               `json:"version,omitempty"`
+	Name       string            `json:"name"`
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
 // SubjectBackup contains all data for a subject
@@ -118,6 +259,8 @@ type SubjectBackup struct {
 	Compatibility string                `json:"compatibility,omitempty"`
 	Mode          string                `json:"mode,omitempty"`
 	Versions      []SchemaVersionBackup `json:"versions"`
+
+	kept []int // every version within --max-versions, saved or unchanged
 }
 
 // SchemaVersionBackup contains a single schema version
@@ -129,6 +272,7 @@ type SchemaVersionBackup struct {
 	References []client.SchemaReference `json:"references,omitempty"`
 	Metadata   *client.SchemaMetadata   `json:"metadata,omitempty"`
 	RuleSet    *client.SchemaRuleSet    `json:"ruleSet,omitempty"`
+	Deleted    bool                     `json:"deleted,omitempty"`
 }
 
 // IDMapping maps schema IDs to subjects/versions for restoration
@@ -144,44 +288,130 @@ func runBackup(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defer reportThrottling(c)
 
 	output.Header("Schema Registry Backup")
 
-	// Create output directory
+	// Create output directory (or archive)
 	timestamp := time.Now().Format("20060102-150405")
 	backupDir := filepath.Join(backupOutput, fmt.Sprintf("sr-backup-%s", timestamp))
 
-	if err := os.MkdirAll(backupDir, 0755); err != nil {
-		return fmt.Errorf("failed to create backup directory: %w", err)
+	// A checkpoint resumes into the directory it was started in
+	var cp *Checkpoint
+	if backupCkptFile != "" {
+		if backupArchive != "" {
+			return fmt.Errorf("--checkpoint cannot be used with --archive: a partly written archive cannot be resumed")
+		}
+		if cp, err = loadCheckpoint(backupCkptFile, "backup"); err != nil {
+			return err
+		}
+		if cp.Location != "" {
+			if _, err := os.Stat(cp.Location); err != nil {
+				return fmt.Errorf("cannot resume: backup directory from checkpoint %s: %w", backupCkptFile, err)
+			}
+			backupDir = cp.Location
+		} else {
+			cp.Location = backupDir
+		}
+	}
+
+	w, err := newBackupWriter(backupDir, backupArchive)
+	if err != nil {
+		return err
 	}
+	defer w.Close()
 
-	output.Info("Backup directory: %s", backupDir)
+	output.Info("Backup location: %s", w.Location())
 	output.Info("Workers: %d", backupWorkers)
+	if cp.Resumed() {
+		output.Info("Resuming from checkpoint %s (%d subject(s) already saved)", backupCkptFile, len(cp.Subjects))
+	} else if err := cp.Save(); err != nil {
+		return err
+	}
+	if backupRedact {
+		output.Info("Redacting schema documentation (doc, description, examples)")
+	}
+	if backupMaxVers > 0 {
+		output.Info("Keeping the newest %d version(s) of each subject", backupMaxVers)
+	}
+	if !backupDeleted {
+		output.Info("Skipping soft-deleted subjects and versions")
+	}
 
 	// Initialize manifest
 	manifest := BackupManifest{
-		Version:     "1.0",
-		CreatedAt:   time.Now().UTC(),
-		RegistryURL: registryURL,
-		Context:     srContext,
-		BySchemaID:  backupByID,
+		Version:      backupFormatVersion,
+		CreatedAt:    time.Now().UTC(),
+		RegistryURL:  registryURL,
+		Context:      srContext,
+		BySchemaID:   backupByID,
+		RedactedDocs: backupRedact,
+		MaxVersions:  backupMaxVers,
+
+		ExcludesDeleted: !backupDeleted,
 	}
 
-	// Get subjects to backup
-	var subjects []string
-	if len(backupSubjects) > 0 {
-		subjects = backupSubjects
-		output.Info("Backing up %d specified subjects", len(subjects))
-	} else {
-		output.Step("Fetching subjects...")
-		var err error
-		subjects, err = c.GetSubjects(true) // Include deleted for complete backup
+	// Load the state captured by the base backup chain
+	var previous map[string]*SubjectBackup
+	if backupSince != "" {
+		chain, cleanup, err := backupChain(backupSince)
 		if err != nil {
-			return fmt.Errorf("failed to get subjects: %w", err)
+			return err
+		}
+		defer cleanup()
+		prevBackups, err := loadSubjectBackups(chain, nil)
+		if err != nil {
+			return err
 		}
+		previous = make(map[string]*SubjectBackup, len(prevBackups))
+		for i := range prevBackups {
+			previous[prevBackups[i].Subject] = &prevBackups[i]
+		}
+
+		absBase, _ := filepath.Abs(backupSince)
+		// Relative to the backup location, which for an archive is its path without extension
+		absDir, _ := filepath.Abs(backupDir)
+		if manifest.BaseBackup, err = filepath.Rel(absDir, absBase); err != nil {
+			manifest.BaseBackup = absBase
+		}
+		output.Info("Incremental backup since: %s (%d backups in chain)", backupSince, len(chain))
+	}
+
+	// Get subjects to backup (soft-deleted ones too, unless --include-deleted=false)
+	if len(backupSubjects) == 0 {
+		output.Step("Fetching subjects...")
+	}
+	subjects, err := selectSubjects(c, backupSubjects, backupFilter, backupRegex, backupDeleted)
+	if err != nil {
+		return err
+	}
+	switch {
+	case backupFilter != "":
+		output.Info("Backing up %d subjects matching '%s'", len(subjects), backupFilter)
+	case len(backupSubjects) > 0:
+		output.Info("Backing up %d specified subjects", len(subjects))
+	default:
 		output.Info("Found %d subjects", len(subjects))
 	}
 
+	// Internal subjects are only backed up when named explicitly
+	if len(backupSubjects) == 0 {
+		var internal int
+		subjects, internal = withoutInternalSubjects(subjects)
+		if internal > 0 {
+			output.Info("Skipping %d internal subject(s) (see --internal-prefixes)", internal)
+		}
+	}
+
+	kept, err := excludeSubjects(subjects, backupExclude, backupExcludePattern, backupRegex)
+	if err != nil {
+		return err
+	}
+	if n := len(subjects) - len(kept); n > 0 {
+		output.Info("Excluding %d subject(s), %d left to back up", n, len(kept))
+	}
+	subjects = kept
+
 	if len(subjects) == 0 {
 		output.Warning("No subjects to backup")
 		return nil
@@ -197,7 +427,7 @@ func runBackup(cmd *cobra.Command, args []string) error {
 		if configData["compatibility"] == "" {
 			configData["compatibility"] = globalConfig.Compatibility
 		}
-		if err := saveJSON(filepath.Join(backupDir, "global-config.json"), configData); err != nil {
+		if err := w.WriteJSON("global-config.json", configData); err != nil {
 			return fmt.Errorf("failed to save global config: %w", err)
 		}
 	}
@@ -206,32 +436,50 @@ func runBackup(cmd *cobra.Command, args []string) error {
 	globalMode, err := c.GetMode()
 	if err == nil && globalMode != nil {
 		modeData := map[string]string{"mode": globalMode.Mode}
-		if err := saveJSON(filepath.Join(backupDir, "global-mode.json"), modeData); err != nil {
+		if err := w.WriteJSON("global-mode.json", modeData); err != nil {
 			return fmt.Errorf("failed to save global mode: %w", err)
 		}
 	}
 
-	// Backup subjects in parallel
-	subjectsDir := filepath.Join(backupDir, "subjects")
-	if err := os.MkdirAll(subjectsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create subjects directory: %w", err)
+	// Subjects saved by an earlier run count as results without being fetched again
+	var backupResults []backupResult
+	remaining := subjects
+	if cp.Resumed() {
+		remaining = nil
+		for _, subj := range subjects {
+			if done := cp.Subject(subj); done != nil {
+				backupResults = append(backupResults, backupResult{Subject: subj, VersionCount: len(done.Versions), IDMappings: done.IDMappings, Unchanged: done.Unchanged})
+			} else {
+				remaining = append(remaining, subj)
+			}
+		}
+		output.Info("Skipping %d subject(s) saved by the previous run", len(subjects)-len(remaining))
 	}
 
+	// Backup subjects in parallel
 	output.Step("Backing up schemas (%d workers)...", backupWorkers)
-	backupResults := backupSubjectsParallel(c, subjects, subjectsDir)
+	backupResults = append(backupResults, backupSubjectsParallel(c, remaining, w, previous, cp)...)
 
 	// Aggregate results
-	var totalSchemas int
+	var totalSchemas, deletedSchemas int
 	var idMappings []IDMapping
 	allIDs := make(map[int]bool)
-	var failedCount int
+	var failedCount, unchangedCount int
+	var failures []operationFailure
 
 	for _, r := range backupResults {
+		failures = append(failures, r.Failures...)
 		if r.Error != nil {
 			failedCount++
+			failures = append(failures, operationFailure{Subject: r.Subject, Error: r.Error.Error()})
+			continue
+		}
+		if r.Unchanged {
+			unchangedCount++
 			continue
 		}
 		totalSchemas += r.VersionCount
+		deletedSchemas += r.DeletedCount
 		if backupByID {
 			idMappings = append(idMappings, r.IDMappings...)
 			for _, m := range r.IDMappings {
@@ -240,16 +488,29 @@ func runBackup(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if backupFailFast && len(failures) > 0 {
+		printFailures(failures)
+		finishCheckpoint(cp, len(failures))
+		return fmt.Errorf("backup aborted after first failure; %s is incomplete", w.Location())
+	}
+
+	if backupMaxVers > 0 {
+		dangling := trimmedReferences(backupResults)
+		for _, d := range dangling {
+			output.Warning("%s, which --max-versions left out; restoring it will fail unless the target already has that version", d)
+		}
+	}
+
 	// Save ID mappings if requested
 	if backupByID && len(idMappings) > 0 {
 		output.Step("Saving schema ID mappings...")
-		if err := saveJSON(filepath.Join(backupDir, "id-mappings.json"), idMappings); err != nil {
+		if err := w.WriteJSON("id-mappings.json", idMappings); err != nil {
 			return fmt.Errorf("failed to save ID mappings: %w", err)
 		}
 
 		// Also save schemas by ID for direct restoration
 		output.Step("Saving schemas by ID...")
-		if err := saveSchemasByIDParallel(c, idMappings, backupDir); err != nil {
+		if err := saveSchemasByIDParallel(c, idMappings, w); err != nil {
 			return fmt.Errorf("failed to save schemas by ID: %w", err)
 		}
 	}
@@ -258,20 +519,42 @@ func runBackup(cmd *cobra.Command, args []string) error {
 	var tagDefCount, tagAssignCount int
 	if backupTags {
 		output.Step("Backing up tags...")
-		tagDefCount, tagAssignCount = backupTagsData(c, subjects, backupDir)
+		tagDefCount, tagAssignCount = backupTagsData(c, subjects, w)
 		manifest.IncludesTags = true
 	}
 
+	// Backup business metadata if enabled
+	var bmDefCount, bmAssignCount int
+	if backupBizMeta {
+		output.Step("Backing up business metadata...")
+		bmDefCount, bmAssignCount, manifest.IncludesBusinessMetadata = backupBusinessMetadataData(c, subjects, w)
+	}
+
 	// Update and save manifest
-	manifest.Statistics.Subjects = len(subjects) - failedCount
+	manifest.Statistics.Subjects = len(subjects) - failedCount - unchangedCount
 	manifest.Statistics.Schemas = totalSchemas
 	manifest.Statistics.TotalIDs = len(allIDs)
+	manifest.Statistics.DeletedVersions = deletedSchemas
 	manifest.Statistics.TagDefinitions = tagDefCount
 	manifest.Statistics.TagAssignments = tagAssignCount
+	manifest.Statistics.BusinessMetadataDefinitions = bmDefCount
+	manifest.Statistics.BusinessMetadataAssignments = bmAssignCount
+
+	// Record checksums of everything written so far (all files but the manifest),
+	// reading back files from earlier runs when resuming
+	manifest.Checksums = w.Checksums()
+	if cp != nil {
+		if manifest.Checksums, err = computeBackupChecksums(backupDir); err != nil {
+			return fmt.Errorf("failed to checksum backup: %w", err)
+		}
+	}
 
-	if err := saveJSON(filepath.Join(backupDir, "manifest.json"), manifest); err != nil {
+	if err := w.WriteJSON("manifest.json", manifest); err != nil {
 		return fmt.Errorf("failed to save manifest: %w", err)
 	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finish backup: %w", err)
+	}
 
 	// Summary
 	output.Header("Backup Complete")
@@ -280,16 +563,28 @@ func runBackup(cmd *cobra.Command, args []string) error {
 		{"Schema Versions", strconv.Itoa(manifest.Statistics.Schemas)},
 		{"Unique Schema IDs", strconv.Itoa(manifest.Statistics.TotalIDs)},
 	}
+	if backupDeleted {
+		rows = append(rows, []string{"Soft-deleted Versions", strconv.Itoa(deletedSchemas)})
+	}
 	if backupTags {
 		rows = append(rows, []string{"Tag Definitions", strconv.Itoa(tagDefCount)})
 		rows = append(rows, []string{"Tag Assignments", strconv.Itoa(tagAssignCount)})
 	}
+	if manifest.IncludesBusinessMetadata {
+		rows = append(rows, []string{"Business Metadata Definitions", strconv.Itoa(bmDefCount)})
+		rows = append(rows, []string{"Business Metadata Assignments", strconv.Itoa(bmAssignCount)})
+	}
+	if previous != nil {
+		rows = append(rows, []string{"Unchanged Subjects", strconv.Itoa(unchangedCount)})
+	}
 	rows = append(rows, []string{"Failed", strconv.Itoa(failedCount)})
-	rows = append(rows, []string{"Location", backupDir})
+	rows = append(rows, []string{"Location", w.Location()})
 	output.PrintTable([]string{"Metric", "Value"}, rows)
+	printFailures(failures)
+	finishCheckpoint(cp, len(failures))
 
 	// Calculate backup size
-	size, _ := getDirSize(backupDir)
+	size, _ := getDirSize(w.Location())
 	output.Info("Backup size: %s", output.FormatBytes(size))
 
 	return nil
@@ -299,24 +594,28 @@ func runBackup(cmd *cobra.Command, args []string) error {
 type backupResult struct {
 	Subject      string
 	VersionCount int
+	DeletedCount int // soft-deleted versions among those saved
 	IDMappings   []IDMapping
+	Unchanged    bool
+	Failures     []operationFailure // versions that could not be fetched
 	Error        error
+
+	Saved      []int                    // versions written to the subject file
+	Kept       []int                    // versions within --max-versions
+	References []client.SchemaReference // references of the versions saved
 }
 
-// backupSubjectsParallel backs up subjects in parallel
-func backupSubjectsParallel(c *client.SchemaRegistryClient, subjects []string, subjectsDir string) []backupResult {
+// backupSubjectsParallel backs up subjects in parallel. With previous state
+// (incremental backup), only new or changed versions are saved.
+func backupSubjectsParallel(c *client.SchemaRegistryClient, subjects []string, w *backupWriter, previous map[string]*SubjectBackup, cp *Checkpoint) []backupResult {
 	jobs := make(chan string, len(subjects))
 	results := make(chan backupResult, len(subjects))
 
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Backing up"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjects), "Backing up")
 
 	// Start workers
 	workers := clampWorkers(backupWorkers)
+	var stopped atomic.Bool // set on the first failure with --fail-fast
 	var wg sync.WaitGroup
 	for i := 0; i < workers; i++ {
 		wg.Add(1)
@@ -324,20 +623,48 @@ func backupSubjectsParallel(c *client.SchemaRegistryClient, subjects []string, s
 			defer wg.Done()
 			for subj := range jobs {
 				result := backupResult{Subject: subj}
+				if stopped.Load() {
+					emitSubjectEvent("backup", SubjectEvent{Subject: subj, Status: "skipped", Error: "stopped after an earlier failure (--fail-fast)"})
+					bar.Add(1)
+					continue
+				}
 
-				subjectBackup, ids, err := backupSubject(c, subj, backupByID)
+				prev := previous[subj]
+				subjectBackup, ids, failures, err := backupSubject(c, subj, backupByID, backupDeleted, prev, backupVerPar, backupMaxVers)
+				result.Failures = failures
+				if backupFailFast && (err != nil || len(failures) > 0) {
+					stopped.Store(true)
+				}
 				if err != nil {
 					result.Error = err
 					results <- result
 					bar.Add(1)
 					continue
 				}
+				result.Kept = subjectBackup.kept
+				for _, ver := range subjectBackup.Versions {
+					result.References = append(result.References, ver.References...)
+				}
+
+				if prev != nil && len(subjectBackup.Versions) == 0 &&
+					subjectBackup.Compatibility == prev.Compatibility && subjectBackup.Mode == prev.Mode {
+					result.Unchanged = true
+					results <- result
+					bar.Add(1)
+					continue
+				}
+
+				if backupRedact {
+					for i := range subjectBackup.Versions {
+						ver := &subjectBackup.Versions[i]
+						ver.Schema = redactSchemaDocs(ver.SchemaType, ver.Schema)
+					}
+				}
 
 				// Save subject backup
 				// Use URL encoding for safe filenames (handles /, _, and special chars)
 				safeName := url.PathEscape(subj)
-				subjectFile := filepath.Join(subjectsDir, safeName+".json")
-				if err := saveJSON(subjectFile, subjectBackup); err != nil {
+				if err := w.WriteJSON("subjects/"+safeName+".json", subjectBackup); err != nil {
 					result.Error = err
 					results <- result
 					bar.Add(1)
@@ -345,6 +672,12 @@ func backupSubjectsParallel(c *client.SchemaRegistryClient, subjects []string, s
 				}
 
 				result.VersionCount = len(subjectBackup.Versions)
+				for _, ver := range subjectBackup.Versions {
+					result.Saved = append(result.Saved, ver.Version)
+					if ver.Deleted {
+						result.DeletedCount++
+					}
+				}
 				result.IDMappings = ids
 				results <- result
 				bar.Add(1)
@@ -370,19 +703,39 @@ func backupSubjectsParallel(c *client.SchemaRegistryClient, subjects []string, s
 	var allResults []backupResult
 	for r := range results {
 		allResults = append(allResults, r)
+		emitSubjectEvent("backup", backupEvent(r))
+		if r.Error == nil && len(r.Failures) == 0 {
+			if err := cp.RecordSubject(r.Subject, checkpointSubject(r)); err != nil {
+				output.Warning("%v", err)
+			}
+		}
 	}
 	bar.Finish()
 
 	return allResults
 }
 
-// saveSchemasByIDParallel saves schemas by ID in parallel
-func saveSchemasByIDParallel(c *client.SchemaRegistryClient, mappings []IDMapping, backupDir string) error {
-	schemasDir := filepath.Join(backupDir, "schemas-by-id")
-	if err := os.MkdirAll(schemasDir, 0755); err != nil {
-		return fmt.Errorf("failed to create schemas-by-id directory: %w", err)
+// checkpointSubject is what a resumed backup needs to know about a saved subject
+func checkpointSubject(r backupResult) CheckpointSubject {
+	return CheckpointSubject{Versions: r.Saved, Unchanged: r.Unchanged, IDMappings: r.IDMappings}
+}
+
+// backupEvent describes a backed-up subject for --jsonl
+func backupEvent(r backupResult) SubjectEvent {
+	ev := SubjectEvent{Subject: r.Subject, Status: "ok", Versions: r.VersionCount}
+	switch {
+	case r.Error != nil:
+		ev.Status, ev.Error = "failed", r.Error.Error()
+	case len(r.Failures) > 0:
+		ev.Status, ev.Error = "partial", fmt.Sprintf("%d version(s) could not be fetched; first: v%d: %s", len(r.Failures), r.Failures[0].Version, r.Failures[0].Error)
+	case r.Unchanged:
+		ev.Status = "unchanged"
 	}
+	return ev
+}
 
+// saveSchemasByIDParallel saves schemas by ID in parallel
+func saveSchemasByIDParallel(c *client.SchemaRegistryClient, mappings []IDMapping, w *backupWriter) error {
 	// Deduplicate by ID
 	uniqueIDs := make(map[int]IDMapping)
 	for _, m := range mappings {
@@ -393,12 +746,7 @@ func saveSchemasByIDParallel(c *client.SchemaRegistryClient, mappings []IDMappin
 	var wg sync.WaitGroup
 	var saved int64
 
-	bar := progressbar.NewOptions(len(uniqueIDs),
-		progressbar.OptionSetDescription("Saving by ID"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(uniqueIDs), "Saving by ID")
 
 	workers := clampWorkers(backupWorkers)
 	for i := 0; i < workers; i++ {
@@ -408,11 +756,14 @@ func saveSchemasByIDParallel(c *client.SchemaRegistryClient, mappings []IDMappin
 			for mapping := range jobs {
 				schema, err := c.GetSchemaByID(mapping.SchemaID)
 				if err == nil {
-					schemaFile := filepath.Join(schemasDir, fmt.Sprintf("%d.json", mapping.SchemaID))
-					saveJSON(schemaFile, map[string]interface{}{
+					text := schema.Schema
+					if backupRedact {
+						text = redactSchemaDocs(mapping.SchemaType, text)
+					}
+					w.WriteJSON(fmt.Sprintf("schemas-by-id/%d.json", mapping.SchemaID), map[string]interface{}{
 						"schemaId":   mapping.SchemaID,
 						"schemaType": mapping.SchemaType,
-						"schema":     schema.Schema,
+						"schema":     text,
 					})
 					atomic.AddInt64(&saved, 1)
 				}
@@ -431,7 +782,7 @@ func saveSchemasByIDParallel(c *client.SchemaRegistryClient, mappings []IDMappin
 }
 
 // backupTagsData backs up tag definitions and assignments
-func backupTagsData(c *client.SchemaRegistryClient, subjects []string, backupDir string) (defCount, assignCount int) {
+func backupTagsData(c *client.SchemaRegistryClient, subjects []string, w *backupWriter) (defCount, assignCount int) {
 	tagBackup := TagBackup{
 		Definitions: []client.Tag{},
 		Assignments: []TagAssignmentBackup{},
@@ -446,12 +797,7 @@ func backupTagsData(c *client.SchemaRegistryClient, subjects []string, backupDir
 	tagBackup.Definitions = tags
 
 	// Get tag assignments for each subject
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Backing up tags"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjects), "Backing up tags")
 
 	type tagResult struct {
 		Assignments []TagAssignmentBackup
@@ -472,15 +818,8 @@ func backupTagsData(c *client.SchemaRegistryClient, subjects []string, backupDir
 				// Get subject-level tags
 				subjectTags, err := c.GetSubjectTags(subj)
 				if err == nil && len(subjectTags) > 0 {
-					var tagNames []string
-					for _, t := range subjectTags {
-						tagNames = append(tagNames, t.TypeName)
-					}
-					result.Assignments = append(result.Assignments, TagAssignmentBackup{
-						Subject:  subj,
-						Version:  0, // 0 = subject-level
-						TagNames: tagNames,
-					})
+					// Version 0 = subject-level
+					result.Assignments = append(result.Assignments, newTagAssignmentBackup(subj, 0, subjectTags))
 				}
 
 				// Get schema-level tags for each version
@@ -489,15 +828,7 @@ func backupTagsData(c *client.SchemaRegistryClient, subjects []string, backupDir
 					for _, v := range versions {
 						schemaTags, err := c.GetSchemaTags(subj, v)
 						if err == nil && len(schemaTags) > 0 {
-							var tagNames []string
-							for _, t := range schemaTags {
-								tagNames = append(tagNames, t.TypeName)
-							}
-							result.Assignments = append(result.Assignments, TagAssignmentBackup{
-								Subject:  subj,
-								Version:  v,
-								TagNames: tagNames,
-							})
+							result.Assignments = append(result.Assignments, newTagAssignmentBackup(subj, v, schemaTags))
 						}
 					}
 				}
@@ -529,18 +860,137 @@ func backupTagsData(c *client.SchemaRegistryClient, subjects []string, backupDir
 	bar.Finish()
 
 	// Save tag backup
-	if err := saveJSON(filepath.Join(backupDir, "tags.json"), tagBackup); err != nil {
+	if err := w.WriteJSON("tags.json", tagBackup); err != nil {
 		output.Warning("Failed to save tags backup: %v", err)
 	}
 
 	return len(tagBackup.Definitions), len(tagBackup.Assignments)
 }
 
-func backupSubject(c *client.SchemaRegistryClient, subject string, byID bool) (*SubjectBackup, []IDMapping, error) {
+// backupBusinessMetadataData backs up business metadata definitions and the
+// metadata attached to each subject and version. ok is false when the catalog
+// has no business metadata API, in which case nothing is written.
+func backupBusinessMetadataData(c *client.SchemaRegistryClient, subjects []string, w *backupWriter) (defCount, assignCount int, ok bool) {
+	defs, err := c.GetBusinessMetadataDefs()
+	if err != nil {
+		output.Warning("Failed to get business metadata definitions (catalog API may not be available): %v", err)
+		return 0, 0, false
+	}
+	bmBackup := BusinessMetadataBackup{
+		Definitions: defs,
+		Assignments: []BusinessMetadataAssignmentBackup{},
+	}
+	if len(defs) > 0 {
+		// Nothing can be attached without a definition
+		bmBackup.Assignments = collectBusinessMetadata(c, subjects, backupWorkers)
+	}
+
+	if err := w.WriteJSON("business-metadata.json", bmBackup); err != nil {
+		output.Warning("Failed to save business metadata backup: %v", err)
+		return 0, 0, false
+	}
+	return len(bmBackup.Definitions), len(bmBackup.Assignments), true
+}
+
+// collectBusinessMetadata fetches the business metadata attached to each
+// subject and its live versions, in parallel
+func collectBusinessMetadata(c client.SchemaRegistryClientInterface, subjects []string, workers int) []BusinessMetadataAssignmentBackup {
+	jobs := make(chan string, len(subjects))
+	results := make(chan []BusinessMetadataAssignmentBackup, len(subjects))
+
+	workers = clampWorkers(workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for subj := range jobs {
+				var found []BusinessMetadataAssignmentBackup
+				if attached, err := c.GetSubjectBusinessMetadata(subj); err == nil {
+					for _, bm := range attached {
+						found = append(found, BusinessMetadataAssignmentBackup{Subject: subj, Name: bm.TypeName, Attributes: bm.Attributes})
+					}
+				}
+				if versions, err := c.GetVersions(subj, false); err == nil {
+					for _, v := range versions {
+						attached, err := c.GetSchemaBusinessMetadata(subj, v)
+						if err != nil {
+							continue
+						}
+						for _, bm := range attached {
+							found = append(found, BusinessMetadataAssignmentBackup{Subject: subj, Version: v, Name: bm.TypeName, Attributes: bm.Attributes})
+						}
+					}
+				}
+				results <- found
+			}
+		}()
+	}
+
+	for _, subj := range subjects {
+		jobs <- subj
+	}
+	close(jobs)
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var all []BusinessMetadataAssignmentBackup
+	for r := range results {
+		all = append(all, r...)
+	}
+	// Keep the file stable between runs
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Subject != all[j].Subject {
+			return all[i].Subject < all[j].Subject
+		}
+		if all[i].Version != all[j].Version {
+			return all[i].Version < all[j].Version
+		}
+		return all[i].Name < all[j].Name
+	})
+	return all
+}
+
+// applyBusinessMetadata creates the definitions (existing ones are fine) and
+// re-attaches each assignment
+func applyBusinessMetadata(c client.SchemaRegistryClientInterface, bmBackup BusinessMetadataBackup) (defsRestored, assignsRestored int) {
+	for _, def := range bmBackup.Definitions {
+		if err := c.CreateBusinessMetadataDef(&def); err != nil && !client.IsConflict(err) {
+			output.Warning("Failed to create business metadata definition %s: %v", def.Name, err)
+			continue
+		}
+		defsRestored++
+	}
+
+	for _, assign := range bmBackup.Assignments {
+		var err error
+		if assign.Version == 0 {
+			err = c.AssignBusinessMetadataToSubject(assign.Subject, assign.Name, assign.Attributes)
+		} else {
+			err = c.AssignBusinessMetadataToSchema(assign.Subject, assign.Version, assign.Name, assign.Attributes)
+		}
+		if err != nil && !client.IsConflict(err) {
+			output.Warning("Failed to attach business metadata %s to %s: %v", assign.Name, assign.Subject, err)
+			continue
+		}
+		assignsRestored++
+	}
+
+	return defsRestored, assignsRestored
+}
+
+// backupSubject fetches a subject's config, mode and its newest maxVersions
+// versions (all when 0), up to parallelVersions at a time; the result stays
+// in version order. Versions already in prev (with the same soft-delete state)
+// are skipped; versions that cannot be fetched are returned as failures.
+func backupSubject(c client.SchemaRegistryClientInterface, subject string, byID, includeDeleted bool, prev *SubjectBackup, parallelVersions, maxVersions int) (*SubjectBackup, []IDMapping, []operationFailure, error) {
 	backup := &SubjectBackup{
 		Subject: subject,
 	}
 	var idMappings []IDMapping
+	var failures []operationFailure
 
 	// Get subject config
 	config, err := c.GetSubjectConfig(subject, false)
@@ -557,15 +1007,65 @@ func backupSubject(c *client.SchemaRegistryClient, subject string, byID bool) (*
 		backup.Mode = mode.Mode
 	}
 
-	// Get all versions
-	versions, err := c.GetVersions(subject, true)
-	if err != nil {
-		return nil, nil, err
+	// Versions missing from the non-deleted list are soft-deleted; a fully
+	// soft-deleted subject has no non-deleted list at all (404)
+	var activeVersions []int
+	if activeVersions, err = c.GetVersions(subject, false); err != nil && !client.IsNotFound(err) {
+		return nil, nil, nil, err
+	}
+	versions := activeVersions
+	if includeDeleted {
+		if versions, err = c.GetVersions(subject, true); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	deleted := softDeletedVersions(versions, activeVersions)
+	versions = newestVersions(versions, maxVersions)
+	backup.kept = versions
+
+	known := make(map[int]SchemaVersionBackup)
+	if prev != nil {
+		for _, ver := range prev.Versions {
+			known[ver.Version] = ver
+		}
+	}
+
+	// Fetch the new versions, each into its own slot so order is kept
+	type fetched struct {
+		schema *client.Schema
+		err    error
+	}
+	results := make([]fetched, len(versions))
+	sem := make(chan struct{}, max(parallelVersions, 1))
+	var wg sync.WaitGroup
+	for i, v := range versions {
+		if _, ok := known[v]; ok {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			schema, err := c.GetSchemaWithDeleted(subject, strconv.Itoa(v), deleted[v])
+			results[i] = fetched{schema, err}
+		}()
 	}
+	wg.Wait()
+
+	for i, v := range versions {
+		if k, ok := known[v]; ok {
+			// Only the soft-delete state of an existing version can change
+			if k.Deleted != deleted[v] {
+				k.Deleted = deleted[v]
+				backup.Versions = append(backup.Versions, k)
+			}
+			continue
+		}
 
-	for _, v := range versions {
-		schema, err := c.GetSchema(subject, strconv.Itoa(v))
+		schema, err := results[i].schema, results[i].err
 		if err != nil {
+			failures = append(failures, operationFailure{Subject: subject, Version: v, Error: err.Error()})
 			continue
 		}
 
@@ -582,6 +1082,7 @@ func backupSubject(c *client.SchemaRegistryClient, subject string, byID bool) (*
 			References: schema.References,
 			Metadata:   schema.Metadata,
 			RuleSet:    schema.RuleSet,
+			Deleted:    deleted[v],
 		})
 
 		if byID {
@@ -594,7 +1095,65 @@ func backupSubject(c *client.SchemaRegistryClient, subject string, byID bool) (*
 		}
 	}
 
-	return backup, idMappings, nil
+	return backup, idMappings, failures, nil
+}
+
+// newestVersions returns the last n of the ascending versions, or all of them
+// when n is 0
+func newestVersions(versions []int, n int) []int {
+	if n <= 0 || len(versions) <= n {
+		return versions
+	}
+	return versions[len(versions)-n:]
+}
+
+// trimmedReferences describes the references from saved versions to versions
+// of backed-up subjects that --max-versions left out. References to subjects
+// outside the backup are not reported.
+func trimmedReferences(results []backupResult) []string {
+	kept := make(map[string]map[int]bool)
+	for _, r := range results {
+		if r.Error != nil {
+			continue
+		}
+		kept[r.Subject] = make(map[int]bool, len(r.Kept))
+		for _, v := range r.Kept {
+			kept[r.Subject][v] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var dangling []string
+	for _, r := range results {
+		for _, ref := range r.References {
+			versions, ok := kept[ref.Subject]
+			if !ok || versions[ref.Version] {
+				continue
+			}
+			msg := fmt.Sprintf("%s references %s version %d", r.Subject, ref.Subject, ref.Version)
+			if !seen[msg] {
+				seen[msg] = true
+				dangling = append(dangling, msg)
+			}
+		}
+	}
+	sort.Strings(dangling)
+	return dangling
+}
+
+// softDeletedVersions returns the versions in all that are not in active
+func softDeletedVersions(all, active []int) map[int]bool {
+	isActive := make(map[int]bool, len(active))
+	for _, v := range active {
+		isActive[v] = true
+	}
+	deleted := make(map[int]bool)
+	for _, v := range all {
+		if !isActive[v] {
+			deleted[v] = true
+		}
+	}
+	return deleted
 }
 
 func saveJSON(path string, data interface{}) error {
@@ -619,69 +1178,274 @@ func getDirSize(path string) (int64, error) {
 	return size, err
 }
 
-// Restore command
-var restoreCmd = &cobra.Command{
-	Use:     "restore <backup-path>",
-	Short:   "Restore Schema Registry from backup",
-	GroupID: groupBulk,
-	Long: `Restore Schema Registry from a backup created by the backup command.
+var backupVerifyCmd = &cobra.Command{
+	Use:   "verify <backup-path>",
+	Short: "Verify backup files against the checksums in its manifest",
+	Long: `Recompute the SHA-256 checksum of every file in a backup and compare it with
+the checksums recorded in manifest.json. Reports missing, modified and
+unexpected files, e.g. from an interrupted or partially copied backup.
 
-Restoration options:
-  • Full restore (creates new subjects)
-  • Restore with original schema IDs (if backup was created with --by-id)
-  • Restore specific subjects only
+For an incremental backup, every backup in its chain is verified.
 
 Examples:
-  # Full restore
-  srctl restore ./backup/sr-backup-20240115-120000
-
-  # Restore with original IDs (requires IMPORT mode)
-  srctl restore ./backup/sr-backup-20240115-120000 --preserve-ids
-
-  # Restore specific subjects
-  srctl restore ./backup/sr-backup-20240115-120000 --subjects user-events
-
-  # Dry run
-  srctl restore ./backup/sr-backup-20240115-120000 --dry-run`,
+  srctl backup verify ./backup/sr-backup-20240115-120000
+  srctl backup verify ./backup/sr-backup-20240115-120000 -o json`,
 	Args: cobra.ExactArgs(1),
-	RunE: runRestore,
+	RunE: runBackupVerify,
 }
 
-var (
-	restoreDryRun        bool
-	restorePreserveID    bool
-	restoreSubjects      []string
-	restoreTags          bool
-	restoreTargetContext string
-)
-
-func init() {
-	restoreCmd.Flags().BoolVar(&restoreDryRun, "dry-run", false, "Validate restore without making changes")
-	restoreCmd.Flags().BoolVar(&restorePreserveID, "preserve-ids", false, "Restore with original schema IDs (requires IMPORT mode)")
-	restoreCmd.Flags().StringSliceVar(&restoreSubjects, "subjects", nil, "Restore only specific subjects")
-	restoreCmd.Flags().BoolVar(&restoreTags, "tags", true, "Restore tag definitions and associations")
-	restoreCmd.Flags().StringVar(&restoreTargetContext, "target-context", "", "Restore into specific context (rewrites subject names)")
-	// Note: Restore is sequential to maintain dependency order (schemas must be registered before schemas that reference them)
-
-	rootCmd.AddCommand(restoreCmd)
+// BackupVerifyResult is the outcome of verifying one backup directory
+type BackupVerifyResult struct {
+	Path    string              `json:"path"`
+	Files   int                 `json:"files"`
+	Issues  []BackupVerifyIssue `json:"issues"`
+	Skipped bool                `json:"skipped,omitempty"`
 }
 
-func runRestore(cmd *cobra.Command, args []string) error {
-	backupPath := args[0]
-
-	output.Header("Schema Registry Restore")
-	output.Info("Source: %s", backupPath)
+// BackupVerifyIssue is a file that does not match the manifest
+type BackupVerifyIssue struct {
+	File    string `json:"file"`
+	Problem string `json:"problem"`
+}
 
-	// Read manifest
-	manifestPath := filepath.Join(backupPath, "manifest.json")
-	manifestData, err := os.ReadFile(manifestPath)
+func runBackupVerify(cmd *cobra.Command, args []string) error {
+	chain, cleanup, err := backupChain(args[0])
 	if err != nil {
-		return fmt.Errorf("failed to read manifest: %w", err)
+		return err
 	}
+	defer cleanup()
 
-	var manifest BackupManifest
-	if err := json.Unmarshal(manifestData, &manifest); err != nil {
-		return fmt.Errorf("invalid manifest: %w", err)
+	var results []BackupVerifyResult
+	issueCount := 0
+	for _, src := range chain {
+		result, err := verifyBackup(src.Dir)
+		if err != nil {
+			return err
+		}
+		result.Path = src.Path
+		issueCount += len(result.Issues)
+		results = append(results, *result)
+	}
+
+	if outputFormat != "table" {
+		if err := output.NewPrinter(outputFormat).Print(results); err != nil {
+			return err
+		}
+	} else {
+		output.Header("Backup Verification")
+		for i := range results {
+			r := &results[i]
+			switch {
+			case r.Skipped:
+				output.Warning("%s: no checksums recorded (created by an older version), skipped", r.Path)
+			case len(r.Issues) == 0:
+				output.Success("%s: %d files verified", r.Path, r.Files)
+			default:
+				printBackupVerifyIssues(r)
+			}
+		}
+	}
+
+	if issueCount > 0 {
+		return fmt.Errorf("backup verification failed: %d issues", issueCount)
+	}
+	return nil
+}
+
+func printBackupVerifyIssues(r *BackupVerifyResult) {
+	output.Error("%s: %d of %d files failed verification", r.Path, len(r.Issues), r.Files)
+	rows := make([][]string, 0, len(r.Issues))
+	for _, issue := range r.Issues {
+		rows = append(rows, []string{issue.File, issue.Problem})
+	}
+	output.PrintTable([]string{"File", "Problem"}, rows)
+}
+
+// computeBackupChecksums returns the SHA-256 of every file in a backup
+// directory except manifest.json, keyed by slash-separated relative path
+func computeBackupChecksums(backupDir string) (map[string]string, error) {
+	checksums := make(map[string]string)
+	err := filepath.Walk(backupDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(backupDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "manifest.json" {
+			return nil
+		}
+		sum, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+		checksums[rel] = sum
+		return nil
+	})
+	return checksums, err
+}
+
+// verifyBackup compares the files in a backup directory with its manifest checksums
+func verifyBackup(backupPath string) (*BackupVerifyResult, error) {
+	manifest, err := readBackupManifest(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BackupVerifyResult{Path: backupPath, Issues: []BackupVerifyIssue{}}
+	if len(manifest.Checksums) == 0 {
+		result.Skipped = true
+		return result, nil
+	}
+
+	actual, err := computeBackupChecksums(backupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup files: %w", err)
+	}
+	result.Files = len(manifest.Checksums)
+
+	for file, want := range manifest.Checksums {
+		got, ok := actual[file]
+		switch {
+		case !ok:
+			result.Issues = append(result.Issues, BackupVerifyIssue{File: file, Problem: "missing"})
+		case got != want:
+			result.Issues = append(result.Issues, BackupVerifyIssue{File: file, Problem: "checksum mismatch"})
+		}
+	}
+	for file := range actual {
+		if _, ok := manifest.Checksums[file]; !ok {
+			result.Issues = append(result.Issues, BackupVerifyIssue{File: file, Problem: "not in manifest"})
+		}
+	}
+	sort.Slice(result.Issues, func(i, j int) bool { return result.Issues[i].File < result.Issues[j].File })
+
+	return result, nil
+}
+
+// fileSHA256 returns the hex SHA-256 of a file's contents
+func fileSHA256(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Restore command
+var restoreCmd = &cobra.Command{
+	Use:     "restore <backup-path>",
+	Short:   "Restore Schema Registry from backup",
+	GroupID: groupBulk,
+	Long: `Restore Schema Registry from a backup created by the backup command.
+
+The backup path can be a backup directory or an archive created with
+backup --archive (.tar.gz, .tgz or .zip).
+
+Restoration options:
+  • Full restore (creates new subjects)
+  • Restore with original schema IDs (if backup was created with --by-id)
+  • Restore specific subjects only
+  • Restore one context from a multi-context backup (--source-context)
+  • Rename subjects on the way (--map-subject old=new, repeatable)
+
+Examples:
+  # Full restore
+  srctl restore ./backup/sr-backup-20240115-120000
+
+  # Restore from an archive
+  srctl restore ./backup/sr-backup-20240115-120000.tar.gz
+
+  # Restore with original IDs (requires IMPORT mode)
+  srctl restore ./backup/sr-backup-20240115-120000 --preserve-ids
+
+  # Restore specific subjects
+  srctl restore ./backup/sr-backup-20240115-120000 --subjects user-events
+
+  # Restore only the .prod context of a full-registry backup
+  srctl restore ./backup/sr-backup-20240115-120000 --source-context .prod
+
+  # Restore a subject under a new name
+  srctl restore ./backup/sr-backup-20240115-120000 --map-subject orders-value=orders.v2-value
+
+  # Check backup checksums before restoring
+  srctl restore ./backup/sr-backup-20240115-120000 --verify
+
+  # Restore only versions that were not soft-deleted
+  srctl restore ./backup/sr-backup-20240115-120000 --skip-deleted
+
+  # Restore independent subjects in parallel
+  srctl restore ./backup/sr-backup-20240115-120000 --workers 20
+
+  # Dry run
+  srctl restore ./backup/sr-backup-20240115-120000 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRestore,
+}
+
+var (
+	restoreDryRun        bool
+	restorePreserveID    bool
+	restoreSubjects      []string
+	restoreTags          bool
+	restoreBizMeta       bool
+	restoreTargetContext string
+	restoreSourceContext string
+	restoreMapSubjects   []string
+	restoreWorkers       int
+	restoreSkipDeleted   bool
+	restoreVerify        bool
+	restoreResetOverride bool
+	restoreSkipGlobal    bool
+)
+
+func init() {
+	restoreCmd.Flags().BoolVar(&restoreDryRun, "dry-run", false, "Validate restore without making changes")
+	restoreCmd.Flags().BoolVar(&restorePreserveID, "preserve-ids", false, "Restore with original schema IDs (requires IMPORT mode)")
+	restoreCmd.Flags().StringSliceVar(&restoreSubjects, "subjects", nil, "Restore only specific subjects")
+	restoreCmd.Flags().BoolVar(&restoreTags, "tags", true, "Restore tag definitions and associations")
+	restoreCmd.Flags().BoolVar(&restoreBizMeta, "business-metadata", true, "Restore business metadata definitions and associations")
+	restoreCmd.Flags().StringVar(&restoreTargetContext, "target-context", "", "Restore into specific context (rewrites subject names)")
+	restoreCmd.Flags().StringArrayVar(&restoreMapSubjects, "map-subject", nil, "Rename a subject as old=new, updating references to it (repeatable)")
+	restoreCmd.Flags().StringVar(&restoreSourceContext, "source-context", "", "Restore only the subjects of this context in the backup (\".\" for the default context)")
+	restoreCmd.Flags().BoolVar(&restoreVerify, "verify", false, "Verify backup checksums before restoring and abort on mismatch")
+	restoreCmd.Flags().BoolVar(&restoreSkipDeleted, "skip-deleted", false, "Do not restore soft-deleted versions (by default they are restored and soft-deleted again)")
+	restoreCmd.Flags().BoolVar(&restoreSkipGlobal, "skip-global-config", false, "Do not apply the backed-up global compatibility and mode")
+	restoreCmd.Flags().BoolVar(&restoreResetOverride, "reset-overrides", false, "Clear existing subject-level compatibility/mode overrides before restoring each subject")
+	// Referenced subjects are always registered before the subjects that reference them;
+	// --workers only parallelizes subjects within the same dependency layer
+	restoreCmd.Flags().IntVar(&restoreWorkers, "workers", 1, "Number of parallel workers for subjects without dependencies on each other")
+
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	backupPath := args[0]
+	renames, err := parseSubjectMap(restoreMapSubjects)
+	if err != nil {
+		return err
+	}
+
+	output.Header("Schema Registry Restore")
+	output.Info("Source: %s", backupPath)
+
+	// Resolve the backup (extracting archives) and any incremental chain
+	chain, cleanup, err := backupChain(backupPath)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// Read manifest
+	manifest, err := readBackupManifest(chain[len(chain)-1].Dir)
+	if err != nil {
+		return err
 	}
 
 	output.Info("Backup created: %s", manifest.CreatedAt.Format(time.RFC3339))
@@ -691,89 +1455,116 @@ func runRestore(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("backup was not created with --by-id, cannot preserve schema IDs")
 	}
 
+	if len(chain) > 1 {
+		output.Info("Incremental backup: applying %d backups in order", len(chain))
+	}
+
+	for _, src := range chain {
+		m, err := readBackupManifest(src.Dir)
+		if err != nil {
+			continue
+		}
+		if m.RedactedDocs {
+			output.Warning("%s was taken with --redact-docs: restored schemas are not byte-identical to the source and may get new IDs", src.Path)
+		}
+		if m.MaxVersions > 0 {
+			output.Warning("%s holds only the newest %d version(s) of each subject: restored subjects start their history there and are renumbered from 1", src.Path, m.MaxVersions)
+		}
+	}
+
+	if restoreVerify {
+		output.Step("Verifying backup checksums...")
+		for _, src := range chain {
+			result, err := verifyBackup(src.Dir)
+			if err != nil {
+				return err
+			}
+			result.Path = src.Path
+			if result.Skipped {
+				output.Warning("%s has no checksums recorded, cannot verify", src.Path)
+			}
+			if len(result.Issues) > 0 {
+				printBackupVerifyIssues(result)
+				return fmt.Errorf("backup %s failed verification (%d issues)", src.Path, len(result.Issues))
+			}
+		}
+		output.Success("Checksums verified")
+	}
+
+	sourceContext := normalizeSourceContext(restoreSourceContext)
+
+	// Global settings come from the newest backup in the chain. They are not
+	// applied when restoring into another context, or only a non-default one,
+	// where they would change the settings of the default context instead.
+	var globals backupGlobals
+	if !restoreSkipGlobal && restoreTargetContext == "" && (sourceContext == "" || sourceContext == ".") {
+		globals, err = readBackupGlobals(chain[len(chain)-1].Dir)
+		if err != nil {
+			return err
+		}
+	}
+
 	c, err := GetClient()
 	if err != nil {
 		return err
 	}
+	defer reportThrottling(c)
 
-	// Set IMPORT mode if preserving IDs
+	// Set IMPORT mode if preserving IDs; afterwards the registry goes back to
+	// the backed-up global mode, or READWRITE if there is none
 	if restorePreserveID && !restoreDryRun {
 		output.Step("Setting registry to IMPORT mode...")
 		if err := c.SetMode("IMPORT"); err != nil {
 			return fmt.Errorf("failed to set IMPORT mode: %w", err)
 		}
+		finalMode := globals.Mode
+		if finalMode == "" || finalMode == "IMPORT" {
+			finalMode = "READWRITE"
+		}
 		defer func() {
-			output.Step("Restoring READWRITE mode...")
-			if err := c.SetMode("READWRITE"); err != nil {
-				output.Error("Failed to restore READWRITE mode; registry may be stuck in IMPORT mode: %v", err)
+			output.Step("Restoring %s mode...", finalMode)
+			if err := c.SetMode(finalMode); err != nil {
+				output.Error("Failed to restore %s mode; registry may be stuck in IMPORT mode: %v", finalMode, err)
 			}
 		}()
 	}
 
-	// Read subject backups
-	subjectsDir := filepath.Join(backupPath, "subjects")
-	files, err := os.ReadDir(subjectsDir)
+	// Read all backup files first to sort by dependencies
+	output.Step("Reading backup files...")
+	backups, err := loadSubjectBackups(chain, restoreSubjects)
 	if err != nil {
-		return fmt.Errorf("failed to read subjects directory: %w", err)
+		return err
 	}
-
-	var toRestore []string
-	for _, f := range files {
-		if !strings.HasSuffix(f.Name(), ".json") {
-			continue
+	if sourceContext != "" {
+		total := len(backups)
+		backups = filterBackupsByContext(backups, sourceContext, manifest.Context)
+		if len(backups) == 0 {
+			return fmt.Errorf("no subjects in context %s found in the backup", sourceContext)
 		}
-		encodedName := strings.TrimSuffix(f.Name(), ".json")
-		// URL decode the filename to get the original subject name
-		subjectName, err := url.PathUnescape(encodedName)
-		if err != nil {
-			subjectName = encodedName // Fall back to encoded name if decode fails
-		}
-
-		// Filter if specific subjects requested
-		if len(restoreSubjects) > 0 {
-			found := false
-			for _, s := range restoreSubjects {
-				if s == subjectName {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
+		output.Info("Restoring %d of %d subjects from context %s", len(backups), total, sourceContext)
+	}
+	if len(renames) > 0 {
+		subjects := make([]string, len(backups))
+		for i, b := range backups {
+			subjects[i] = b.Subject
 		}
-
-		toRestore = append(toRestore, f.Name())
+		renames.warnUnmatched(subjects)
+		renameBackupSubjects(backups, renames.rename)
 	}
 
 	if restoreDryRun {
 		output.Header("Dry Run - Would Restore")
-		for _, f := range toRestore {
-			encodedName := strings.TrimSuffix(f, ".json")
-			subjectName, _ := url.PathUnescape(encodedName)
-			fmt.Printf("  %s %s\n", output.Green("→"), subjectName)
+		for _, b := range backups {
+			fmt.Printf("  %s %s\n", output.Green("→"), b.Subject)
 		}
-		output.Info("\nTotal: %d subjects", len(toRestore))
-		return nil
-	}
-
-	// Read all backup files first to sort by dependencies
-	output.Step("Reading backup files...")
-	var backups []SubjectBackup
-	for _, f := range toRestore {
-		filePath := filepath.Join(subjectsDir, f)
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			output.Warning("Failed to read %s: %v", f, err)
-			continue
+		output.Info("\nTotal: %d subjects", len(backups))
+		if globals.Compatibility != "" {
+			output.Info("Would set global compatibility to %s", globals.Compatibility)
 		}
-
-		var backup SubjectBackup
-		if err := json.Unmarshal(data, &backup); err != nil {
-			output.Warning("Failed to parse %s: %v", f, err)
-			continue
+		if globals.Mode != "" {
+			output.Info("Would set global mode to %s", globals.Mode)
 		}
-		backups = append(backups, backup)
+		return nil
 	}
 
 	// Rewrite subject names and references if target context specified
@@ -785,69 +1576,44 @@ func runRestore(cmd *cobra.Command, args []string) error {
 	// Sort backups by dependencies (subjects without references first)
 	sortBackupsByDependencies(backups)
 
-	// Perform restore
-	output.Step("Restoring %d subjects...", len(backups))
-	bar := progressbar.NewOptions(len(backups),
-		progressbar.OptionSetDescription("Restoring"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionClearOnFinish(),
-	)
-
-	var restored, failed int
-
-	for _, backup := range backups {
-		// Set subject config
-		if backup.Compatibility != "" {
-			if err := c.SetSubjectConfig(backup.Subject, backup.Compatibility); err != nil {
-				output.Warning("Failed to set compatibility for %s: %v", backup.Subject, err)
-			}
+	// Group into layers that only depend on earlier layers
+	layers := backupDependencyLayers(backups)
+	if layers == nil {
+		output.Warning("Circular references between subjects detected; restoring sequentially")
+		for _, b := range backups {
+			layers = append(layers, []SubjectBackup{b})
 		}
-		if backup.Mode != "" {
-			if err := c.SetSubjectMode(backup.Subject, backup.Mode); err != nil {
-				output.Warning("Failed to set mode for %s: %v", backup.Subject, err)
-			}
-		}
-
-		// Register schemas (in order by version)
-		sort.Slice(backup.Versions, func(i, j int) bool {
-			return backup.Versions[i].Version < backup.Versions[j].Version
-		})
-
-		allSucceeded := true
-		for _, ver := range backup.Versions {
-			schema := &client.Schema{
-				Schema:     ver.Schema,
-				SchemaType: ver.SchemaType,
-				References: ver.References,
-				Metadata:   ver.Metadata,
-				RuleSet:    ver.RuleSet,
-			}
-			// Include schema ID if preserving IDs
-			if restorePreserveID {
-				schema.ID = ver.SchemaID
-			}
+	}
 
-			_, err := c.RegisterSchema(backup.Subject, schema)
-			if err != nil {
-				output.Warning("Failed to restore %s v%d: %v", backup.Subject, ver.Version, err)
-				allSucceeded = false
-			}
-		}
+	// Perform restore
+	output.Step("Restoring %d subjects in %d dependency layers (%d workers)...", len(backups), len(layers), clampWorkers(restoreWorkers))
+	bar := newProgressBar(len(backups), "Restoring")
 
-		if allSucceeded {
-			restored++
-		} else {
-			failed++
-		}
-		bar.Add(1)
+	var restored, failed int
+	versions := newRestoredVersions(backups)
+	for _, layer := range layers {
+		r, f := restoreLayerParallel(c, layer, versions, restoreWorkers, bar)
+		restored += r
+		failed += f
 	}
 	bar.Finish()
 
+	// Apply global settings after the subjects, so the global compatibility
+	// doesn't reject historical versions and a READONLY mode doesn't block them
+	applyBackupGlobals(c, globals, !restorePreserveID)
+
 	// Restore tags if available and enabled
 	var tagDefsRestored, tagAssignsRestored int
 	if restoreTags && manifest.IncludesTags {
 		output.Step("Restoring tags...")
-		tagDefsRestored, tagAssignsRestored = restoreTagsData(c, backupPath)
+		tagDefsRestored, tagAssignsRestored = restoreTagsData(c, chain[len(chain)-1].Dir)
+	}
+
+	// Restore business metadata if available and enabled
+	var bmDefsRestored, bmAssignsRestored int
+	if restoreBizMeta && manifest.IncludesBusinessMetadata {
+		output.Step("Restoring business metadata...")
+		bmDefsRestored, bmAssignsRestored = restoreBusinessMetadataData(c, chain[len(chain)-1].Dir)
 	}
 
 	output.Header("Restore Complete")
@@ -859,53 +1625,663 @@ func runRestore(cmd *cobra.Command, args []string) error {
 		rows = append(rows, []string{"Tag Definitions", strconv.Itoa(tagDefsRestored)})
 		rows = append(rows, []string{"Tag Assignments", strconv.Itoa(tagAssignsRestored)})
 	}
+	if restoreBizMeta && manifest.IncludesBusinessMetadata {
+		rows = append(rows, []string{"Business Metadata Definitions", strconv.Itoa(bmDefsRestored)})
+		rows = append(rows, []string{"Business Metadata Assignments", strconv.Itoa(bmAssignsRestored)})
+	}
 	output.PrintTable([]string{"Status", "Count"}, rows)
 
 	return nil
 }
 
-// rewriteBackupContexts rewrites subject names and references to use a new context
-func rewriteBackupContexts(backups []SubjectBackup, targetContext string) {
-	// Build mapping from old subject names to new subject names
-	rewriteMap := make(map[string]string)
+// readBackupManifest reads manifest.json from a backup directory
+func readBackupManifest(backupPath string) (*BackupManifest, error) {
+	manifestData, err := os.ReadFile(filepath.Join(backupPath, "manifest.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
 
-	for i := range backups {
-		oldSubject := backups[i].Subject
-		newSubject := rewriteSubjectContextForBackup(oldSubject, targetContext)
-		rewriteMap[oldSubject] = newSubject
-		backups[i].Subject = newSubject
+	var manifest BackupManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
 	}
+	if err := migrateBackupManifest(&manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
 
-	// Rewrite references in all versions
-	for i := range backups {
-		for j := range backups[i].Versions {
-			for k := range backups[i].Versions[j].References {
-				oldRefSubject := backups[i].Versions[j].References[k].Subject
-				if newRefSubject, ok := rewriteMap[oldRefSubject]; ok {
-					backups[i].Versions[j].References[k].Subject = newRefSubject
+// migrateBackupManifest brings a manifest up to backupFormatVersion, or
+// refuses a format this release doesn't know, e.g. one written by a newer
+// srctl. A manifest without a version is from before versions were checked,
+// and so is 1.0.
+func migrateBackupManifest(m *BackupManifest) error {
+	if m.Version == "" {
+		m.Version = "1.0"
+	}
+	for m.Version != backupFormatVersion {
+		migrate, ok := backupManifestMigrations[m.Version]
+		if !ok {
+			return fmt.Errorf("unsupported backup format version %s (this srctl reads %s); upgrade srctl to restore this backup", m.Version, backupFormatVersion)
+		}
+		from := m.Version
+		if err := migrate(m); err != nil {
+			return fmt.Errorf("failed to migrate backup format %s: %w", from, err)
+		}
+		if m.Version == from {
+			return fmt.Errorf("migration of backup format %s did not change its version", from)
+		}
+	}
+	return nil
+}
+
+// backupSource is one backup in a chain: the path given by the user (a
+// directory or archive) and the local directory its files are read from
+type backupSource struct {
+	Path string
+	Dir  string
+}
+
+// backupChain returns the backups an (incremental) backup builds on, from the
+// full backup first to backupPath last. Archives are extracted to temporary
+// directories, which the returned cleanup function removes.
+func backupChain(backupPath string) ([]backupSource, func(), error) {
+	var chain []backupSource
+	var tempDirs []string
+	cleanup := func() {
+		for _, dir := range tempDirs {
+			os.RemoveAll(dir)
+		}
+	}
+	seen := make(map[string]bool)
+
+	for path := backupPath; path != ""; {
+		abs, _ := filepath.Abs(path)
+		if seen[abs] {
+			cleanup()
+			return nil, nil, fmt.Errorf("backup chain loops back to %s", path)
+		}
+		seen[abs] = true
+
+		dir := path
+		if isBackupArchive(path) {
+			tmp, root, err := extractBackupArchive(path)
+			if tmp != "" {
+				tempDirs = append(tempDirs, tmp)
+			}
+			if err != nil {
+				cleanup()
+				return nil, nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+			}
+			dir = root
+		}
+
+		manifest, err := readBackupManifest(dir)
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		chain = append([]backupSource{{Path: path, Dir: dir}}, chain...)
+
+		path = manifest.BaseBackup
+		if path != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(backupLocation(abs), path)
+		}
+	}
+
+	return chain, cleanup, nil
+}
+
+// isBackupArchive reports whether path is a backup archive rather than a directory
+func isBackupArchive(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz") || strings.HasSuffix(path, ".zip")
+}
+
+// backupLocation returns the path incremental base paths are relative to: the
+// backup directory, or an archive's path without its extension
+func backupLocation(path string) string {
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(path, ext) {
+			return strings.TrimSuffix(path, ext)
+		}
+	}
+	return path
+}
+
+// extractBackupArchive extracts a tar.gz or zip backup into a temporary
+// directory (tmp), returning the directory holding manifest.json (root)
+func extractBackupArchive(archivePath string) (tmp, root string, err error) {
+	tmp, err = os.MkdirTemp("", "srctl-restore-")
+	if err != nil {
+		return "", "", err
+	}
+
+	// Same entry checks and size limits as import
+	err = readArchiveEntries(archivePath, func(name string, content []byte) error {
+		if filepath.IsAbs(name) {
+			return fmt.Errorf("archive entry contains path traversal: %s", name)
+		}
+		target := filepath.Join(tmp, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, content, 0600)
+	})
+	if err != nil {
+		return tmp, "", err
+	}
+
+	// Accept archives of a backup directory (single top-level folder) as well
+	root = tmp
+	if _, statErr := os.Stat(filepath.Join(root, "manifest.json")); statErr != nil {
+		if entries, _ := os.ReadDir(tmp); len(entries) == 1 && entries[0].IsDir() {
+			root = filepath.Join(tmp, entries[0].Name())
+		}
+	}
+	return tmp, root, nil
+}
+
+// backupWriter writes backup files into a directory or straight into a
+// tar.gz/zip archive, recording each file's SHA-256. Safe for concurrent use.
+type backupWriter struct {
+	mu        sync.Mutex
+	dir       string
+	path      string
+	file      *os.File
+	gz        *gzip.Writer
+	tw        *tar.Writer
+	zw        *zip.Writer
+	checksums map[string]string
+	closed    bool
+}
+
+// newBackupWriter creates the backup directory, or dir plus the archive
+// extension when archive is "tar.gz" or "zip"
+func newBackupWriter(dir, archive string) (*backupWriter, error) {
+	w := &backupWriter{dir: dir, path: dir, checksums: make(map[string]string)}
+
+	switch archive {
+	case "":
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create backup directory: %w", err)
+		}
+		return w, nil
+	case "tar.gz", "zip":
+	default:
+		return nil, fmt.Errorf("unsupported archive format: %s (use tar.gz or zip)", archive)
+	}
+
+	w.path = dir + "." + archive
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+	w.file = file
+	if archive == "zip" {
+		w.zw = zip.NewWriter(file)
+	} else {
+		w.gz = gzip.NewWriter(file)
+		w.tw = tar.NewWriter(w.gz)
+	}
+	return w, nil
+}
+
+// Location returns the backup directory or archive path
+func (w *backupWriter) Location() string {
+	return w.path
+}
+
+// WriteJSON writes data as indented JSON to name, a slash-separated path
+// relative to the backup root
+func (w *backupWriter) WriteJSON(name string, data interface{}) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(content)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch {
+	case w.tw != nil:
+		header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), ModTime: time.Now()}
+		if err := w.tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := w.tw.Write(content); err != nil {
+			return err
+		}
+	case w.zw != nil:
+		f, err := w.zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(content); err != nil {
+			return err
+		}
+	default:
+		path := filepath.Join(w.dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0600); err != nil {
+			return err
+		}
+	}
+
+	if name != "manifest.json" {
+		w.checksums[name] = hex.EncodeToString(sum[:])
+	}
+	return nil
+}
+
+// Checksums returns the SHA-256 of every file written except the manifest
+func (w *backupWriter) Checksums() map[string]string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	checksums := make(map[string]string, len(w.checksums))
+	for name, sum := range w.checksums {
+		checksums[name] = sum
+	}
+	return checksums
+}
+
+// Close finishes the archive, if any. Calling it again is a no-op.
+func (w *backupWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed || w.file == nil {
+		return nil
+	}
+	w.closed = true
+
+	var err error
+	if w.tw != nil {
+		err = w.tw.Close()
+		if gzErr := w.gz.Close(); err == nil {
+			err = gzErr
+		}
+	} else {
+		err = w.zw.Close()
+	}
+	if fileErr := w.file.Close(); err == nil {
+		err = fileErr
+	}
+	return err
+}
+
+// loadSubjectBackups reads the subject files of each backup in chain, applying
+// later backups on top of earlier ones. If only is set, other subjects are skipped.
+func loadSubjectBackups(chain []backupSource, only []string) ([]SubjectBackup, error) {
+	merged := make(map[string]*SubjectBackup)
+
+	for _, src := range chain {
+		subjectsDir := filepath.Join(src.Dir, "subjects")
+		files, err := os.ReadDir(subjectsDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read subjects directory: %w", err)
+		}
+
+		for _, f := range files {
+			if !strings.HasSuffix(f.Name(), ".json") {
+				continue
+			}
+			encodedName := strings.TrimSuffix(f.Name(), ".json")
+			// URL decode the filename to get the original subject name
+			subjectName, err := url.PathUnescape(encodedName)
+			if err != nil {
+				subjectName = encodedName // Fall back to encoded name if decode fails
+			}
+
+			// Filter if specific subjects requested
+			if len(only) > 0 && !slices.Contains(only, subjectName) {
+				continue
+			}
+
+			data, err := os.ReadFile(filepath.Join(subjectsDir, f.Name()))
+			if err != nil {
+				output.Warning("Failed to read %s: %v", f.Name(), err)
+				continue
+			}
+
+			var backup SubjectBackup
+			if err := json.Unmarshal(data, &backup); err != nil {
+				output.Warning("Failed to parse %s: %v", f.Name(), err)
+				continue
+			}
+			mergeSubjectBackup(merged, backup)
+		}
+	}
+
+	var subjects []string
+	for subj := range merged {
+		subjects = append(subjects, subj)
+	}
+	sort.Strings(subjects)
+
+	backups := make([]SubjectBackup, 0, len(subjects))
+	for _, subj := range subjects {
+		backups = append(backups, *merged[subj])
+	}
+	return backups, nil
+}
+
+// mergeSubjectBackup applies a (possibly incremental) subject backup on top of
+// what has been loaded so far: versions are replaced by number, and config and
+// mode come from the latest backup
+func mergeSubjectBackup(merged map[string]*SubjectBackup, backup SubjectBackup) {
+	existing, ok := merged[backup.Subject]
+	if !ok {
+		merged[backup.Subject] = &backup
+		return
+	}
+
+	existing.Compatibility = backup.Compatibility
+	existing.Mode = backup.Mode
+	for _, ver := range backup.Versions {
+		replaced := false
+		for i := range existing.Versions {
+			if existing.Versions[i].Version == ver.Version {
+				existing.Versions[i] = ver
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			existing.Versions = append(existing.Versions, ver)
+		}
+	}
+	sort.Slice(existing.Versions, func(i, j int) bool {
+		return existing.Versions[i].Version < existing.Versions[j].Version
+	})
+}
+
+// restoreLayerParallel restores subjects that do not reference each other using a worker pool
+func restoreLayerParallel(c client.SchemaRegistryClientInterface, layer []SubjectBackup, versions *restoredVersions, numWorkers int, bar *progressbar.ProgressBar) (restored, failed int) {
+	numWorkers = clampWorkers(numWorkers)
+	jobs := make(chan SubjectBackup, len(layer))
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for backup := range jobs {
+				ok := restoreSubjectBackup(c, backup, versions)
+				mu.Lock()
+				if ok {
+					restored++
 				} else {
-					// Reference to a subject not in our backup set - try to rewrite anyway
-					backups[i].Versions[j].References[k].Subject = rewriteSubjectContextForBackup(oldRefSubject, targetContext)
+					failed++
 				}
+				mu.Unlock()
+				bar.Add(1)
 			}
+		}()
+	}
+
+	for _, b := range layer {
+		jobs <- b
+	}
+	close(jobs)
+	wg.Wait()
+
+	return restored, failed
+}
+
+// backupGlobals is the registry-wide compatibility and mode saved by backup
+type backupGlobals struct {
+	Compatibility string
+	Mode          string
+}
+
+// readBackupGlobals reads global-config.json and global-mode.json from a backup
+// directory. Either file may be missing.
+func readBackupGlobals(backupPath string) (backupGlobals, error) {
+	var globals backupGlobals
+	var err error
+	if globals.Compatibility, err = readBackupSetting(backupPath, "global-config.json", "compatibility"); err != nil {
+		return globals, err
+	}
+	if globals.Mode, err = readBackupSetting(backupPath, "global-mode.json", "mode"); err != nil {
+		return globals, err
+	}
+	return globals, nil
+}
+
+// readBackupSetting returns one key of a small JSON settings file, or "" if the
+// file does not exist
+func readBackupSetting(backupPath, name, key string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(backupPath, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	var values map[string]string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return "", fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return values[key], nil
+}
+
+// applyBackupGlobals sets the backed-up global compatibility and, if setMode
+// is true, the global mode. Failures are reported but do not fail the restore.
+func applyBackupGlobals(c client.SchemaRegistryClientInterface, globals backupGlobals, setMode bool) {
+	if globals.Compatibility != "" {
+		output.Step("Restoring global compatibility (%s)...", globals.Compatibility)
+		if err := c.SetConfig(globals.Compatibility); err != nil {
+			output.Warning("Failed to restore global compatibility: %v", err)
+		}
+	}
+	if setMode && globals.Mode != "" {
+		output.Step("Restoring global mode (%s)...", globals.Mode)
+		if err := c.SetMode(globals.Mode); err != nil {
+			output.Warning("Failed to restore global mode: %v", err)
 		}
 	}
 }
 
-// rewriteSubjectContextForBackup rewrites a single subject name to use a new context
-func rewriteSubjectContextForBackup(subject string, targetContext string) string {
-	baseName := subject
+// restoredVersions maps the backed-up versions that other subjects reference
+// to the versions they were restored as. These differ when the backed-up
+// history has gaps, since the registry numbers restored versions from 1.
+type restoredVersions struct {
+	mu         sync.Mutex
+	referenced map[string]map[int]bool
+	restored   map[string]map[int]int
+}
+
+// newRestoredVersions tracks the versions referenced within backups
+func newRestoredVersions(backups []SubjectBackup) *restoredVersions {
+	r := &restoredVersions{
+		referenced: make(map[string]map[int]bool),
+		restored:   make(map[string]map[int]int),
+	}
+	for _, b := range backups {
+		for _, ver := range b.Versions {
+			for _, ref := range ver.References {
+				if r.referenced[ref.Subject] == nil {
+					r.referenced[ref.Subject] = make(map[int]bool)
+				}
+				r.referenced[ref.Subject][ref.Version] = true
+			}
+		}
+	}
+	return r
+}
+
+// isReferenced reports whether another subject references subject's version
+func (r *restoredVersions) isReferenced(subject string, version int) bool {
+	return r.referenced[subject][version]
+}
+
+// record notes that the backed-up version of subject was restored as restored
+func (r *restoredVersions) record(subject string, version, restored int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.restored[subject] == nil {
+		r.restored[subject] = make(map[int]int)
+	}
+	r.restored[subject][version] = restored
+}
+
+// rewrite returns refs pointing at the restored versions. References to
+// versions not restored in this run are kept as they are.
+func (r *restoredVersions) rewrite(refs []client.SchemaReference) []client.SchemaReference {
+	if len(refs) == 0 {
+		return refs
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rewritten := make([]client.SchemaReference, len(refs))
+	for i, ref := range refs {
+		if v, ok := r.restored[ref.Subject][ref.Version]; ok {
+			ref.Version = v
+		}
+		rewritten[i] = ref
+	}
+	return rewritten
+}
 
-	// Check if subject has a context prefix (:.context:name format)
-	if strings.HasPrefix(subject, ":.") {
-		// Find the second colon after the context
-		afterFirstColon := subject[2:] // Skip ":."
-		colonIdx := strings.Index(afterFirstColon, ":")
-		if colonIdx != -1 {
-			baseName = afterFirstColon[colonIdx+1:] // Extract name after context
+// restoreSubjectBackup restores a subject's config, mode and versions, returning
+// whether every version was registered. References are pointed at the versions
+// their subjects were restored as, which dependency order restores first.
+func restoreSubjectBackup(c client.SchemaRegistryClientInterface, backup SubjectBackup, versions *restoredVersions) bool {
+	if restoreResetOverride {
+		if err := clearSubjectOverrides(c, backup.Subject); err != nil {
+			output.Warning("Failed to clear overrides for %s: %v", backup.Subject, err)
 		}
 	}
 
+	// Set subject config
+	if backup.Compatibility != "" {
+		if err := c.SetSubjectConfig(backup.Subject, backup.Compatibility); err != nil {
+			output.Warning("Failed to set compatibility for %s: %v", backup.Subject, err)
+		}
+	}
+
+	// Register schemas (in order by version)
+	sort.Slice(backup.Versions, func(i, j int) bool {
+		return backup.Versions[i].Version < backup.Versions[j].Version
+	})
+
+	// A soft-deleted version with the same schema as a later one must be deleted
+	// before the later one is registered, or the registry folds the later one
+	// onto it and deleting it leaves the subject without a live version
+	var restoring []SchemaVersionBackup
+	for _, ver := range backup.Versions {
+		if !ver.Deleted || !restoreSkipDeleted {
+			restoring = append(restoring, ver)
+		}
+	}
+	deleteNow := make(map[int]bool)
+	for i, ver := range restoring {
+		if !ver.Deleted {
+			continue
+		}
+		for _, later := range restoring[i+1:] {
+			if later.Schema == ver.Schema || (later.SchemaID != 0 && later.SchemaID == ver.SchemaID) {
+				deleteNow[ver.Version] = true
+				break
+			}
+		}
+	}
+
+	allSucceeded := true
+	var toSoftDelete []int
+	for _, ver := range restoring {
+		schema := &client.Schema{
+			Schema:     ver.Schema,
+			SchemaType: ver.SchemaType,
+			References: versions.rewrite(ver.References),
+			Metadata:   ver.Metadata,
+			RuleSet:    ver.RuleSet,
+		}
+		// Include schema ID if preserving IDs
+		if restorePreserveID {
+			schema.ID = ver.SchemaID
+		}
+
+		id, err := c.RegisterSchema(backup.Subject, schema)
+		if err != nil {
+			output.Warning("Failed to restore %s v%d: %v", backup.Subject, ver.Version, err)
+			allSucceeded = false
+			continue
+		}
+		referenced := versions.isReferenced(backup.Subject, ver.Version)
+		if ver.Deleted || referenced {
+			// Version numbers may differ after restore, so look up the one just registered
+			restoredVersion, err := registeredVersion(c, backup.Subject, id)
+			if err != nil {
+				output.Warning("Failed to find restored version of %s v%d: %v", backup.Subject, ver.Version, err)
+				allSucceeded = false
+				continue
+			}
+			if referenced {
+				versions.record(backup.Subject, ver.Version, restoredVersion)
+			}
+			switch {
+			case deleteNow[ver.Version]:
+				if _, err := c.DeleteVersion(backup.Subject, strconv.Itoa(restoredVersion), false); err != nil {
+					output.Warning("Failed to soft-delete restored %s v%d: %v", backup.Subject, restoredVersion, err)
+					allSucceeded = false
+				}
+			case ver.Deleted:
+				toSoftDelete = append(toSoftDelete, restoredVersion)
+			}
+		}
+	}
+
+	// Re-apply the other soft deletes once all versions are registered, so
+	// later versions are checked against the same history as in the original
+	// registry
+	for _, v := range toSoftDelete {
+		if _, err := c.DeleteVersion(backup.Subject, strconv.Itoa(v), false); err != nil {
+			output.Warning("Failed to soft-delete restored %s v%d: %v", backup.Subject, v, err)
+			allSucceeded = false
+		}
+	}
+
+	// Set the subject mode last: a READONLY or READWRITE override would
+	// otherwise block registering the versions above
+	if backup.Mode != "" {
+		if err := c.SetSubjectMode(backup.Subject, backup.Mode); err != nil {
+			output.Warning("Failed to set mode for %s: %v", backup.Subject, err)
+		}
+	}
+	return allSucceeded
+}
+
+// rewriteBackupContexts rewrites subject names and references to use a new context
+func rewriteBackupContexts(backups []SubjectBackup, targetContext string) {
+	renameBackupSubjects(backups, func(subject string) string {
+		return rewriteSubjectContextForBackup(subject, targetContext)
+	})
+}
+
+// renameBackupSubjects renames subjects and the references to them, including
+// references to subjects outside the backup set
+func renameBackupSubjects(backups []SubjectBackup, rename func(string) string) {
+	for i := range backups {
+		backups[i].Subject = rename(backups[i].Subject)
+		for j := range backups[i].Versions {
+			renameReferences(backups[i].Versions[j].References, rename)
+		}
+	}
+}
+
+// rewriteSubjectContextForBackup rewrites a single subject name to use a new context
+func rewriteSubjectContextForBackup(subject string, targetContext string) string {
+	_, baseName, _ := client.SplitQualifiedSubject(subject)
+
 	// Create new subject name with target context
 	if targetContext == "" || targetContext == "." {
 		return baseName
@@ -920,6 +2296,36 @@ func rewriteSubjectContextForBackup(subject string, targetContext string) string
 	return fmt.Sprintf(":%s:%s", ctx, baseName)
 }
 
+// normalizeSourceContext adds the leading dot to a --source-context name;
+// "" means no filtering and "." the default context
+func normalizeSourceContext(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.HasPrefix(name, ".") {
+		return name
+	}
+	return "." + name
+}
+
+// filterBackupsByContext keeps the subjects that belong to sourceContext. Unqualified
+// subjects belong to the context the backup was taken from (backupContext),
+// or the default context.
+func filterBackupsByContext(backups []SubjectBackup, sourceContext, backupContext string) []SubjectBackup {
+	if backupContext == "" {
+		backupContext = "."
+	}
+	var kept []SubjectBackup
+	for _, b := range backups {
+		ctx, _, ok := client.SplitQualifiedSubject(b.Subject)
+		if !ok {
+			ctx = backupContext
+		}
+		if ctx == sourceContext {
+			kept = append(kept, b)
+		}
+	}
+	return kept
+}
+
 // sortBackupsByDependencies sorts backups so dependencies come first
 func sortBackupsByDependencies(backups []SubjectBackup) {
 	// Build dependency graph
@@ -987,6 +2393,58 @@ func sortBackupsByDependencies(backups []SubjectBackup) {
 	copy(backups, result)
 }
 
+// backupDependencyLayers groups backups into topological layers: each layer only
+// references subjects in earlier layers (or outside the backup). Returns nil if
+// the references contain a cycle.
+func backupDependencyLayers(backups []SubjectBackup) [][]SubjectBackup {
+	bySubject := make(map[string]SubjectBackup)
+	for _, b := range backups {
+		bySubject[b.Subject] = b
+	}
+
+	deps := make(map[string]map[string]bool)
+	for _, b := range backups {
+		deps[b.Subject] = make(map[string]bool)
+		for _, ver := range b.Versions {
+			for _, ref := range ver.References {
+				if _, ok := bySubject[ref.Subject]; ok && ref.Subject != b.Subject {
+					deps[b.Subject][ref.Subject] = true
+				}
+			}
+		}
+	}
+
+	var layers [][]SubjectBackup
+	done := 0
+	for done < len(deps) {
+		var ready []string
+		for subj, d := range deps {
+			if d != nil && len(d) == 0 {
+				ready = append(ready, subj)
+			}
+		}
+		if len(ready) == 0 {
+			return nil
+		}
+		sort.Strings(ready)
+
+		layer := make([]SubjectBackup, 0, len(ready))
+		for _, subj := range ready {
+			layer = append(layer, bySubject[subj])
+			deps[subj] = nil
+		}
+		for _, d := range deps {
+			for _, subj := range ready {
+				delete(d, subj)
+			}
+		}
+		layers = append(layers, layer)
+		done += len(ready)
+	}
+
+	return layers
+}
+
 // restoreTagsData restores tag definitions and assignments from backup
 func restoreTagsData(c *client.SchemaRegistryClient, backupPath string) (defsRestored, assignsRestored int) {
 	tagsFile := filepath.Join(backupPath, "tags.json")
@@ -1007,7 +2465,7 @@ func restoreTagsData(c *client.SchemaRegistryClient, backupPath string) (defsRes
 		err := c.CreateTag(&tag)
 		if err != nil {
 			// Tag might already exist, which is fine
-			if !strings.Contains(err.Error(), "already exists") {
+			if !client.IsConflict(err) {
 				output.Warning("Failed to create tag %s: %v", tag.Name, err)
 				continue
 			}
@@ -1021,12 +2479,12 @@ func restoreTagsData(c *client.SchemaRegistryClient, backupPath string) (defsRes
 			var err error
 			if assign.Version == 0 {
 				// Subject-level tag
-				err = c.AssignTagToSubject(assign.Subject, tagName)
+				err = c.AssignTagToSubject(assign.Subject, tagName, assign.Attributes[tagName])
 			} else {
 				// Schema-level tag
-				err = c.AssignTagToSchema(assign.Subject, assign.Version, tagName)
+				err = c.AssignTagToSchema(assign.Subject, assign.Version, tagName, assign.Attributes[tagName])
 			}
-			if err != nil && !strings.Contains(err.Error(), "already") {
+			if err != nil && !client.IsConflict(err) {
 				output.Warning("Failed to assign tag %s to %s: %v", tagName, assign.Subject, err)
 				continue
 			}
@@ -1036,3 +2494,21 @@ func restoreTagsData(c *client.SchemaRegistryClient, backupPath string) (defsRes
 
 	return defsRestored, assignsRestored
 }
+
+// restoreBusinessMetadataData restores business metadata definitions and
+// assignments from backup
+func restoreBusinessMetadataData(c *client.SchemaRegistryClient, backupPath string) (defsRestored, assignsRestored int) {
+	data, err := os.ReadFile(filepath.Join(backupPath, "business-metadata.json"))
+	if err != nil {
+		output.Warning("No business metadata file found in backup")
+		return 0, 0
+	}
+
+	var bmBackup BusinessMetadataBackup
+	if err := json.Unmarshal(data, &bmBackup); err != nil {
+		output.Warning("Failed to parse business metadata file: %v", err)
+		return 0, 0
+	}
+
+	return applyBusinessMetadata(c, bmBackup)
+}
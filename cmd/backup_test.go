@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -279,6 +282,66 @@ func TestBackupResult(t *testing.T) {
 	}
 }
 
+func TestNewTagAssignmentBackup(t *testing.T) {
+	backup := newTagAssignmentBackup("user-events", 2, []client.TagAssignment{
+		{TypeName: "PII", Attributes: map[string]string{"classification": "restricted"}},
+		{TypeName: "SENSITIVE"},
+	})
+
+	if backup.Subject != "user-events" || backup.Version != 2 {
+		t.Errorf("unexpected target %s:%d", backup.Subject, backup.Version)
+	}
+	if !reflect.DeepEqual(backup.TagNames, []string{"PII", "SENSITIVE"}) {
+		t.Errorf("unexpected tag names %v", backup.TagNames)
+	}
+	want := map[string]map[string]string{"PII": {"classification": "restricted"}}
+	if !reflect.DeepEqual(backup.Attributes, want) {
+		t.Errorf("expected attributes %v, got %v", want, backup.Attributes)
+	}
+
+	// Assignments without attributes keep the old JSON shape
+	data, err := json.Marshal(newTagAssignmentBackup("user-events", 0, []client.TagAssignment{{TypeName: "PII"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "attributes") {
+		t.Errorf("expected no attributes key, got %s", data)
+	}
+}
+
+func TestBusinessMetadataRoundTrip(t *testing.T) {
+	source := client.NewMockClient()
+	addTestSubject(source, "orders-value", 2)
+	addTestSubject(source, "users-value", 1)
+	source.BusinessMetadataDefs = []client.BusinessMetadataDef{{Name: "Ownership"}}
+	source.AssignBusinessMetadataToSubject("users-value", "Ownership", map[string]string{"team": "identity"})
+	source.AssignBusinessMetadataToSchema("orders-value", 2, "Ownership", map[string]string{"team": "payments"})
+
+	assignments := collectBusinessMetadata(source, []string{"users-value", "orders-value"}, 2)
+	want := []BusinessMetadataAssignmentBackup{
+		{Subject: "orders-value", Version: 2, Name: "Ownership", Attributes: map[string]string{"team": "payments"}},
+		{Subject: "users-value", Name: "Ownership", Attributes: map[string]string{"team": "identity"}},
+	}
+	if !reflect.DeepEqual(assignments, want) {
+		t.Fatalf("collectBusinessMetadata() = %+v, want %+v", assignments, want)
+	}
+
+	target := client.NewMockClient()
+	defs, assigns := applyBusinessMetadata(target, BusinessMetadataBackup{
+		Definitions: source.BusinessMetadataDefs,
+		Assignments: assignments,
+	})
+	if defs != 1 || assigns != 2 {
+		t.Errorf("expected 1 definition and 2 assignments restored, got %d and %d", defs, assigns)
+	}
+	if got := target.BusinessMetadata["orders-value:2"]; len(got) != 1 || got[0].Attributes["team"] != "payments" {
+		t.Errorf("unexpected restored version metadata %+v", got)
+	}
+	if got := target.BusinessMetadata["users-value"]; len(got) != 1 || got[0].Attributes["team"] != "identity" {
+		t.Errorf("unexpected restored subject metadata %+v", got)
+	}
+}
+
 func TestRestoreTagOperations(t *testing.T) {
 	mock := client.NewMockClient()
 
@@ -303,7 +366,7 @@ func TestRestoreTagOperations(t *testing.T) {
 	}
 
 	// Test assigning tag
-	err = mock.AssignTagToSubject("test-subject", "PII")
+	err = mock.AssignTagToSubject("test-subject", "PII", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -313,3 +376,560 @@ func TestRestoreTagOperations(t *testing.T) {
 		t.Errorf("expected 1 AssignTagToSubject call, got %d", assignCalls)
 	}
 }
+
+func TestBackupDependencyLayers(t *testing.T) {
+	withRefs := func(subject string, refs ...string) SubjectBackup {
+		b := SubjectBackup{Subject: subject, Versions: []SchemaVersionBackup{{Version: 1}}}
+		for _, r := range refs {
+			b.Versions[0].References = append(b.Versions[0].References, client.SchemaReference{Name: r, Subject: r, Version: 1})
+		}
+		return b
+	}
+
+	backups := []SubjectBackup{
+		withRefs("order", "address", "customer"),
+		withRefs("customer", "address"),
+		withRefs("address"),
+		withRefs("product"),
+		withRefs("invoice", "external-subject"),
+	}
+
+	layers := backupDependencyLayers(backups)
+	var got [][]string
+	for _, layer := range layers {
+		var names []string
+		for _, b := range layer {
+			names = append(names, b.Subject)
+		}
+		got = append(got, names)
+	}
+
+	want := [][]string{{"address", "invoice", "product"}, {"customer"}, {"order"}}
+	if len(got) != len(want) {
+		t.Fatalf("expected layers %v, got %v", want, got)
+	}
+	for i := range want {
+		if len(got[i]) != len(want[i]) {
+			t.Fatalf("expected layers %v, got %v", want, got)
+		}
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Errorf("expected layers %v, got %v", want, got)
+			}
+		}
+	}
+
+	// Cycles cannot be layered
+	cyclic := []SubjectBackup{withRefs("a", "b"), withRefs("b", "a")}
+	if layers := backupDependencyLayers(cyclic); layers != nil {
+		t.Errorf("expected nil for cyclic references, got %d layers", len(layers))
+	}
+}
+
+func TestBackupSubjectParallelVersions(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders-value", 25)
+
+	for _, parallel := range []int{0, 1, 8} {
+		backup, ids, failures, err := backupSubject(mock, "orders-value", true, true, nil, parallel, 0)
+		if err != nil {
+			t.Fatalf("parallel %d: unexpected error: %v", parallel, err)
+		}
+		if len(failures) > 0 {
+			t.Fatalf("parallel %d: unexpected failures %v", parallel, failures)
+		}
+		if len(backup.Versions) != 25 || len(ids) != 25 {
+			t.Fatalf("parallel %d: expected 25 versions and ID mappings, got %d and %d", parallel, len(backup.Versions), len(ids))
+		}
+		for i, ver := range backup.Versions {
+			if ver.Version != i+1 || ver.SchemaID != 100+i || ids[i].Version != i+1 {
+				t.Errorf("parallel %d: position %d holds version %d (ID %d)", parallel, i, ver.Version, ver.SchemaID)
+			}
+		}
+	}
+
+	// Versions already in the previous backup are not fetched again
+	prev, _, _, _ := backupSubject(mock, "orders-value", false, true, nil, 4, 0)
+	before := mock.GetCallCount("GetSchemaWithDeleted")
+	backup, _, _, err := backupSubject(mock, "orders-value", false, true, prev, 4, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := mock.GetCallCount("GetSchemaWithDeleted") - before; n != 0 {
+		t.Errorf("expected no schema fetches for known versions, got %d", n)
+	}
+	if len(backup.Versions) != 0 {
+		t.Errorf("expected no changed versions, got %d", len(backup.Versions))
+	}
+}
+
+func TestBackupSubjectMaxVersions(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders-value", 5)
+
+	backup, _, _, err := backupSubject(mock, "orders-value", false, true, nil, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int
+	for _, ver := range backup.Versions {
+		got = append(got, ver.Version)
+	}
+	if !reflect.DeepEqual(got, []int{4, 5}) || !reflect.DeepEqual(backup.kept, []int{4, 5}) {
+		t.Errorf("expected versions [4 5], got %v (kept %v)", got, backup.kept)
+	}
+
+	if v := newestVersions([]int{1, 2}, 5); !reflect.DeepEqual(v, []int{1, 2}) {
+		t.Errorf("expected all versions when under the limit, got %v", v)
+	}
+}
+
+func TestTrimmedReferences(t *testing.T) {
+	results := []backupResult{
+		{Subject: "common", Kept: []int{3, 4}},
+		{Subject: "orders-value", Kept: []int{7}, References: []client.SchemaReference{
+			{Name: "common.Money", Subject: "common", Version: 2},
+			{Name: "common.Id", Subject: "common", Version: 4},
+			{Name: "other.Thing", Subject: "not-backed-up", Version: 1},
+		}},
+		{Subject: "users-value", Kept: []int{2}, References: []client.SchemaReference{
+			{Name: "common.Money", Subject: "common", Version: 2},
+		}},
+	}
+
+	got := trimmedReferences(results)
+	want := []string{
+		"orders-value references common version 2",
+		"users-value references common version 2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("trimmedReferences() = %v, want %v", got, want)
+	}
+}
+
+func TestSoftDeletedVersions(t *testing.T) {
+	deleted := softDeletedVersions([]int{1, 2, 3, 4}, []int{1, 3})
+	if len(deleted) != 2 || !deleted[2] || !deleted[4] {
+		t.Errorf("expected versions 2 and 4 to be soft-deleted, got %v", deleted)
+	}
+
+	// Fully soft-deleted subject
+	deleted = softDeletedVersions([]int{1, 2}, nil)
+	if len(deleted) != 2 {
+		t.Errorf("expected all versions soft-deleted, got %v", deleted)
+	}
+
+	// Deleted flag round-trips and is omitted when false
+	data, _ := json.Marshal(SchemaVersionBackup{Version: 1})
+	if strings.Contains(string(data), "deleted") {
+		t.Errorf("expected deleted to be omitted, got %s", data)
+	}
+}
+
+func TestIncrementalBackupChain(t *testing.T) {
+	root := t.TempDir()
+	writeBackup := func(name, base string, subjects ...SubjectBackup) string {
+		dir := filepath.Join(root, name)
+		os.MkdirAll(filepath.Join(dir, "subjects"), 0755)
+		if err := saveJSON(filepath.Join(dir, "manifest.json"), BackupManifest{Version: "1.0", BaseBackup: base}); err != nil {
+			t.Fatalf("failed to write manifest: %v", err)
+		}
+		for _, sb := range subjects {
+			if err := saveJSON(filepath.Join(dir, "subjects", sb.Subject+".json"), sb); err != nil {
+				t.Fatalf("failed to write subject: %v", err)
+			}
+		}
+		return dir
+	}
+
+	writeBackup("full", "",
+		SubjectBackup{Subject: "orders", Compatibility: "BACKWARD", Versions: []SchemaVersionBackup{{Version: 1, Schema: "v1"}, {Version: 2, Schema: "v2"}}},
+		SubjectBackup{Subject: "users", Versions: []SchemaVersionBackup{{Version: 1, Schema: "u1"}}},
+	)
+	writeBackup("incr1", "../full",
+		SubjectBackup{Subject: "orders", Compatibility: "FULL", Versions: []SchemaVersionBackup{{Version: 2, Schema: "v2", Deleted: true}, {Version: 3, Schema: "v3"}}},
+	)
+	incr2 := writeBackup("incr2", "../incr1",
+		SubjectBackup{Subject: "payments", Versions: []SchemaVersionBackup{{Version: 1, Schema: "p1"}}},
+	)
+
+	chain, cleanup, err := backupChain(incr2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cleanup()
+	if len(chain) != 3 || filepath.Base(chain[0].Dir) != "full" || chain[2].Dir != incr2 {
+		t.Fatalf("expected chain full -> incr1 -> incr2, got %v", chain)
+	}
+
+	backups, err := loadSubjectBackups(chain, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("expected 3 subjects, got %d", len(backups))
+	}
+
+	orders := backups[0]
+	if orders.Subject != "orders" || orders.Compatibility != "FULL" {
+		t.Errorf("expected orders with FULL compatibility, got %s %s", orders.Subject, orders.Compatibility)
+	}
+	if len(orders.Versions) != 3 || !orders.Versions[1].Deleted || orders.Versions[2].Schema != "v3" {
+		t.Errorf("expected v1, soft-deleted v2 and v3, got %+v", orders.Versions)
+	}
+
+	// Subject filter
+	backups, _ = loadSubjectBackups(chain, []string{"payments"})
+	if len(backups) != 1 || backups[0].Subject != "payments" {
+		t.Errorf("expected only payments, got %+v", backups)
+	}
+
+	// A chain that refers back to itself is rejected
+	loop := writeBackup("loop", "../loop")
+	if _, _, err := backupChain(loop); err == nil {
+		t.Error("expected error for looping backup chain")
+	}
+}
+
+func TestVerifyBackupChecksums(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "subjects"), 0755)
+	saveJSON(filepath.Join(dir, "subjects", "orders.json"), SubjectBackup{Subject: "orders"})
+	saveJSON(filepath.Join(dir, "subjects", "users.json"), SubjectBackup{Subject: "users"})
+	saveJSON(filepath.Join(dir, "global-config.json"), map[string]string{"compatibility": "BACKWARD"})
+
+	checksums, err := computeBackupChecksums(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checksums) != 3 || checksums["subjects/orders.json"] == "" {
+		t.Fatalf("expected 3 checksums keyed by relative path, got %v", checksums)
+	}
+	saveJSON(filepath.Join(dir, "manifest.json"), BackupManifest{Version: "1.0", Checksums: checksums})
+
+	result, err := verifyBackup(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Issues) != 0 || result.Files != 3 {
+		t.Errorf("expected clean verification of 3 files, got %+v", result)
+	}
+
+	// Truncate one file, remove another, add an unexpected one
+	os.WriteFile(filepath.Join(dir, "subjects", "orders.json"), []byte(`{"subj`), 0644)
+	os.Remove(filepath.Join(dir, "subjects", "users.json"))
+	os.WriteFile(filepath.Join(dir, "subjects", "extra.json"), []byte(`{}`), 0644)
+
+	result, err = verifyBackup(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	problems := make(map[string]string)
+	for _, issue := range result.Issues {
+		problems[issue.File] = issue.Problem
+	}
+	if problems["subjects/orders.json"] != "checksum mismatch" ||
+		problems["subjects/users.json"] != "missing" ||
+		problems["subjects/extra.json"] != "not in manifest" {
+		t.Errorf("unexpected issues: %v", problems)
+	}
+
+	// Backups without checksums are skipped
+	old := t.TempDir()
+	saveJSON(filepath.Join(old, "manifest.json"), BackupManifest{Version: "1.0"})
+	if result, _ := verifyBackup(old); result == nil || !result.Skipped {
+		t.Error("expected backup without checksums to be skipped")
+	}
+}
+
+func TestBackupWriterArchives(t *testing.T) {
+	for _, format := range []string{"", "tar.gz", "zip"} {
+		t.Run("archive="+format, func(t *testing.T) {
+			root := t.TempDir()
+			w, err := newBackupWriter(filepath.Join(root, "sr-backup-1"), format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			orders := SubjectBackup{Subject: "orders", Versions: []SchemaVersionBackup{{Version: 1, Schema: `{"type":"string"}`}}}
+			if err := w.WriteJSON("subjects/orders.json", orders); err != nil {
+				t.Fatalf("failed to write subject: %v", err)
+			}
+			if err := w.WriteJSON("manifest.json", BackupManifest{Version: "1.0", Checksums: w.Checksums()}); err != nil {
+				t.Fatalf("failed to write manifest: %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("failed to close: %v", err)
+			}
+
+			if format != "" && w.Location() != filepath.Join(root, "sr-backup-1."+format) {
+				t.Errorf("unexpected archive location %s", w.Location())
+			}
+
+			chain, cleanup, err := backupChain(w.Location())
+			if err != nil {
+				t.Fatalf("failed to open backup: %v", err)
+			}
+			defer cleanup()
+
+			result, err := verifyBackup(chain[0].Dir)
+			if err != nil || len(result.Issues) != 0 || result.Files != 1 {
+				t.Errorf("expected 1 verified file, got %+v (err %v)", result, err)
+			}
+
+			backups, err := loadSubjectBackups(chain, nil)
+			if err != nil || len(backups) != 1 || backups[0].Subject != "orders" {
+				t.Errorf("expected orders backup, got %+v (err %v)", backups, err)
+			}
+		})
+	}
+
+	if _, err := newBackupWriter(t.TempDir(), "rar"); err == nil {
+		t.Error("expected error for unsupported archive format")
+	}
+}
+
+func TestRestoreBackupGlobals(t *testing.T) {
+	dir := t.TempDir()
+
+	// A backup without global files restores nothing
+	globals, err := readBackupGlobals(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if globals != (backupGlobals{}) {
+		t.Errorf("expected empty globals, got %+v", globals)
+	}
+
+	saveJSON(filepath.Join(dir, "global-config.json"), map[string]string{"compatibility": "FULL_TRANSITIVE"})
+	saveJSON(filepath.Join(dir, "global-mode.json"), map[string]string{"mode": "READONLY"})
+	globals, err = readBackupGlobals(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if globals.Compatibility != "FULL_TRANSITIVE" || globals.Mode != "READONLY" {
+		t.Errorf("unexpected globals: %+v", globals)
+	}
+
+	mock := client.NewMockClient()
+	applyBackupGlobals(mock, globals, false)
+	if mock.GlobalConfig.CompatibilityLevel != "FULL_TRANSITIVE" {
+		t.Errorf("expected global compatibility FULL_TRANSITIVE, got %s", mock.GlobalConfig.CompatibilityLevel)
+	}
+	if mock.GetCallCount("SetMode") != 0 {
+		t.Error("expected mode to be left to the caller")
+	}
+
+	applyBackupGlobals(mock, globals, true)
+	if mock.GlobalMode.Mode != "READONLY" {
+		t.Errorf("expected global mode READONLY, got %s", mock.GlobalMode.Mode)
+	}
+}
+
+func TestReadBackupManifestVersion(t *testing.T) {
+	dir := t.TempDir()
+	write := func(m BackupManifest) {
+		t.Helper()
+		if err := saveJSON(filepath.Join(dir, "manifest.json"), m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(BackupManifest{Version: backupFormatVersion})
+	if _, err := readBackupManifest(dir); err != nil {
+		t.Errorf("current format: %v", err)
+	}
+
+	write(BackupManifest{})
+	if m, err := readBackupManifest(dir); err != nil || m.Version != "1.0" {
+		t.Errorf("a manifest without a version should read as 1.0, got %+v, %v", m, err)
+	}
+
+	write(BackupManifest{Version: "2.0"})
+	if _, err := readBackupManifest(dir); err == nil || !strings.Contains(err.Error(), "unsupported backup format version 2.0") {
+		t.Errorf("expected an unsupported format error, got %v", err)
+	}
+
+	// An older format is migrated step by step
+	backupManifestMigrations["0.9"] = func(m *BackupManifest) error {
+		m.Version = backupFormatVersion
+		m.BySchemaID = true
+		return nil
+	}
+	defer delete(backupManifestMigrations, "0.9")
+	write(BackupManifest{Version: "0.9"})
+	m, err := readBackupManifest(dir)
+	if err != nil {
+		t.Fatalf("migration: %v", err)
+	}
+	if m.Version != backupFormatVersion || !m.BySchemaID {
+		t.Errorf("manifest not migrated: %+v", m)
+	}
+}
+
+// softDeleteMock reports some versions as soft-deleted, which the mock client
+// does not model
+type softDeleteMock struct {
+	*client.MockSchemaRegistryClient
+	deleted map[int]bool
+}
+
+func (m *softDeleteMock) GetVersions(subject string, includeDeleted bool) ([]int, error) {
+	versions, err := m.MockSchemaRegistryClient.GetVersions(subject, includeDeleted)
+	if err != nil || includeDeleted {
+		return versions, err
+	}
+	var active []int
+	for _, v := range versions {
+		if !m.deleted[v] {
+			active = append(active, v)
+		}
+	}
+	return active, nil
+}
+
+// RegisterSchema returns the ID of a live version with the same schema
+// instead of adding a version, as the registry does
+func (m *softDeleteMock) RegisterSchema(subject string, schema *client.Schema) (int, error) {
+	live, _ := m.GetVersions(subject, false)
+	for _, v := range live {
+		if existing, err := m.GetSchema(subject, strconv.Itoa(v)); err == nil && existing.Schema == schema.Schema {
+			return existing.ID, nil
+		}
+	}
+	return m.MockSchemaRegistryClient.RegisterSchema(subject, schema)
+}
+
+func (m *softDeleteMock) DeleteVersion(subject string, version string, permanent bool) (int, error) {
+	v, err := m.MockSchemaRegistryClient.DeleteVersion(subject, version, permanent)
+	if err == nil && !permanent {
+		m.deleted[v] = true
+	}
+	return v, err
+}
+
+func TestBackupSubjectIncludeDeleted(t *testing.T) {
+	mock := &softDeleteMock{MockSchemaRegistryClient: client.NewMockClient(), deleted: map[int]bool{2: true}}
+	addTestSubject(mock.MockSchemaRegistryClient, "orders-value", 3)
+
+	backup, _, _, err := backupSubject(mock, "orders-value", false, true, nil, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backup.Versions) != 3 || !backup.Versions[1].Deleted {
+		t.Errorf("expected 3 versions with version 2 soft-deleted, got %+v", backup.Versions)
+	}
+
+	backup, _, _, err = backupSubject(mock, "orders-value", false, false, nil, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int
+	for _, ver := range backup.Versions {
+		got = append(got, ver.Version)
+		if ver.Deleted {
+			t.Errorf("version %d should not be marked deleted", ver.Version)
+		}
+	}
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("expected only active versions [1 3], got %v", got)
+	}
+}
+
+func TestFilterBackupsByContext(t *testing.T) {
+	backups := []SubjectBackup{{Subject: "users-value"}, {Subject: ":.prod:orders-value"}, {Subject: ":.staging:orders-value"}, {Subject: ":.prod:users-value"}}
+	subjects := func(bs []SubjectBackup) []string {
+		var names []string
+		for _, b := range bs {
+			names = append(names, b.Subject)
+		}
+		return names
+	}
+
+	if got := subjects(filterBackupsByContext(backups, normalizeSourceContext("prod"), "")); !reflect.DeepEqual(got, []string{":.prod:orders-value", ":.prod:users-value"}) {
+		t.Errorf("prod: got %v", got)
+	}
+	if got := subjects(filterBackupsByContext(backups, normalizeSourceContext("."), "")); !reflect.DeepEqual(got, []string{"users-value"}) {
+		t.Errorf("default context: got %v", got)
+	}
+	// Unqualified subjects of a backup taken with --context belong to that context
+	if got := subjects(filterBackupsByContext(backups, ".staging", ".staging")); !reflect.DeepEqual(got, []string{"users-value", ":.staging:orders-value"}) {
+		t.Errorf("staging: got %v", got)
+	}
+}
+
+func TestRestoreSubjectBackupRewritesReferenceVersions(t *testing.T) {
+	mock := client.NewMockClient()
+	backups := []SubjectBackup{
+		{Subject: ":.prod:common", Versions: []SchemaVersionBackup{
+			{Version: 2, SchemaType: "AVRO", Schema: `{"type":"enum","name":"Status","symbols":["A"]}`},
+			{Version: 4, SchemaType: "AVRO", Schema: `{"type":"enum","name":"Status","symbols":["A","B"]}`},
+		}},
+		{Subject: ":.prod:orders-value", Versions: []SchemaVersionBackup{
+			{Version: 1, SchemaType: "AVRO", Schema: `{"type":"record","name":"Order","fields":[{"name":"status","type":"Status"}]}`,
+				References: []client.SchemaReference{{Name: "Status", Subject: ":.prod:common", Version: 4}}},
+		}},
+	}
+	rewriteBackupContexts(backups, ".staging")
+
+	versions := newRestoredVersions(backups)
+	for _, b := range backups {
+		if !restoreSubjectBackup(mock, b, versions) {
+			t.Fatalf("failed to restore %s", b.Subject)
+		}
+	}
+
+	orders, err := mock.GetSchema(":.staging:orders-value", "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := client.SchemaReference{Name: "Status", Subject: ":.staging:common", Version: 2}
+	if len(orders.References) != 1 || orders.References[0] != want {
+		t.Errorf("expected reference %+v, got %+v", want, orders.References)
+	}
+	// The backup itself is left as it was
+	if v := backups[1].Versions[0].References[0].Version; v != 4 {
+		t.Errorf("backup reference should keep version 4, got %d", v)
+	}
+}
+
+func TestRenameBackupSubjects(t *testing.T) {
+	backups := []SubjectBackup{
+		{Subject: "common", Versions: []SchemaVersionBackup{{Version: 1}}},
+		{Subject: "orders-value", Versions: []SchemaVersionBackup{{Version: 1, References: []client.SchemaReference{
+			{Name: "Status", Subject: "common", Version: 1},
+			{Name: "Money", Subject: "money", Version: 3},
+		}}}},
+	}
+	renames := subjectMap{"common": "shared-types", "money": "billing-money"}
+	renameBackupSubjects(backups, renames.rename)
+
+	if backups[0].Subject != "shared-types" || backups[1].Subject != "orders-value" {
+		t.Errorf("unexpected subjects %s, %s", backups[0].Subject, backups[1].Subject)
+	}
+	refs := backups[1].Versions[0].References
+	if refs[0].Subject != "shared-types" || refs[1].Subject != "billing-money" {
+		t.Errorf("references not renamed: %+v", refs)
+	}
+}
+
+func TestRestoreSubjectBackupReregisteredDeletedSchema(t *testing.T) {
+	mock := &softDeleteMock{MockSchemaRegistryClient: client.NewMockClient(), deleted: map[int]bool{}}
+	schema := `{"type":"record","name":"User","fields":[{"name":"id","type":"long"}]}`
+	backup := SubjectBackup{Subject: "users-value", Versions: []SchemaVersionBackup{
+		{Version: 1, SchemaID: 7, SchemaType: "AVRO", Schema: schema, Deleted: true},
+		{Version: 2, SchemaID: 7, SchemaType: "AVRO", Schema: schema},
+	}}
+
+	if !restoreSubjectBackup(mock, backup, newRestoredVersions(nil)) {
+		t.Fatal("restore reported a failure")
+	}
+	live, err := mock.GetVersions("users-value", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, _ := mock.GetVersions("users-value", true)
+	if !reflect.DeepEqual(live, []int{2}) || !reflect.DeepEqual(all, []int{1, 2}) {
+		t.Errorf("expected v1 soft-deleted and v2 live, got live %v of %v", live, all)
+	}
+}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var canonicalizeFile string
+
+var canonicalizeCmd = &cobra.Command{
+	Use:     "canonicalize [subject] [version]",
+	Short:   "Print an Avro schema in Parsing Canonical Form",
+	GroupID: groupSchema,
+	Long: `Print an Avro schema in the Parsing Canonical Form from the Avro specification.
+
+The canonical form keeps only what affects how data is read: doc, aliases,
+defaults, order and logical types are dropped, names are fully qualified, keys
+are in a fixed order and there is no whitespace. Two schemas with the same
+canonical form read and write the same data, which makes it the basis for
+fingerprint and compare --normalize.
+
+Unlike normalize, which keeps every attribute and only sorts keys, the output
+is not meant to replace the schema. Only Avro schemas have a canonical form.
+
+Examples:
+  # Canonical form of a local file
+  srctl canonicalize --file ./schemas/user.avsc
+
+  # Canonical form of a registered schema (latest, or a given version)
+  srctl canonicalize user-events
+  srctl canonicalize user-events 3`,
+	Args:        cobra.MaximumNArgs(2),
+	Annotations: readOnlyAnnotations,
+	RunE:        runCanonicalize,
+}
+
+func init() {
+	canonicalizeCmd.Flags().StringVarP(&canonicalizeFile, "file", "f", "", "Canonicalize a local schema file instead of a registered schema")
+
+	rootCmd.AddCommand(canonicalizeCmd)
+}
+
+func runCanonicalize(cmd *cobra.Command, args []string) error {
+	if (len(args) == 0) == (canonicalizeFile == "") {
+		return fmt.Errorf("provide either a subject or --file")
+	}
+
+	var content, schemaType string
+	if canonicalizeFile != "" {
+		data, err := os.ReadFile(canonicalizeFile)
+		if err != nil {
+			return fmt.Errorf("failed to read schema file: %w", err)
+		}
+		content = string(data)
+		schemaType = detectSchemaType(content, canonicalizeFile)
+	} else {
+		version := "latest"
+		if len(args) > 1 {
+			version = args[1]
+		}
+		c, err := GetClient()
+		if err != nil {
+			return err
+		}
+		schema, err := c.GetSchema(args[0], version)
+		if err != nil {
+			return fmt.Errorf("failed to get schema: %w", err)
+		}
+		content, schemaType = schema.Schema, schema.SchemaType
+	}
+	if schemaType != "" && schemaType != "AVRO" {
+		return fmt.Errorf("%s schemas have no Parsing Canonical Form; use normalize instead", schemaType)
+	}
+
+	canonical, err := canonicalizeAvro(content)
+	if err != nil {
+		return fmt.Errorf("failed to canonicalize schema: %w", err)
+	}
+
+	if outputFormat != "table" {
+		return output.NewPrinter(outputFormat).Print(map[string]interface{}{
+			"schemaType": "AVRO",
+			"canonical":  canonical,
+		})
+	}
+
+	fmt.Println(canonical)
+	return nil
+}
+
+// canonicalizeAvro returns the Parsing Canonical Form of an Avro schema: only
+// the attributes that affect parsing, in a fixed order, with full names and no
+// whitespace. Named types after their first definition are written as names.
+func canonicalizeAvro(schema string) (string, error) {
+	dec := json.NewDecoder(strings.NewReader(schema))
+	dec.UseNumber()
+	var node interface{}
+	if err := dec.Decode(&node); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := writeAvroCanonical(&b, node, "", make(map[string]bool)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeAvroCanonical writes node in Parsing Canonical Form. ns is the
+// enclosing namespace and seen the named types already written, which later
+// uses refer to by full name.
+func writeAvroCanonical(b *strings.Builder, node interface{}, ns string, seen map[string]bool) error {
+	switch n := node.(type) {
+	case string:
+		if !avroPrimitiveTypes[n] {
+			n = avroFullName(n, ns)
+		}
+		writeCanonicalString(b, n)
+		return nil
+	case []interface{}:
+		b.WriteByte('[')
+		for i, branch := range n {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := writeAvroCanonical(b, branch, ns, seen); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+		return nil
+	}
+
+	n, ok := node.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected Avro schema element: %v", node)
+	}
+	typ, ok := n["type"].(string)
+	if !ok {
+		// {"type": {...}} or {"type": [...]} wraps another schema
+		if inner, ok := n["type"]; ok {
+			return writeAvroCanonical(b, inner, ns, seen)
+		}
+		return fmt.Errorf("schema object has no type")
+	}
+
+	switch typ {
+	case "record", "error", "enum", "fixed":
+	case "array":
+		b.WriteString(`{"type":"array","items":`)
+		if err := writeAvroCanonical(b, n["items"], ns, seen); err != nil {
+			return err
+		}
+		b.WriteByte('}')
+		return nil
+	case "map":
+		b.WriteString(`{"type":"map","values":`)
+		if err := writeAvroCanonical(b, n["values"], ns, seen); err != nil {
+			return err
+		}
+		b.WriteByte('}')
+		return nil
+	default:
+		// A primitive with attributes such as logicalType, or a named type reference
+		return writeAvroCanonical(b, typ, ns, seen)
+	}
+
+	name, _ := n["name"].(string)
+	if name == "" {
+		return fmt.Errorf("%s has no name", typ)
+	}
+	if namespace, ok := n["namespace"].(string); ok {
+		ns = namespace
+	}
+	fullName := avroFullName(name, ns)
+	if seen[fullName] {
+		writeCanonicalString(b, fullName)
+		return nil
+	}
+	seen[fullName] = true
+	ns = ""
+	if i := strings.LastIndex(fullName, "."); i >= 0 {
+		ns = fullName[:i]
+	}
+
+	if typ == "error" {
+		typ = "record"
+	}
+	b.WriteString(`{"name":`)
+	writeCanonicalString(b, fullName)
+	b.WriteString(`,"type":`)
+	writeCanonicalString(b, typ)
+
+	switch typ {
+	case "record":
+		fields, _ := n["fields"].([]interface{})
+		b.WriteString(`,"fields":[`)
+		for i, f := range fields {
+			field, ok := f.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("invalid field in %s", fullName)
+			}
+			fieldName, _ := field["name"].(string)
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(`{"name":`)
+			writeCanonicalString(b, fieldName)
+			b.WriteString(`,"type":`)
+			if err := writeAvroCanonical(b, field["type"], ns, seen); err != nil {
+				return err
+			}
+			b.WriteByte('}')
+		}
+		b.WriteByte(']')
+	case "enum":
+		symbols, _ := n["symbols"].([]interface{})
+		b.WriteString(`,"symbols":[`)
+		for i, s := range symbols {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			symbol, _ := s.(string)
+			writeCanonicalString(b, symbol)
+		}
+		b.WriteByte(']')
+	case "fixed":
+		size, err := strconv.Atoi(fmt.Sprint(n["size"]))
+		if err != nil {
+			return fmt.Errorf("invalid size for fixed %s: %v", fullName, n["size"])
+		}
+		b.WriteString(`,"size":`)
+		b.WriteString(strconv.Itoa(size))
+	}
+	b.WriteByte('}')
+	return nil
+}
+
+// writeCanonicalString writes s as a JSON string, leaving non-ASCII and
+// HTML characters unescaped
+func writeCanonicalString(b *strings.Builder, s string) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	b.WriteString(strings.TrimSuffix(buf.String(), "\n"))
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import "testing"
+
+func TestCanonicalizeAvro(t *testing.T) {
+	schema := `{
+  "type": "record",
+  "namespace": "com.example",
+  "name": "Order",
+  "doc": "An order",
+  "fields": [
+    {"name": "id", "type": {"type": "string", "logicalType": "uuid"}, "doc": "Order ID"},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "PAID"]}, "default": "NEW"},
+    {"name": "previous", "type": ["null", "Status"], "default": null},
+    {"name": "hash", "type": {"size": "16", "type": "fixed", "name": "Hash", "namespace": "com.example.types"}},
+    {"name": "tags", "type": {"type": "map", "values": {"type": "array", "items": "string"}}}
+  ]
+}`
+	want := `{"name":"com.example.Order","type":"record","fields":[` +
+		`{"name":"id","type":"string"},` +
+		`{"name":"status","type":{"name":"com.example.Status","type":"enum","symbols":["NEW","PAID"]}},` +
+		`{"name":"previous","type":["null","com.example.Status"]},` +
+		`{"name":"hash","type":{"name":"com.example.types.Hash","type":"fixed","size":16}},` +
+		`{"name":"tags","type":{"type":"map","values":{"type":"array","items":"string"}}}]}`
+
+	got, err := canonicalizeAvro(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	// Nested types inherit the namespace of the enclosing full name
+	got, err = canonicalizeAvro(`{"type":"record","name":"a.Outer","fields":[{"name":"in","type":{"type":"record","name":"Inner","fields":[]}},{"name":"again","type":"a.Inner"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"a.Outer","type":"record","fields":[{"name":"in","type":{"name":"a.Inner","type":"record","fields":[]}},{"name":"again","type":"a.Inner"}]}`; got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	if _, err := canonicalizeAvro(`{"type":"record","fields":[]}`); err == nil {
+		t.Error("expected an error for a record without a name")
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/srctl/srctl/internal/output"
+)
+
+// Checkpoint records the subjects and versions a clone or backup finished, so
+// a re-run with the same --checkpoint file skips them. It is rewritten after
+// each subject completes. A nil *Checkpoint is valid and records nothing.
+type Checkpoint struct {
+	Command   string                        `json:"command"`
+	Source    string                        `json:"source,omitempty"`   // clone: source registry and context
+	Target    string                        `json:"target,omitempty"`   // clone: target registry and context
+	Location  string                        `json:"location,omitempty"` // backup: directory being written
+	UpdatedAt time.Time                     `json:"updatedAt"`
+	Subjects  map[string]*CheckpointSubject `json:"subjects"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// CheckpointSubject is the finished work for one subject. A clone records
+// each source version registered in the target; a backup records a subject
+// once its file is saved, with what the manifest needs to count it.
+type CheckpointSubject struct {
+	Versions   []int       `json:"versions,omitempty"`
+	Unchanged  bool        `json:"unchanged,omitempty"`
+	IDMappings []IDMapping `json:"idMappings,omitempty"`
+}
+
+// loadCheckpoint reads the checkpoint at path, or starts an empty one if the
+// file doesn't exist yet. A checkpoint written by another command is an error.
+func loadCheckpoint(path, command string) (*Checkpoint, error) {
+	cp := &Checkpoint{Command: command, Subjects: make(map[string]*CheckpointSubject), path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	if cp.Command != command {
+		return nil, fmt.Errorf("checkpoint %s was written by %s, not %s", path, cp.Command, command)
+	}
+	if cp.Subjects == nil {
+		cp.Subjects = make(map[string]*CheckpointSubject)
+	}
+	return cp, nil
+}
+
+// Resumed reports whether the checkpoint already holds finished work
+func (cp *Checkpoint) Resumed() bool {
+	if cp == nil {
+		return false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return len(cp.Subjects) > 0
+}
+
+// Subject returns the finished work recorded for subj, or nil
+func (cp *Checkpoint) Subject(subj string) *CheckpointSubject {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.Subjects[subj]
+}
+
+// VersionDone reports whether version of subj was recorded
+func (cp *Checkpoint) VersionDone(subj string, version int) bool {
+	s := cp.Subject(subj)
+	if s == nil {
+		return false
+	}
+	for _, v := range s.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordVersion marks one version of subj as done; call Save to persist it
+func (cp *Checkpoint) RecordVersion(subj string, version int) {
+	if cp == nil {
+		return
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	s := cp.Subjects[subj]
+	if s == nil {
+		s = &CheckpointSubject{}
+		cp.Subjects[subj] = s
+	}
+	s.Versions = append(s.Versions, version)
+	sort.Ints(s.Versions)
+}
+
+// RecordSubject marks subj as done and saves the checkpoint
+func (cp *Checkpoint) RecordSubject(subj string, s CheckpointSubject) error {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	cp.Subjects[subj] = &s
+	cp.mu.Unlock()
+	return cp.Save()
+}
+
+// Save writes the checkpoint through a temporary file, so an interrupted
+// write never leaves it truncated
+func (cp *Checkpoint) Save() error {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(cp.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to save checkpoint: %w", err)
+		}
+	}
+	tmp := cp.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, cp.path); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the checkpoint file once the run has nothing left to resume
+func (cp *Checkpoint) Remove() error {
+	if cp == nil {
+		return nil
+	}
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
+
+// finishCheckpoint removes the checkpoint after a run without failures, so the
+// next run starts over, or says how to resume the failed work
+func finishCheckpoint(cp *Checkpoint, failed int) {
+	if cp == nil {
+		return
+	}
+	if failed > 0 {
+		output.Info("Progress saved; re-run with --checkpoint %s to retry what failed", cp.path)
+		return
+	}
+	if err := cp.Remove(); err != nil {
+		output.Warning("%v", err)
+	}
+}
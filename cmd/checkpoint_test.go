@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "backup.json")
+
+	cp, err := loadCheckpoint(path, "backup")
+	if err != nil {
+		t.Fatalf("loadCheckpoint on a missing file: %v", err)
+	}
+	if cp.Resumed() {
+		t.Fatal("a new checkpoint should not be resumed")
+	}
+	cp.Location = "/backups/sr-backup-1"
+	r := backupResult{Subject: "orders-value", VersionCount: 2, Saved: []int{1, 2}, IDMappings: []IDMapping{{SchemaID: 7, Subject: "orders-value", Version: 2}}}
+	if err := cp.RecordSubject(r.Subject, checkpointSubject(r)); err != nil {
+		t.Fatalf("RecordSubject: %v", err)
+	}
+
+	loaded, err := loadCheckpoint(path, "backup")
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if !loaded.Resumed() || loaded.Location != "/backups/sr-backup-1" {
+		t.Fatalf("unexpected checkpoint: %+v", loaded)
+	}
+	if !loaded.VersionDone("orders-value", 2) || loaded.VersionDone("orders-value", 3) || loaded.VersionDone("users-value", 1) {
+		t.Errorf("unexpected versions: %+v", loaded.Subjects)
+	}
+	if s := loaded.Subject("orders-value"); len(s.IDMappings) != 1 || s.IDMappings[0].SchemaID != 7 {
+		t.Errorf("ID mappings not kept: %+v", s)
+	}
+
+	// Another command's checkpoint is refused
+	if _, err := loadCheckpoint(path, "clone"); err == nil {
+		t.Error("expected an error loading a backup checkpoint for clone")
+	}
+
+	// Failures keep the file for the next run; a clean run removes it
+	finishCheckpoint(loaded, 1)
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("checkpoint should be kept after failures: %v", err)
+	}
+	finishCheckpoint(loaded, 0)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("checkpoint should be removed after a clean run, got %v", err)
+	}
+
+	// A nil checkpoint records nothing
+	var none *Checkpoint
+	none.RecordVersion("orders-value", 1)
+	if none.VersionDone("orders-value", 1) || none.Save() != nil {
+		t.Error("nil checkpoint should be a no-op")
+	}
+}
+
+func TestLoadCloneCheckpoint(t *testing.T) {
+	savedSource, savedTarget, savedCtx := cloneSource, cloneTarget, cloneTargetContext
+	defer func() { cloneSource, cloneTarget, cloneTargetContext = savedSource, savedTarget, savedCtx }()
+	cloneSource, cloneTarget, cloneTargetContext = "dev", "prod", ".orders"
+
+	path := filepath.Join(t.TempDir(), "clone.json")
+	cp, err := loadCloneCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.RecordVersion("orders-value", 1)
+	if err := cp.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadCloneCheckpoint(path); err != nil {
+		t.Errorf("same source and target should resume: %v", err)
+	}
+	cloneTargetContext = ".payments"
+	if _, err := loadCloneCheckpoint(path); err == nil {
+		t.Error("expected an error resuming into a different target context")
+	}
+}
@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
-	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/srctl/srctl/internal/client"
 	"github.com/srctl/srctl/internal/output"
@@ -31,15 +36,33 @@ Examples:
   # Compare specific subjects
   srctl compare --source dev --target prod --subjects user-events
 
+  # Compare subjects matching a pattern (glob, or regex with --regex)
+  srctl compare --source dev --target prod --filter "user-*"
+  srctl compare --source dev --target prod --filter '^user-.*-value$' --regex
+
   # Compare by schema ID
   srctl compare --source dev --target prod --by-id
 
+  # Compare every version's schema and references, not just the latest
+  srctl compare --source dev --target prod --deep
+
+  # Ignore whitespace and key-order differences in schema content
+  srctl compare --source dev --target prod --normalize
+
+  # Compare Avro in Parsing Canonical Form: doc, default, logicalType,
+  # aliases and order drift is not reported
+  srctl compare --source dev --target prod --canonical
+
   # Show only differences
   srctl compare --source dev --target prod --diff-only
 
+  # Write a reviewable plan of drifted subjects for clone --plan
+  srctl compare --source dev --target prod --export-diff drift.json
+
   # Compare specific contexts
   srctl compare --source dev --target prod --source-context .staging --target-context .production`,
-	RunE: runCompare,
+	Annotations: readOnlyAnnotations,
+	RunE:        runCompare,
 }
 
 var (
@@ -51,13 +74,26 @@ var (
 	compareSourceContext string
 	compareTargetContext string
 	compareWorkers       int
+	compareFilter        string
+	compareRegex         bool
+	compareDeep          bool
+	compareExportDiff    string
+	compareNormalize     bool
+	compareCanonical     bool
 )
 
 func init() {
 	compareCmd.Flags().StringVar(&compareSource, "source", "", "Source registry name (required)")
 	compareCmd.Flags().StringVar(&compareTarget, "target", "", "Target registry name (required)")
 	compareCmd.Flags().StringSliceVar(&compareSubjects, "subjects", nil, "Compare only specific subjects")
+	compareCmd.Flags().StringVarP(&compareFilter, "filter", "f", "", "Compare only subjects matching a glob pattern")
+	compareCmd.Flags().BoolVar(&compareRegex, "regex", false, "Treat --filter as a regular expression instead of a glob")
 	compareCmd.Flags().BoolVar(&compareByID, "by-id", false, "Compare using schema IDs")
+	compareCmd.Flags().BoolVar(&compareDeep, "deep", false, "Compare every version's schema and references, reporting the first divergence")
+	addJSONLFlag(compareCmd)
+	compareCmd.Flags().StringVar(&compareExportDiff, "export-diff", "", "Write source-only and differing subjects to a JSON plan for clone --plan")
+	compareCmd.Flags().BoolVar(&compareNormalize, "normalize", false, "Canonicalize schemas before comparing content (ignores whitespace and key order)")
+	compareCmd.Flags().BoolVar(&compareCanonical, "canonical", false, "Compare Avro schemas in Parsing Canonical Form (also ignores doc, default, logicalType, aliases and order, so drift in those is not reported)")
 	compareCmd.Flags().BoolVar(&compareDiffOnly, "diff-only", false, "Show only differences")
 	compareCmd.Flags().StringVar(&compareSourceContext, "source-context", "", "Source context")
 	compareCmd.Flags().StringVar(&compareTargetContext, "target-context", "", "Target context")
@@ -80,10 +116,14 @@ type CompareResult struct {
 	TargetVers   int
 	SourceLatest int
 	TargetLatest int
+	FirstDiff    int    // first diverging source version with --deep
+	DiffReason   string // why FirstDiff diverges
+	Versions     []int  // source versions, when known
 	Error        string // non-empty if comparison failed for this subject
 }
 
 func runCompare(cmd *cobra.Command, args []string) error {
+	startSummary()
 	output.Header("Registry Comparison")
 	output.Info("Source: %s", compareSource)
 	output.Info("Target: %s", compareTarget)
@@ -107,15 +147,17 @@ func runCompare(cmd *cobra.Command, args []string) error {
 		targetClient = targetClient.WithContext(compareTargetContext)
 	}
 
-	// Get subjects from both registries
+	// Get subjects from both registries, narrowed server-side when the filter
+	// has a literal prefix
+	prefix := subjectPrefix(compareFilter, compareRegex)
 	output.Step("Fetching subjects from source...")
-	sourceSubjects, err := sourceClient.GetSubjects(false)
+	sourceSubjects, err := listSubjects(sourceClient, prefix, false)
 	if err != nil {
 		return fmt.Errorf("failed to get source subjects: %w", err)
 	}
 
 	output.Step("Fetching subjects from target...")
-	targetSubjects, err := targetClient.GetSubjects(false)
+	targetSubjects, err := listSubjects(targetClient, prefix, false)
 	if err != nil {
 		return fmt.Errorf("failed to get target subjects: %w", err)
 	}
@@ -125,6 +167,12 @@ func runCompare(cmd *cobra.Command, args []string) error {
 		sourceSubjects = filterByList(sourceSubjects, compareSubjects)
 		targetSubjects = filterByList(targetSubjects, compareSubjects)
 	}
+	if sourceSubjects, err = matchSubjects(sourceSubjects, compareFilter, compareRegex); err != nil {
+		return err
+	}
+	if targetSubjects, err = matchSubjects(targetSubjects, compareFilter, compareRegex); err != nil {
+		return err
+	}
 
 	// Build maps
 	sourceMap := make(map[string]bool)
@@ -155,7 +203,34 @@ func runCompare(cmd *cobra.Command, args []string) error {
 		sourceClient, targetClient, allSubjects, sourceMap, targetMap,
 	)
 
-	// Display results
+	if structuredOutput() {
+		report := buildCompareReport(results, identical, different, sourceOnly, targetOnly)
+		if err := printSummary(report); err != nil {
+			return err
+		}
+	} else {
+		printCompareResults(results, identical, different, sourceOnly, targetOnly)
+	}
+
+	if compareExportDiff != "" {
+		plan := buildDiffPlan(results)
+		plan.Source = compareSource
+		plan.Target = compareTarget
+		plan.SourceContext = compareSourceContext
+		plan.TargetContext = compareTargetContext
+		if err := saveJSON(compareExportDiff, plan); err != nil {
+			return fmt.Errorf("failed to write diff plan: %w", err)
+		}
+		output.Success("Wrote plan for %d subject(s) to %s", len(plan.Subjects), compareExportDiff)
+		output.Info("Review it, then reconcile with: srctl clone --source %s --target %s --plan %s",
+			compareSource, compareTarget, compareExportDiff)
+	}
+
+	return nil
+}
+
+// printCompareResults prints the summary and per-subject details as tables
+func printCompareResults(results []CompareResult, identical, different, sourceOnly, targetOnly int) {
 	output.Header("Comparison Results")
 
 	// Summary
@@ -193,17 +268,7 @@ func runCompare(cmd *cobra.Command, args []string) error {
 		output.SubHeader("Subjects with Differences")
 		rows := [][]string{}
 		for _, r := range results {
-			if r.VersionDiff || r.SchemaDiff || r.ConfigDiff {
-				diffs := []string{}
-				if r.VersionDiff {
-					diffs = append(diffs, fmt.Sprintf("versions (%d/%d)", r.SourceVers, r.TargetVers))
-				}
-				if r.SchemaDiff {
-					diffs = append(diffs, "schema content")
-				}
-				if r.ConfigDiff {
-					diffs = append(diffs, "config")
-				}
+			if diffs := compareDifferences(r); len(diffs) > 0 {
 				rows = append(rows, []string{r.Subject, strings.Join(diffs, ", ")})
 			}
 		}
@@ -218,8 +283,174 @@ func runCompare(cmd *cobra.Command, args []string) error {
 			}
 		}
 	}
+}
 
-	return nil
+// CompareReport is compare's -o json/yaml output
+type CompareReport struct {
+	Source        string           `json:"source"`
+	Target        string           `json:"target"`
+	SourceContext string           `json:"sourceContext,omitempty"`
+	TargetContext string           `json:"targetContext,omitempty"`
+	Identical     int              `json:"identical"`
+	Different     int              `json:"different"`
+	SourceOnly    int              `json:"sourceOnly"`
+	TargetOnly    int              `json:"targetOnly"`
+	Total         int              `json:"total"`
+	Subjects      []CompareSubject `json:"subjects"`
+}
+
+// CompareSubject is one subject's comparison; Status is as for --jsonl
+type CompareSubject struct {
+	Subject        string   `json:"subject"`
+	Status         string   `json:"status"`
+	SourceVersions int      `json:"sourceVersions"`
+	TargetVersions int      `json:"targetVersions"`
+	Differences    []string `json:"differences,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// buildCompareReport sorts the results by subject; --diff-only leaves out
+// identical subjects
+func buildCompareReport(results []CompareResult, identical, different, sourceOnly, targetOnly int) CompareReport {
+	report := CompareReport{
+		Source:        compareSource,
+		Target:        compareTarget,
+		SourceContext: compareSourceContext,
+		TargetContext: compareTargetContext,
+		Identical:     identical,
+		Different:     different,
+		SourceOnly:    sourceOnly,
+		TargetOnly:    targetOnly,
+		Total:         len(results),
+		Subjects:      []CompareSubject{},
+	}
+	for _, r := range results {
+		ev := compareEvent(r)
+		if compareDiffOnly && ev.Status == "identical" {
+			continue
+		}
+		cs := CompareSubject{
+			Subject:        r.Subject,
+			Status:         ev.Status,
+			SourceVersions: r.SourceVers,
+			TargetVersions: r.TargetVers,
+			Differences:    ev.Details,
+			Error:          r.Error,
+		}
+		if r.SourceOnly && cs.SourceVersions == 0 {
+			cs.SourceVersions = len(r.Versions)
+		}
+		report.Subjects = append(report.Subjects, cs)
+	}
+	sort.Slice(report.Subjects, func(i, j int) bool {
+		return report.Subjects[i].Subject < report.Subjects[j].Subject
+	})
+	return report
+}
+
+// DiffPlan lists the subjects and versions clone needs to reconcile drift
+type DiffPlan struct {
+	Version       string            `json:"version"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	Source        string            `json:"source"`
+	Target        string            `json:"target"`
+	SourceContext string            `json:"sourceContext,omitempty"`
+	TargetContext string            `json:"targetContext,omitempty"`
+	Subjects      []DiffPlanSubject `json:"subjects"`
+}
+
+// DiffPlanSubject is one subject to reconcile and why
+type DiffPlanSubject struct {
+	Subject  string   `json:"subject"`
+	Reason   []string `json:"reason"`
+	Versions []int    `json:"versions"`
+}
+
+// buildDiffPlan turns compare results into a clone plan. Source-only subjects
+// take every version; differing subjects take the versions from the first
+// known divergence onward, or all of them when it is unknown. Subjects that
+// only exist in the target or failed to compare are left out.
+func buildDiffPlan(results []CompareResult) DiffPlan {
+	plan := DiffPlan{Version: "1.0", CreatedAt: time.Now().UTC(), Subjects: []DiffPlanSubject{}}
+
+	for _, r := range results {
+		if r.TargetOnly || r.Error != "" || len(r.Versions) == 0 {
+			continue
+		}
+
+		var reason []string
+		versions := r.Versions
+		switch {
+		case r.SourceOnly:
+			reason = append(reason, "source-only")
+		case r.VersionDiff || r.SchemaDiff || r.ConfigDiff:
+			if r.VersionDiff {
+				reason = append(reason, "versions")
+			}
+			if r.SchemaDiff {
+				reason = append(reason, "schema")
+			}
+			if r.ConfigDiff {
+				reason = append(reason, "config")
+			}
+			if r.FirstDiff > 0 {
+				if r.DiffReason == "missing in source" {
+					// Extra target versions cannot be reconciled by cloning
+					versions = nil
+				} else if i := slices.Index(versions, r.FirstDiff); i >= 0 {
+					versions = versions[i:]
+				}
+			}
+		default:
+			continue
+		}
+
+		if len(versions) == 0 && !r.ConfigDiff {
+			continue
+		}
+		plan.Subjects = append(plan.Subjects, DiffPlanSubject{
+			Subject:  r.Subject,
+			Reason:   reason,
+			Versions: append([]int{}, versions...),
+		})
+	}
+
+	sort.Slice(plan.Subjects, func(i, j int) bool {
+		return plan.Subjects[i].Subject < plan.Subjects[j].Subject
+	})
+	return plan
+}
+
+// readDiffPlan loads a plan written by compare --export-diff
+func readDiffPlan(path string) (*DiffPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	var plan DiffPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	return &plan, nil
+}
+
+// applyDiffPlan keeps only the planned versions of planned subjects. Schemas
+// of other subjects (pulled in as references) are left untouched.
+func applyDiffPlan(toClone []schemaToClone, plan *DiffPlan) []schemaToClone {
+	planned := make(map[string][]int, len(plan.Subjects))
+	for _, ps := range plan.Subjects {
+		planned[ps.Subject] = ps.Versions
+	}
+
+	var kept []schemaToClone
+	for _, s := range toClone {
+		versions, ok := planned[s.Subject]
+		if ok && !slices.Contains(versions, s.Version) {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	return kept
 }
 
 func filterByList(subjects []string, filter []string) []string {
@@ -250,15 +481,11 @@ func compareSubjectsParallel(
 	}
 
 	workers := clampWorkers(compareWorkers)
+	mode := comparisonMode(compareNormalize, compareCanonical)
 	jobs := make(chan string, len(subjectList))
 	results := make(chan CompareResult, len(subjectList))
 
-	bar := progressbar.NewOptions(len(subjectList),
-		progressbar.OptionSetDescription("Comparing"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjectList), "Comparing")
 
 	// Start workers
 	var wg sync.WaitGroup
@@ -274,6 +501,13 @@ func compareSubjectsParallel(
 
 				if !inTarget {
 					result.SourceOnly = true
+					if compareExportDiff != "" {
+						if versions, err := sourceClient.GetVersions(subj, false); err == nil {
+							result.Versions = versions
+						} else {
+							result.Error = fmt.Sprintf("source: %v", err)
+						}
+					}
 				} else if !inSource {
 					result.TargetOnly = true
 				} else {
@@ -299,6 +533,7 @@ func compareSubjectsParallel(
 					}
 
 					result.SourceVers = len(sourceVersions)
+					result.Versions = sourceVersions
 					result.TargetVers = len(targetVersions)
 
 					if len(sourceVersions) != len(targetVersions) {
@@ -310,31 +545,21 @@ func compareSubjectsParallel(
 						result.SourceLatest = sourceVersions[len(sourceVersions)-1]
 						result.TargetLatest = targetVersions[len(targetVersions)-1]
 
-						sourceSchema, ssErr := sourceClient.GetSchema(subj, "latest")
-						targetSchema, tsErr := targetClient.GetSchema(subj, "latest")
-
-						if ssErr != nil || tsErr != nil {
-							errMsg := ""
-							if ssErr != nil {
-								errMsg = fmt.Sprintf("source schema: %v", ssErr)
-							}
-							if tsErr != nil {
-								if errMsg != "" {
-									errMsg += "; "
-								}
-								errMsg += fmt.Sprintf("target schema: %v", tsErr)
+						if compareDeep {
+							first, reason, err := firstDivergentVersion(sourceClient, targetClient, subj, sourceVersions, targetVersions, mode)
+							if err != nil {
+								result.Error = err.Error()
+							} else if first > 0 {
+								result.SchemaDiff = true
+								result.FirstDiff = first
+								result.DiffReason = reason
 							}
-							result.Error = errMsg
 						} else {
-							if compareByID {
-								if sourceSchema.ID != targetSchema.ID {
-									result.SchemaDiff = true
-								}
-							} else {
-								if sourceSchema.Schema != targetSchema.Schema {
-									result.SchemaDiff = true
-								}
+							different, err := latestSchemasDiffer(sourceClient, targetClient, subj, compareByID, mode)
+							if err != nil {
+								result.Error = err.Error()
 							}
+							result.SchemaDiff = different
 						}
 
 						// Compare config
@@ -383,6 +608,7 @@ func compareSubjectsParallel(
 
 	for r := range results {
 		allResults = append(allResults, r)
+		emitSubjectEvent("compare", compareEvent(r))
 
 		if r.SourceOnly {
 			sourceOnly++
@@ -399,6 +625,46 @@ func compareSubjectsParallel(
 	return allResults, identical, sourceOnly, targetOnly, different
 }
 
+// compareDifferences describes what differs for a subject in both registries,
+// e.g. "versions (3/2)", "config"
+func compareDifferences(r CompareResult) []string {
+	var diffs []string
+	if r.VersionDiff {
+		diffs = append(diffs, fmt.Sprintf("versions (%d/%d)", r.SourceVers, r.TargetVers))
+	}
+	if r.SchemaDiff {
+		if r.FirstDiff > 0 {
+			diffs = append(diffs, fmt.Sprintf("schema content (from v%d: %s)", r.FirstDiff, r.DiffReason))
+		} else {
+			diffs = append(diffs, "schema content")
+		}
+	}
+	if r.ConfigDiff {
+		diffs = append(diffs, "config")
+	}
+	return diffs
+}
+
+// compareEvent describes a compared subject for --jsonl
+func compareEvent(r CompareResult) SubjectEvent {
+	ev := SubjectEvent{Subject: r.Subject, Versions: r.SourceVers, Error: r.Error}
+	switch {
+	case r.SourceOnly:
+		ev.Status, ev.Versions = "source-only", len(r.Versions)
+	case r.TargetOnly:
+		ev.Status = "target-only"
+	case r.Error != "" && !r.VersionDiff && !r.SchemaDiff && !r.ConfigDiff:
+		ev.Status = "failed"
+	default:
+		ev.Details = compareDifferences(r)
+		ev.Status = "identical"
+		if len(ev.Details) > 0 {
+			ev.Status = "different"
+		}
+	}
+	return ev
+}
+
 // Clone command
 var cloneCmd = &cobra.Command{
 	Use:     "clone",
@@ -412,6 +678,7 @@ This ensures referential integrity and consistent IDs across environments.
 Clone options:
   • Clone all subjects or specific subjects
   • Clone to different context  
+  • Rename subjects on the way (--map-subject old=new, repeatable)
   • Preserve schema IDs (default, requires IMPORT mode on target)
   • Clone subject-level configurations
   • Multi-threaded cloning
@@ -430,17 +697,29 @@ Examples:
   # Clone to different context
   srctl clone --source dev --target prod --target-context .staging
 
+  # Clone a subject under a new name (references to it are updated)
+  srctl clone --source dev --target prod --map-subject orders-value=orders.v2-value
+
   # Clone WITHOUT preserving schema IDs (new IDs will be assigned)
   srctl clone --source dev --target prod --no-preserve-ids
 
   # Clone with all configs
   srctl clone --source dev --target prod --configs
 
-  # Dry run
+  # Dry run: which subjects would be created, extended or skipped
   srctl clone --source dev --target prod --dry-run
 
+  # Skip the confirmation prompt (e.g. in CI)
+  srctl clone --source dev --target prod --yes
+
   # Clone with filter
-  srctl clone --source dev --target prod --filter "user-*"`,
+  srctl clone --source dev --target prod --filter "user-*"
+
+  # Clone with a regular expression filter
+  srctl clone --source dev --target prod --filter '^user-.*-value$' --regex
+
+  # Reconcile exactly the drift found by compare --export-diff
+  srctl clone --source dev --target prod --plan drift.json`,
 	RunE: runClone,
 }
 
@@ -457,21 +736,42 @@ var (
 	cloneNoPreserveIDs bool
 	cloneConfigs       bool
 	cloneTags          bool
+	cloneBizMeta       bool
+	cloneMaxVersions   int
+	cloneNormalize     bool
+	cloneResetOverride bool
+	cloneRegex         bool
+	cloneYes           bool
+	cloneFailFast      bool
+	clonePlanFile      string
+	cloneCkptFile      string
+	cloneMapSubjects   []string
 )
 
 func init() {
 	cloneCmd.Flags().StringVar(&cloneSource, "source", "", "Source registry name (required)")
 	cloneCmd.Flags().StringVar(&cloneTarget, "target", "", "Target registry name (required)")
 	cloneCmd.Flags().StringSliceVar(&cloneSubjects, "subjects", nil, "Clone only specific subjects")
-	cloneCmd.Flags().StringVarP(&cloneFilter, "filter", "f", "", "Filter subjects by pattern")
+	cloneCmd.Flags().StringVarP(&cloneFilter, "filter", "f", "", "Filter subjects by glob pattern")
+	cloneCmd.Flags().StringVar(&clonePlanFile, "plan", "", "Clone only the subjects and versions in a plan written by compare --export-diff")
+	cloneCmd.Flags().StringVar(&cloneCkptFile, "checkpoint", "", "Record cloned versions in this file; re-running with it skips them and resumes")
+	cloneCmd.Flags().BoolVar(&cloneFailFast, "fail-fast", false, "Stop cloning at the first failed schema")
+	addJSONLFlag(cloneCmd)
+	cloneCmd.Flags().BoolVarP(&cloneYes, "yes", "y", false, "Skip the confirmation prompt before writing to the target")
+	cloneCmd.Flags().BoolVar(&cloneRegex, "regex", false, "Treat --filter as a regular expression instead of a glob")
 	cloneCmd.Flags().BoolVar(&cloneDryRun, "dry-run", false, "Preview clone without making changes")
 	cloneCmd.Flags().StringVar(&cloneSourceContext, "source-context", "", "Source context")
 	cloneCmd.Flags().StringVar(&cloneTargetContext, "target-context", "", "Target context")
+	cloneCmd.Flags().StringArrayVar(&cloneMapSubjects, "map-subject", nil, "Rename a subject in the target as old=new, updating references to it (repeatable)")
 	cloneCmd.Flags().BoolVar(&cloneSkipExisting, "skip-existing", false, "Skip subjects that already exist in target")
 	cloneCmd.Flags().IntVar(&cloneWorkers, "workers", 10, "Number of parallel workers for cloning")
 	cloneCmd.Flags().BoolVar(&cloneNoPreserveIDs, "no-preserve-ids", false, "Do NOT preserve schema IDs (new IDs will be assigned)")
 	cloneCmd.Flags().BoolVar(&cloneConfigs, "configs", true, "Clone subject-level configurations")
 	cloneCmd.Flags().BoolVar(&cloneTags, "tags", false, "Clone tag definitions and associations")
+	cloneCmd.Flags().BoolVar(&cloneBizMeta, "business-metadata", false, "Clone business metadata definitions and associations")
+	cloneCmd.Flags().IntVar(&cloneMaxVersions, "max-versions", 0, "Clone only the newest N versions of each subject, plus older versions they reference (0 = all)")
+	cloneCmd.Flags().BoolVar(&cloneNormalize, "normalize", false, "Ask the target registry to normalize schemas on registration")
+	cloneCmd.Flags().BoolVar(&cloneResetOverride, "reset-overrides", false, "Clear target subject-level compatibility/mode overrides the source does not have")
 
 	cloneCmd.MarkFlagRequired("source")
 	cloneCmd.MarkFlagRequired("target")
@@ -480,11 +780,24 @@ func init() {
 }
 
 func runClone(cmd *cobra.Command, args []string) error {
+	renames, err := parseSubjectMap(cloneMapSubjects)
+	if err != nil {
+		return err
+	}
+
+	startSummary()
 	output.Header("Clone Schemas")
 	output.Info("Source: %s", cloneSource)
 	output.Info("Target: %s", cloneTarget)
 	output.Info("Workers: %d", cloneWorkers)
 
+	var cp *Checkpoint
+	if cloneCkptFile != "" {
+		if cp, err = loadCloneCheckpoint(cloneCkptFile); err != nil {
+			return err
+		}
+	}
+
 	// Get clients
 	sourceClient, err := GetClientForRegistry(cloneSource)
 	if err != nil {
@@ -495,6 +808,8 @@ func runClone(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to connect to target: %w", err)
 	}
+	targetClient.Normalize = cloneNormalize
+	defer reportThrottling(sourceClient, targetClient)
 
 	// Apply contexts
 	if cloneSourceContext != "" {
@@ -506,47 +821,42 @@ func runClone(cmd *cobra.Command, args []string) error {
 		output.Info("Target context: %s", cloneTargetContext)
 	}
 
-	// Set IMPORT mode if preserving IDs.
-	// Global IMPORT mode is best-effort: Confluent SR only permits global
-	// mode=IMPORT when the registry has no subjects (error 42205 otherwise).
-	// On a non-empty target we fall back to the per-subject IMPORT mode set in
-	// the clone worker loop, which works regardless of existing subjects.
-	if !cloneNoPreserveIDs {
-		output.Step("Setting target registry to IMPORT mode...")
-		globalImportSet := false
-		if err := targetClient.SetMode("IMPORT"); err != nil {
-			errMsg := err.Error()
-			if strings.Contains(errMsg, "found existing subjects") || strings.Contains(errMsg, "42205") {
-				output.Warning("Could not set global IMPORT mode because the target registry already contains subjects; falling back to per-subject IMPORT mode for ID preservation: %v", err)
-			} else {
-				return fmt.Errorf("failed to set IMPORT mode (required for --preserve-ids): %w", err)
-			}
-		} else {
-			globalImportSet = true
-		}
-		if globalImportSet {
-			defer func() {
-				output.Step("Restoring READWRITE mode...")
-				if err := targetClient.SetMode("READWRITE"); err != nil {
-					output.Error("Failed to restore READWRITE mode; target registry may be stuck in IMPORT mode: %v", err)
-				}
-			}()
-		}
+	// Get subjects to clone. A plan is checked against the full subject list,
+	// otherwise a literal filter prefix narrows the listing server-side.
+	prefix := ""
+	if clonePlanFile == "" {
+		prefix = subjectPrefix(cloneFilter, cloneRegex)
 	}
-
-	// Get subjects to clone
 	output.Step("Fetching subjects from source...")
-	subjects, err := sourceClient.GetSubjects(false)
+	subjects, err := listSubjects(sourceClient, prefix, false)
 	if err != nil {
 		return fmt.Errorf("failed to get source subjects: %w", err)
 	}
 
 	// Filter subjects
+	var diffPlan *DiffPlan
+	if clonePlanFile != "" {
+		if diffPlan, err = readDiffPlan(clonePlanFile); err != nil {
+			return err
+		}
+		if diffPlan.Source != cloneSource || diffPlan.Target != cloneTarget {
+			output.Warning("Plan was made for %s -> %s, cloning %s -> %s", diffPlan.Source, diffPlan.Target, cloneSource, cloneTarget)
+		}
+		planned := make([]string, 0, len(diffPlan.Subjects))
+		for _, ps := range diffPlan.Subjects {
+			planned = append(planned, ps.Subject)
+		}
+		if missing := len(planned) - len(filterByList(subjects, planned)); missing > 0 {
+			output.Warning("%d planned subject(s) no longer exist in source", missing)
+		}
+		subjects = filterByList(subjects, planned)
+		output.Info("Plan: %s (%d subjects)", clonePlanFile, len(planned))
+	}
 	if len(cloneSubjects) > 0 {
 		subjects = filterByList(subjects, cloneSubjects)
 	}
-	if cloneFilter != "" {
-		subjects = filterSubjects(subjects, cloneFilter)
+	if subjects, err = matchSubjects(subjects, cloneFilter, cloneRegex); err != nil {
+		return err
 	}
 
 	if len(subjects) == 0 {
@@ -555,20 +865,42 @@ func runClone(cmd *cobra.Command, args []string) error {
 	}
 
 	output.Info("Found %d subjects to clone", len(subjects))
+	renames.warnUnmatched(subjects)
+
+	// The names the subjects get in the target
+	targetSubjects := subjects
+	if len(renames) > 0 {
+		targetSubjects = make([]string, len(subjects))
+		for i, subj := range subjects {
+			targetSubjects[i] = renames.rename(subj)
+		}
+	}
 
-	// Get existing subjects in target if skip-existing
-	var existingTarget map[string]bool
-	if cloneSkipExisting {
-		targetSubjects, _ := targetClient.GetSubjects(false)
-		existingTarget = make(map[string]bool)
+	// Get existing subjects in target (skipped with --skip-existing, shown in the plan)
+	existingTarget := make(map[string]bool)
+	if targetSubjects, err := targetClient.GetSubjects(false); err == nil {
 		for _, s := range targetSubjects {
 			existingTarget[s] = true
 		}
+	} else {
+		output.Warning("Could not list target subjects: %v", err)
 	}
 
 	// Collect schemas with dependencies using parallel fetching
+	if cloneMaxVersions > 0 {
+		output.Info("Cloning the newest %d version(s) of each subject", cloneMaxVersions)
+	}
 	output.Step("Collecting schemas and dependencies (%d workers)...", cloneWorkers)
-	toClone, refsNeeded := collectSchemasParallel(sourceClient, subjects, existingTarget)
+	toClone, refsNeeded := collectSchemasParallel(sourceClient, subjects, existingTarget, renames)
+	if diffPlan != nil {
+		toClone = applyDiffPlan(toClone, diffPlan)
+		refsNeeded = make(map[string]bool)
+		for _, s := range toClone {
+			for _, ref := range s.References {
+				refsNeeded[fmt.Sprintf("%s:%d", ref.Subject, ref.Version)] = true
+			}
+		}
+	}
 
 	// Add referenced schemas that aren't already included
 	for key := range refsNeeded {
@@ -614,34 +946,138 @@ func runClone(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Rename mapped subjects; the checkpoint records the names in the target
+	if len(renames) > 0 {
+		renameCloneSubjects(toClone, renames.rename)
+	}
+
+	// Versions cloned by an earlier run with the same checkpoint
+	if cp.Resumed() {
+		var remaining []schemaToClone
+		for _, s := range toClone {
+			if !cp.VersionDone(s.Subject, s.Version) {
+				remaining = append(remaining, s)
+			}
+		}
+		output.Info("Resuming from checkpoint %s: skipping %d version(s) already cloned", cloneCkptFile, len(toClone)-len(remaining))
+		toClone = remaining
+	}
+
 	output.Info("Total schemas to clone: %d", len(toClone))
 
+	plan := buildClonePlan(targetSubjects, toClone, existingTarget, cloneSkipExisting)
+	var newSubjects, existingSubjects, skippedSubjects int
+	for _, p := range plan {
+		switch {
+		case p.Skipped:
+			skippedSubjects++
+		case p.Exists:
+			existingSubjects++
+		default:
+			newSubjects++
+		}
+	}
+
 	// Dry run
 	if cloneDryRun {
-		output.Header("Dry Run - Would Clone")
-
-		// Group by subject
-		subjCount := make(map[string]int)
-		for _, s := range toClone {
-			subjCount[s.Subject]++
+		if structuredOutput() {
+			return printSummary(plan)
 		}
+		output.Header("Dry Run - Would Clone")
 
-		rows := [][]string{}
-		for subj, count := range subjCount {
-			rows = append(rows, []string{subj, strconv.Itoa(count)})
+		rows := make([][]string, 0, len(plan))
+		for _, p := range plan {
+			versions := strconv.Itoa(p.Versions)
+			action := "create"
+			switch {
+			case p.Skipped:
+				versions = "-"
+				action = "skip (exists in target)"
+			case p.Exists:
+				action = "add versions (exists in target)"
+			}
+			rows = append(rows, []string{p.Subject, versions, action})
 		}
-		output.PrintTable([]string{"Subject", "Versions"}, rows)
+		output.PrintTable([]string{"Subject", "Versions", "Action"}, rows)
 
-		if !cloneNoPreserveIDs {
+		output.Info("New subjects: %d, existing subjects: %d, skipped: %d", newSubjects, existingSubjects, skippedSubjects)
+		if cloneNoPreserveIDs {
+			output.Warning("Schema IDs would NOT be preserved (target assigns new IDs)")
+		} else {
 			output.Info("Schema IDs would be preserved (IMPORT mode)")
 		}
 
 		return nil
 	}
 
+	if len(toClone) == 0 {
+		output.Info("Nothing to clone")
+		finishCheckpoint(cp, 0)
+		return nil
+	}
+
+	// Confirm before mutating the target
+	idNote := "preserving schema IDs"
+	if cloneNoPreserveIDs {
+		idNote = "WITHOUT preserving schema IDs"
+	}
+	prompt := fmt.Sprintf("Clone %d schema versions into '%s' (%d new subjects, %d existing), %s?",
+		len(toClone), cloneTarget, newSubjects, existingSubjects, idNote)
+	if !cloneYes && !confirmAction(prompt) {
+		output.Info("Cancelled")
+		return nil
+	}
+
+	// Set IMPORT mode if preserving IDs.
+	// Global IMPORT mode is best-effort: Confluent SR only permits global
+	// mode=IMPORT when the registry has no subjects (error 42205 otherwise).
+	// On a non-empty target we fall back to the per-subject IMPORT mode set in
+	// the clone worker loop, which works regardless of existing subjects.
+	if !cloneNoPreserveIDs {
+		output.Step("Setting target registry to IMPORT mode...")
+		globalImportSet := false
+		if err := targetClient.SetMode("IMPORT"); err != nil {
+			if client.HasErrorCode(err, client.ErrCodeOperationNotPermitted) {
+				output.Warning("Could not set global IMPORT mode because the target registry already contains subjects; falling back to per-subject IMPORT mode for ID preservation: %v", err)
+			} else {
+				return fmt.Errorf("failed to set IMPORT mode (required for --preserve-ids): %w", err)
+			}
+		} else {
+			globalImportSet = true
+		}
+		if globalImportSet {
+			defer func() {
+				output.Step("Restoring READWRITE mode...")
+				if err := targetClient.SetMode("READWRITE"); err != nil {
+					output.Error("Failed to restore READWRITE mode; target registry may be stuck in IMPORT mode: %v", err)
+				}
+			}()
+		}
+	}
+
 	// Perform clone in parallel
 	output.Step("Cloning schemas (%d workers)...", cloneWorkers)
-	cloned, skipped, failed := cloneSchemasParallel(targetClient, toClone)
+	cloned, skipped, failures := cloneSchemasParallel(targetClient, toClone, cp)
+	finishCheckpoint(cp, len(failures))
+	summary := CloneSummary{
+		Source:       cloneSource,
+		Target:       cloneTarget,
+		Cloned:       cloned,
+		Skipped:      skipped,
+		Failed:       len(failures),
+		PreservedIDs: !cloneNoPreserveIDs,
+		Failures:     failures,
+	}
+	if cloneFailFast && len(failures) > 0 {
+		if structuredOutput() {
+			if err := printSummary(summary); err != nil {
+				return err
+			}
+		} else {
+			printFailures(failures)
+		}
+		return fmt.Errorf("clone aborted after first failure (%d schemas cloned)", cloned)
+	}
 
 	// Clone tags if enabled
 	var tagsCloned int
@@ -650,16 +1086,33 @@ func runClone(cmd *cobra.Command, args []string) error {
 		tagsCloned = cloneTagsData(sourceClient, targetClient, subjects)
 	}
 
+	// Clone business metadata if enabled
+	var bmCloned int
+	if cloneBizMeta && !cloneDryRun {
+		output.Step("Cloning business metadata...")
+		bmCloned = cloneBusinessMetadataData(sourceClient, targetClient, subjects)
+	}
+
+	if structuredOutput() {
+		summary.TagsCloned = tagsCloned
+		summary.BusinessMetadataCloned = bmCloned
+		return printSummary(summary)
+	}
+
 	output.Header("Clone Complete")
 	rows := [][]string{
 		{"Cloned", strconv.Itoa(cloned)},
 		{"Skipped (exists)", strconv.Itoa(skipped)},
-		{"Failed", strconv.Itoa(failed)},
+		{"Failed", strconv.Itoa(len(failures))},
 	}
 	if cloneTags {
 		rows = append(rows, []string{"Tags Cloned", strconv.Itoa(tagsCloned)})
 	}
+	if cloneBizMeta {
+		rows = append(rows, []string{"Business Metadata Cloned", strconv.Itoa(bmCloned)})
+	}
 	output.PrintTable([]string{"Status", "Count"}, rows)
+	printFailures(failures)
 
 	if !cloneNoPreserveIDs {
 		output.Info("Schema IDs preserved via IMPORT mode")
@@ -668,6 +1121,23 @@ func runClone(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// cloneBusinessMetadataData clones business metadata definitions and
+// assignments between registries, returning the assignments cloned
+func cloneBusinessMetadataData(source, target client.SchemaRegistryClientInterface, subjects []string) int {
+	defs, err := source.GetBusinessMetadataDefs()
+	if err != nil {
+		output.Warning("Failed to get source business metadata definitions: %v", err)
+		return 0
+	}
+
+	bmBackup := BusinessMetadataBackup{Definitions: defs}
+	if len(defs) > 0 {
+		bmBackup.Assignments = collectBusinessMetadata(source, subjects, cloneWorkers)
+	}
+	_, cloned := applyBusinessMetadata(target, bmBackup)
+	return cloned
+}
+
 // cloneTagsData clones tag definitions and assignments between registries
 func cloneTagsData(source, target *client.SchemaRegistryClient, subjects []string) int {
 	cloned := 0
@@ -681,7 +1151,7 @@ func cloneTagsData(source, target *client.SchemaRegistryClient, subjects []strin
 
 	for _, tag := range sourceTags {
 		err := target.CreateTag(&tag)
-		if err != nil && !strings.Contains(err.Error(), "already exists") {
+		if err != nil && !client.IsConflict(err) {
 			output.Warning("Failed to create tag %s: %v", tag.Name, err)
 		}
 	}
@@ -692,7 +1162,7 @@ func cloneTagsData(source, target *client.SchemaRegistryClient, subjects []strin
 		subjectTags, err := source.GetSubjectTags(subj)
 		if err == nil {
 			for _, t := range subjectTags {
-				err := target.AssignTagToSubject(subj, t.TypeName)
+				err := target.AssignTagToSubject(subj, t.TypeName, t.Attributes)
 				if err == nil {
 					cloned++
 				}
@@ -709,7 +1179,7 @@ func cloneTagsData(source, target *client.SchemaRegistryClient, subjects []strin
 			schemaTags, err := source.GetSchemaTags(subj, v)
 			if err == nil {
 				for _, t := range schemaTags {
-					err := target.AssignTagToSchema(subj, v, t.TypeName)
+					err := target.AssignTagToSchema(subj, v, t.TypeName, t.Attributes)
 					if err == nil {
 						cloned++
 					}
@@ -735,11 +1205,67 @@ type schemaToClone struct {
 	Mode        string
 }
 
-// collectSchemasParallel collects schemas from source in parallel
+// clonePlanEntry describes what clone will do with one subject
+type clonePlanEntry struct {
+	Subject  string `json:"subject"`
+	Versions int    `json:"versions"`
+	Exists   bool   `json:"exists"`  // subject already exists in the target
+	Skipped  bool   `json:"skipped"` // existing subject skipped with --skip-existing
+}
+
+// CloneSummary is clone's -o json/yaml output
+type CloneSummary struct {
+	Source                 string             `json:"source"`
+	Target                 string             `json:"target"`
+	Cloned                 int                `json:"cloned"`
+	Skipped                int                `json:"skipped"`
+	Failed                 int                `json:"failed"`
+	TagsCloned             int                `json:"tagsCloned,omitempty"`
+	BusinessMetadataCloned int                `json:"businessMetadataCloned,omitempty"`
+	PreservedIDs           bool               `json:"preservedIds"`
+	Failures               []operationFailure `json:"failures,omitempty"`
+}
+
+// buildClonePlan summarizes the schemas to clone per subject, sorted by subject,
+// including selected subjects skipped because they already exist in the target
+func buildClonePlan(subjects []string, toClone []schemaToClone, existing map[string]bool, skipExisting bool) []clonePlanEntry {
+	versions := make(map[string]int)
+	for _, s := range toClone {
+		versions[s.Subject]++
+	}
+
+	var plan []clonePlanEntry
+	for subj, n := range versions {
+		plan = append(plan, clonePlanEntry{Subject: subj, Versions: n, Exists: existing[subj]})
+	}
+	if skipExisting {
+		for _, subj := range subjects {
+			if existing[subj] && versions[subj] == 0 {
+				plan = append(plan, clonePlanEntry{Subject: subj, Exists: true, Skipped: true})
+			}
+		}
+	}
+
+	sort.Slice(plan, func(i, j int) bool { return plan[i].Subject < plan[j].Subject })
+	return plan
+}
+
+// renameCloneSubjects renames subjects and the references to them, including
+// references to subjects not being cloned
+func renameCloneSubjects(toClone []schemaToClone, rename func(string) string) {
+	for i := range toClone {
+		toClone[i].Subject = rename(toClone[i].Subject)
+		renameReferences(toClone[i].References, rename)
+	}
+}
+
+// collectSchemasParallel collects schemas from source in parallel. With
+// --skip-existing, subjects whose (renamed) name exists in the target are skipped.
 func collectSchemasParallel(
 	sourceClient *client.SchemaRegistryClient,
 	subjects []string,
 	existingTarget map[string]bool,
+	renames subjectMap,
 ) ([]schemaToClone, map[string]bool) {
 	type collectResult struct {
 		Schemas []schemaToClone
@@ -749,12 +1275,7 @@ func collectSchemasParallel(
 	jobs := make(chan string, len(subjects))
 	results := make(chan collectResult, len(subjects))
 
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Collecting"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjects), "Collecting")
 
 	// Start workers
 	var wg sync.WaitGroup
@@ -765,7 +1286,7 @@ func collectSchemasParallel(
 			for subj := range jobs {
 				result := collectResult{Refs: make(map[string]bool)}
 
-				if cloneSkipExisting && existingTarget != nil && existingTarget[subj] {
+				if cloneSkipExisting && existingTarget != nil && existingTarget[renames.rename(subj)] {
 					bar.Add(1)
 					results <- result
 					continue
@@ -777,6 +1298,8 @@ func collectSchemasParallel(
 					results <- result
 					continue
 				}
+				// Older versions that kept ones reference come back in through refsNeeded
+				versions = newestVersions(versions, cloneMaxVersions)
 
 				// Get subject config if enabled
 				var configLevel, mode string
@@ -860,8 +1383,9 @@ func collectSchemasParallel(
 	return allSchemas, allRefs
 }
 
-// cloneSchemasParallel clones schemas to target in parallel
-func cloneSchemasParallel(targetClient *client.SchemaRegistryClient, schemas []schemaToClone) (cloned, skipped, failed int) {
+// cloneSchemasParallel clones schemas to target in parallel, recording each
+// version registered (or already in the target) in cp
+func cloneSchemasParallel(targetClient *client.SchemaRegistryClient, schemas []schemaToClone, cp *Checkpoint) (cloned, skipped int, failures []operationFailure) {
 	// We need to clone schemas in order (references first)
 	// For simplicity, we'll process in batches by subject
 
@@ -879,15 +1403,115 @@ func cloneSchemasParallel(targetClient *client.SchemaRegistryClient, schemas []s
 
 	jobs := make(chan string, len(subjects))
 
-	var clonedCount, skippedCount, failedCount int64
+	var clonedCount, skippedCount int64
+	var failuresMu sync.Mutex
+	var stopped atomic.Bool // set on the first failure with --fail-fast
 	configsSet := sync.Map{}
 
-	bar := progressbar.NewOptions(len(schemas),
-		progressbar.OptionSetDescription("Cloning"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(schemas), "Cloning")
+
+	// cloneSubject registers one subject's schemas in version order. When
+	// preserving IDs the subject is switched to IMPORT first and restored in a
+	// deferred call, so neither a failed registration nor a panic leaves it
+	// stuck in IMPORT mode; a panic is recorded as a failure of the subject.
+	cloneSubject := func(subj string) {
+		schemasForSubj := bySubject[subj]
+		progress := cloneProgress{Subject: subj}
+		fail := func(f operationFailure) {
+			failuresMu.Lock()
+			failures = append(failures, f)
+			failuresMu.Unlock()
+			progress.Failures = append(progress.Failures, f)
+			if cloneFailFast {
+				stopped.Store(true)
+			}
+		}
+		defer func() {
+			if err := cp.Save(); err != nil {
+				output.Warning("%v", err)
+			}
+		}()
+		defer func() { emitSubjectEvent("clone", progress.event()) }()
+		defer func() {
+			if r := recover(); r != nil {
+				fail(operationFailure{Subject: subj, Error: fmt.Sprintf("internal error: %v", r)})
+			}
+		}()
+
+		// Drop stale target overrides so subjects without a source
+		// override inherit the target's global settings
+		if cloneResetOverride {
+			if err := clearSubjectOverrides(targetClient, subj); err != nil {
+				output.Warning("Failed to clear overrides for %s: %v", subj, err)
+			}
+		}
+
+		// Set subject config if not already set (only first schema has it)
+		if len(schemasForSubj) > 0 && schemasForSubj[0].ConfigLevel != "" {
+			if _, loaded := configsSet.LoadOrStore(subj, true); !loaded {
+				targetClient.SetSubjectConfig(subj, schemasForSubj[0].ConfigLevel)
+			}
+		}
+
+		// Set subject mode: when preserving IDs, force IMPORT mode at subject level.
+		// Confluent Cloud requires subject-level IMPORT mode in addition to the
+		// global IMPORT mode. Without this, GetSubjectMode(defaultToGlobal=true)
+		// returns the source's READWRITE mode which overrides the target's global
+		// IMPORT mode, causing "Subject X is not in import mode" errors.
+		if !cloneNoPreserveIDs {
+			if err := targetClient.SetSubjectMode(subj, "IMPORT"); err != nil {
+				// Registration may still succeed under a global IMPORT mode
+				output.Warning("Failed to set IMPORT mode for %s: %v", subj, err)
+			} else {
+				defer func() {
+					if err := restoreSubjectMode(targetClient, subj, schemasForSubj); err != nil {
+						output.Error("Failed to restore mode for %s; it may be left in IMPORT mode: %v", subj, err)
+						fail(operationFailure{Subject: subj, Error: fmt.Sprintf("failed to restore mode after IMPORT: %v", err)})
+					}
+				}()
+			}
+		} else if len(schemasForSubj) > 0 && schemasForSubj[0].Mode != "" {
+			targetClient.SetSubjectMode(subj, schemasForSubj[0].Mode)
+		}
+
+		// Register schemas in order (by version)
+		for _, s := range schemasForSubj {
+			if stopped.Load() {
+				progress.NotRun++
+				bar.Add(1)
+				continue
+			}
+			schema := &client.Schema{
+				Schema:     s.Schema,
+				SchemaType: s.SchemaType,
+				References: s.References,
+				Metadata:   s.Metadata,
+				RuleSet:    s.RuleSet,
+			}
+
+			// If preserving IDs, we need to use the register with ID
+			// (assuming the target is in IMPORT mode)
+			if !cloneNoPreserveIDs {
+				schema.ID = s.SchemaID
+			}
+
+			_, err := targetClient.RegisterSchema(s.Subject, schema)
+			if err != nil {
+				if client.HasErrorCode(err, client.ErrCodeIDDoesNotMatch) {
+					atomic.AddInt64(&skippedCount, 1)
+					progress.Skipped++
+					cp.RecordVersion(s.Subject, s.Version)
+				} else {
+					fail(operationFailure{Subject: s.Subject, Version: s.Version, Error: err.Error()})
+				}
+			} else {
+				atomic.AddInt64(&clonedCount, 1)
+				progress.Cloned++
+				cp.RecordVersion(s.Subject, s.Version)
+			}
+			bar.Add(1)
+		}
+	}
 
 	// Start workers
 	workers := clampWorkers(cloneWorkers)
@@ -897,60 +1521,12 @@ func cloneSchemasParallel(targetClient *client.SchemaRegistryClient, schemas []s
 		go func() {
 			defer wg.Done()
 			for subj := range jobs {
-				schemasForSubj := bySubject[subj]
-
-				// Set subject config if not already set (only first schema has it)
-				if len(schemasForSubj) > 0 && schemasForSubj[0].ConfigLevel != "" {
-					if _, loaded := configsSet.LoadOrStore(subj, true); !loaded {
-						targetClient.SetSubjectConfig(subj, schemasForSubj[0].ConfigLevel)
-					}
-				}
-
-				// Set subject mode: when preserving IDs, force IMPORT mode at subject level.
-				// Confluent Cloud requires subject-level IMPORT mode in addition to the
-				// global IMPORT mode. Without this, GetSubjectMode(defaultToGlobal=true)
-				// returns the source's READWRITE mode which overrides the target's global
-				// IMPORT mode, causing "Subject X is not in import mode" errors.
-				if !cloneNoPreserveIDs {
-					targetClient.SetSubjectMode(subj, "IMPORT")
-				} else if len(schemasForSubj) > 0 && schemasForSubj[0].Mode != "" {
-					targetClient.SetSubjectMode(subj, schemasForSubj[0].Mode)
-				}
-
-				// Register schemas in order (by version)
-				for _, s := range schemasForSubj {
-					schema := &client.Schema{
-						Schema:     s.Schema,
-						SchemaType: s.SchemaType,
-						References: s.References,
-						Metadata:   s.Metadata,
-						RuleSet:    s.RuleSet,
-					}
-
-					// If preserving IDs, we need to use the register with ID
-					// (assuming the target is in IMPORT mode)
-					if !cloneNoPreserveIDs {
-						schema.ID = s.SchemaID
-					}
-
-					_, err := targetClient.RegisterSchema(s.Subject, schema)
-					if err != nil {
-						if strings.Contains(err.Error(), "already exists") ||
-							strings.Contains(err.Error(), "already registered") {
-							atomic.AddInt64(&skippedCount, 1)
-						} else {
-							atomic.AddInt64(&failedCount, 1)
-						}
-					} else {
-						atomic.AddInt64(&clonedCount, 1)
-					}
-					bar.Add(1)
-				}
-
-				// Restore subject mode to READWRITE after registration
-				if !cloneNoPreserveIDs {
-					targetClient.SetSubjectMode(subj, "READWRITE")
+				if stopped.Load() {
+					emitSubjectEvent("clone", SubjectEvent{Subject: subj, Status: "skipped", Error: "stopped after an earlier failure (--fail-fast)"})
+					bar.Add(len(bySubject[subj]))
+					continue
 				}
+				cloneSubject(subj)
 			}
 		}()
 	}
@@ -963,5 +1539,212 @@ func cloneSchemasParallel(targetClient *client.SchemaRegistryClient, schemas []s
 	wg.Wait()
 	bar.Finish()
 
-	return int(clonedCount), int(skippedCount), int(failedCount)
+	return int(clonedCount), int(skippedCount), failures
+}
+
+// loadCloneCheckpoint opens a clone checkpoint, refusing one made for a
+// different source or target
+func loadCloneCheckpoint(path string) (*Checkpoint, error) {
+	cp, err := loadCheckpoint(path, "clone")
+	if err != nil {
+		return nil, err
+	}
+	source := strings.TrimSpace(cloneSource + " " + cloneSourceContext)
+	target := strings.TrimSpace(cloneTarget + " " + cloneTargetContext)
+	if cp.Resumed() && (cp.Source != source || cp.Target != target) {
+		return nil, fmt.Errorf("checkpoint %s is for a clone from %s to %s, not %s to %s", path, cp.Source, cp.Target, source, target)
+	}
+	cp.Source, cp.Target = source, target
+	return cp, nil
+}
+
+// restoreSubjectMode undoes the temporary IMPORT override on a cloned subject.
+// With --reset-overrides it is replaced by the source's mode, or removed when
+// the source had none; otherwise the subject is set to READWRITE.
+func restoreSubjectMode(c client.SchemaRegistryClientInterface, subj string, schemas []schemaToClone) error {
+	switch {
+	case !cloneResetOverride:
+		return c.SetSubjectMode(subj, "READWRITE")
+	case len(schemas) > 0 && schemas[0].Mode != "":
+		return c.SetSubjectMode(subj, schemas[0].Mode)
+	default:
+		if err := c.DeleteSubjectMode(subj); err != nil && !client.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+}
+
+// cloneProgress counts what happened to one subject's versions during a clone
+type cloneProgress struct {
+	Subject  string
+	Cloned   int
+	Skipped  int // already in the target under a different ID
+	NotRun   int // not attempted after a --fail-fast stop
+	Failures []operationFailure
+}
+
+// event describes the cloned subject for --jsonl
+func (p cloneProgress) event() SubjectEvent {
+	ev := SubjectEvent{Subject: p.Subject, Status: "cloned", Versions: p.Cloned}
+	if p.Skipped > 0 {
+		ev.Details = append(ev.Details, fmt.Sprintf("%d version(s) skipped: ID already used in target", p.Skipped))
+	}
+	if p.NotRun > 0 {
+		ev.Details = append(ev.Details, fmt.Sprintf("%d version(s) not attempted (--fail-fast)", p.NotRun))
+	}
+	if len(p.Failures) > 0 {
+		ev.Status = "partial"
+		if p.Cloned == 0 {
+			ev.Status = "failed"
+		}
+		ev.Error = fmt.Sprintf("v%d: %s", p.Failures[0].Version, p.Failures[0].Error)
+		if len(p.Failures) > 1 {
+			ev.Error += fmt.Sprintf(" (+%d more)", len(p.Failures)-1)
+		}
+	}
+	return ev
+}
+
+// latestSchemasDiffer compares the latest version of a subject on both sides,
+// by schema content (as mode prepares it) or, with byID, by ID using a
+// metadata-only fetch
+func latestSchemasDiffer(source, target client.SchemaRegistryClientInterface, subject string, byID bool, mode contentComparison) (bool, error) {
+	var sourceKey, targetKey string
+	var sourceErr, targetErr error
+	if byID {
+		var sourceInfo, targetInfo *client.SchemaVersionInfo
+		if sourceInfo, sourceErr = source.GetLatestSchemaMetadata(subject); sourceErr == nil {
+			sourceKey = strconv.Itoa(sourceInfo.ID)
+		}
+		if targetInfo, targetErr = target.GetLatestSchemaMetadata(subject); targetErr == nil {
+			targetKey = strconv.Itoa(targetInfo.ID)
+		}
+	} else {
+		var sourceSchema, targetSchema *client.Schema
+		if sourceSchema, sourceErr = source.GetSchema(subject, "latest"); sourceErr == nil {
+			sourceKey = comparableSchema(sourceSchema, mode)
+		}
+		if targetSchema, targetErr = target.GetSchema(subject, "latest"); targetErr == nil {
+			targetKey = comparableSchema(targetSchema, mode)
+		}
+	}
+
+	if sourceErr != nil || targetErr != nil {
+		var errs []string
+		if sourceErr != nil {
+			errs = append(errs, fmt.Sprintf("source schema: %v", sourceErr))
+		}
+		if targetErr != nil {
+			errs = append(errs, fmt.Sprintf("target schema: %v", targetErr))
+		}
+		return false, errors.New(strings.Join(errs, "; "))
+	}
+	return sourceKey != targetKey, nil
+}
+
+// firstDivergentVersion walks both version histories in order and returns the
+// first source version whose schema or references differ from the target.
+// Versions are paired by position so renumbered histories still line up.
+// Returns 0 when the histories are identical.
+func firstDivergentVersion(source, target client.SchemaRegistryClientInterface, subject string, sourceVersions, targetVersions []int, mode contentComparison) (int, string, error) {
+	for i := 0; i < len(sourceVersions) || i < len(targetVersions); i++ {
+		if i >= len(targetVersions) {
+			return sourceVersions[i], "missing in target", nil
+		}
+		if i >= len(sourceVersions) {
+			return targetVersions[i], "missing in source", nil
+		}
+
+		sourceSchema, err := source.GetSchema(subject, strconv.Itoa(sourceVersions[i]))
+		if err != nil {
+			return 0, "", fmt.Errorf("source schema v%d: %w", sourceVersions[i], err)
+		}
+		targetSchema, err := target.GetSchema(subject, strconv.Itoa(targetVersions[i]))
+		if err != nil {
+			return 0, "", fmt.Errorf("target schema v%d: %w", targetVersions[i], err)
+		}
+
+		sourceType, targetType := sourceSchema.SchemaType, targetSchema.SchemaType
+		if sourceType == "" {
+			sourceType = "AVRO"
+		}
+		if targetType == "" {
+			targetType = "AVRO"
+		}
+		if sourceType != targetType {
+			return sourceVersions[i], "schema type", nil
+		}
+		if comparableSchema(sourceSchema, mode) != comparableSchema(targetSchema, mode) {
+			return sourceVersions[i], "schema", nil
+		}
+		if !sameReferences(sourceSchema.References, targetSchema.References) {
+			return sourceVersions[i], "references", nil
+		}
+	}
+	return 0, "", nil
+}
+
+// contentComparison is how schema content is prepared before comparing
+type contentComparison int
+
+const (
+	// contentExact compares content byte for byte
+	contentExact contentComparison = iota
+	// contentNormalized compares content as normalize prints it, keeping every
+	// attribute
+	contentNormalized
+	// contentCanonical compares Avro in Parsing Canonical Form, which drops
+	// doc, default, logicalType, aliases and order, and other types as
+	// normalize prints them
+	contentCanonical
+)
+
+// comparisonMode returns the content comparison selected by --normalize and
+// --canonical
+func comparisonMode(normalize, canonical bool) contentComparison {
+	switch {
+	case canonical:
+		return contentCanonical
+	case normalize:
+		return contentNormalized
+	default:
+		return contentExact
+	}
+}
+
+// comparableSchema returns the schema content to compare, prepared as mode
+// says. Content that fails to parse is compared as-is.
+func comparableSchema(schema *client.Schema, mode contentComparison) string {
+	if mode == contentExact {
+		return schema.Schema
+	}
+	if mode == contentCanonical && (schema.SchemaType == "" || schema.SchemaType == "AVRO") {
+		if canonical, err := canonicalizeAvro(schema.Schema); err == nil {
+			return canonical
+		}
+		return schema.Schema
+	}
+	if canonical, err := canonicalizeSchema(schema.Schema, schema.SchemaType); err == nil {
+		return canonical
+	}
+	return schema.Schema
+}
+
+// sameReferences reports whether two reference sets are equal, ignoring order
+func sameReferences(a, b []client.SchemaReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[client.SchemaReference]int, len(a))
+	for _, r := range a {
+		seen[r]++
+	}
+	for _, r := range b {
+		if seen[r] == 0 {
+			return false
+		}
+		seen[r]--
+	}
+	return true
 }
@@ -1,6 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/srctl/srctl/internal/client"
@@ -305,3 +312,339 @@ func TestCompareVersionCounts(t *testing.T) {
 		t.Error("expected source to have more versions than target")
 	}
 }
+
+func TestBuildClonePlan(t *testing.T) {
+	toClone := []schemaToClone{
+		{Subject: "orders", Version: 1},
+		{Subject: "orders", Version: 2},
+		{Subject: "users", Version: 1},
+		{Subject: "address", Version: 1}, // pulled in as a reference
+	}
+	existing := map[string]bool{"users": true, "payments": true}
+	subjects := []string{"orders", "users", "payments"}
+
+	plan := buildClonePlan(subjects, toClone, existing, true)
+	if len(plan) != 4 {
+		t.Fatalf("expected 4 plan entries, got %+v", plan)
+	}
+
+	expected := []clonePlanEntry{
+		{Subject: "address", Versions: 1},
+		{Subject: "orders", Versions: 2},
+		{Subject: "payments", Exists: true, Skipped: true},
+		{Subject: "users", Versions: 1, Exists: true},
+	}
+	for i, want := range expected {
+		if plan[i] != want {
+			t.Errorf("entry %d: expected %+v, got %+v", i, want, plan[i])
+		}
+	}
+
+	// Without --skip-existing nothing is reported as skipped
+	for _, p := range buildClonePlan(subjects, toClone, existing, false) {
+		if p.Skipped {
+			t.Errorf("unexpected skipped entry %+v", p)
+		}
+	}
+}
+
+func TestFirstDivergentVersion(t *testing.T) {
+	ref := client.SchemaReference{Name: "Address", Subject: "address", Version: 1}
+	history := func(schemas ...client.Schema) *client.MockSchemaRegistryClient {
+		mock := client.NewMockClient()
+		for i := range schemas {
+			schemas[i].Subject = "orders"
+			schemas[i].Version = i + 1
+			schemas[i].ID = 100 + i
+		}
+		mock.AddSubject("orders", schemas)
+		return mock
+	}
+
+	tests := []struct {
+		name        string
+		source      *client.MockSchemaRegistryClient
+		target      *client.MockSchemaRegistryClient
+		wantVersion int
+		wantReason  string
+	}{
+		{
+			name:   "identical histories",
+			source: history(client.Schema{Schema: "a"}, client.Schema{Schema: "b", References: []client.SchemaReference{ref}}),
+			target: history(client.Schema{Schema: "a", SchemaType: "AVRO"}, client.Schema{Schema: "b", References: []client.SchemaReference{ref}}),
+		},
+		{
+			name:        "intermediate version differs",
+			source:      history(client.Schema{Schema: "a"}, client.Schema{Schema: "b"}, client.Schema{Schema: "c"}),
+			target:      history(client.Schema{Schema: "a"}, client.Schema{Schema: "x"}, client.Schema{Schema: "c"}),
+			wantVersion: 2,
+			wantReason:  "schema",
+		},
+		{
+			name:        "references differ",
+			source:      history(client.Schema{Schema: "a", References: []client.SchemaReference{ref}}),
+			target:      history(client.Schema{Schema: "a"}),
+			wantVersion: 1,
+			wantReason:  "references",
+		},
+		{
+			name:        "target history is shorter",
+			source:      history(client.Schema{Schema: "a"}, client.Schema{Schema: "b"}),
+			target:      history(client.Schema{Schema: "a"}),
+			wantVersion: 2,
+			wantReason:  "missing in target",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sv, _ := tt.source.GetVersions("orders", false)
+			tv, _ := tt.target.GetVersions("orders", false)
+			version, reason, err := firstDivergentVersion(tt.source, tt.target, "orders", sv, tv, contentExact)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != tt.wantVersion || reason != tt.wantReason {
+				t.Errorf("expected v%d (%q), got v%d (%q)", tt.wantVersion, tt.wantReason, version, reason)
+			}
+		})
+	}
+}
+
+func TestDiffPlan(t *testing.T) {
+	results := []CompareResult{
+		{Subject: "users", SourceOnly: true, Versions: []int{1, 2}},
+		{Subject: "orders", SchemaDiff: true, FirstDiff: 2, DiffReason: "schema", Versions: []int{1, 2, 3}},
+		{Subject: "payments", VersionDiff: true, Versions: []int{1, 2}},
+		{Subject: "audit", SchemaDiff: true, FirstDiff: 3, DiffReason: "missing in source", Versions: []int{1, 2}},
+		{Subject: "identical", Versions: []int{1}},
+		{Subject: "target-only", TargetOnly: true},
+		{Subject: "broken", SchemaDiff: true, Error: "source: timeout", Versions: []int{1}},
+	}
+
+	plan := buildDiffPlan(results)
+	got := make(map[string][]int)
+	for _, ps := range plan.Subjects {
+		got[ps.Subject] = ps.Versions
+	}
+	want := map[string][]int{
+		"orders":   {2, 3},
+		"payments": {1, 2},
+		"users":    {1, 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected plan %v, got %v", want, got)
+	}
+	for subject, versions := range want {
+		if fmt.Sprint(got[subject]) != fmt.Sprint(versions) {
+			t.Errorf("%s: expected versions %v, got %v", subject, versions, got[subject])
+		}
+	}
+	if plan.Subjects[0].Subject != "orders" {
+		t.Errorf("expected subjects sorted, got %s first", plan.Subjects[0].Subject)
+	}
+
+	// Round-trip through a file and filter the collected schemas
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := saveJSON(path, plan); err != nil {
+		t.Fatalf("failed to write plan: %v", err)
+	}
+	loaded, err := readDiffPlan(path)
+	if err != nil {
+		t.Fatalf("failed to read plan: %v", err)
+	}
+
+	toClone := []schemaToClone{
+		{Subject: "orders", Version: 1},
+		{Subject: "orders", Version: 2},
+		{Subject: "orders", Version: 3},
+		{Subject: "address", Version: 1}, // reference, not in plan
+	}
+	kept := applyDiffPlan(toClone, loaded)
+	var keys []string
+	for _, s := range kept {
+		keys = append(keys, fmt.Sprintf("%s:%d", s.Subject, s.Version))
+	}
+	if fmt.Sprint(keys) != "[orders:2 orders:3 address:1]" {
+		t.Errorf("unexpected schemas kept: %v", keys)
+	}
+}
+
+func TestLatestSchemasDiffer(t *testing.T) {
+	source := client.NewMockClient()
+	target := client.NewMockClient()
+	source.AddSubject("orders", []client.Schema{{Subject: "orders", Version: 1, ID: 100, Schema: `{"type":"string"}`}})
+	target.AddSubject("orders", []client.Schema{{Subject: "orders", Version: 1, ID: 200, Schema: `{"type":"string"}`}})
+
+	different, err := latestSchemasDiffer(source, target, "orders", false, contentExact)
+	if err != nil || different {
+		t.Errorf("expected identical content, got different=%v err=%v", different, err)
+	}
+
+	different, err = latestSchemasDiffer(source, target, "orders", true, contentExact)
+	if err != nil || !different {
+		t.Errorf("expected different IDs, got different=%v err=%v", different, err)
+	}
+	if source.GetCallCount("GetSchema") != 1 || source.GetCallCount("GetLatestSchemaMetadata") != 1 {
+		t.Error("expected --by-id to use the metadata-only fetch")
+	}
+
+	if _, err := latestSchemasDiffer(source, target, "missing", true, contentExact); err == nil {
+		t.Error("expected error for missing subject")
+	}
+}
+
+func TestCompareNormalize(t *testing.T) {
+	source := client.NewMockClient()
+	target := client.NewMockClient()
+	source.AddSubject("orders", []client.Schema{{Subject: "orders", Version: 1, ID: 1, Schema: `{"type":"record","name":"Order","fields":[]}`}})
+	target.AddSubject("orders", []client.Schema{{Subject: "orders", Version: 1, ID: 1, Schema: "{\n  \"name\": \"Order\",\n  \"type\": \"record\",\n  \"fields\": []\n}"}})
+
+	if different, _ := latestSchemasDiffer(source, target, "orders", false, contentExact); !different {
+		t.Error("expected byte-for-byte comparison to differ")
+	}
+	if different, _ := latestSchemasDiffer(source, target, "orders", false, contentNormalized); different {
+		t.Error("expected normalized comparison to match")
+	}
+
+	sv, _ := source.GetVersions("orders", false)
+	tv, _ := target.GetVersions("orders", false)
+	if first, _, _ := firstDivergentVersion(source, target, "orders", sv, tv, contentNormalized); first != 0 {
+		t.Errorf("expected no divergence with normalize, got v%d", first)
+	}
+}
+
+func TestCloneRestoresSubjectModeOnFailure(t *testing.T) {
+	savedPreserve, savedReset, savedFailFast := cloneNoPreserveIDs, cloneResetOverride, cloneFailFast
+	defer func() {
+		cloneNoPreserveIDs, cloneResetOverride, cloneFailFast = savedPreserve, savedReset, savedFailFast
+	}()
+	cloneNoPreserveIDs, cloneResetOverride, cloneFailFast = false, false, false
+
+	var mu sync.Mutex
+	modes := make(map[string][]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/mode/"):
+			var body struct{ Mode string }
+			json.NewDecoder(r.Body).Decode(&body)
+			subj := strings.TrimPrefix(r.URL.Path, "/mode/")
+			mu.Lock()
+			modes[subj] = append(modes[subj], body.Mode)
+			mu.Unlock()
+			if subj == "stuck-value" && body.Mode == "READWRITE" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`{"mode":"` + body.Mode + `"}`))
+		case r.Method == http.MethodPost && strings.Contains(r.URL.Path, "broken-value"):
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error_code":42201,"message":"Invalid schema"}`))
+		case r.Method == http.MethodPost:
+			w.Write([]byte(`{"id":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	target := client.NewClient(server.URL, nil)
+	target.MaxRetries = 0
+	schemas := []schemaToClone{
+		{Subject: "ok-value", Version: 1, SchemaID: 1, Schema: `"string"`},
+		{Subject: "broken-value", Version: 1, SchemaID: 2, Schema: `"string"`},
+		{Subject: "stuck-value", Version: 1, SchemaID: 3, Schema: `"string"`},
+	}
+	cp, err := loadCheckpoint(filepath.Join(t.TempDir(), "clone.json"), "clone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cloned, _, failures := cloneSchemasParallel(target, schemas, cp)
+
+	if cloned != 2 {
+		t.Errorf("cloned = %d, want 2", cloned)
+	}
+	for _, subj := range []string{"ok-value", "broken-value", "stuck-value"} {
+		if got := modes[subj]; len(got) != 2 || got[0] != "IMPORT" || got[1] != "READWRITE" {
+			t.Errorf("%s modes = %v, want IMPORT then READWRITE", subj, got)
+		}
+	}
+	// The failed registration and the failed restore are both reported
+	if len(failures) != 2 {
+		t.Fatalf("expected 2 failures, got %+v", failures)
+	}
+	for _, f := range failures {
+		if f.Subject == "stuck-value" && !strings.Contains(f.Error, "restore mode") {
+			t.Errorf("unexpected failure for stuck-value: %+v", f)
+		}
+	}
+
+	// Registered versions are checkpointed, the failed one is left to retry
+	saved, err := loadCheckpoint(cp.path, "clone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.VersionDone("ok-value", 1) || !saved.VersionDone("stuck-value", 1) || saved.VersionDone("broken-value", 1) {
+		t.Errorf("unexpected checkpoint: %+v", saved.Subjects)
+	}
+}
+
+func TestCompareCanonicalAvro(t *testing.T) {
+	source := client.NewMockClient()
+	target := client.NewMockClient()
+	source.AddSubject("users", []client.Schema{{Subject: "users", Version: 1, ID: 1,
+		Schema: `{"type":"record","name":"User","namespace":"com.example","fields":[{"name":"id","type":"long"}]}`}})
+	target.AddSubject("users", []client.Schema{{Subject: "users", Version: 1, ID: 1,
+		Schema: `{"type":"record","name":"com.example.User","doc":"A user","fields":[{"name":"id","type":"long","doc":"ID"}]}`}})
+
+	if different, _ := latestSchemasDiffer(source, target, "users", false, contentCanonical); different {
+		t.Error("expected docs and namespace spelling to be ignored with canonical")
+	}
+	if different, _ := latestSchemasDiffer(source, target, "users", false, contentNormalized); !different {
+		t.Error("expected normalize to report doc drift")
+	}
+
+	// JSON Schema keeps its description when canonical
+	jsonSchema := &client.Schema{SchemaType: "JSON", Schema: `{"type":"object","description":"x"}`}
+	if got := comparableSchema(jsonSchema, contentCanonical); got != `{"description":"x","type":"object"}` {
+		t.Errorf("unexpected canonical JSON schema %s", got)
+	}
+}
+
+func TestCompareNormalizeReportsDefaultDrift(t *testing.T) {
+	source := client.NewMockClient()
+	target := client.NewMockClient()
+	source.AddSubject("users", []client.Schema{{Subject: "users", Version: 1, ID: 1,
+		Schema: `{"type":"record","name":"User","fields":[{"name":"tier","type":"string","default":"free"}]}`}})
+	target.AddSubject("users", []client.Schema{{Subject: "users", Version: 1, ID: 1,
+		Schema: `{"type":"record","name":"User","fields":[{"name":"tier","type":"string","default":"paid"}]}`}})
+
+	if different, _ := latestSchemasDiffer(source, target, "users", false, contentNormalized); !different {
+		t.Error("expected normalize to report a changed default")
+	}
+	sv, _ := source.GetVersions("users", false)
+	tv, _ := target.GetVersions("users", false)
+	if first, reason, _ := firstDivergentVersion(source, target, "users", sv, tv, contentNormalized); first != 1 || reason != "schema" {
+		t.Errorf("expected v1 to diverge on schema, got v%d (%s)", first, reason)
+	}
+	if different, _ := latestSchemasDiffer(source, target, "users", false, contentCanonical); different {
+		t.Error("expected canonical to ignore a changed default")
+	}
+}
+
+func TestComparisonMode(t *testing.T) {
+	tests := []struct {
+		normalize, canonical bool
+		want                 contentComparison
+	}{
+		{false, false, contentExact},
+		{true, false, contentNormalized},
+		{false, true, contentCanonical},
+		{true, true, contentCanonical},
+	}
+	for _, tt := range tests {
+		if got := comparisonMode(tt.normalize, tt.canonical); got != tt.want {
+			t.Errorf("comparisonMode(%v, %v) = %v, want %v", tt.normalize, tt.canonical, got, tt.want)
+		}
+	}
+}
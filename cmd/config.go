@@ -41,18 +41,59 @@ Examples:
   srctl config user-events --set FULL
 
   # View configuration summary for all levels
-  srctl config --all`,
+  srctl config --all
+
+  # Subcommand forms
+  srctl config get user-events
+  srctl config set user-events --compatibility FULL
+  srctl config set --global --compatibility BACKWARD
+  srctl config delete user-events`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [subject]",
+	Short: "Show global or subject compatibility",
+	Long: `Show the global compatibility level, or the subject, global and effective
+levels when a subject is given.`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: readOnlyAnnotations,
+	RunE:        runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [subject]",
+	Short: "Set global or subject compatibility",
+	Long: `Set the compatibility level for a subject, or for the whole registry with
+--global. One of the two is required so the global level is never changed by
+accident.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigSet,
+}
+
+var configDeleteCmd = &cobra.Command{
+	Use:   "delete <subject>",
+	Short: "Remove a subject's compatibility override",
+	Long:  `Delete the subject-level compatibility so the subject falls back to the global level.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigDelete,
 }
 
 var (
-	configSet     string
-	configShowAll bool
+	configSet           string
+	configShowAll       bool
+	configCompatibility string
+	configGlobal        bool
 )
 
 func init() {
 	configCmd.Flags().StringVar(&configSet, "set", "", "Set compatibility level")
 	configCmd.Flags().BoolVar(&configShowAll, "all", false, "Show configuration at all levels")
 
+	configSetCmd.Flags().StringVar(&configCompatibility, "compatibility", "", "Compatibility level to set")
+	configSetCmd.Flags().BoolVar(&configGlobal, "global", false, "Set the global compatibility level")
+	configSetCmd.MarkFlagRequired("compatibility")
+
+	configCmd.AddCommand(configGetCmd, configSetCmd, configDeleteCmd)
 	configCmd.RunE = runConfig
 	rootCmd.AddCommand(configCmd)
 }
@@ -84,7 +125,53 @@ func runConfig(cmd *cobra.Command, args []string) error {
 	return showGlobalConfig(c, printer)
 }
 
-func setConfig(c *client.SchemaRegistryClient, args []string, level string) error {
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	printer := output.NewPrinter(outputFormat)
+	if len(args) > 0 {
+		return showSubjectConfig(c, args[0], printer)
+	}
+	return showGlobalConfig(c, printer)
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	if configGlobal && len(args) > 0 {
+		return fmt.Errorf("--global cannot be combined with a subject")
+	}
+	if !configGlobal && len(args) == 0 {
+		return fmt.Errorf("specify a subject or --global")
+	}
+
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+	return setConfig(c, args, configCompatibility)
+}
+
+func runConfigDelete(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+	return deleteSubjectConfig(c, args[0])
+}
+
+// deleteSubjectConfig resets a subject to the global compatibility level
+func deleteSubjectConfig(c client.SchemaRegistryClientInterface, subject string) error {
+	output.Step("Removing compatibility override for subject: %s", subject)
+	if err := c.DeleteSubjectConfig(subject); err != nil {
+		return fmt.Errorf("failed to delete subject config: %w", err)
+	}
+	output.Success("Subject %s now uses the global compatibility level", subject)
+	return nil
+}
+
+func setConfig(c client.SchemaRegistryClientInterface, args []string, level string) error {
 	level = strings.ToUpper(level)
 
 	validLevels := map[string]bool{
@@ -117,7 +204,7 @@ func setConfig(c *client.SchemaRegistryClient, args []string, level string) erro
 	return nil
 }
 
-func showGlobalConfig(c *client.SchemaRegistryClient, printer *output.Printer) error {
+func showGlobalConfig(c client.SchemaRegistryClientInterface, printer *output.Printer) error {
 	config, err := c.GetConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get config: %w", err)
@@ -148,7 +235,7 @@ func showGlobalConfig(c *client.SchemaRegistryClient, printer *output.Printer) e
 	})
 }
 
-func showSubjectConfig(c *client.SchemaRegistryClient, subject string, printer *output.Printer) error {
+func showSubjectConfig(c client.SchemaRegistryClientInterface, subject string, printer *output.Printer) error {
 	// Get global config
 	globalConfig, err := c.GetConfig()
 	if err != nil {
@@ -280,18 +367,66 @@ Examples:
   srctl mode user-events --set IMPORT
 
   # View mode at all levels
-  srctl mode --all`,
+  srctl mode --all
+
+  # Subcommand forms
+  srctl mode get user-events
+  srctl mode set user-events READONLY
+  srctl mode set READWRITE
+  srctl mode delete user-events`,
 	RunE: runMode,
 }
 
+var modeGetCmd = &cobra.Command{
+	Use:   "get [subject]",
+	Short: "Show global or subject mode",
+	Long: `Show the global mode, or the subject, global and effective modes when a
+subject is given.`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: readOnlyAnnotations,
+	RunE:        runModeGet,
+}
+
+var modeSetCmd = &cobra.Command{
+	Use:   "set [subject] READONLY|READWRITE|IMPORT",
+	Short: "Set global or subject mode",
+	Long: `Set the mode for a subject, or for the whole registry when no subject is
+given. Switching the global registry into READONLY or IMPORT asks for
+confirmation unless --yes is passed.
+
+Examples:
+  # Freeze a subject before maintenance
+  srctl mode set user-events READONLY
+
+  # Unfreeze it again
+  srctl mode set user-events READWRITE
+
+  # Put the whole registry into IMPORT mode without prompting
+  srctl mode set IMPORT --yes`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runModeSet,
+}
+
+var modeDeleteCmd = &cobra.Command{
+	Use:   "delete <subject>",
+	Short: "Remove a subject's mode override",
+	Long:  `Delete the subject-level mode so the subject falls back to the global mode.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runModeDelete,
+}
+
 var (
 	modeSet     string
 	modeShowAll bool
+	modeYes     bool
 )
 
 func init() {
 	modeCmd.Flags().StringVar(&modeSet, "set", "", "Set mode (READWRITE, READONLY, IMPORT)")
 	modeCmd.Flags().BoolVar(&modeShowAll, "all", false, "Show mode at all levels")
+	modeSetCmd.Flags().BoolVarP(&modeYes, "yes", "y", false, "Skip the confirmation prompt for global mode changes")
+
+	modeCmd.AddCommand(modeGetCmd, modeSetCmd, modeDeleteCmd)
 
 	rootCmd.AddCommand(modeCmd)
 }
@@ -323,7 +458,73 @@ func runMode(cmd *cobra.Command, args []string) error {
 	return showGlobalMode(c, printer)
 }
 
-func setMode(c *client.SchemaRegistryClient, args []string, mode string) error {
+func runModeGet(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	printer := output.NewPrinter(outputFormat)
+	if len(args) > 0 {
+		return showSubjectMode(c, args[0], printer)
+	}
+	return showGlobalMode(c, printer)
+}
+
+func runModeSet(cmd *cobra.Command, args []string) error {
+	subjectArgs, mode := args[:len(args)-1], args[len(args)-1]
+
+	if modeNeedsConfirm(subjectArgs, mode) && !modeYes &&
+		!confirmAction(fmt.Sprintf("Switch the global registry to %s mode?", strings.ToUpper(mode))) {
+		output.Info("Cancelled")
+		return nil
+	}
+
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+	return setMode(c, subjectArgs, mode)
+}
+
+func runModeDelete(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	subject := args[0]
+	output.Step("Removing mode override for subject: %s", subject)
+	if err := c.DeleteSubjectMode(subject); err != nil {
+		return fmt.Errorf("failed to delete subject mode: %w", err)
+	}
+	output.Success("Subject %s now uses the global mode", subject)
+	return nil
+}
+
+// clearSubjectOverrides removes a subject's compatibility and mode overrides.
+// A subject without an override (404) is not an error.
+func clearSubjectOverrides(c client.SchemaRegistryClientInterface, subject string) error {
+	if err := c.DeleteSubjectConfig(subject); err != nil && !client.IsNotFound(err) {
+		return fmt.Errorf("failed to delete subject config: %w", err)
+	}
+	if err := c.DeleteSubjectMode(subject); err != nil && !client.IsNotFound(err) {
+		return fmt.Errorf("failed to delete subject mode: %w", err)
+	}
+	return nil
+}
+
+// modeNeedsConfirm reports whether a mode change blocks normal writes
+// registry-wide
+func modeNeedsConfirm(subjectArgs []string, mode string) bool {
+	if len(subjectArgs) > 0 {
+		return false
+	}
+	mode = strings.ToUpper(mode)
+	return mode == "READONLY" || mode == "IMPORT"
+}
+
+func setMode(c client.SchemaRegistryClientInterface, args []string, mode string) error {
 	mode = strings.ToUpper(mode)
 
 	validModes := map[string]bool{
@@ -352,7 +553,7 @@ func setMode(c *client.SchemaRegistryClient, args []string, mode string) error {
 	return nil
 }
 
-func showGlobalMode(c *client.SchemaRegistryClient, printer *output.Printer) error {
+func showGlobalMode(c client.SchemaRegistryClientInterface, printer *output.Printer) error {
 	mode, err := c.GetMode()
 	if err != nil {
 		return fmt.Errorf("failed to get mode: %w", err)
@@ -378,7 +579,7 @@ func showGlobalMode(c *client.SchemaRegistryClient, printer *output.Printer) err
 	})
 }
 
-func showSubjectMode(c *client.SchemaRegistryClient, subject string, printer *output.Printer) error {
+func showSubjectMode(c client.SchemaRegistryClientInterface, subject string, printer *output.Printer) error {
 	// Get global mode
 	globalMode, err := c.GetMode()
 	if err != nil {
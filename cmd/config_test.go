@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/srctl/srctl/internal/client"
@@ -218,3 +219,94 @@ func TestModeError(t *testing.T) {
 		t.Error("expected error")
 	}
 }
+
+func TestConfigSubcommands(t *testing.T) {
+	mock := client.NewMockClient()
+
+	if err := setConfig(mock, []string{"orders-value"}, "full"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mock.SubjectConfigs["orders-value"].CompatibilityLevel; got != "FULL" {
+		t.Errorf("expected FULL, got %s", got)
+	}
+
+	if err := setConfig(mock, nil, "SIDEWAYS"); err == nil {
+		t.Error("expected error for an invalid compatibility level")
+	}
+
+	if err := deleteSubjectConfig(mock, "orders-value"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := mock.SubjectConfigs["orders-value"]; ok {
+		t.Error("expected subject override to be removed")
+	}
+	if err := deleteSubjectConfig(mock, "orders-value"); err == nil {
+		t.Error("expected error deleting a missing override")
+	}
+}
+
+func TestConfigSetRequiresScope(t *testing.T) {
+	defer func() { configGlobal = false }()
+
+	if err := runConfigSet(configSetCmd, nil); err == nil {
+		t.Error("expected error without a subject or --global")
+	}
+
+	configGlobal = true
+	if err := runConfigSet(configSetCmd, []string{"orders-value"}); err == nil {
+		t.Error("expected error combining --global with a subject")
+	}
+}
+
+func TestModeSubcommands(t *testing.T) {
+	mock := client.NewMockClient()
+
+	if err := setMode(mock, []string{"orders-value"}, "readonly"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mock.SubjectModes["orders-value"].Mode; got != "READONLY" {
+		t.Errorf("expected READONLY, got %s", got)
+	}
+	if err := setMode(mock, nil, "FROZEN"); err == nil {
+		t.Error("expected error for an invalid mode")
+	}
+
+	tests := []struct {
+		subjects []string
+		mode     string
+		want     bool
+	}{
+		{nil, "READONLY", true},
+		{nil, "import", true},
+		{nil, "READWRITE", false},
+		{[]string{"orders-value"}, "READONLY", false},
+	}
+	for _, tt := range tests {
+		if got := modeNeedsConfirm(tt.subjects, tt.mode); got != tt.want {
+			t.Errorf("modeNeedsConfirm(%v, %s) = %v, want %v", tt.subjects, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestClearSubjectOverrides(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.SubjectConfigs["orders-value"] = &client.Config{CompatibilityLevel: "NONE"}
+	mock.SubjectModes["orders-value"] = &client.Mode{Mode: "READONLY"}
+
+	if err := clearSubjectOverrides(mock, "orders-value"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.SubjectConfigs) != 0 || len(mock.SubjectModes) != 0 {
+		t.Errorf("expected overrides to be cleared, got configs=%v modes=%v", mock.SubjectConfigs, mock.SubjectModes)
+	}
+
+	// Subjects without overrides are fine
+	if err := clearSubjectOverrides(mock, "orders-value"); err != nil {
+		t.Errorf("expected missing overrides to be ignored, got %v", err)
+	}
+
+	mock.ModeError = fmt.Errorf("forbidden (status 403)")
+	if err := clearSubjectOverrides(mock, "orders-value"); err == nil {
+		t.Error("expected non-404 errors to be returned")
+	}
+}
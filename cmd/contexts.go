@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srctl/srctl/internal/output"
+)
+
+// ContextsCmd for getting contexts
+var contextsCmd = &cobra.Command{
+	Use:   "contexts",
+	Short: "List and manage contexts in the Schema Registry",
+	Long: `List and manage the contexts (tenants) available in the Schema Registry.
+
+Contexts allow logical separation of schemas within a single Schema Registry cluster.
+Running 'srctl contexts' without a subcommand lists them.
+
+Examples:
+  # List all contexts
+  srctl contexts list
+
+  # Output as JSON
+  srctl contexts list -o json
+
+  # Permanently delete every subject in a context
+  srctl contexts delete .staging`,
+	RunE: runContexts,
+}
+
+var contextsListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List all contexts in the Schema Registry",
+	Args:        cobra.NoArgs,
+	Annotations: readOnlyAnnotations,
+	RunE:        runContexts,
+}
+
+var contextsDeleteCmd = &cobra.Command{
+	Use:   "delete <context>",
+	Short: "Permanently delete all subjects in a context",
+	Long: `Permanently delete every subject and schema version in a context.
+
+Subjects are soft deleted and then hard deleted in parallel, the same as
+'srctl delete --context <context> --force'. The default context (.) cannot be
+deleted this way; use 'srctl delete --all --force' instead.
+
+Examples:
+  # Show what would be deleted
+  srctl contexts delete .staging --dry-run
+
+  # Delete without prompting
+  srctl contexts delete .staging --yes --workers 20`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContextsDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(contextsCmd)
+	contextsCmd.AddCommand(contextsListCmd)
+	contextsCmd.AddCommand(contextsDeleteCmd)
+
+	contextsDeleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Skip confirmation prompts")
+	contextsDeleteCmd.Flags().IntVar(&deleteWorkers, "workers", 10, "Number of parallel workers")
+	contextsDeleteCmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "List the subjects and versions that would be deleted without deleting anything")
+}
+
+func runContexts(cmd *cobra.Command, args []string) error {
+	srClient, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	contexts, err := srClient.GetContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	printer := output.NewPrinter(outputFormat)
+
+	if outputFormat == "table" {
+		output.Header("Schema Registry Contexts")
+		if len(contexts) == 0 {
+			output.Info("No contexts found (using default context)")
+			return nil
+		}
+
+		rows := make([][]string, len(contexts))
+		for i, ctx := range contexts {
+			rows[i] = []string{strconv.Itoa(i + 1), ctx}
+		}
+		output.PrintTable([]string{"#", "Context"}, rows)
+		fmt.Printf("\nTotal: %d context(s)\n", len(contexts))
+		return nil
+	}
+
+	return printer.Print(contexts)
+}
+
+func runContextsDelete(cmd *cobra.Command, args []string) error {
+	ctx, err := normalizeContextName(args[0])
+	if err != nil {
+		return err
+	}
+
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	contexts, err := c.GetContexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+	if !slices.Contains(contexts, ctx) {
+		return fmt.Errorf("context '%s' not found", ctx)
+	}
+
+	return forceDeleteContextParallel(c.WithContext(ctx), ctx)
+}
+
+// normalizeContextName adds the leading dot the registry uses for context
+// names ("staging" -> ".staging") and rejects the default context
+func normalizeContextName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("context name is required")
+	}
+	if !strings.HasPrefix(name, ".") {
+		name = "." + name
+	}
+	if name == "." {
+		return "", fmt.Errorf("refusing to delete the default context; use 'srctl delete --all --force' instead")
+	}
+	return name, nil
+}
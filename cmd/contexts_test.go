@@ -0,0 +1,34 @@
+package cmd
+
+import "testing"
+
+func TestNormalizeContextName(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: ".staging", want: ".staging"},
+		{input: "staging", want: ".staging"},
+		{input: " .prod ", want: ".prod"},
+		{input: ".", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := normalizeContextName(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("normalizeContextName(%q) = %q, want error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("normalizeContextName(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("normalizeContextName(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"sync"
 
 
-	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/srctl/srctl/internal/client"
 	"github.com/srctl/srctl/internal/output"
@@ -221,12 +220,7 @@ func analyzeDanglingParallel(c *client.SchemaRegistryClient, subjects []string,
 	resultChan := make(chan danglingResult, len(subjects))
 
 	// Progress bar
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Analyzing"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjects), "Analyzing")
 
 	// Start workers
 	var wg sync.WaitGroup
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var (
+	dedupTop     int
+	dedupMinUses int
+	dedupWorkers int
+	dedupNoBulk  bool
+)
+
+var dedupCmd = &cobra.Command{
+	Use:     "dedup",
+	Short:   "Report schema IDs shared by several subjects or versions",
+	GroupID: groupConfig,
+	Long: `Find schema IDs used by more than one subject or version.
+
+The registry gives identical schemas the same ID, so a shared ID means the same
+schema was registered several times: intentionally (a common type used by many
+topics) or by accident (copies that should have been references). Each shared
+ID is listed with the subject versions using it, most-reused first; IDs used
+by more than one subject are marked as cross-subject.
+
+Candidate IDs come from the bulk /schemas listing; their live usage is then
+confirmed with /schemas/ids/{id}/versions, so soft-deleted versions don't count.
+
+Examples:
+  # Top 20 most-reused schemas
+  srctl dedup
+
+  # Every schema used at least 5 times, as JSON
+  srctl dedup --top 0 --min-uses 5 -o json
+
+  # Within one context
+  srctl dedup --context .prod`,
+	Annotations: readOnlyAnnotations,
+	RunE:        runDedup,
+}
+
+func init() {
+	dedupCmd.Flags().IntVar(&dedupTop, "top", 20, "Show the N most-reused schema IDs (0 = all)")
+	dedupCmd.Flags().IntVar(&dedupMinUses, "min-uses", 2, "Only report IDs used by at least this many subject versions")
+	dedupCmd.Flags().IntVar(&dedupWorkers, "workers", 10, "Number of parallel workers")
+	dedupCmd.Flags().BoolVar(&dedupNoBulk, "no-bulk", false, "Fetch each version individually instead of using the bulk /schemas endpoint")
+	rootCmd.AddCommand(dedupCmd)
+}
+
+// DedupReport lists the schema IDs shared by several subject versions
+type DedupReport struct {
+	UniqueIDs int `json:"uniqueIds"`
+	SharedIDs int `json:"sharedIds"`
+	// RedundantVersions counts the versions beyond the first for each shared ID
+	RedundantVersions int            `json:"redundantVersions"`
+	Schemas           []SharedSchema `json:"schemas"`
+}
+
+// SharedSchema is a schema ID and the subject versions using it
+type SharedSchema struct {
+	ID           int                     `json:"id"`
+	SchemaType   string                  `json:"schemaType"`
+	Subjects     int                     `json:"subjects"`
+	CrossSubject bool                    `json:"crossSubject"`
+	UsedBy       []client.SubjectVersion `json:"usedBy"`
+}
+
+func runDedup(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	output.Step("Collecting schema IDs...")
+	candidates, uniqueIDs, err := collectDuplicateCandidates(c, dedupNoBulk, dedupWorkers)
+	if err != nil {
+		return err
+	}
+
+	output.Step("Checking usage of %d candidate ID(s)...", len(candidates))
+	report, err := buildDedupReport(c, candidates, dedupMinUses, dedupWorkers)
+	if err != nil {
+		return err
+	}
+	report.UniqueIDs = uniqueIDs
+	if dedupTop > 0 && len(report.Schemas) > dedupTop {
+		report.Schemas = report.Schemas[:dedupTop]
+	}
+
+	if outputFormat != "table" {
+		return output.NewPrinter(outputFormat).Print(report)
+	}
+
+	output.Header("Schema Deduplication")
+	output.PrintTable([]string{"Metric", "Value"}, [][]string{
+		{"Unique Schema IDs", strconv.Itoa(report.UniqueIDs)},
+		{"Shared Schema IDs", strconv.Itoa(report.SharedIDs)},
+		{"Redundant Versions", strconv.Itoa(report.RedundantVersions)},
+	})
+	if len(report.Schemas) == 0 {
+		output.Success("No schema ID is used by %d or more subject versions", dedupMinUses)
+		return nil
+	}
+
+	output.SubHeader("Most-Reused Schemas")
+	rows := make([][]string, len(report.Schemas))
+	for i, s := range report.Schemas {
+		scope := "same subject"
+		if s.CrossSubject {
+			scope = "cross-subject"
+		}
+		rows[i] = []string{strconv.Itoa(s.ID), s.SchemaType, strconv.Itoa(len(s.UsedBy)), strconv.Itoa(s.Subjects), scope, formatUsedBy(s.UsedBy, 3)}
+	}
+	output.PrintTable([]string{"ID", "Type", "Versions", "Subjects", "Scope", "Used By"}, rows)
+	return nil
+}
+
+// collectDuplicateCandidates returns the schema IDs that appear more than once
+// across subject versions (with their type), and the number of unique IDs
+func collectDuplicateCandidates(c client.SchemaRegistryClientInterface, noBulk bool, workers int) (map[int]string, int, error) {
+	var schemas []client.Schema
+	fetched := false
+	if !noBulk {
+		bulk, err := fetchSchemasBulk(c, "", statsBulkPageSize)
+		if err != nil {
+			output.Warning("Bulk schema fetch unavailable, falling back to per-version fetches: %v", err)
+		} else {
+			fetched = true
+		}
+		for _, versions := range bulk {
+			schemas = append(schemas, versions...)
+		}
+	}
+	if !fetched {
+		var err error
+		if schemas, err = fetchSchemasPerVersion(c, workers); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	counts := make(map[int]int)
+	types := make(map[int]string)
+	for _, s := range schemas {
+		counts[s.ID]++
+		schemaType := s.SchemaType
+		if schemaType == "" {
+			schemaType = "AVRO"
+		}
+		types[s.ID] = schemaType
+	}
+
+	candidates := make(map[int]string)
+	for id, n := range counts {
+		if n > 1 {
+			candidates[id] = types[id]
+		}
+	}
+	return candidates, len(counts), nil
+}
+
+// fetchSchemasPerVersion fetches every live version of every subject
+func fetchSchemasPerVersion(c client.SchemaRegistryClientInterface, workers int) ([]client.Schema, error) {
+	subjects, err := c.GetSubjects(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subjects: %w", err)
+	}
+
+	jobs := make(chan string, len(subjects))
+	for _, subj := range subjects {
+		jobs <- subj
+	}
+	close(jobs)
+
+	var mu sync.Mutex
+	var schemas []client.Schema
+	var wg sync.WaitGroup
+	for i := 0; i < clampWorkers(workers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for subj := range jobs {
+				versions, err := c.GetVersions(subj, false)
+				if err != nil {
+					continue
+				}
+				for _, v := range versions {
+					schema, err := c.GetSchema(subj, strconv.Itoa(v))
+					if err != nil {
+						continue
+					}
+					mu.Lock()
+					schemas = append(schemas, *schema)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return schemas, nil
+}
+
+// buildDedupReport looks up the live subject versions of each candidate ID and
+// keeps those used at least minUses times, most-reused first
+func buildDedupReport(c client.SchemaRegistryClientInterface, candidates map[int]string, minUses, workers int) (*DedupReport, error) {
+	ids := make(chan int, len(candidates))
+	for id := range candidates {
+		ids <- id
+	}
+	close(ids)
+
+	var mu sync.Mutex
+	var firstErr error
+	report := &DedupReport{Schemas: []SharedSchema{}}
+	var wg sync.WaitGroup
+	for i := 0; i < clampWorkers(workers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				usedBy, err := c.GetSchemaSubjectVersionsByID(id)
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to get versions for schema ID %d: %w", id, err)
+					}
+					mu.Unlock()
+					continue
+				}
+				if len(usedBy) > 1 {
+					report.SharedIDs++
+					report.RedundantVersions += len(usedBy) - 1
+				}
+				if len(usedBy) >= max(minUses, 2) {
+					report.Schemas = append(report.Schemas, newSharedSchema(id, candidates[id], usedBy))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(report.Schemas, func(i, j int) bool {
+		a, b := report.Schemas[i], report.Schemas[j]
+		if len(a.UsedBy) != len(b.UsedBy) {
+			return len(a.UsedBy) > len(b.UsedBy)
+		}
+		return a.ID < b.ID
+	})
+	return report, nil
+}
+
+func newSharedSchema(id int, schemaType string, usedBy []client.SubjectVersion) SharedSchema {
+	sort.Slice(usedBy, func(i, j int) bool {
+		if usedBy[i].Subject != usedBy[j].Subject {
+			return usedBy[i].Subject < usedBy[j].Subject
+		}
+		return usedBy[i].Version < usedBy[j].Version
+	})
+	subjects := make(map[string]bool)
+	for _, sv := range usedBy {
+		subjects[sv.Subject] = true
+	}
+	return SharedSchema{
+		ID:           id,
+		SchemaType:   schemaType,
+		Subjects:     len(subjects),
+		CrossSubject: len(subjects) > 1,
+		UsedBy:       usedBy,
+	}
+}
+
+// formatUsedBy lists up to limit subject versions, e.g. "a:1, b:3 (+2 more)"
+func formatUsedBy(usedBy []client.SubjectVersion, limit int) string {
+	parts := make([]string, 0, limit)
+	for i, sv := range usedBy {
+		if i == limit {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s:%d", sv.Subject, sv.Version))
+	}
+	s := strings.Join(parts, ", ")
+	if extra := len(usedBy) - limit; extra > 0 {
+		s += fmt.Sprintf(" (+%d more)", extra)
+	}
+	return s
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/srctl/srctl/internal/client"
+)
+
+func TestDedupReport(t *testing.T) {
+	mock := client.NewMockClient()
+	// addTestSubject numbers IDs from 100, so these share 100 and 101
+	addTestSubject(mock, "orders-value", 2)
+	addTestSubject(mock, "payments-value", 2)
+	addTestSubject(mock, "users-value", 1)
+	// Same schema registered twice in one subject
+	mock.AddSubject("events-value", []client.Schema{
+		{Subject: "events-value", Version: 1, ID: 200, Schema: `"string"`},
+		{Subject: "events-value", Version: 2, ID: 200, Schema: `"string"`},
+		{Subject: "events-value", Version: 3, ID: 201, SchemaType: "JSON", Schema: `{}`},
+	})
+
+	for _, noBulk := range []bool{false, true} {
+		candidates, unique, err := collectDuplicateCandidates(mock, noBulk, 2)
+		if err != nil {
+			t.Fatalf("noBulk=%v: unexpected error: %v", noBulk, err)
+		}
+		if unique != 4 {
+			t.Errorf("noBulk=%v: expected 4 unique IDs, got %d", noBulk, unique)
+		}
+		if want := map[int]string{100: "AVRO", 101: "AVRO", 200: "AVRO"}; !reflect.DeepEqual(candidates, want) {
+			t.Errorf("noBulk=%v: candidates = %v, want %v", noBulk, candidates, want)
+		}
+
+		report, err := buildDedupReport(mock, candidates, 2, 2)
+		if err != nil {
+			t.Fatalf("noBulk=%v: unexpected error: %v", noBulk, err)
+		}
+		if report.SharedIDs != 3 || report.RedundantVersions != 4 {
+			t.Errorf("noBulk=%v: expected 3 shared IDs and 4 redundant versions, got %d and %d", noBulk, report.SharedIDs, report.RedundantVersions)
+		}
+
+		var ids []int
+		for _, s := range report.Schemas {
+			ids = append(ids, s.ID)
+		}
+		if !reflect.DeepEqual(ids, []int{100, 101, 200}) {
+			t.Fatalf("noBulk=%v: expected IDs [100 101 200] most-reused first, got %v", noBulk, ids)
+		}
+		top := report.Schemas[0]
+		wantUsedBy := []client.SubjectVersion{
+			{Subject: "orders-value", Version: 1},
+			{Subject: "payments-value", Version: 1},
+			{Subject: "users-value", Version: 1},
+		}
+		if !reflect.DeepEqual(top.UsedBy, wantUsedBy) || top.Subjects != 3 || !top.CrossSubject {
+			t.Errorf("noBulk=%v: unexpected top schema %+v", noBulk, top)
+		}
+		if report.Schemas[2].CrossSubject {
+			t.Errorf("noBulk=%v: expected ID 200 to be same-subject", noBulk)
+		}
+	}
+
+	report, err := buildDedupReport(mock, map[int]string{100: "AVRO", 101: "AVRO"}, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Schemas) != 1 || report.Schemas[0].ID != 100 {
+		t.Errorf("expected only ID 100 with --min-uses 3, got %+v", report.Schemas)
+	}
+}
+
+func TestFormatUsedBy(t *testing.T) {
+	usedBy := []client.SubjectVersion{{Subject: "a", Version: 1}, {Subject: "b", Version: 2}, {Subject: "c", Version: 3}}
+	if got := formatUsedBy(usedBy, 2); got != "a:1, b:2 (+1 more)" {
+		t.Errorf("formatUsedBy() = %q", got)
+	}
+	if got := formatUsedBy(usedBy, 3); got != "a:1, b:2, c:3" {
+		t.Errorf("formatUsedBy() = %q", got)
+	}
+}
@@ -4,26 +4,31 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 
-	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/srctl/srctl/internal/client"
 	"github.com/srctl/srctl/internal/output"
 )
 
 var (
-	deleteForce        bool
-	deletePermanent    bool
-	deleteKeepLatest   int
-	deletePurgeSoftDel bool
-	deleteYes          bool
-	deleteWorkers      int
-	deleteSubjects     []string
-	deleteSkipRefCheck bool
+	deleteForce          bool
+	deletePermanent      bool
+	deleteKeepLatest     int
+	deletePurgeSoftDel   bool
+	deleteYes            bool
+	deleteWorkers        int
+	deleteSubjects       []string
+	deleteSkipRefCheck   bool
+	deleteFilter         string
+	deleteRegex          bool
+	deleteDryRun         bool
+	deleteExclude        []string
+	deleteExcludePattern string
 )
 
 var deleteCmd = &cobra.Command{
@@ -46,6 +51,8 @@ Advanced delete operations with --force:
 
 Bulk delete operations:
   • Delete multiple subjects (--subjects)
+  • Delete subjects matching a glob pattern (--filter, * wildcard, case-insensitive)
+    or a regular expression (--filter with --regex)
   • Multi-threaded deletion (--workers)
 
 Keep latest N versions:
@@ -73,6 +80,12 @@ Examples:
   # Delete multiple subjects with multi-threading
   srctl delete --subjects user-events,order-events --workers 10
 
+  # Delete all subjects matching a pattern (in the current --context)
+  srctl delete --filter "events.*" --permanent
+
+  # Delete subjects matching a regular expression
+  srctl delete --filter '^events\..*-value$' --regex --permanent
+
   # Force delete entire context with multi-threading
   srctl delete --context .mycontext --force --workers 20
 
@@ -92,7 +105,14 @@ Examples:
   srctl delete --context .mycontext --purge-soft-deleted
 
   # Purge soft-deleted for specific subject
-  srctl delete user-events --purge-soft-deleted`,
+  srctl delete user-events --purge-soft-deleted
+
+  # Empty a context except for protected subjects
+  srctl delete --context .mycontext --force --exclude audit-value --exclude-pattern "_*"
+
+  # Preview exactly which versions any mode would delete
+  srctl delete user-events --keep-latest 3 --dry-run
+  srctl delete --purge-soft-deleted --dry-run`,
 	RunE: runDelete,
 }
 
@@ -107,22 +127,58 @@ func init() {
 	deleteCmd.Flags().BoolVar(&deleteAll, "all", false, "Delete all subjects in registry (requires --force)")
 	deleteCmd.Flags().IntVar(&deleteWorkers, "workers", 10, "Number of parallel workers for bulk operations")
 	deleteCmd.Flags().StringSliceVar(&deleteSubjects, "subjects", nil, "Delete specific subjects (comma-separated)")
+	deleteCmd.Flags().StringVar(&deleteFilter, "filter", "", "Delete subjects matching a glob pattern (e.g. \"user-*\"); combines with --subjects and --context")
+	deleteCmd.Flags().BoolVar(&deleteRegex, "regex", false, "Treat --filter as a regular expression instead of a glob")
 	deleteCmd.Flags().BoolVar(&deleteSkipRefCheck, "skip-ref-check", false, "Skip referential integrity check (not recommended)")
+	deleteCmd.Flags().StringSliceVar(&deleteExclude, "exclude", nil, "Subjects to leave alone in bulk deletes (comma-separated)")
+	deleteCmd.Flags().StringVar(&deleteExcludePattern, "exclude-pattern", "", "Leave alone subjects matching a glob pattern (a regular expression with --regex)")
+	deleteCmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "List the subjects and versions that would be deleted without deleting anything")
+	addJSONLFlag(deleteCmd)
 
 	rootCmd.AddCommand(deleteCmd)
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
+	startSummary()
 	c, err := GetClient()
 	if err != nil {
 		return err
 	}
+	defer reportThrottling(c)
 
 	// Handle purge soft-deleted schemas
 	if deletePurgeSoftDel {
 		return purgeSoftDeleted(c, args)
 	}
 
+	// Resolve --filter into the bulk subject list
+	if deleteFilter != "" {
+		if len(args) > 0 || deleteAll {
+			return fmt.Errorf("--filter cannot be combined with a subject argument or --all")
+		}
+		subjects, err := selectSubjects(c, deleteSubjects, deleteFilter, deleteRegex, false)
+		if err != nil {
+			return err
+		}
+		if len(subjects) == 0 {
+			output.Warning("No subjects match '%s'", deleteFilter)
+			return nil
+		}
+		deleteSubjects = subjects
+	}
+
+	// Drop excluded subjects from the bulk list
+	if len(deleteSubjects) > 0 {
+		deleteSubjects, err = excludeDeleteSubjects(deleteSubjects)
+		if err != nil {
+			return err
+		}
+		if len(deleteSubjects) == 0 {
+			output.Warning("All selected subjects are excluded")
+			return nil
+		}
+	}
+
 	// Handle keep latest N versions
 	if deleteKeepLatest > 0 {
 		if len(args) == 0 && len(deleteSubjects) == 0 {
@@ -189,6 +245,10 @@ func deleteVersion(c *client.SchemaRegistryClient, subject, version string) erro
 		return fmt.Errorf("referential integrity violation")
 	}
 
+	if deleteDryRun {
+		return printVersionDeletePlan(c, subject, version, deletePermanent)
+	}
+
 	if !deleteYes && !confirmAction(fmt.Sprintf("Delete version %s of %s?", version, subject)) {
 		output.Info("Cancelled")
 		return nil
@@ -199,6 +259,9 @@ func deleteVersion(c *client.SchemaRegistryClient, subject, version string) erro
 		return fmt.Errorf("failed to delete version: %w", err)
 	}
 
+	if structuredOutput() {
+		return printSummary(DeleteSummary{Subject: subject, Permanent: deletePermanent, Versions: 1})
+	}
 	if deletePermanent {
 		output.Success("Permanently deleted version %d", deletedVersion)
 	} else {
@@ -222,6 +285,10 @@ func deleteSubject(c *client.SchemaRegistryClient, subject string) error {
 		return fmt.Errorf("referential integrity violation")
 	}
 
+	if deleteDryRun {
+		return printDeletePlan(planSubjectDeletes(c, []string{subject}, deletePermanent))
+	}
+
 	if !deleteYes && !confirmAction(fmt.Sprintf("Delete subject %s?", subject)) {
 		output.Info("Cancelled")
 		return nil
@@ -232,6 +299,9 @@ func deleteSubject(c *client.SchemaRegistryClient, subject string) error {
 		return fmt.Errorf("failed to delete subject: %w", err)
 	}
 
+	if structuredOutput() {
+		return printSummary(DeleteSummary{Subject: subject, Permanent: deletePermanent, Subjects: 1, Versions: len(versions)})
+	}
 	if deletePermanent {
 		output.Success("Permanently deleted subject with %d versions", len(versions))
 	} else {
@@ -255,6 +325,10 @@ func forceDeleteSubject(c *client.SchemaRegistryClient, subject string) error {
 		return fmt.Errorf("referential integrity violation")
 	}
 
+	if deleteDryRun {
+		return printDeletePlan(planSubjectDeletes(c, []string{subject}, true))
+	}
+
 	if !deleteYes && !confirmAction(fmt.Sprintf("PERMANENTLY delete ALL versions of %s? This cannot be undone!", subject)) {
 		output.Info("Cancelled")
 		return nil
@@ -271,7 +345,7 @@ func forceDeleteSubject(c *client.SchemaRegistryClient, subject string) error {
 	// Step 2: Soft delete the subject if not already
 	output.Step("Step 2/3: Soft deleting subject...")
 	_, err = c.DeleteSubject(subject, false)
-	if err != nil && !strings.Contains(err.Error(), "already deleted") {
+	if err != nil && !client.IsSoftDeleted(err) {
 		// May already be soft deleted, continue
 		output.Warning("Soft delete warning: %v", err)
 	}
@@ -284,10 +358,25 @@ func forceDeleteSubject(c *client.SchemaRegistryClient, subject string) error {
 		return fmt.Errorf("failed to permanently delete: %w", err)
 	}
 
+	if structuredOutput() {
+		return printSummary(DeleteSummary{Subject: subject, Permanent: true, Subjects: 1, Versions: len(deletedVersions)})
+	}
 	output.Success("Permanently deleted subject %s with %d versions", subject, len(deletedVersions))
 	return nil
 }
 
+// splitKeepLatest sorts versions ascending and splits them into the versions to
+// delete and the newest keepN to keep. It does not rely on the registry's
+// ordering, since deleting from an unsorted list could remove the newest ones.
+func splitKeepLatest(versions []int, keepN int) (toDelete, toKeep []int) {
+	sorted := slices.Clone(versions)
+	slices.Sort(sorted)
+	if len(sorted) <= keepN {
+		return nil, sorted
+	}
+	return sorted[:len(sorted)-keepN], sorted[len(sorted)-keepN:]
+}
+
 func keepLatestVersions(c *client.SchemaRegistryClient, subject string, keepN int) error {
 	output.Header("Keep Latest %d Versions: %s", keepN, subject)
 
@@ -302,9 +391,7 @@ func keepLatestVersions(c *client.SchemaRegistryClient, subject string, keepN in
 		return nil
 	}
 
-	// Sort versions and identify ones to delete
-	toDelete := versions[:len(versions)-keepN]
-	toKeep := versions[len(versions)-keepN:]
+	toDelete, toKeep := splitKeepLatest(versions, keepN)
 
 	permanentDelete := deletePermanent || deleteForce
 	deleteType := "soft"
@@ -316,31 +403,30 @@ func keepLatestVersions(c *client.SchemaRegistryClient, subject string, keepN in
 	output.Info("Will %s delete: %v", deleteType, toDelete)
 	output.Info("Will keep: %v", toKeep)
 
+	if deleteDryRun {
+		return printDeletePlan([]plannedDelete{{Subject: subject, Versions: toDelete, Permanent: permanentDelete}})
+	}
+
 	if !deleteYes && !confirmAction(fmt.Sprintf("%s delete %d versions?", strings.ToUpper(deleteType[:1])+deleteType[1:], len(toDelete))) {
 		output.Info("Cancelled")
 		return nil
 	}
 
-	bar := progressbar.NewOptions(len(toDelete),
-		progressbar.OptionSetDescription("Deleting old versions"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(toDelete), "Deleting old versions")
 
-	var deleted, failed int
+	var deleted, failed, skipped int
 	for _, v := range toDelete {
 		// Check referential integrity before irreversible delete
 		if refs, refErr := checkReferentialIntegrity(c, subject, v); refErr == nil && len(refs) > 0 {
-			output.Error("Cannot delete version %d: referenced by schema IDs: %v (use --skip-ref-check to bypass)", v, refs)
-			failed++
+			output.Warning("Skipping version %d: referenced by schema IDs: %v (use --skip-ref-check to bypass)", v, refs)
+			skipped++
 			bar.Add(1)
 			continue
 		}
 
 		// Soft delete first
 		_, err := c.DeleteVersion(subject, strconv.Itoa(v), false)
-		if err != nil && !strings.Contains(err.Error(), "already deleted") {
+		if err != nil && !client.IsSoftDeleted(err) {
 			failed++
 			bar.Add(1)
 			continue
@@ -362,10 +448,20 @@ func keepLatestVersions(c *client.SchemaRegistryClient, subject string, keepN in
 
 	bar.Finish()
 
+	if structuredOutput() {
+		return printSummary(DeleteSummary{
+			Subject:    subject,
+			Permanent:  permanentDelete,
+			Versions:   deleted,
+			Kept:       len(toKeep),
+			Referenced: skipped,
+			Failed:     failed,
+		})
+	}
 	if permanentDelete {
-		output.Success("Permanently deleted %d versions (failed: %d)", deleted, failed)
+		output.Success("Permanently deleted %d versions (failed: %d, skipped as referenced: %d)", deleted, failed, skipped)
 	} else {
-		output.Success("Soft deleted %d versions (failed: %d)", deleted, failed)
+		output.Success("Soft deleted %d versions (failed: %d, skipped as referenced: %d)", deleted, failed, skipped)
 	}
 	output.Info("Remaining versions: %v", toKeep)
 
@@ -432,17 +528,16 @@ func purgeSoftDeletedSubject(c interface {
 
 	output.Info("Found %d soft-deleted versions: %v", len(softDeleted), softDeleted)
 
+	if deleteDryRun {
+		return printDeletePlan([]plannedDelete{{Subject: subject, Versions: softDeleted, Permanent: true}})
+	}
+
 	if !deleteYes && !confirmAction("Permanently delete these versions?") {
 		output.Info("Cancelled")
 		return nil
 	}
 
-	bar := progressbar.NewOptions(len(softDeleted),
-		progressbar.OptionSetDescription("Purging soft-deleted"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(softDeleted), "Purging soft-deleted")
 
 	var purged, failed int
 	for _, v := range softDeleted {
@@ -457,6 +552,9 @@ func purgeSoftDeletedSubject(c interface {
 
 	bar.Finish()
 
+	if structuredOutput() {
+		return printSummary(DeleteSummary{Subject: subject, Permanent: true, Versions: purged, Failed: failed})
+	}
 	output.Success("Purged %d soft-deleted versions (failed: %d)", purged, failed)
 	return nil
 }
@@ -484,6 +582,13 @@ func purgeSoftDeletedAll(c interface {
 		activeSubjects = []string{}
 	}
 
+	if allSubjects, err = excludeDeleteSubjects(allSubjects); err != nil {
+		return err
+	}
+	if activeSubjects, err = excludeSubjects(activeSubjects, deleteExclude, deleteExcludePattern, deleteRegex); err != nil {
+		return err
+	}
+
 	activeSet := make(map[string]bool)
 	for _, s := range activeSubjects {
 		activeSet[s] = true
@@ -535,6 +640,18 @@ func purgeSoftDeletedAll(c interface {
 	output.Info("Found %d soft-deleted subjects", len(softDeletedSubjects))
 	output.Info("Found %d soft-deleted versions in active subjects", len(versionsToPurge))
 
+	if deleteDryRun {
+		plan := planSubjectDeletes(c, softDeletedSubjects, true)
+		for _, vp := range versionsToPurge {
+			if n := len(plan); n > 0 && plan[n-1].Subject == vp.subject {
+				plan[n-1].Versions = append(plan[n-1].Versions, vp.version)
+			} else {
+				plan = append(plan, plannedDelete{Subject: vp.subject, Versions: []int{vp.version}, Permanent: true})
+			}
+		}
+		return printDeletePlan(plan)
+	}
+
 	if !deleteYes && !confirmAction(fmt.Sprintf("Permanently purge %d items?", totalToPurge)) {
 		output.Info("Cancelled")
 		return nil
@@ -545,12 +662,7 @@ func purgeSoftDeletedAll(c interface {
 	var purgedSubjects, purgedVersions, failedSubjects, failedVersions int
 
 	if len(softDeletedSubjects) > 0 {
-		bar := progressbar.NewOptions(len(softDeletedSubjects),
-			progressbar.OptionSetDescription("Purging subjects"),
-			progressbar.OptionShowCount(),
-			progressbar.OptionSetWidth(40),
-			progressbar.OptionClearOnFinish(),
-		)
+		bar := newProgressBar(len(softDeletedSubjects), "Purging subjects")
 
 		for _, subj := range softDeletedSubjects {
 			_, err := c.DeleteSubject(subj, true)
@@ -567,12 +679,7 @@ func purgeSoftDeletedAll(c interface {
 	// Step 3: Purge soft-deleted versions
 	output.Step("Step 3/3: Purging soft-deleted versions...")
 	if len(versionsToPurge) > 0 {
-		bar := progressbar.NewOptions(len(versionsToPurge),
-			progressbar.OptionSetDescription("Purging versions"),
-			progressbar.OptionShowCount(),
-			progressbar.OptionSetWidth(40),
-			progressbar.OptionClearOnFinish(),
-		)
+		bar := newProgressBar(len(versionsToPurge), "Purging versions")
 
 		for _, vp := range versionsToPurge {
 			_, err := c.DeleteVersion(vp.subject, strconv.Itoa(vp.version), true)
@@ -587,6 +694,14 @@ func purgeSoftDeletedAll(c interface {
 	}
 
 	// Summary
+	if structuredOutput() {
+		return printSummary(DeleteSummary{
+			Permanent: true,
+			Subjects:  purgedSubjects,
+			Versions:  purgedVersions,
+			Failed:    failedSubjects + failedVersions,
+		})
+	}
 	output.Header("Purge Complete")
 	output.PrintTable(
 		[]string{"Type", "Purged", "Failed"},
@@ -599,6 +714,143 @@ func purgeSoftDeletedAll(c interface {
 	return nil
 }
 
+// DeleteSummary is what a delete did, printed with -o json/yaml. Subjects
+// counts whole subjects removed and Versions the versions removed; Subject is
+// set when a single subject was targeted.
+type DeleteSummary struct {
+	Subject    string             `json:"subject,omitempty"`
+	Permanent  bool               `json:"permanent"`
+	Subjects   int                `json:"subjects"`
+	Versions   int                `json:"versions"`
+	Kept       int                `json:"kept,omitempty"`       // versions kept by --keep-latest
+	Referenced int                `json:"referenced,omitempty"` // subjects or versions left alone because they are referenced
+	Failed     int                `json:"failed"`
+	Failures   []operationFailure `json:"failures,omitempty"`
+}
+
+// plannedDelete is one subject's share of a delete, as listed by --dry-run
+type plannedDelete struct {
+	Subject   string `json:"subject"`
+	Versions  []int  `json:"versions"`
+	Permanent bool   `json:"permanent"`
+}
+
+// planSubjectDeletes lists the versions deleting whole subjects would remove:
+// active versions for a soft delete, every version for a permanent one
+func planSubjectDeletes(c interface {
+	GetVersions(string, bool) ([]int, error)
+}, subjects []string, permanent bool) []plannedDelete {
+	plan := make([]plannedDelete, 0, len(subjects))
+	for _, subj := range subjects {
+		versions, err := c.GetVersions(subj, permanent)
+		if err != nil {
+			output.Warning("Could not list versions of %s: %v", subj, err)
+		}
+		plan = append(plan, plannedDelete{Subject: subj, Versions: versions, Permanent: permanent})
+	}
+	return plan
+}
+
+// planKeepLatest lists the versions --keep-latest would delete per subject
+func planKeepLatest(c interface {
+	GetVersions(string, bool) ([]int, error)
+}, subjects []string, keepN int, permanent bool) ([]plannedDelete, error) {
+	var plan []plannedDelete
+	for _, subj := range subjects {
+		versions, err := c.GetVersions(subj, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get versions for %s: %w", subj, err)
+		}
+		if toDelete, _ := splitKeepLatest(versions, keepN); len(toDelete) > 0 {
+			plan = append(plan, plannedDelete{Subject: subj, Versions: toDelete, Permanent: permanent})
+		}
+	}
+	return plan, nil
+}
+
+// printVersionDeletePlan prints the plan for deleting one version, resolving
+// "latest" to its number
+func printVersionDeletePlan(c *client.SchemaRegistryClient, subject, version string, permanent bool) error {
+	v, err := strconv.Atoi(version)
+	if err != nil {
+		schema, err := c.GetSchema(subject, version)
+		if err != nil {
+			return fmt.Errorf("failed to resolve version %s: %w", version, err)
+		}
+		v = schema.Version
+	}
+	return printDeletePlan([]plannedDelete{{Subject: subject, Versions: []int{v}, Permanent: permanent}})
+}
+
+// selectForceDeleteSubjects lists every subject (including soft-deleted) for a
+// context or registry-wide delete, minus --exclude matches and subjects still
+// referenced from outside the set. skipInternal also leaves internal subjects.
+func selectForceDeleteSubjects(c *client.SchemaRegistryClient, skipInternal bool) ([]string, map[string][]int, error) {
+	subjects, err := c.GetSubjects(true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get subjects: %w", err)
+	}
+	output.Info("Found %d subjects", len(subjects))
+
+	if skipInternal {
+		var internal int
+		subjects, internal = withoutInternalSubjects(subjects)
+		if internal > 0 {
+			output.Info("Leaving %d internal subject(s) (see --internal-prefixes)", internal)
+		}
+	}
+
+	subjects, err = excludeDeleteSubjects(subjects)
+	if err != nil {
+		return nil, nil, err
+	}
+	skipped := findReferencedSubjects(c, subjects)
+	reportReferencedSubjects(skipped)
+	return withoutSubjects(subjects, skipped), skipped, nil
+}
+
+// excludeDeleteSubjects applies --exclude and --exclude-pattern
+func excludeDeleteSubjects(subjects []string) ([]string, error) {
+	kept, err := excludeSubjects(subjects, deleteExclude, deleteExcludePattern, deleteRegex)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(subjects) - len(kept); n > 0 {
+		output.Info("Excluding %d subject(s)", n)
+	}
+	return kept, nil
+}
+
+// printDeletePlan prints what a delete would remove, honoring --output
+func printDeletePlan(plan []plannedDelete) error {
+	if outputFormat != "table" {
+		return printSummary(plan)
+	}
+
+	output.Header("Dry Run - Would Delete")
+	var rows [][]string
+	totalVersions := 0
+	for _, p := range plan {
+		deleteType := "soft"
+		if p.Permanent {
+			deleteType = "permanent"
+		}
+		versions := make([]string, len(p.Versions))
+		for i, v := range p.Versions {
+			versions[i] = strconv.Itoa(v)
+		}
+		rows = append(rows, []string{p.Subject, strings.Join(versions, ", "), deleteType})
+		totalVersions += len(p.Versions)
+	}
+	if len(rows) == 0 {
+		output.Info("Nothing to delete")
+		return nil
+	}
+	output.PrintTable([]string{"Subject", "Versions", "Delete Type"}, rows)
+	output.Info("\nTotal: %d subjects, %d versions (dry run, nothing deleted)", len(plan), totalVersions)
+	return nil
+}
+
 func confirmAction(prompt string) bool {
 	fmt.Printf("\n%s [y/N]: ", prompt)
 	reader := bufio.NewReader(os.Stdin)
@@ -648,6 +900,139 @@ func checkSubjectReferentialIntegrity(c *client.SchemaRegistryClient, subject st
 	return refsByVersion, nil
 }
 
+// findReferencedSubjects returns the subjects that are still referenced by
+// schemas outside the set being deleted, mapped to the referencing schema IDs.
+// References between subjects in the set don't block the delete, unless the
+// referencing subject is itself kept. Returns nil with --skip-ref-check.
+func findReferencedSubjects(c client.SchemaRegistryClientInterface, subjects []string) map[string][]int {
+	if deleteSkipRefCheck || len(subjects) == 0 {
+		return nil
+	}
+
+	// For every subject, the IDs referencing any of its versions and the
+	// subjects those IDs are registered under
+	type subjectRefs struct {
+		subject   string
+		referrers map[int][]string
+	}
+
+	jobs := make(chan string, len(subjects))
+	results := make(chan subjectRefs, len(subjects))
+	var wg sync.WaitGroup
+	for i := 0; i < clampWorkers(deleteWorkers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for subj := range jobs {
+				r := subjectRefs{subject: subj, referrers: make(map[int][]string)}
+				versions, err := c.GetVersions(subj, true)
+				if err != nil {
+					results <- r
+					continue
+				}
+				for _, v := range versions {
+					ids, err := c.GetSchemaReferencedBy(subj, v)
+					if err != nil {
+						// Older registries lack the endpoint; treat as unreferenced
+						continue
+					}
+					for _, id := range ids {
+						if _, done := r.referrers[id]; done {
+							continue
+						}
+						svs, err := c.GetSchemaSubjectVersionsByID(id)
+						if err != nil {
+							// An unresolvable referrer counts as outside the set
+							r.referrers[id] = []string{""}
+							continue
+						}
+						for _, sv := range svs {
+							r.referrers[id] = append(r.referrers[id], sv.Subject)
+						}
+					}
+				}
+				results <- r
+			}
+		}()
+	}
+	for _, subj := range subjects {
+		jobs <- subj
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	refs := make(map[string]map[int][]string)
+	for r := range results {
+		refs[r.subject] = r.referrers
+	}
+
+	deleting := make(map[string]bool, len(subjects))
+	for _, subj := range subjects {
+		deleting[subj] = true
+	}
+
+	// Keeping a subject can keep the subjects it references alive, so repeat
+	// until nothing changes
+	skipped := make(map[string][]int)
+	for changed := true; changed; {
+		changed = false
+		for _, subj := range subjects {
+			if !deleting[subj] {
+				continue
+			}
+			var blocking []int
+			for id, referrers := range refs[subj] {
+				for _, referrer := range referrers {
+					if referrer != subj && !deleting[referrer] {
+						blocking = append(blocking, id)
+						break
+					}
+				}
+			}
+			if len(blocking) > 0 {
+				slices.Sort(blocking)
+				skipped[subj] = blocking
+				deleting[subj] = false
+				changed = true
+			}
+		}
+	}
+	return skipped
+}
+
+// withoutSubjects returns subjects minus the skipped ones, keeping the order
+func withoutSubjects(subjects []string, skipped map[string][]int) []string {
+	if len(skipped) == 0 {
+		return subjects
+	}
+	var remaining []string
+	for _, subj := range subjects {
+		if _, ok := skipped[subj]; !ok {
+			remaining = append(remaining, subj)
+		}
+	}
+	return remaining
+}
+
+// reportReferencedSubjects lists subjects left alone because other schemas
+// still reference them
+func reportReferencedSubjects(skipped map[string][]int) {
+	if len(skipped) == 0 {
+		return
+	}
+	output.Warning("Skipping %d subject(s) still referenced by other schemas (use --skip-ref-check to bypass):", len(skipped))
+	subjects := make([]string, 0, len(skipped))
+	for subj := range skipped {
+		subjects = append(subjects, subj)
+	}
+	slices.Sort(subjects)
+	for _, subj := range subjects {
+		output.Warning("  %s: referenced by schema IDs %v", subj, skipped[subj])
+		emitSubjectEvent("delete", SubjectEvent{Subject: subj, Status: "skipped", Error: fmt.Sprintf("referenced by schema IDs %v", skipped[subj])})
+	}
+}
+
 // forceDeleteVersion force deletes a specific version (soft + hard delete)
 func forceDeleteVersion(c *client.SchemaRegistryClient, subject, version string) error {
 	output.Header("Force Delete Version: %s v%s", subject, version)
@@ -662,6 +1047,10 @@ func forceDeleteVersion(c *client.SchemaRegistryClient, subject, version string)
 		return fmt.Errorf("referential integrity violation")
 	}
 
+	if deleteDryRun {
+		return printVersionDeletePlan(c, subject, version, true)
+	}
+
 	if !deleteYes && !confirmAction(fmt.Sprintf("PERMANENTLY delete version %s of %s? This cannot be undone!", version, subject)) {
 		output.Info("Cancelled")
 		return nil
@@ -670,7 +1059,7 @@ func forceDeleteVersion(c *client.SchemaRegistryClient, subject, version string)
 	// Step 1: Soft delete
 	output.Step("Step 1/2: Soft deleting version...")
 	_, err = c.DeleteVersion(subject, version, false)
-	if err != nil && !strings.Contains(err.Error(), "already deleted") {
+	if err != nil && !client.IsSoftDeleted(err) {
 		output.Warning("Soft delete warning: %v", err)
 	}
 
@@ -681,6 +1070,9 @@ func forceDeleteVersion(c *client.SchemaRegistryClient, subject, version string)
 		return fmt.Errorf("failed to permanently delete: %w", err)
 	}
 
+	if structuredOutput() {
+		return printSummary(DeleteSummary{Subject: subject, Permanent: true, Versions: 1})
+	}
 	output.Success("Permanently deleted version %d of %s", deletedVersion, subject)
 	return nil
 }
@@ -690,6 +1082,18 @@ func deleteSubjectsParallel(c *client.SchemaRegistryClient, subjects []string) e
 	output.Header("Bulk Delete Subjects")
 	output.Info("Subjects to delete: %d", len(subjects))
 
+	skipped := findReferencedSubjects(c, subjects)
+	subjects = withoutSubjects(subjects, skipped)
+	reportReferencedSubjects(skipped)
+	if len(subjects) == 0 {
+		output.Warning("Nothing left to delete")
+		return nil
+	}
+
+	if deleteDryRun {
+		return printDeletePlan(planSubjectDeletes(c, subjects, deleteForce || deletePermanent))
+	}
+
 	if !deleteYes && !confirmAction(fmt.Sprintf("Delete %d subjects?", len(subjects))) {
 		output.Info("Cancelled")
 		return nil
@@ -709,12 +1113,7 @@ func deleteSubjectsParallel(c *client.SchemaRegistryClient, subjects []string) e
 	if deleteForce || deletePermanent {
 		totalOps *= 2 // soft + hard delete
 	}
-	bar := progressbar.NewOptions(totalOps,
-		progressbar.OptionSetDescription("Deleting"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(totalOps, "Deleting")
 
 	// Start workers
 	workers := clampWorkers(deleteWorkers)
@@ -726,22 +1125,11 @@ func deleteSubjectsParallel(c *client.SchemaRegistryClient, subjects []string) e
 			for subj := range jobs {
 				result := deleteResult{Subject: subj}
 
-				// Check referential integrity before irreversible delete
-				if refsByVersion, refErr := checkSubjectReferentialIntegrity(c, subj); refErr == nil && len(refsByVersion) > 0 {
-					result.Error = fmt.Errorf("referential integrity violation: subject has versions referenced by other schemas (use --skip-ref-check to bypass)")
-					results <- result
-					bar.Add(1)
-					if deleteForce || deletePermanent {
-						bar.Add(1) // Skip hard delete progress
-					}
-					continue
-				}
-
 				// Soft delete
 				versions, err := c.DeleteSubject(subj, false)
 				bar.Add(1)
 
-				if err != nil && !strings.Contains(err.Error(), "already deleted") {
+				if err != nil && !client.IsSoftDeleted(err) {
 					result.Error = err
 					results <- result
 					if deleteForce || deletePermanent {
@@ -784,19 +1172,33 @@ func deleteSubjectsParallel(c *client.SchemaRegistryClient, subjects []string) e
 	var deleted, failed int
 	var totalVersions int
 	var errors []string
+	var failures []operationFailure
 
 	for r := range results {
 		if r.Error != nil {
 			failed++
 			errors = append(errors, fmt.Sprintf("%s: %v", r.Subject, r.Error))
+			failures = append(failures, operationFailure{Subject: r.Subject, Error: r.Error.Error()})
+			emitSubjectEvent("delete", SubjectEvent{Subject: r.Subject, Status: "failed", Versions: r.Versions, Error: r.Error.Error()})
 		} else {
 			deleted++
 			totalVersions += r.Versions
+			emitSubjectEvent("delete", SubjectEvent{Subject: r.Subject, Status: "deleted", Versions: r.Versions})
 		}
 	}
 	bar.Finish()
 
 	// Summary
+	if structuredOutput() {
+		return printSummary(DeleteSummary{
+			Permanent:  deleteForce || deletePermanent,
+			Subjects:   deleted,
+			Versions:   totalVersions,
+			Referenced: len(skipped),
+			Failed:     failed,
+			Failures:   failures,
+		})
+	}
 	output.Header("Delete Complete")
 	deleteType := "Soft"
 	if deleteForce || deletePermanent {
@@ -808,6 +1210,7 @@ func deleteSubjectsParallel(c *client.SchemaRegistryClient, subjects []string) e
 			{"Delete Type", deleteType},
 			{"Subjects Deleted", strconv.Itoa(deleted)},
 			{"Total Versions", strconv.Itoa(totalVersions)},
+			{"Skipped (referenced)", strconv.Itoa(len(skipped))},
 			{"Failed", strconv.Itoa(failed)},
 		},
 	)
@@ -829,21 +1232,23 @@ func forceDeleteContextParallel(c *client.SchemaRegistryClient, ctx string) erro
 	output.Header("Force Delete Context: %s", ctx)
 	output.Warning("This will PERMANENTLY delete ALL subjects and schemas in context '%s'!", ctx)
 
-	if !deleteYes && !confirmAction("Are you absolutely sure? This cannot be undone!") {
-		output.Info("Cancelled")
-		return nil
-	}
-
 	// Get all subjects in context
 	output.Step("Step 1/4: Fetching all subjects...")
-	subjects, err := c.GetSubjects(true)
+	subjects, skipped, err := selectForceDeleteSubjects(c, false)
 	if err != nil {
-		return fmt.Errorf("failed to get subjects: %w", err)
+		return err
 	}
-	output.Info("Found %d subjects", len(subjects))
-
 	if len(subjects) == 0 {
-		output.Success("Context is already empty")
+		output.Success("No subjects to delete in context '%s'", ctx)
+		return nil
+	}
+
+	if deleteDryRun {
+		return printDeletePlan(planSubjectDeletes(c, subjects, true))
+	}
+
+	if !deleteYes && !confirmAction(fmt.Sprintf("Permanently delete %d subjects in context '%s'? This cannot be undone!", len(subjects), ctx)) {
+		output.Info("Cancelled")
 		return nil
 	}
 
@@ -864,8 +1269,17 @@ func forceDeleteContextParallel(c *client.SchemaRegistryClient, ctx string) erro
 
 	// Summary
 	output.Step("Step 4/4: Cleanup complete")
-	output.Success("Deleted %d subjects with %d total versions from context '%s' (failed: %d)",
-		len(subjects)-failedCount, totalVersions, ctx, failedCount)
+	if structuredOutput() {
+		return printSummary(DeleteSummary{
+			Permanent:  true,
+			Subjects:   len(subjects) - failedCount,
+			Versions:   totalVersions,
+			Referenced: len(skipped),
+			Failed:     failedCount,
+		})
+	}
+	output.Success("Deleted %d subjects with %d total versions from context '%s' (failed: %d, skipped as referenced: %d)",
+		len(subjects)-failedCount, totalVersions, ctx, failedCount, len(skipped))
 
 	return nil
 }
@@ -875,16 +1289,6 @@ func forceDeleteAllParallel(c *client.SchemaRegistryClient) error {
 	output.Header("⚠️  DANGER: Empty Entire Schema Registry")
 	output.Error("This will PERMANENTLY delete ALL schemas across ALL contexts!")
 
-	if !deleteYes {
-		fmt.Print("\nType 'DELETE EVERYTHING' to confirm: ")
-		reader := bufio.NewReader(os.Stdin)
-		confirmation, _ := reader.ReadString('\n')
-		if strings.TrimSpace(confirmation) != "DELETE EVERYTHING" {
-			output.Info("Cancelled - confirmation text did not match")
-			return nil
-		}
-	}
-
 	// Get all contexts
 	output.Step("Step 1/5: Fetching all contexts...")
 	contexts, err := c.GetContexts()
@@ -896,17 +1300,30 @@ func forceDeleteAllParallel(c *client.SchemaRegistryClient) error {
 
 	// Get all subjects
 	output.Step("Step 2/5: Fetching all subjects...")
-	subjects, err := c.GetSubjects(true)
+	subjects, skipped, err := selectForceDeleteSubjects(c, true)
 	if err != nil {
-		return fmt.Errorf("failed to get subjects: %w", err)
+		return err
 	}
-	output.Info("Found %d subjects", len(subjects))
-
 	if len(subjects) == 0 {
-		output.Success("Schema Registry is already empty")
+		output.Success("No subjects to delete")
 		return nil
 	}
 
+	if deleteDryRun {
+		return printDeletePlan(planSubjectDeletes(c, subjects, true))
+	}
+
+	if !deleteYes {
+		fmt.Printf("\n%d subjects will be permanently deleted.", len(subjects))
+		fmt.Print("\nType 'DELETE EVERYTHING' to confirm: ")
+		reader := bufio.NewReader(os.Stdin)
+		confirmation, _ := reader.ReadString('\n')
+		if strings.TrimSpace(confirmation) != "DELETE EVERYTHING" {
+			output.Info("Cancelled - confirmation text did not match")
+			return nil
+		}
+	}
+
 	// Phase 1: Soft delete all
 	output.Step("Step 3/5: Soft deleting all subjects (%d workers)...", clampWorkers(deleteWorkers))
 	softDeleteParallel(c, subjects)
@@ -917,8 +1334,17 @@ func forceDeleteAllParallel(c *client.SchemaRegistryClient) error {
 
 	// Summary
 	output.Step("Step 5/5: Complete")
-	output.Success("Deleted %d subjects with %d total versions (failed: %d)",
-		len(subjects)-failedCount, totalVersions, failedCount)
+	if structuredOutput() {
+		return printSummary(DeleteSummary{
+			Permanent:  true,
+			Subjects:   len(subjects) - failedCount,
+			Versions:   totalVersions,
+			Referenced: len(skipped),
+			Failed:     failedCount,
+		})
+	}
+	output.Success("Deleted %d subjects with %d total versions (failed: %d, skipped as referenced: %d)",
+		len(subjects)-failedCount, totalVersions, failedCount, len(skipped))
 
 	return nil
 }
@@ -928,12 +1354,7 @@ func softDeleteParallel(c *client.SchemaRegistryClient, subjects []string) {
 	var wg sync.WaitGroup
 	jobs := make(chan string, len(subjects))
 
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Soft deleting"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjects), "Soft deleting")
 
 	workers := clampWorkers(deleteWorkers)
 	for i := 0; i < workers; i++ {
@@ -963,12 +1384,7 @@ func hardDeleteParallel(c *client.SchemaRegistryClient, subjects []string) (tota
 	var versions int64
 	var failed int64
 
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Hard deleting"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjects), "Hard deleting")
 
 	workers := clampWorkers(deleteWorkers)
 	for i := 0; i < workers; i++ {
@@ -976,19 +1392,14 @@ func hardDeleteParallel(c *client.SchemaRegistryClient, subjects []string) (tota
 		go func() {
 			defer wg.Done()
 			for subj := range jobs {
-				// Check referential integrity before irreversible hard delete
-				if refsByVersion, refErr := checkSubjectReferentialIntegrity(c, subj); refErr == nil && len(refsByVersion) > 0 {
-					output.Error("Cannot delete %s: referenced by other schemas (use --skip-ref-check to bypass)", subj)
-					atomic.AddInt64(&failed, 1)
-					bar.Add(1)
-					continue
-				}
-
+				// Referenced subjects were already filtered out by the caller
 				vers, err := c.DeleteSubject(subj, true)
 				if err != nil {
 					atomic.AddInt64(&failed, 1)
+					emitSubjectEvent("delete", SubjectEvent{Subject: subj, Status: "failed", Error: err.Error()})
 				} else {
 					atomic.AddInt64(&versions, int64(len(vers)))
+					emitSubjectEvent("delete", SubjectEvent{Subject: subj, Status: "deleted", Versions: len(vers)})
 				}
 				bar.Add(1)
 			}
@@ -1009,6 +1420,14 @@ func hardDeleteParallel(c *client.SchemaRegistryClient, subjects []string) (tota
 func keepLatestVersionsMulti(c *client.SchemaRegistryClient, subjects []string, keepN int) error {
 	output.Header("Keep Latest %d Versions for %d Subjects", keepN, len(subjects))
 
+	if deleteDryRun {
+		plan, err := planKeepLatest(c, subjects, keepN, deleteForce)
+		if err != nil {
+			return err
+		}
+		return printDeletePlan(plan)
+	}
+
 	if !deleteYes && !confirmAction(fmt.Sprintf("Process %d subjects?", len(subjects))) {
 		output.Info("Cancelled")
 		return nil
@@ -1018,18 +1437,14 @@ func keepLatestVersionsMulti(c *client.SchemaRegistryClient, subjects []string,
 		Subject string
 		Deleted int
 		Kept    int
+		Skipped int // Versions kept because other schemas reference them
 		Error   error
 	}
 
 	jobs := make(chan string, len(subjects))
 	results := make(chan keepResult, len(subjects))
 
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Processing"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjects), "Processing")
 
 	// Start workers
 	workers := clampWorkers(deleteWorkers)
@@ -1056,19 +1471,20 @@ func keepLatestVersionsMulti(c *client.SchemaRegistryClient, subjects []string,
 					continue
 				}
 
-				toDelete := versions[:len(versions)-keepN]
+				toDelete, _ := splitKeepLatest(versions, keepN)
 				result.Kept = keepN
 
 				for _, v := range toDelete {
 					// Check referential integrity before irreversible delete
 					if refs, refErr := checkReferentialIntegrity(c, subj, v); refErr == nil && len(refs) > 0 {
-						output.Error("Cannot delete %s version %d: referenced by schema IDs: %v (use --skip-ref-check to bypass)", subj, v, refs)
+						output.Warning("Skipping %s version %d: referenced by schema IDs: %v (use --skip-ref-check to bypass)", subj, v, refs)
+						result.Skipped++
 						continue
 					}
 
 					// Soft delete
 					_, err := c.DeleteVersion(subj, strconv.Itoa(v), false)
-					if err != nil && !strings.Contains(err.Error(), "already deleted") {
+					if err != nil && !client.IsSoftDeleted(err) {
 						continue
 					}
 
@@ -1103,18 +1519,37 @@ func keepLatestVersionsMulti(c *client.SchemaRegistryClient, subjects []string,
 	}()
 
 	// Collect results
-	var totalDeleted, totalKept, errCount int
+	var totalDeleted, totalKept, totalSkipped, errCount int
 	for r := range results {
 		if r.Error != nil {
 			errCount++
+			emitSubjectEvent("delete", SubjectEvent{Subject: r.Subject, Status: "failed", Error: r.Error.Error()})
 		} else {
 			totalDeleted += r.Deleted
 			totalKept += r.Kept
+			totalSkipped += r.Skipped
+			ev := SubjectEvent{Subject: r.Subject, Status: "deleted", Versions: r.Deleted, Details: []string{fmt.Sprintf("kept %d", r.Kept)}}
+			if r.Deleted == 0 {
+				ev.Status = "unchanged"
+			}
+			if r.Skipped > 0 {
+				ev.Details = append(ev.Details, fmt.Sprintf("%d referenced version(s) kept", r.Skipped))
+			}
+			emitSubjectEvent("delete", ev)
 		}
 	}
 	bar.Finish()
 
 	// Summary
+	if structuredOutput() {
+		return printSummary(DeleteSummary{
+			Permanent:  deleteForce,
+			Versions:   totalDeleted,
+			Kept:       totalKept,
+			Referenced: totalSkipped,
+			Failed:     errCount,
+		})
+	}
 	deleteType := "Soft"
 	if deleteForce {
 		deleteType = "Permanent"
@@ -1127,6 +1562,7 @@ func keepLatestVersionsMulti(c *client.SchemaRegistryClient, subjects []string,
 			{"Subjects Processed", strconv.Itoa(len(subjects))},
 			{"Versions Deleted", strconv.Itoa(totalDeleted)},
 			{"Versions Kept", strconv.Itoa(totalKept)},
+			{"Skipped (referenced)", strconv.Itoa(totalSkipped)},
 			{"Errors", strconv.Itoa(errCount)},
 		},
 	)
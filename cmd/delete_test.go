@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/srctl/srctl/internal/client"
@@ -145,6 +146,26 @@ func TestKeepLatestLogic(t *testing.T) {
 	}
 }
 
+func TestSplitKeepLatestUnsorted(t *testing.T) {
+	versions := []int{4, 1, 5, 3, 2}
+
+	toDelete, toKeep := splitKeepLatest(versions, 2)
+	if !slices.Equal(toDelete, []int{1, 2, 3}) {
+		t.Errorf("expected to delete [1 2 3], got %v", toDelete)
+	}
+	if !slices.Equal(toKeep, []int{4, 5}) {
+		t.Errorf("expected to keep [4 5], got %v", toKeep)
+	}
+	if !slices.Equal(versions, []int{4, 1, 5, 3, 2}) {
+		t.Errorf("input should not be modified, got %v", versions)
+	}
+
+	toDelete, toKeep = splitKeepLatest([]int{3, 1}, 5)
+	if len(toDelete) != 0 || !slices.Equal(toKeep, []int{1, 3}) {
+		t.Errorf("expected nothing to delete, got delete=%v keep=%v", toDelete, toKeep)
+	}
+}
+
 func TestDeletePermanent(t *testing.T) {
 	mock := client.NewMockClient()
 	mock.AddSubject("test-subject", []client.Schema{
@@ -219,3 +240,99 @@ func TestConfirmActionFunction(t *testing.T) {
 	// Test that confirmAction function exists
 	_ = confirmAction
 }
+
+// softDeleteFake reports versions 1-2 of every subject as soft-deleted and
+// counts delete calls
+type softDeleteFake struct {
+	deletes int
+}
+
+func (f *softDeleteFake) GetSubjects(includeDeleted bool) ([]string, error) {
+	if includeDeleted {
+		return []string{"orders", "gone"}, nil
+	}
+	return []string{"orders"}, nil
+}
+
+func (f *softDeleteFake) GetVersions(subject string, includeDeleted bool) ([]int, error) {
+	if includeDeleted {
+		return []int{1, 2, 3}, nil
+	}
+	return []int{3}, nil
+}
+
+func (f *softDeleteFake) DeleteSubject(string, bool) ([]int, error) {
+	f.deletes++
+	return nil, nil
+}
+
+func (f *softDeleteFake) DeleteVersion(string, string, bool) (int, error) {
+	f.deletes++
+	return 0, nil
+}
+
+func TestDeleteDryRun(t *testing.T) {
+	deleteDryRun = true
+	defer func() { deleteDryRun = false }()
+
+	fake := &softDeleteFake{}
+	if err := purgeSoftDeleted(fake, []string{"orders"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := purgeSoftDeleted(fake, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.deletes != 0 {
+		t.Errorf("expected no deletes in dry run, got %d", fake.deletes)
+	}
+
+	plan := planSubjectDeletes(fake, []string{"orders"}, false)
+	if len(plan) != 1 || !slices.Equal(plan[0].Versions, []int{3}) || plan[0].Permanent {
+		t.Errorf("expected soft delete of active version 3, got %+v", plan)
+	}
+	plan = planSubjectDeletes(fake, []string{"orders"}, true)
+	if !slices.Equal(plan[0].Versions, []int{1, 2, 3}) {
+		t.Errorf("expected permanent delete of all versions, got %+v", plan)
+	}
+
+	mock := client.NewMockClient()
+	addTestSubject(mock, "five", 5)
+	addTestSubject(mock, "one", 1)
+	plan, err := planKeepLatest(mock, []string{"five", "one"}, 2, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan) != 1 || plan[0].Subject != "five" || !slices.Equal(plan[0].Versions, []int{1, 2, 3}) {
+		t.Errorf("unexpected keep-latest plan: %+v", plan)
+	}
+}
+
+func TestFindReferencedSubjects(t *testing.T) {
+	mock := client.NewMockClient()
+	ref := func(subject string) []client.SchemaReference {
+		return []client.SchemaReference{{Name: subject, Subject: subject, Version: 1}}
+	}
+	mock.AddSubject("address", []client.Schema{{Subject: "address", Version: 1, ID: 1}})
+	mock.AddSubject("customer", []client.Schema{{Subject: "customer", Version: 1, ID: 2, References: ref("address")}})
+	mock.AddSubject("order", []client.Schema{{Subject: "order", Version: 1, ID: 3, References: ref("customer")}})
+
+	// Everything goes: references inside the set don't block
+	if skipped := findReferencedSubjects(mock, []string{"address", "customer", "order"}); len(skipped) != 0 {
+		t.Errorf("expected nothing skipped, got %v", skipped)
+	}
+
+	// order stays, so customer is still referenced, and so is address
+	skipped := findReferencedSubjects(mock, []string{"address", "customer"})
+	if !slices.Equal(skipped["customer"], []int{3}) || !slices.Equal(skipped["address"], []int{2}) {
+		t.Errorf("expected customer and address skipped, got %v", skipped)
+	}
+	if remaining := withoutSubjects([]string{"address", "customer", "order"}, skipped); !slices.Equal(remaining, []string{"order"}) {
+		t.Errorf("expected only order to remain, got %v", remaining)
+	}
+
+	deleteSkipRefCheck = true
+	defer func() { deleteSkipRefCheck = false }()
+	if skipped := findReferencedSubjects(mock, []string{"address"}); skipped != nil {
+		t.Errorf("expected no check with --skip-ref-check, got %v", skipped)
+	}
+}
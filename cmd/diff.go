@@ -3,6 +3,8 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,7 +16,7 @@ import (
 )
 
 var diffCmd = &cobra.Command{
-	Use:     "diff <subject1>[@version1] [subject2[@version2]]",
+	Use:     "diff <subject1>[@version1] [subject2[@version2]] | <subject> <version1> <version2>",
 	Short:   "Compare schemas between versions, subjects, or registries",
 	GroupID: groupSchema,
 	Long: `Compare schemas and show differences.
@@ -23,6 +25,11 @@ Comparison modes:
   • Compare two versions of the same subject
   • Compare schemas from different subjects
   • Compare schemas across different registries
+  • Compare two local schema files
+
+Shows fields (nested Avro fields, JSON Schema properties, Protobuf message
+fields) that were added, removed or changed type, from the first schema to
+the second.
 
 Examples:
   # Compare latest with previous version
@@ -30,6 +37,13 @@ Examples:
 
   # Compare specific versions
   srctl diff user-events@3 user-events@5
+  srctl diff user-events 3 5
+
+  # Compare two local files
+  srctl diff --file order-v1.avsc --file order-v2.avsc
+
+  # Machine-readable field changes
+  srctl diff user-events 3 5 -o json
 
   # Compare different subjects
   srctl diff user-events order-events
@@ -47,6 +61,7 @@ var (
 	diffSchemaID2    int
 	diffWithRegistry string
 	diffShowFull     bool
+	diffFiles        []string
 )
 
 func init() {
@@ -54,11 +69,32 @@ func init() {
 	diffCmd.Flags().IntVar(&diffSchemaID2, "with-id", 0, "Second schema ID to compare")
 	diffCmd.Flags().StringVar(&diffWithRegistry, "with-registry", "", "Compare with schema from another registry")
 	diffCmd.Flags().BoolVar(&diffShowFull, "full", false, "Show full schema content in diff")
+	diffCmd.Flags().StringArrayVarP(&diffFiles, "file", "f", nil, "Local schema file to compare (give twice: old, then new)")
 
 	rootCmd.AddCommand(diffCmd)
 }
 
 func runDiff(cmd *cobra.Command, args []string) error {
+	// Compare two local files
+	if len(diffFiles) > 0 {
+		if len(diffFiles) != 2 || len(args) > 0 {
+			return fmt.Errorf("--file must be given exactly twice (old and new schema) and without subjects")
+		}
+		var schemas [2]*client.Schema
+		for i, path := range diffFiles {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			schemas[i] = &client.Schema{Schema: string(content), SchemaType: detectSchemaType(string(content), path)}
+		}
+		return showDiff(schemas[0], schemas[1], diffFiles[0], "", diffFiles[1], "")
+	}
+
+	if len(args) > 3 {
+		return fmt.Errorf("too many arguments")
+	}
+
 	c, err := GetClient()
 	if err != nil {
 		return err
@@ -77,10 +113,15 @@ func runDiff(cmd *cobra.Command, args []string) error {
 	subject1, version1 := parseSubjectVersion(args[0])
 
 	var subject2, version2 string
-	if len(args) > 1 {
+	switch {
+	case len(args) == 3:
+		// <subject> <version1> <version2>
+		subject2 = subject1
+		version1, version2 = args[1], args[2]
+	case len(args) == 2:
 		subject2, version2 = parseSubjectVersion(args[1])
-	} else {
-		// Compare with previous version
+	default:
+		// Compare the previous version (left) with the given one (right)
 		subject2 = subject1
 		if version1 == "latest" {
 			// Get actual versions
@@ -91,11 +132,11 @@ func runDiff(cmd *cobra.Command, args []string) error {
 			if len(versions) < 2 {
 				return fmt.Errorf("subject has only one version, nothing to compare")
 			}
-			version1 = strconv.Itoa(versions[len(versions)-1])
-			version2 = strconv.Itoa(versions[len(versions)-2])
+			version1 = strconv.Itoa(versions[len(versions)-2])
+			version2 = strconv.Itoa(versions[len(versions)-1])
 		} else {
 			v, _ := strconv.Atoi(version1)
-			version2 = strconv.Itoa(v - 1)
+			version1, version2 = strconv.Itoa(v-1), version1
 		}
 	}
 
@@ -144,9 +185,26 @@ func compareSchemasByID(c *client.SchemaRegistryClient, id1, id2 int) error {
 	return showDiff(schema1, schema2, fmt.Sprintf("ID:%d", id1), "", fmt.Sprintf("ID:%d", id2), "")
 }
 
-func showDiff(schema1, schema2 *client.Schema, name1, ver1, name2, ver2 string) error {
-	output.Header("Schema Diff")
+// FieldChange is a field that differs between two schemas
+type FieldChange struct {
+	Field   string `json:"field"`
+	OldType string `json:"oldType,omitempty"`
+	NewType string `json:"newType,omitempty"`
+}
 
+// SchemaDiff is the field-level difference from the left schema to the right
+type SchemaDiff struct {
+	Left       string        `json:"left"`
+	Right      string        `json:"right"`
+	SchemaType string        `json:"schemaType"`
+	Identical  bool          `json:"identical"`
+	Added      []FieldChange `json:"added"`
+	Removed    []FieldChange `json:"removed"`
+	Modified   []FieldChange `json:"modified"`
+	Unchanged  int           `json:"unchanged"`
+}
+
+func showDiff(schema1, schema2 *client.Schema, name1, ver1, name2, ver2 string) error {
 	// Header info
 	leftLabel := name1
 	if ver1 != "" {
@@ -157,18 +215,6 @@ func showDiff(schema1, schema2 *client.Schema, name1, ver1, name2, ver2 string)
 		rightLabel = fmt.Sprintf("%s@%s", name2, ver2)
 	}
 
-	fmt.Printf("  %s  ←→  %s\n", output.Cyan(leftLabel), output.Cyan(rightLabel))
-	fmt.Println()
-
-	// Parse schemas for structured diff
-	var parsed1, parsed2 interface{}
-	if err := json.Unmarshal([]byte(schema1.Schema), &parsed1); err != nil {
-		output.Warning("Failed to parse first schema as JSON: %v", err)
-	}
-	if err := json.Unmarshal([]byte(schema2.Schema), &parsed2); err != nil {
-		output.Warning("Failed to parse second schema as JSON: %v", err)
-	}
-
 	// Compare basic properties
 	type1 := schema1.SchemaType
 	if type1 == "" {
@@ -179,107 +225,155 @@ func showDiff(schema1, schema2 *client.Schema, name1, ver1, name2, ver2 string)
 		type2 = "AVRO"
 	}
 
+	fields1 := extractSchemaFields(schema1.Schema, type1)
+	fields2 := extractSchemaFields(schema2.Schema, type2)
+	diff := computeSchemaDiff(fields1, fields2)
+	diff.Left, diff.Right, diff.SchemaType = leftLabel, rightLabel, type2
+	diff.Identical = schema1.Schema == schema2.Schema
+
+	printer := output.NewPrinter(outputFormat)
+	if outputFormat != "table" {
+		return printer.Print(diff)
+	}
+
+	output.Header("Schema Diff")
+	fmt.Printf("  %s  ←→  %s\n", output.Cyan(leftLabel), output.Cyan(rightLabel))
+	fmt.Println()
+
 	if type1 != type2 {
 		output.Warning("Schema types differ: %s vs %s", type1, type2)
 	}
 
-	// For Avro schemas, do structured diff
-	if type1 == "AVRO" && type2 == "AVRO" {
-		return diffAvroSchemas(parsed1, parsed2)
+	// Without fields to compare (e.g. a primitive schema), fall back to a line diff
+	if len(fields1) == 0 && len(fields2) == 0 {
+		return diffText(schema1.Schema, schema2.Schema)
 	}
 
-	// For other types, do line-by-line diff
-	return diffText(schema1.Schema, schema2.Schema)
+	printSchemaDiff(diff)
+	return nil
 }
 
-func diffAvroSchemas(schema1, schema2 interface{}) error {
-	// Extract fields from Avro schemas
-	fields1 := extractAvroFields(schema1)
-	fields2 := extractAvroFields(schema2)
+// extractSchemaFields returns field path -> type for any schema type: nested
+// Avro record fields, JSON Schema properties, or Protobuf message fields
+// (as Message.field, with the field number in the type)
+func extractSchemaFields(content, schemaType string) map[string]string {
+	fields := make(map[string]string)
 
-	// Find additions, deletions, modifications
-	allFields := make(map[string]bool)
-	for f := range fields1 {
-		allFields[f] = true
-	}
-	for f := range fields2 {
-		allFields[f] = true
+	switch strings.ToUpper(schemaType) {
+	case "AVRO":
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+			return fields
+		}
+		for path, f := range extractAvroFieldsDeep(parsed, "") {
+			fields[path] = f.Type
+		}
+	case "JSON":
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+			return fields
+		}
+		return extractJSONSchemaProperties(parsed, "")
+	case "PROTOBUF":
+		fieldRe := regexp.MustCompile(`(?m)^\s*(repeated\s+|optional\s+|required\s+)?(map\s*<[^>]+>|[\w.]+)\s+(\w+)\s*=\s*(\d+)`)
+		for _, msg := range parseProtobufMessages(content) {
+			if msg.Kind != "message" {
+				continue
+			}
+			for _, f := range fieldRe.FindAllStringSubmatch(msg.Body, -1) {
+				if f[2] == "option" || f[2] == "reserved" {
+					continue
+				}
+				fields[msg.Name+"."+f[3]] = fmt.Sprintf("%s%s = %s", f[1], f[2], f[4])
+			}
+		}
 	}
 
-	var added, removed, modified, unchanged []string
-
-	for field := range allFields {
-		f1, exists1 := fields1[field]
-		f2, exists2 := fields2[field]
+	return fields
+}
 
-		if !exists1 {
-			added = append(added, field)
-		} else if !exists2 {
-			removed = append(removed, field)
-		} else if f1 != f2 {
-			modified = append(modified, field)
-		} else {
-			unchanged = append(unchanged, field)
+// computeSchemaDiff compares two field maps from old (fields1) to new (fields2)
+func computeSchemaDiff(fields1, fields2 map[string]string) SchemaDiff {
+	diff := SchemaDiff{
+		Added:    []FieldChange{},
+		Removed:  []FieldChange{},
+		Modified: []FieldChange{},
+	}
+
+	for field, t1 := range fields1 {
+		t2, exists := fields2[field]
+		switch {
+		case !exists:
+			diff.Removed = append(diff.Removed, FieldChange{Field: field, OldType: t1})
+		case t1 != t2:
+			diff.Modified = append(diff.Modified, FieldChange{Field: field, OldType: t1, NewType: t2})
+		default:
+			diff.Unchanged++
+		}
+	}
+	for field, t2 := range fields2 {
+		if _, exists := fields1[field]; !exists {
+			diff.Added = append(diff.Added, FieldChange{Field: field, NewType: t2})
 		}
 	}
 
-	sort.Strings(added)
-	sort.Strings(removed)
-	sort.Strings(modified)
-	sort.Strings(unchanged)
+	for _, changes := range [][]FieldChange{diff.Added, diff.Removed, diff.Modified} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	}
+	return diff
+}
 
+func printSchemaDiff(diff SchemaDiff) {
 	green := color.New(color.FgGreen).SprintFunc()
 	red := color.New(color.FgRed).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 
 	// Summary
 	output.SubHeader("Summary")
-	fmt.Printf("  Added:     %d field(s)\n", len(added))
-	fmt.Printf("  Removed:   %d field(s)\n", len(removed))
-	fmt.Printf("  Modified:  %d field(s)\n", len(modified))
-	fmt.Printf("  Unchanged: %d field(s)\n", len(unchanged))
+	fmt.Printf("  Added:     %d field(s)\n", len(diff.Added))
+	fmt.Printf("  Removed:   %d field(s)\n", len(diff.Removed))
+	fmt.Printf("  Modified:  %d field(s)\n", len(diff.Modified))
+	fmt.Printf("  Unchanged: %d field(s)\n", diff.Unchanged)
 	fmt.Println()
 
 	// Details
-	if len(added) > 0 {
+	if len(diff.Added) > 0 {
 		output.SubHeader("Added Fields")
-		for _, f := range added {
-			fmt.Printf("  %s %s: %s\n", green("+"), f, fields2[f])
+		for _, f := range diff.Added {
+			fmt.Printf("  %s %s: %s\n", green("+"), f.Field, f.NewType)
 		}
 		fmt.Println()
 	}
 
-	if len(removed) > 0 {
+	if len(diff.Removed) > 0 {
 		output.SubHeader("Removed Fields")
-		for _, f := range removed {
-			fmt.Printf("  %s %s: %s\n", red("-"), f, fields1[f])
+		for _, f := range diff.Removed {
+			fmt.Printf("  %s %s: %s\n", red("-"), f.Field, f.OldType)
 		}
 		fmt.Println()
 	}
 
-	if len(modified) > 0 {
+	if len(diff.Modified) > 0 {
 		output.SubHeader("Modified Fields")
-		for _, f := range modified {
-			fmt.Printf("  %s %s:\n", yellow("~"), f)
-			fmt.Printf("    %s %s\n", red("-"), fields1[f])
-			fmt.Printf("    %s %s\n", green("+"), fields2[f])
+		for _, f := range diff.Modified {
+			fmt.Printf("  %s %s:\n", yellow("~"), f.Field)
+			fmt.Printf("    %s %s\n", red("-"), f.OldType)
+			fmt.Printf("    %s %s\n", green("+"), f.NewType)
 		}
 		fmt.Println()
 	}
 
 	// Compatibility analysis
 	output.SubHeader("Compatibility Analysis")
-	if len(removed) > 0 {
+	if len(diff.Removed) > 0 {
 		output.Warning("Removing fields may break backward compatibility")
 	}
-	if len(added) > 0 {
+	if len(diff.Added) > 0 {
 		output.Info("Added fields - check if they have defaults for backward compatibility")
 	}
-	if len(removed) == 0 && len(modified) == 0 {
+	if len(diff.Removed) == 0 && len(diff.Modified) == 0 {
 		output.Success("Changes appear to be backward compatible")
 	}
-
-	return nil
 }
 
 func extractAvroFields(schema interface{}) map[string]string {
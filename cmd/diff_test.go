@@ -197,3 +197,90 @@ func TestCompareSchemasByID(t *testing.T) {
 		t.Error("expected schema content to match")
 	}
 }
+
+func TestExtractSchemaFieldsAndDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		old        string
+		new        string
+		added      []string
+		removed    []string
+		modified   []string
+	}{
+		{
+			name:       "avro nested",
+			schemaType: "AVRO",
+			old:        `{"type":"record","name":"User","fields":[{"name":"id","type":"int"},{"name":"email","type":"string"},{"name":"address","type":{"type":"record","name":"Address","fields":[{"name":"city","type":"string"}]}}]}`,
+			new:        `{"type":"record","name":"User","fields":[{"name":"id","type":"long"},{"name":"name","type":"string"},{"name":"address","type":{"type":"record","name":"Address","fields":[{"name":"city","type":"string"},{"name":"zip","type":"string"}]}}]}`,
+			added:      []string{"address.zip", "name"},
+			removed:    []string{"email"},
+			modified:   []string{"id"},
+		},
+		{
+			name:       "json schema",
+			schemaType: "JSON",
+			old:        `{"type":"object","properties":{"id":{"type":"integer"},"tags":{"type":"array","items":{"type":"string"}}}}`,
+			new:        `{"type":"object","properties":{"id":{"type":"string"},"note":{"type":"string"}}}`,
+			added:      []string{"note"},
+			removed:    []string{"tags"},
+			modified:   []string{"id"},
+		},
+		{
+			name:       "protobuf",
+			schemaType: "PROTOBUF",
+			old:        "syntax = \"proto3\";\nmessage Order {\n  string id = 1;\n  int32 qty = 2;\n}\n",
+			new:        "syntax = \"proto3\";\nmessage Order {\n  string id = 1;\n  int64 qty = 2;\n  map<string, string> labels = 3;\n}\n",
+			added:      []string{"Order.labels"},
+			modified:   []string{"Order.qty"},
+		},
+	}
+
+	names := func(changes []FieldChange) []string {
+		var out []string
+		for _, c := range changes {
+			out = append(out, c.Field)
+		}
+		return out
+	}
+	equal := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := computeSchemaDiff(extractSchemaFields(tt.old, tt.schemaType), extractSchemaFields(tt.new, tt.schemaType))
+			if got := names(diff.Added); !equal(got, tt.added) {
+				t.Errorf("added: expected %v, got %v", tt.added, got)
+			}
+			if got := names(diff.Removed); !equal(got, tt.removed) {
+				t.Errorf("removed: expected %v, got %v", tt.removed, got)
+			}
+			if got := names(diff.Modified); !equal(got, tt.modified) {
+				t.Errorf("modified: expected %v, got %v", tt.modified, got)
+			}
+		})
+	}
+}
+
+func TestComputeSchemaDiffTypes(t *testing.T) {
+	diff := computeSchemaDiff(map[string]string{"id": "int", "name": "string"}, map[string]string{"id": "long", "name": "string"})
+
+	if diff.Unchanged != 1 {
+		t.Errorf("expected 1 unchanged field, got %d", diff.Unchanged)
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0].OldType != "int" || diff.Modified[0].NewType != "long" {
+		t.Errorf("expected id int -> long, got %+v", diff.Modified)
+	}
+	if diff.Added == nil || diff.Removed == nil {
+		t.Error("expected empty (non-nil) added/removed lists for JSON output")
+	}
+}
@@ -296,8 +296,13 @@ func explainProtobuf(content string, exp *SchemaExplanation) {
 		exp.Namespace = m[1]
 	}
 
-	// Extract messages
-	messages := parseProtobufMessages(content)
+	// Extract messages (enums are skipped; the first message is the main one)
+	var messages []protoMessage
+	for _, m := range parseProtobufMessages(content) {
+		if m.Kind == "message" {
+			messages = append(messages, m)
+		}
+	}
 	if len(messages) > 0 {
 		exp.Name = messages[0].Name
 	}
@@ -14,7 +14,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/srctl/srctl/internal/client"
 	"github.com/srctl/srctl/internal/output"
@@ -28,6 +27,7 @@ var (
 	exportFilter         string
 	exportIncludeDeleted bool
 	exportWorkers        int
+	exportSubjects       []string
 )
 
 // schemaExport represents a schema to be exported
@@ -38,6 +38,21 @@ type schemaExport struct {
 	SchemaType string
 	Schema     string
 	References []client.SchemaReference
+	Metadata   *client.SchemaMetadata
+	RuleSet    *client.SchemaRuleSet
+}
+
+// schemaFileMetadata is the v<version>.metadata.json sidecar written next to
+// each exported schema file and read back by import
+type schemaFileMetadata struct {
+	Subject    string                   `json:"subject"`
+	Version    int                      `json:"version"`
+	SchemaID   int                      `json:"schemaId,omitempty"`
+	SchemaType string                   `json:"schemaType"`
+	References []client.SchemaReference `json:"references,omitempty"`
+	Metadata   *client.SchemaMetadata   `json:"metadata,omitempty"`
+	RuleSet    *client.SchemaRuleSet    `json:"ruleSet,omitempty"`
+	ExportedAt string                   `json:"exportedAt,omitempty"`
 }
 
 var exportCmd = &cobra.Command{
@@ -46,12 +61,16 @@ var exportCmd = &cobra.Command{
 	GroupID: groupBulk,
 	Long: `Export schemas from Schema Registry to local files or archive.
 
-Exports schemas organized in directory structure:
+Exports schemas in the directory layout that 'srctl import' reads, so an
+export can be imported as-is into another registry:
   <output>/
     <context>/
       <subject>/
         v<version>.<ext>
-        metadata.json
+        v<version>.metadata.json
+
+The metadata sidecar records the subject, version, schema ID, schema type,
+references and data contract metadata/rules of each version.
 
 Archive formats:
   • tar.gz (default)
@@ -67,9 +86,12 @@ Examples:
   # Export to zip archive
   srctl export --output schemas.zip --archive zip
 
-  # Export specific subjects
+  # Export subjects matching a pattern
   srctl export --filter "user-*" --output ./schemas
 
+  # Export specific subjects
+  srctl export --subjects user-events,order-events --output ./schemas
+
   # Export with all referenced schemas
   srctl export --with-refs --output ./schemas
 
@@ -90,6 +112,7 @@ func init() {
 	exportCmd.Flags().BoolVar(&exportWithRefs, "with-refs", false, "Include all referenced schemas")
 	exportCmd.Flags().StringVar(&exportVersions, "versions", "all", "Versions to export: all, latest, or comma-separated list")
 	exportCmd.Flags().StringVarP(&exportFilter, "filter", "f", "", "Filter subjects by pattern")
+	exportCmd.Flags().StringSliceVar(&exportSubjects, "subjects", nil, "Specific subjects to export (comma-separated)")
 	exportCmd.Flags().BoolVar(&exportIncludeDeleted, "deleted", false, "Include soft-deleted subjects")
 	exportCmd.Flags().IntVar(&exportWorkers, "workers", 20, "Number of parallel workers for fetching schemas")
 
@@ -101,14 +124,20 @@ func runExport(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defer reportThrottling(c)
 
 	output.Header("Exporting Schemas")
 
 	// Get subjects
-	output.Step("Fetching subjects...")
-	subjects, err := c.GetSubjects(exportIncludeDeleted)
-	if err != nil {
-		return fmt.Errorf("failed to get subjects: %w", err)
+	var subjects []string
+	if len(exportSubjects) > 0 {
+		subjects = exportSubjects
+	} else {
+		output.Step("Fetching subjects...")
+		subjects, err = c.GetSubjects(exportIncludeDeleted)
+		if err != nil {
+			return fmt.Errorf("failed to get subjects: %w", err)
+		}
 	}
 
 	// Apply filter
@@ -178,6 +207,8 @@ func runExport(cmd *cobra.Command, args []string) error {
 					SchemaType: schemaType,
 					Schema:     schema.Schema,
 					References: schema.References,
+					Metadata:   schema.Metadata,
+					RuleSet:    schema.RuleSet,
 				})
 			}
 		}
@@ -207,12 +238,7 @@ func collectExportSchemasParallel(c *client.SchemaRegistryClient, subjects []str
 	results := make(chan []schemaExport, len(subjects))
 
 	// Progress bar
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Fetching"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjects), "Fetching")
 
 	// Start workers
 	var wg sync.WaitGroup
@@ -286,6 +312,8 @@ func fetchSubjectSchemas(c *client.SchemaRegistryClient, subject string, version
 			SchemaType: schemaType,
 			Schema:     schema.Schema,
 			References: schema.References,
+			Metadata:   schema.Metadata,
+			RuleSet:    schema.RuleSet,
 		})
 	}
 
@@ -322,11 +350,7 @@ func filterVersions(versions []int, filter string) []int {
 func exportToDirectory(schemas []schemaExport, outputPath string) error {
 	output.Step("Writing to directory: %s", outputPath)
 
-	bar := progressbar.NewOptions(len(schemas),
-		progressbar.OptionSetDescription("Exporting"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(schemas), "Exporting")
 
 	ctx := srContext
 	if ctx == "" {
@@ -360,35 +384,15 @@ func exportToDirectory(schemas []schemaExport, outputPath string) error {
 
 		// Write schema file
 		schemaPath := filepath.Join(dir, fmt.Sprintf("v%d.%s", s.Version, ext))
-		schemaContent := s.Schema
-		if s.SchemaType == "AVRO" {
-			// Pretty print JSON
-			var parsed interface{}
-			if json.Unmarshal([]byte(s.Schema), &parsed) == nil {
-				if pretty, err := json.MarshalIndent(parsed, "", "  "); err == nil {
-					schemaContent = string(pretty)
-				}
-			}
-		}
-		if err := os.WriteFile(schemaPath, []byte(schemaContent), 0600); err != nil {
+		if err := os.WriteFile(schemaPath, []byte(exportSchemaContent(s)), 0600); err != nil {
 			return fmt.Errorf("failed to write schema: %w", err)
 		}
 
 		// Write metadata
-		metadata := map[string]interface{}{
-			"subject":    s.Subject,
-			"version":    s.Version,
-			"schemaId":   s.SchemaID,
-			"schemaType": s.SchemaType,
-			"exportedAt": time.Now().UTC().Format(time.RFC3339),
-		}
-		if len(s.References) > 0 {
-			metadata["references"] = s.References
-		}
-
 		metadataPath := filepath.Join(dir, fmt.Sprintf("v%d.metadata.json", s.Version))
-		metadataBytes, _ := json.MarshalIndent(metadata, "", "  ")
-		os.WriteFile(metadataPath, metadataBytes, 0600)
+		if err := os.WriteFile(metadataPath, exportMetadataJSON(s), 0600); err != nil {
+			return fmt.Errorf("failed to write metadata: %w", err)
+		}
 
 		bar.Add(1)
 	}
@@ -417,11 +421,7 @@ func exportToTar(schemas []schemaExport, outputPath string) error {
 	tarWriter := tar.NewWriter(gzWriter)
 	defer tarWriter.Close()
 
-	bar := progressbar.NewOptions(len(schemas),
-		progressbar.OptionSetDescription("Archiving"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(schemas), "Archiving")
 
 	ctx := srContext
 	if ctx == "" {
@@ -434,34 +434,13 @@ func exportToTar(schemas []schemaExport, outputPath string) error {
 
 		// Schema file
 		schemaPath := filepath.Join(ctx, safeSubject, fmt.Sprintf("v%d.%s", s.Version, ext))
-		schemaContent := s.Schema
-		if s.SchemaType == "AVRO" {
-			var parsed interface{}
-			if json.Unmarshal([]byte(s.Schema), &parsed) == nil {
-				if pretty, err := json.MarshalIndent(parsed, "", "  "); err == nil {
-					schemaContent = string(pretty)
-				}
-			}
-		}
-
-		if err := addToTar(tarWriter, schemaPath, []byte(schemaContent)); err != nil {
+		if err := addToTar(tarWriter, schemaPath, []byte(exportSchemaContent(s))); err != nil {
 			return err
 		}
 
 		// Metadata
-		metadata := map[string]interface{}{
-			"subject":    s.Subject,
-			"version":    s.Version,
-			"schemaId":   s.SchemaID,
-			"schemaType": s.SchemaType,
-			"exportedAt": time.Now().UTC().Format(time.RFC3339),
-		}
-		if len(s.References) > 0 {
-			metadata["references"] = s.References
-		}
-		metadataBytes, _ := json.MarshalIndent(metadata, "", "  ")
 		metadataPath := filepath.Join(ctx, safeSubject, fmt.Sprintf("v%d.metadata.json", s.Version))
-		if err := addToTar(tarWriter, metadataPath, metadataBytes); err != nil {
+		if err := addToTar(tarWriter, metadataPath, exportMetadataJSON(s)); err != nil {
 			return err
 		}
 
@@ -505,11 +484,7 @@ func exportToZip(schemas []schemaExport, outputPath string) error {
 	zipWriter := zip.NewWriter(file)
 	defer zipWriter.Close()
 
-	bar := progressbar.NewOptions(len(schemas),
-		progressbar.OptionSetDescription("Archiving"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(schemas), "Archiving")
 
 	ctx := srContext
 	if ctx == "" {
@@ -522,43 +497,21 @@ func exportToZip(schemas []schemaExport, outputPath string) error {
 
 		// Schema file
 		schemaPath := filepath.Join(ctx, safeSubject, fmt.Sprintf("v%d.%s", s.Version, ext))
-		schemaContent := s.Schema
-		if s.SchemaType == "AVRO" {
-			var parsed interface{}
-			if json.Unmarshal([]byte(s.Schema), &parsed) == nil {
-				if pretty, err := json.MarshalIndent(parsed, "", "  "); err == nil {
-					schemaContent = string(pretty)
-				}
-			}
-		}
-
 		w, err := zipWriter.Create(schemaPath)
 		if err != nil {
 			return err
 		}
-		if _, err := w.Write([]byte(schemaContent)); err != nil {
+		if _, err := w.Write([]byte(exportSchemaContent(s))); err != nil {
 			return err
 		}
 
 		// Metadata
-		metadata := map[string]interface{}{
-			"subject":    s.Subject,
-			"version":    s.Version,
-			"schemaId":   s.SchemaID,
-			"schemaType": s.SchemaType,
-			"exportedAt": time.Now().UTC().Format(time.RFC3339),
-		}
-		if len(s.References) > 0 {
-			metadata["references"] = s.References
-		}
-		metadataBytes, _ := json.MarshalIndent(metadata, "", "  ")
 		metadataPath := filepath.Join(ctx, safeSubject, fmt.Sprintf("v%d.metadata.json", s.Version))
-
 		w, err = zipWriter.Create(metadataPath)
 		if err != nil {
 			return err
 		}
-		if _, err := w.Write(metadataBytes); err != nil {
+		if _, err := w.Write(exportMetadataJSON(s)); err != nil {
 			return err
 		}
 
@@ -570,6 +523,35 @@ func exportToZip(schemas []schemaExport, outputPath string) error {
 	return nil
 }
 
+// exportSchemaContent returns the schema text to write; Avro is pretty-printed
+func exportSchemaContent(s schemaExport) string {
+	if s.SchemaType == "AVRO" {
+		var parsed interface{}
+		if json.Unmarshal([]byte(s.Schema), &parsed) == nil {
+			if pretty, err := json.MarshalIndent(parsed, "", "  "); err == nil {
+				return string(pretty)
+			}
+		}
+	}
+	return s.Schema
+}
+
+// exportMetadataJSON renders the metadata sidecar for an exported schema
+func exportMetadataJSON(s schemaExport) []byte {
+	metadata := schemaFileMetadata{
+		Subject:    s.Subject,
+		Version:    s.Version,
+		SchemaID:   s.SchemaID,
+		SchemaType: s.SchemaType,
+		References: s.References,
+		Metadata:   s.Metadata,
+		RuleSet:    s.RuleSet,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	metadataBytes, _ := json.MarshalIndent(metadata, "", "  ")
+	return metadataBytes
+}
+
 func getSchemaExtension(schemaType string) string {
 	switch strings.ToUpper(schemaType) {
 	case "PROTOBUF":
@@ -228,3 +228,61 @@ func TestExportVersionModes(t *testing.T) {
 		}
 	}
 }
+
+func TestExportImportRoundTrip(t *testing.T) {
+	dir, cleanup := createTempDir()
+	defer cleanup()
+
+	schemas := []schemaExport{
+		{Subject: "common-types", Version: 1, SchemaID: 100, SchemaType: "AVRO", Schema: `{"type":"record","name":"Common","fields":[]}`},
+		{
+			Subject:    "orders/value",
+			Version:    3,
+			SchemaID:   101,
+			SchemaType: "PROTOBUF",
+			Schema:     `syntax = "proto3"; message Order {}`,
+			References: []client.SchemaReference{
+				{Name: "common.avsc", Subject: "common-types", Version: 1},
+			},
+			Metadata: &client.SchemaMetadata{Properties: map[string]string{"owner": "team-a"}},
+		},
+	}
+
+	if err := exportToDirectory(schemas, dir); err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+
+	imported, err := readFromDirectory(dir)
+	if err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	if len(imported) != 2 {
+		t.Fatalf("expected 2 schemas, got %d", len(imported))
+	}
+
+	bySubject := make(map[string]schemaToImport)
+	for _, s := range imported {
+		bySubject[s.Subject] = s
+	}
+
+	orders, ok := bySubject["orders/value"]
+	if !ok {
+		t.Fatalf("expected subject 'orders/value' to round-trip, got %v", bySubject)
+	}
+	if orders.Version != 3 || orders.SchemaType != "PROTOBUF" {
+		t.Errorf("expected PROTOBUF v3, got %s v%d", orders.SchemaType, orders.Version)
+	}
+	if orders.Schema != schemas[1].Schema {
+		t.Errorf("expected schema content to round-trip, got %q", orders.Schema)
+	}
+	if len(orders.References) != 1 || orders.References[0].Subject != "common-types" {
+		t.Errorf("expected reference to round-trip, got %v", orders.References)
+	}
+	if orders.Metadata == nil || orders.Metadata.Properties["owner"] != "team-a" {
+		t.Errorf("expected metadata to round-trip, got %v", orders.Metadata)
+	}
+
+	if common, ok := bySubject["common-types"]; !ok || common.SchemaType != "AVRO" {
+		t.Errorf("expected AVRO subject 'common-types' to round-trip, got %v", common)
+	}
+}
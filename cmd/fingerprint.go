@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var (
+	fingerprintFile string
+	fingerprintType string
+)
+
+var fingerprintCmd = &cobra.Command{
+	Use:     "fingerprint [subject] [version]",
+	Short:   "Compute a schema's fingerprint",
+	GroupID: groupSchema,
+	Long: `Compute fingerprints that identify a schema independent of its registry ID.
+
+Avro schemas get the CRC-64-AVRO (Rabin) fingerprint and a SHA-256, both over
+the Parsing Canonical Form from the Avro specification: doc, aliases, defaults
+and other attributes that don't affect parsing are dropped, names are fully
+qualified and keys are in a fixed order. The CRC-64 is printed in hex; Avro
+libraries return the same value as a 64-bit integer.
+
+JSON and Protobuf schemas get a SHA-256 over the canonical form printed by
+the normalize command.
+
+Schemas with the same fingerprint are the same schema, so fingerprints can
+match schemas across registries where their IDs differ.
+
+Examples:
+  # Fingerprint a local file
+  srctl fingerprint --file ./schemas/user.avsc
+
+  # Fingerprint the latest version of a subject
+  srctl fingerprint user-events
+
+  # Fingerprint a specific version
+  srctl fingerprint user-events 3
+
+  # Machine-readable output
+  srctl fingerprint user-events -o json`,
+	Args:        cobra.MaximumNArgs(2),
+	Annotations: readOnlyAnnotations,
+	RunE:        runFingerprint,
+}
+
+func init() {
+	fingerprintCmd.Flags().StringVarP(&fingerprintFile, "file", "f", "", "Fingerprint a local schema file instead of a registered schema")
+	fingerprintCmd.Flags().StringVarP(&fingerprintType, "type", "t", "", "Schema type: AVRO, PROTOBUF, JSON (auto-detected if not set)")
+
+	rootCmd.AddCommand(fingerprintCmd)
+}
+
+// SchemaFingerprint is the fingerprint command's output
+type SchemaFingerprint struct {
+	Subject    string `json:"subject,omitempty"`
+	Version    int    `json:"version,omitempty"`
+	File       string `json:"file,omitempty"`
+	SchemaType string `json:"schemaType"`
+	CRC64Avro  string `json:"crc64Avro,omitempty"`
+	SHA256     string `json:"sha256"`
+}
+
+func runFingerprint(cmd *cobra.Command, args []string) error {
+	if (len(args) == 0) == (fingerprintFile == "") {
+		return fmt.Errorf("provide either a subject or --file")
+	}
+
+	var fp SchemaFingerprint
+	var content string
+	if fingerprintFile != "" {
+		data, err := os.ReadFile(fingerprintFile)
+		if err != nil {
+			return fmt.Errorf("failed to read schema file: %w", err)
+		}
+		content = string(data)
+		fp.File = fingerprintFile
+	} else {
+		version := "latest"
+		if len(args) > 1 {
+			version = args[1]
+		}
+		c, err := GetClient()
+		if err != nil {
+			return err
+		}
+		schema, err := c.GetSchema(args[0], version)
+		if err != nil {
+			return fmt.Errorf("failed to get schema: %w", err)
+		}
+		content = schema.Schema
+		fp.Subject, fp.Version, fp.SchemaType = args[0], schema.Version, schema.SchemaType
+		if fp.SchemaType == "" {
+			fp.SchemaType = "AVRO"
+		}
+	}
+
+	if fingerprintType != "" {
+		fp.SchemaType = strings.ToUpper(fingerprintType)
+	}
+	if fp.SchemaType == "" {
+		fp.SchemaType = detectSchemaType(content, fingerprintFile)
+	}
+
+	if err := fingerprintSchema(&fp, content); err != nil {
+		return err
+	}
+
+	if outputFormat != "table" {
+		return output.NewPrinter(outputFormat).Print(fp)
+	}
+
+	rows := [][]string{}
+	if fp.CRC64Avro != "" {
+		rows = append(rows, []string{"CRC-64-AVRO", fp.CRC64Avro})
+	}
+	rows = append(rows, []string{"SHA-256", fp.SHA256})
+	output.PrintTable([]string{"Algorithm", "Fingerprint"}, rows)
+	return nil
+}
+
+// fingerprintSchema fills in the fingerprints of content, a schema of type
+// fp.SchemaType
+func fingerprintSchema(fp *SchemaFingerprint, content string) error {
+	var canonical string
+	var err error
+	if fp.SchemaType == "AVRO" {
+		if canonical, err = canonicalizeAvro(content); err != nil {
+			return fmt.Errorf("failed to compute canonical form: %w", err)
+		}
+		fp.CRC64Avro = fmt.Sprintf("%016x", avroFingerprint64([]byte(canonical)))
+	} else if canonical, err = canonicalizeSchema(content, fp.SchemaType); err != nil {
+		return fmt.Errorf("failed to compute canonical form: %w", err)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	fp.SHA256 = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// avroFingerprintEmpty is the CRC-64-AVRO fingerprint of no data
+const avroFingerprintEmpty uint64 = 0xc15d213aa4d7a795
+
+var avroFingerprintTable = func() [256]uint64 {
+	var table [256]uint64
+	for i := range table {
+		fp := uint64(i)
+		for j := 0; j < 8; j++ {
+			fp = (fp >> 1) ^ (avroFingerprintEmpty & -(fp & 1))
+		}
+		table[i] = fp
+	}
+	return table
+}()
+
+// avroFingerprint64 returns the CRC-64-AVRO (Rabin) fingerprint of data
+func avroFingerprint64(data []byte) uint64 {
+	fp := avroFingerprintEmpty
+	for _, b := range data {
+		fp = (fp >> 8) ^ avroFingerprintTable[byte(fp)^b]
+	}
+	return fp
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import "testing"
+
+func TestAvroFingerprint64(t *testing.T) {
+	// Vectors from the Avro specification's schema tests
+	tests := []struct {
+		schema string
+		want   int64
+	}{
+		{`"null"`, 7195948357588979594},
+		{`{"type":"int"}`, 8247732601305521295},
+		{`"string"`, -8142146995180207161},
+		{`["int","boolean"]`, 5392556393470105090},
+		{`{"fields":[], "type":"record", "name":"foo"}`, -4824392279771201922},
+	}
+	for _, tt := range tests {
+		canonical, err := canonicalizeAvro(tt.schema)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.schema, err)
+		}
+		if got := int64(avroFingerprint64([]byte(canonical))); got != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.schema, tt.want, got)
+		}
+	}
+}
+
+func TestFingerprintSchema(t *testing.T) {
+	// Documentation and formatting don't change the fingerprint
+	a := SchemaFingerprint{SchemaType: "AVRO"}
+	b := SchemaFingerprint{SchemaType: "AVRO"}
+	if err := fingerprintSchema(&a, `{"type":"record","name":"User","fields":[{"name":"id","type":"long"}]}`); err != nil {
+		t.Fatal(err)
+	}
+	if err := fingerprintSchema(&b, `{"doc":"A user","name":"User","type":"record","fields":[{"type":"long","name":"id","doc":"ID"}]}`); err != nil {
+		t.Fatal(err)
+	}
+	if a.CRC64Avro == "" || a.CRC64Avro != b.CRC64Avro || a.SHA256 != b.SHA256 {
+		t.Errorf("expected equal fingerprints, got %+v and %+v", a, b)
+	}
+
+	// JSON Schema gets only a SHA-256
+	j := SchemaFingerprint{SchemaType: "JSON"}
+	if err := fingerprintSchema(&j, `{"type":"object"}`); err != nil {
+		t.Fatal(err)
+	}
+	if j.CRC64Avro != "" || len(j.SHA256) != 64 {
+		t.Errorf("unexpected JSON fingerprint %+v", j)
+	}
+}
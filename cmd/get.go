@@ -20,7 +20,7 @@ var (
 )
 
 var getCmd = &cobra.Command{
-	Use:     "get <subject>",
+	Use:     "get <subject> [version]",
 	Short:   "Get schema from registry",
 	GroupID: groupSchema,
 	Long: `Get a schema from the Schema Registry by subject name or by schema ID.
@@ -30,10 +30,11 @@ Examples:
   srctl get user-events
 
   # Get specific version
+  srctl get user-events 3
   srctl get user-events --version 3
 
   # Get schema by ID
-  srctl get --id 12345
+  srctl get --by-id 12345
 
   # Get schema with all referenced schemas
   srctl get user-events --with-refs
@@ -45,20 +46,22 @@ Examples:
   srctl get user-events -o json --pretty`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if getSchemaID > 0 {
-			return nil // Subject not required when using --id
+			return cobra.NoArgs(cmd, args) // Subject not used with --by-id
 		}
-		if len(args) != 1 {
-			return fmt.Errorf("requires subject name argument (or use --id flag)")
+		if len(args) < 1 || len(args) > 2 {
+			return fmt.Errorf("requires subject name argument and optional version (or use --by-id flag)")
 		}
 		return nil
 	},
-	RunE: runGet,
+	Annotations: readOnlyAnnotations,
+	RunE:        runGet,
 }
 
 func init() {
 	getCmd.Flags().StringVarP(&getVersion, "version", "v", "latest", "Schema version (number or 'latest')")
 	getCmd.Flags().BoolVar(&getWithRefs, "with-refs", false, "Include all referenced schemas")
-	getCmd.Flags().IntVar(&getSchemaID, "id", 0, "Get schema by global ID instead of subject")
+	getCmd.Flags().IntVar(&getSchemaID, "by-id", 0, "Get schema by global ID instead of subject")
+	getCmd.Flags().IntVar(&getSchemaID, "id", 0, "Alias for --by-id")
 	getCmd.Flags().BoolVar(&getPrettySchema, "pretty", false, "Pretty print the schema content")
 
 	rootCmd.AddCommand(getCmd)
@@ -75,6 +78,9 @@ func runGet(cmd *cobra.Command, args []string) error {
 	// Get by ID
 	if getSchemaID > 0 {
 		schema, err := srClient.GetSchemaByID(getSchemaID)
+		if client.IsSchemaNotFound(err) {
+			return fmt.Errorf("schema ID %d not found: %w", getSchemaID, err)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to get schema: %w", err)
 		}
@@ -85,6 +91,11 @@ func runGet(cmd *cobra.Command, args []string) error {
 			output.Warning("Could not retrieve subject versions: %v", err)
 		}
 
+		if outputFormat == "table" {
+			printSchemaByIDTable(schema, subjectVersions)
+			return nil
+		}
+
 		result := map[string]interface{}{
 			"id":         schema.ID,
 			"schemaType": schema.SchemaType,
@@ -102,8 +113,17 @@ func runGet(cmd *cobra.Command, args []string) error {
 
 	// Get by subject
 	subject := args[0]
-	schema, err := srClient.GetSchema(subject, getVersion)
+	version, err := resolveGetVersion(args, cmd.Flags().Changed("version"), getVersion)
 	if err != nil {
+		return err
+	}
+	schema, err := srClient.GetSchema(subject, version)
+	switch {
+	case client.IsSubjectNotFound(err):
+		return fmt.Errorf("subject %s not found: %w", subject, err)
+	case client.IsVersionNotFound(err):
+		return fmt.Errorf("version %s of subject %s not found: %w", version, subject, err)
+	case err != nil:
 		return fmt.Errorf("failed to get schema: %w", err)
 	}
 
@@ -138,6 +158,22 @@ func runGet(cmd *cobra.Command, args []string) error {
 	return printer.Print(result)
 }
 
+// resolveGetVersion picks the version from the positional argument or --version
+func resolveGetVersion(args []string, flagSet bool, flagVersion string) (string, error) {
+	if len(args) < 2 {
+		return flagVersion, nil
+	}
+	if flagSet && args[1] != flagVersion {
+		return "", fmt.Errorf("version given both as argument (%s) and --version (%s)", args[1], flagVersion)
+	}
+	if args[1] != "latest" {
+		if _, err := strconv.Atoi(args[1]); err != nil {
+			return "", fmt.Errorf("invalid version %q: must be a number or 'latest'", args[1])
+		}
+	}
+	return args[1], nil
+}
+
 func collectReferences(c *client.SchemaRegistryClient, refs []client.SchemaReference, visited map[string]bool) ([]map[string]interface{}, error) {
 	var result []map[string]interface{}
 
@@ -217,6 +253,45 @@ func printSchemaTable(schema *client.Schema, refSchemas []map[string]interface{}
 	}
 }
 
+func printSchemaByIDTable(schema *client.Schema, subjectVersions []client.SubjectVersion) {
+	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("Schema Details"))
+	fmt.Println(strings.Repeat("─", 60))
+
+	schemaType := schema.SchemaType
+	if schemaType == "" {
+		schemaType = "AVRO"
+	}
+
+	output.PrintTable(
+		[]string{"Property", "Value"},
+		[][]string{
+			{"Schema ID", strconv.Itoa(schema.ID)},
+			{"Type", schemaType},
+		},
+	)
+
+	if len(subjectVersions) > 0 {
+		output.SubHeader("Used By")
+		var rows [][]string
+		for _, sv := range subjectVersions {
+			rows = append(rows, []string{sv.Subject, strconv.Itoa(sv.Version)})
+		}
+		output.PrintTable([]string{"Subject", "Version"}, rows)
+	}
+
+	if len(schema.References) > 0 {
+		output.SubHeader("References")
+		var refRows [][]string
+		for _, ref := range schema.References {
+			refRows = append(refRows, []string{ref.Name, ref.Subject, strconv.Itoa(ref.Version)})
+		}
+		output.PrintTable([]string{"Name", "Subject", "Version"}, refRows)
+	}
+
+	output.SubHeader("Schema")
+	fmt.Println(formatSchema(schema.Schema, true))
+}
+
 func formatSchema(schema string, pretty bool) interface{} {
 	if !pretty {
 		return schema
@@ -232,56 +307,3 @@ func formatSchema(schema string, pretty bool) interface{} {
 	}
 	return schema
 }
-
-// ContextsCmd for getting contexts
-var contextsCmd = &cobra.Command{
-	Use:   "contexts",
-	Short: "List all contexts in the Schema Registry",
-	Long: `List all contexts (tenants) available in the Schema Registry.
-
-Contexts allow logical separation of schemas within a single Schema Registry cluster.
-
-Examples:
-  # List all contexts
-  srctl contexts
-
-  # Output as JSON
-  srctl contexts -o json`,
-	RunE: runContexts,
-}
-
-func init() {
-	rootCmd.AddCommand(contextsCmd)
-}
-
-func runContexts(cmd *cobra.Command, args []string) error {
-	srClient, err := GetClient()
-	if err != nil {
-		return err
-	}
-
-	contexts, err := srClient.GetContexts()
-	if err != nil {
-		return fmt.Errorf("failed to get contexts: %w", err)
-	}
-
-	printer := output.NewPrinter(outputFormat)
-
-	if outputFormat == "table" {
-		output.Header("Schema Registry Contexts")
-		if len(contexts) == 0 {
-			output.Info("No contexts found (using default context)")
-			return nil
-		}
-
-		rows := make([][]string, len(contexts))
-		for i, ctx := range contexts {
-			rows[i] = []string{strconv.Itoa(i + 1), ctx}
-		}
-		output.PrintTable([]string{"#", "Context"}, rows)
-		fmt.Printf("\nTotal: %d context(s)\n", len(contexts))
-		return nil
-	}
-
-	return printer.Print(contexts)
-}
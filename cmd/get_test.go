@@ -211,3 +211,34 @@ func TestGetSchemaWithReferences(t *testing.T) {
 		t.Errorf("expected reference to 'common-types', got '%s'", retrieved.References[0].Subject)
 	}
 }
+
+func TestResolveGetVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		flagSet bool
+		flag    string
+		want    string
+		wantErr bool
+	}{
+		{name: "default latest", args: []string{"orders"}, flag: "latest", want: "latest"},
+		{name: "version flag", args: []string{"orders"}, flagSet: true, flag: "2", want: "2"},
+		{name: "positional version", args: []string{"orders", "3"}, flag: "latest", want: "3"},
+		{name: "positional latest", args: []string{"orders", "latest"}, flag: "latest", want: "latest"},
+		{name: "matching flag and argument", args: []string{"orders", "3"}, flagSet: true, flag: "3", want: "3"},
+		{name: "conflicting flag and argument", args: []string{"orders", "3"}, flagSet: true, flag: "2", wantErr: true},
+		{name: "invalid version", args: []string{"orders", "three"}, flag: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveGetVersion(tt.args, tt.flagSet, tt.flag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
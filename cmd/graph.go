@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var (
+	graphFormat          string
+	graphWorkers         int
+	graphNoBulk          bool
+	graphReverse         bool
+	graphHighlightCycles bool
+)
+
+var graphCmd = &cobra.Command{
+	Use:     "graph",
+	Short:   "Export the schema reference graph as DOT or Mermaid",
+	GroupID: groupConfig,
+	Long: `Walk every subject, collect the references of each version and print a
+subject-level dependency graph in Graphviz DOT or Mermaid format.
+
+An edge A -> B means a version of A references B. With --reverse the edges
+point from a referenced subject to the subjects that depend on it. Progress
+and cycle warnings go to stderr, so the graph can be redirected to a file.
+
+Examples:
+  # Render with Graphviz
+  srctl graph > refs.dot && dot -Tsvg refs.dot -o refs.svg
+
+  # Mermaid, for Markdown docs
+  srctl graph --format mermaid > refs.mmd
+
+  # Who depends on what, with reference cycles in red
+  srctl graph --reverse --highlight-cycles`,
+	Annotations: readOnlyAnnotations,
+	RunE:        runGraph,
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "Graph format: dot or mermaid")
+	graphCmd.Flags().IntVar(&graphWorkers, "workers", 20, "Number of parallel workers for fetching schemas")
+	graphCmd.Flags().BoolVar(&graphNoBulk, "no-bulk", false, "Fetch each version individually instead of using the bulk /schemas endpoint")
+	graphCmd.Flags().BoolVar(&graphReverse, "reverse", false, "Point edges from referenced subjects to the subjects that reference them")
+	graphCmd.Flags().BoolVar(&graphHighlightCycles, "highlight-cycles", false, "Color subjects and edges that are part of a reference cycle")
+
+	rootCmd.AddCommand(graphCmd)
+}
+
+// refGraph is a subject-level reference graph. Edges[a][b] means a references b.
+type refGraph struct {
+	Nodes []string
+	Edges map[string]map[string]bool
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	graphFormat = strings.ToLower(graphFormat)
+	if graphFormat != "dot" && graphFormat != "mermaid" {
+		return fmt.Errorf("invalid format %q: must be dot or mermaid", graphFormat)
+	}
+
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+	defer reportThrottling(c)
+
+	subjects, err := c.GetSubjects(false)
+	if err != nil {
+		return fmt.Errorf("failed to get subjects: %w", err)
+	}
+
+	var bulk map[string][]client.Schema
+	if !graphNoBulk {
+		bulk, err = fetchSchemasBulk(c, "", statsBulkPageSize)
+		if err != nil {
+			output.Warning("Bulk schema fetch unavailable, falling back to per-version fetches: %v", err)
+			bulk = nil
+		}
+	}
+
+	results := analyzeSubjectsParallel(c, subjects, graphWorkers, bulk)
+	for _, r := range results {
+		for _, e := range r.Errors {
+			output.Warning("%s: %s", r.Subject, e)
+		}
+	}
+
+	g := buildRefGraph(results)
+	if graphReverse {
+		g = g.reversed()
+	}
+
+	cycles := g.cycleNodes()
+	if len(cycles) > 0 {
+		output.Warning("%d subject(s) are part of a reference cycle", len(cycles))
+	}
+	if !graphHighlightCycles {
+		cycles = nil
+	}
+
+	if graphFormat == "mermaid" {
+		fmt.Print(renderMermaid(g, cycles))
+	} else {
+		fmt.Print(renderDOT(g, cycles))
+	}
+	return nil
+}
+
+// buildRefGraph collapses version references into subject edges. Only
+// subjects that reference or are referenced appear as nodes.
+func buildRefGraph(results []subjectResult) *refGraph {
+	g := &refGraph{Edges: make(map[string]map[string]bool)}
+	nodes := make(map[string]bool)
+	for _, r := range results {
+		for _, ref := range r.References {
+			if g.Edges[r.Subject] == nil {
+				g.Edges[r.Subject] = make(map[string]bool)
+			}
+			g.Edges[r.Subject][ref.Subject] = true
+			nodes[r.Subject] = true
+			nodes[ref.Subject] = true
+		}
+	}
+	for n := range nodes {
+		g.Nodes = append(g.Nodes, n)
+	}
+	sort.Strings(g.Nodes)
+	return g
+}
+
+// reversed returns the graph with every edge flipped
+func (g *refGraph) reversed() *refGraph {
+	r := &refGraph{Nodes: g.Nodes, Edges: make(map[string]map[string]bool)}
+	for from, tos := range g.Edges {
+		for to := range tos {
+			if r.Edges[to] == nil {
+				r.Edges[to] = make(map[string]bool)
+			}
+			r.Edges[to][from] = true
+		}
+	}
+	return r
+}
+
+// sortedEdges returns the edges ordered by source, then target
+func (g *refGraph) sortedEdges() [][2]string {
+	var edges [][2]string
+	for _, from := range g.Nodes {
+		var tos []string
+		for to := range g.Edges[from] {
+			tos = append(tos, to)
+		}
+		sort.Strings(tos)
+		for _, to := range tos {
+			edges = append(edges, [2]string{from, to})
+		}
+	}
+	return edges
+}
+
+// cycleNodes returns the nodes on a reference cycle, mapped to an identifier
+// of their strongly connected component (Tarjan's algorithm)
+func (g *refGraph) cycleNodes() map[string]int {
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	next, component := 0, 0
+	result := make(map[string]int)
+
+	var visit func(v string)
+	visit = func(v string) {
+		index[v], lowlink[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for w := range g.Edges[v] {
+			if _, seen := index[w]; !seen {
+				visit(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], index[w])
+			}
+		}
+
+		if lowlink[v] != index[v] {
+			return
+		}
+		var members []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			members = append(members, w)
+			if w == v {
+				break
+			}
+		}
+		// A single node is only a cycle if it references itself
+		if len(members) > 1 || g.Edges[v][v] {
+			for _, m := range members {
+				result[m] = component
+			}
+			component++
+		}
+	}
+
+	for _, n := range g.Nodes {
+		if _, seen := index[n]; !seen {
+			visit(n)
+		}
+	}
+	return result
+}
+
+// inCycle reports whether an edge lies within a reference cycle
+func inCycle(cycles map[string]int, from, to string) bool {
+	a, okA := cycles[from]
+	b, okB := cycles[to]
+	return okA && okB && a == b
+}
+
+func renderDOT(g *refGraph, cycles map[string]int) string {
+	quote := func(s string) string {
+		return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph schemas {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		if _, ok := cycles[n]; ok {
+			fmt.Fprintf(&b, "  %s [color=red];\n", quote(n))
+		} else {
+			fmt.Fprintf(&b, "  %s;\n", quote(n))
+		}
+	}
+	for _, e := range g.sortedEdges() {
+		if inCycle(cycles, e[0], e[1]) {
+			fmt.Fprintf(&b, "  %s -> %s [color=red];\n", quote(e[0]), quote(e[1]))
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s;\n", quote(e[0]), quote(e[1]))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func renderMermaid(g *refGraph, cycles map[string]int) string {
+	ids := make(map[string]string, len(g.Nodes))
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for i, n := range g.Nodes {
+		ids[n] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[n], strings.ReplaceAll(n, `"`, "#quot;"))
+	}
+
+	var cycleLinks []string
+	for i, e := range g.sortedEdges() {
+		fmt.Fprintf(&b, "  %s --> %s\n", ids[e[0]], ids[e[1]])
+		if inCycle(cycles, e[0], e[1]) {
+			cycleLinks = append(cycleLinks, fmt.Sprint(i))
+		}
+	}
+
+	if len(cycles) > 0 {
+		var cycleNodes []string
+		for _, n := range g.Nodes {
+			if _, ok := cycles[n]; ok {
+				cycleNodes = append(cycleNodes, ids[n])
+			}
+		}
+		b.WriteString("  classDef cycle stroke:red,stroke-width:2px\n")
+		fmt.Fprintf(&b, "  class %s cycle\n", strings.Join(cycleNodes, ","))
+		if len(cycleLinks) > 0 {
+			fmt.Fprintf(&b, "  linkStyle %s stroke:red\n", strings.Join(cycleLinks, ","))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/srctl/srctl/internal/client"
+)
+
+func TestRefGraph(t *testing.T) {
+	mock := client.NewMockClient()
+	ref := func(subject string) []client.SchemaReference {
+		return []client.SchemaReference{{Name: subject, Subject: subject, Version: 1}}
+	}
+	mock.AddSubject("address", []client.Schema{{Subject: "address", Version: 1, ID: 1}})
+	mock.AddSubject("customer", []client.Schema{{Subject: "customer", Version: 1, ID: 2, References: ref("address")}})
+	mock.AddSubject("order", []client.Schema{
+		{Subject: "order", Version: 1, ID: 3, References: ref("customer")},
+		{Subject: "order", Version: 2, ID: 4, References: ref("customer")},
+	})
+	mock.AddSubject("a", []client.Schema{{Subject: "a", Version: 1, ID: 5, References: ref("b")}})
+	mock.AddSubject("b", []client.Schema{{Subject: "b", Version: 1, ID: 6, References: ref("a")}})
+	mock.AddSubject("standalone", []client.Schema{{Subject: "standalone", Version: 1, ID: 7}})
+
+	results := analyzeSubjectsParallel(mock, []string{"address", "customer", "order", "a", "b", "standalone"}, 2, nil)
+	g := buildRefGraph(results)
+
+	if strings.Join(g.Nodes, ",") != "a,address,b,customer,order" {
+		t.Errorf("unexpected nodes: %v", g.Nodes)
+	}
+	if len(g.sortedEdges()) != 4 {
+		t.Errorf("expected 4 deduplicated edges, got %v", g.sortedEdges())
+	}
+
+	cycles := g.cycleNodes()
+	if len(cycles) != 2 || !inCycle(cycles, "a", "b") || inCycle(cycles, "order", "customer") {
+		t.Errorf("unexpected cycles: %v", cycles)
+	}
+
+	dot := renderDOT(g, cycles)
+	for _, want := range []string{`"order" -> "customer";`, `"a" -> "b" [color=red];`, `"a" [color=red];`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("expected DOT to contain %q, got:\n%s", want, dot)
+		}
+	}
+
+	rev := renderDOT(g.reversed(), nil)
+	if !strings.Contains(rev, `"customer" -> "order";`) || strings.Contains(rev, "color=red") {
+		t.Errorf("unexpected reversed DOT:\n%s", rev)
+	}
+
+	mermaid := renderMermaid(g, cycles)
+	for _, want := range []string{"graph LR", `n4["order"]`, "n4 --> n3", "class n0,n2 cycle", "linkStyle 0,1 stroke:red"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("expected Mermaid to contain %q, got:\n%s", want, mermaid)
+		}
+	}
+}
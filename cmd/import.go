@@ -13,7 +13,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/srctl/srctl/internal/client"
 	"github.com/srctl/srctl/internal/output"
@@ -24,6 +23,12 @@ var (
 	importSkipExisting  bool
 	importCompatibility string
 	importTargetContext string
+	importNormalize     bool
+	importStrictVersion bool
+	importCheckCompat   bool
+	importLayout        string
+	importPreserveIDs   bool
+	importMapSubjects   []string
 )
 
 var importCmd = &cobra.Command{
@@ -39,6 +44,11 @@ Expected directory structure:
         v<version>.avsc (or .proto, .json)
         v<version>.metadata.json (optional)
 
+A flat directory of standalone schema files works too: each file is
+registered under its file name (orders-value.avsc to orders-value) and the
+registry assigns the version. With --layout auto (the default), files named
+v<version>.<ext> are read as nested and any others as flat.
+
 Archives supported:
   • tar.gz / tgz
   • zip
@@ -61,8 +71,26 @@ Examples:
   # Import into specific context
   srctl import ./schemas --target-context .production
 
+  # Import a subject under a new name (references to it are updated)
+  srctl import ./schemas --map-subject orders-value=orders.v2-value
+
+  # Import a directory of <subject>.avsc files
+  srctl import ./schemas --layout flat
+
+  # Keep schema IDs and version numbers from the metadata files (IMPORT mode)
+  srctl import ./schemas --preserve-ids
+
   # Set compatibility for imported schemas
-  srctl import ./schemas --compatibility BACKWARD`,
+  srctl import ./schemas --compatibility BACKWARD
+
+  # Let the registry normalize schemas so formatting doesn't change IDs
+  srctl import ./schemas --normalize
+
+  # Fail instead of warning when a version is registered under another number
+  srctl import ./schemas --strict-versions
+
+  # Skip schemas that aren't compatible with the subject's latest version
+  srctl import ./schemas --check-compatibility`,
 	Args: cobra.ExactArgs(1),
 	RunE: runImport,
 }
@@ -72,6 +100,12 @@ func init() {
 	importCmd.Flags().BoolVar(&importSkipExisting, "skip-existing", false, "Skip subjects that already exist")
 	importCmd.Flags().StringVar(&importCompatibility, "compatibility", "", "Set compatibility for imported schemas")
 	importCmd.Flags().StringVar(&importTargetContext, "target-context", "", "Import into specific context")
+	importCmd.Flags().StringArrayVar(&importMapSubjects, "map-subject", nil, "Rename a subject as old=new, updating references to it (repeatable)")
+	importCmd.Flags().BoolVar(&importNormalize, "normalize", false, "Ask the registry to normalize schemas on registration")
+	importCmd.Flags().StringVar(&importLayout, "layout", "auto", "Directory layout: nested (<context>/<subject>/v<version>.<ext>), flat (<subject>.<ext>) or auto")
+	importCmd.Flags().BoolVar(&importCheckCompat, "check-compatibility", false, "Check each schema against the subject's latest version and skip incompatible ones instead of registering them")
+	importCmd.Flags().BoolVar(&importPreserveIDs, "preserve-ids", false, "Register with the schema IDs and versions from the metadata files (sets IMPORT mode)")
+	importCmd.Flags().BoolVar(&importStrictVersion, "strict-versions", false, "Fail when a schema would not be registered under the version in its file name or metadata")
 
 	rootCmd.AddCommand(importCmd)
 }
@@ -79,21 +113,33 @@ func init() {
 type schemaToImport struct {
 	Subject    string
 	Version    int
+	SchemaID   int // from metadata; used with --preserve-ids
 	SchemaType string
 	Schema     string
 	References []client.SchemaReference
+	Metadata   *client.SchemaMetadata
+	RuleSet    *client.SchemaRuleSet
 	FilePath   string
 }
 
 func runImport(cmd *cobra.Command, args []string) error {
 	sourcePath := args[0]
 
+	switch importLayout {
+	case "auto", "nested", "flat":
+	default:
+		return fmt.Errorf("invalid --layout %q: use auto, nested or flat", importLayout)
+	}
+	renames, err := parseSubjectMap(importMapSubjects)
+	if err != nil {
+		return err
+	}
+
 	output.Header("Importing Schemas")
 	output.Info("Source: %s", sourcePath)
 
 	// Detect source type and read schemas
 	var schemas []schemaToImport
-	var err error
 
 	stat, err := os.Stat(sourcePath)
 	if err != nil {
@@ -102,10 +148,8 @@ func runImport(cmd *cobra.Command, args []string) error {
 
 	if stat.IsDir() {
 		schemas, err = readFromDirectory(sourcePath)
-	} else if strings.HasSuffix(sourcePath, ".tar.gz") || strings.HasSuffix(sourcePath, ".tgz") {
-		schemas, err = readFromTar(sourcePath)
-	} else if strings.HasSuffix(sourcePath, ".zip") {
-		schemas, err = readFromZip(sourcePath)
+	} else if strings.HasSuffix(sourcePath, ".tar.gz") || strings.HasSuffix(sourcePath, ".tgz") || strings.HasSuffix(sourcePath, ".zip") {
+		schemas, err = readSchemasFromArchive(sourcePath)
 	} else {
 		return fmt.Errorf("unsupported source format: %s", sourcePath)
 	}
@@ -121,12 +165,26 @@ func runImport(cmd *cobra.Command, args []string) error {
 
 	output.Info("Found %d schema versions to import", len(schemas))
 
-	// Rewrite subject names if target context is specified
+	// Rename mapped subjects, then move everything to the target context
+	if len(renames) > 0 {
+		subjects := make([]string, len(schemas))
+		for i, s := range schemas {
+			subjects[i] = s.Subject
+		}
+		renames.warnUnmatched(subjects)
+		renameImportSubjects(schemas, renames.rename)
+	}
 	if importTargetContext != "" {
 		rewriteSubjectContexts(schemas, importTargetContext)
 		output.Info("Target context: %s (rewriting subject names)", importTargetContext)
 	}
 
+	if importPreserveIDs {
+		if err := checkImportIDs(schemas); err != nil {
+			return err
+		}
+	}
+
 	// Sort schemas to handle dependencies (schemas without references first)
 	sortSchemasByDependencies(schemas)
 
@@ -135,16 +193,28 @@ func runImport(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	c.Normalize = importNormalize
+	defer reportThrottling(c)
 
-	// Get existing subjects for skip-existing check
+	// Get existing subjects for skip-existing and version checks
 	var existingSubjects map[string]bool
-	if importSkipExisting {
-		existing, err := c.GetSubjects(false)
-		if err == nil {
-			existingSubjects = make(map[string]bool)
-			for _, s := range existing {
-				existingSubjects[s] = true
-			}
+	existing, err := c.GetSubjects(false)
+	if err == nil {
+		existingSubjects = make(map[string]bool)
+		for _, s := range existing {
+			existingSubjects[s] = true
+		}
+	}
+
+	// New subjects are numbered from 1, so a gap in the import set means a
+	// version would be registered under another number. IMPORT mode keeps
+	// the numbers as they are.
+	if gaps := findImportVersionGaps(schemas, existingSubjects); len(gaps) > 0 && !importPreserveIDs {
+		for _, gap := range gaps {
+			output.Warning("%s", gap)
+		}
+		if importStrictVersion {
+			return fmt.Errorf("%d subject(s) are missing versions; nothing was imported (--strict-versions)", len(gaps))
 		}
 	}
 
@@ -153,10 +223,44 @@ func runImport(cmd *cobra.Command, args []string) error {
 		return dryRunImport(c, schemas, existingSubjects)
 	}
 
+	// Set IMPORT mode if preserving IDs; afterwards the registry goes back to
+	// the mode it was in, or READWRITE
+	if importPreserveIDs {
+		finalMode := "READWRITE"
+		if mode, err := c.GetMode(); err == nil && mode.Mode != "" && mode.Mode != "IMPORT" {
+			finalMode = mode.Mode
+		}
+		output.Step("Setting registry to IMPORT mode...")
+		if err := c.SetMode("IMPORT"); err != nil {
+			return fmt.Errorf("failed to set IMPORT mode (required for --preserve-ids): %w", err)
+		}
+		defer func() {
+			output.Step("Restoring %s mode...", finalMode)
+			if err := c.SetMode(finalMode); err != nil {
+				output.Error("Failed to restore %s mode; registry may be stuck in IMPORT mode: %v", finalMode, err)
+			}
+		}()
+	}
+
 	// Perform import
 	return performImport(c, schemas, existingSubjects)
 }
 
+// checkImportIDs makes sure every schema has the ID and version --preserve-ids
+// registers it with; IDs only come from metadata files
+func checkImportIDs(schemas []schemaToImport) error {
+	var missing []string
+	for _, s := range schemas {
+		if s.SchemaID <= 0 || s.Version <= 0 {
+			missing = append(missing, s.FilePath)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("--preserve-ids needs a schema ID and version from a metadata file for every schema; %d have none (e.g. %s)", len(missing), missing[0])
+}
+
 func readFromDirectory(rootPath string) ([]schemaToImport, error) {
 	var schemas []schemaToImport
 
@@ -204,61 +308,18 @@ func parseSchemaFile(rootPath, filePath string) (schemaToImport, error) {
 	}
 	schema.Schema = string(content)
 
-	// Determine schema type from extension
+	// Determine schema type from extension and content; metadata overrides it
 	ext := filepath.Ext(filePath)
-	switch ext {
-	case ".proto":
-		schema.SchemaType = "PROTOBUF"
-	case ".json":
-		// Check if JSON Schema or Avro
-		if strings.Contains(schema.Schema, `"$schema"`) {
-			schema.SchemaType = "JSON"
-		} else {
-			schema.SchemaType = "AVRO"
-		}
-	default:
-		schema.SchemaType = "AVRO"
-	}
+	schema.SchemaType = importSchemaType(schema.Schema, filePath)
 
 	// Parse path to extract subject and version
 	relPath, _ := filepath.Rel(rootPath, filePath)
-	parts := strings.Split(relPath, string(filepath.Separator))
-
-	// Expected: context/subject/v<version>.ext
-	if len(parts) >= 2 {
-		schema.Subject = parts[len(parts)-2]
-
-		// Parse version from filename
-		filename := parts[len(parts)-1]
-		filename = strings.TrimSuffix(filename, ext)
-		if strings.HasPrefix(filename, "v") {
-			if v, err := strconv.Atoi(filename[1:]); err == nil {
-				schema.Version = v
-			}
-		}
-	}
+	schema.Subject, schema.Version = subjectVersionFromPath(strings.Split(relPath, string(filepath.Separator)), importLayout)
 
 	// Try to read metadata file
 	metadataPath := strings.TrimSuffix(filePath, ext) + ".metadata.json"
 	if metadataContent, err := os.ReadFile(metadataPath); err == nil {
-		var metadata struct {
-			Subject    string                   `json:"subject"`
-			Version    int                      `json:"version"`
-			SchemaType string                   `json:"schemaType"`
-			References []client.SchemaReference `json:"references"`
-		}
-		if json.Unmarshal(metadataContent, &metadata) == nil {
-			if metadata.Subject != "" {
-				schema.Subject = metadata.Subject
-			}
-			if metadata.Version > 0 {
-				schema.Version = metadata.Version
-			}
-			if metadata.SchemaType != "" {
-				schema.SchemaType = metadata.SchemaType
-			}
-			schema.References = metadata.References
-		}
+		applySchemaFileMetadata(&schema, metadataContent)
 	}
 
 	if schema.Subject == "" {
@@ -273,25 +334,62 @@ const (
 	maxArchiveTotalSize = 1024 * 1024 * 1024 // 1 GB total
 )
 
-func readFromTar(tarPath string) ([]schemaToImport, error) {
+// readSchemasFromArchive reads schema and metadata files from a tar.gz or zip archive
+func readSchemasFromArchive(archivePath string) ([]schemaToImport, error) {
 	var schemas []schemaToImport
 
-	file, err := os.Open(tarPath)
+	schemaFiles := make(map[string]string)   // path -> content
+	metadataFiles := make(map[string]string) // path -> content
+
+	err := readArchiveEntries(archivePath, func(name string, content []byte) error {
+		if strings.Contains(name, ".metadata.") {
+			metadataFiles[name] = string(content)
+		} else if hasSchemaExtension(name) {
+			schemaFiles[name] = string(content)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+
+	// Parse schema files
+	for path, content := range schemaFiles {
+		schema, err := parseSchemaFromArchive(path, content, metadataFiles)
+		if err != nil {
+			output.Warning("Skipping %s: %v", path, err)
+			continue
+		}
+		schemas = append(schemas, schema)
+	}
+
+	return schemas, nil
+}
+
+// readArchiveEntries calls fn with the name and content of each file in a
+// tar.gz/tgz or zip archive, rejecting path traversal and oversized entries
+func readArchiveEntries(archivePath string, fn func(name string, content []byte) error) error {
+	if strings.HasSuffix(archivePath, ".zip") {
+		return readZipEntries(archivePath, fn)
+	}
+	return readTarGzEntries(archivePath, fn)
+}
+
+func readTarGzEntries(tarPath string, fn func(name string, content []byte) error) error {
+	file, err := os.Open(tarPath)
+	if err != nil {
+		return err
+	}
 	defer file.Close()
 
 	gzReader, err := gzip.NewReader(file)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer gzReader.Close()
 
 	tarReader := tar.NewReader(gzReader)
 
-	schemaFiles := make(map[string]string)   // path -> content
-	metadataFiles := make(map[string]string) // path -> content
 	var totalSize int64
 
 	for {
@@ -300,7 +398,7 @@ func readFromTar(tarPath string) ([]schemaToImport, error) {
 			break
 		}
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		if header.Typeflag != tar.TypeReg {
@@ -309,57 +407,41 @@ func readFromTar(tarPath string) ([]schemaToImport, error) {
 
 		// Reject path traversal in entry names
 		if strings.Contains(header.Name, "..") {
-			return nil, fmt.Errorf("tar entry contains path traversal: %s", header.Name)
+			return fmt.Errorf("tar entry contains path traversal: %s", header.Name)
 		}
 
 		if header.Size > maxArchiveEntrySize {
-			return nil, fmt.Errorf("tar entry %s exceeds maximum size (%d bytes)", header.Name, maxArchiveEntrySize)
+			return fmt.Errorf("tar entry %s exceeds maximum size (%d bytes)", header.Name, maxArchiveEntrySize)
 		}
 
 		totalSize += header.Size
 		if totalSize > maxArchiveTotalSize {
-			return nil, fmt.Errorf("archive exceeds maximum total size (%d bytes)", maxArchiveTotalSize)
+			return fmt.Errorf("archive exceeds maximum total size (%d bytes)", maxArchiveTotalSize)
 		}
 
 		content, err := io.ReadAll(io.LimitReader(tarReader, maxArchiveEntrySize+1))
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if int64(len(content)) > maxArchiveEntrySize {
-			return nil, fmt.Errorf("tar entry %s exceeds maximum size", header.Name)
+			return fmt.Errorf("tar entry %s exceeds maximum size", header.Name)
 		}
 
-		if strings.Contains(header.Name, ".metadata.") {
-			metadataFiles[header.Name] = string(content)
-		} else if hasSchemaExtension(header.Name) {
-			schemaFiles[header.Name] = string(content)
-		}
-	}
-
-	// Parse schema files
-	for path, content := range schemaFiles {
-		schema, err := parseSchemaFromArchive(path, content, metadataFiles)
-		if err != nil {
-			output.Warning("Skipping %s: %v", path, err)
-			continue
+		if err := fn(header.Name, content); err != nil {
+			return err
 		}
-		schemas = append(schemas, schema)
 	}
 
-	return schemas, nil
+	return nil
 }
 
-func readFromZip(zipPath string) ([]schemaToImport, error) {
-	var schemas []schemaToImport
-
+func readZipEntries(zipPath string, fn func(name string, content []byte) error) error {
 	reader, err := zip.OpenReader(zipPath)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer reader.Close()
 
-	schemaFiles := make(map[string]string)
-	metadataFiles := make(map[string]string)
 	var totalSize uint64
 
 	for _, file := range reader.File {
@@ -369,16 +451,16 @@ func readFromZip(zipPath string) ([]schemaToImport, error) {
 
 		// Reject path traversal in entry names
 		if strings.Contains(file.Name, "..") {
-			return nil, fmt.Errorf("zip entry contains path traversal: %s", file.Name)
+			return fmt.Errorf("zip entry contains path traversal: %s", file.Name)
 		}
 
 		if file.UncompressedSize64 > uint64(maxArchiveEntrySize) {
-			return nil, fmt.Errorf("zip entry %s exceeds maximum size (%d bytes)", file.Name, maxArchiveEntrySize)
+			return fmt.Errorf("zip entry %s exceeds maximum size (%d bytes)", file.Name, maxArchiveEntrySize)
 		}
 
 		totalSize += file.UncompressedSize64
 		if totalSize > uint64(maxArchiveTotalSize) {
-			return nil, fmt.Errorf("archive exceeds maximum total size (%d bytes)", maxArchiveTotalSize)
+			return fmt.Errorf("archive exceeds maximum total size (%d bytes)", maxArchiveTotalSize)
 		}
 
 		rc, err := file.Open()
@@ -392,26 +474,15 @@ func readFromZip(zipPath string) ([]schemaToImport, error) {
 			continue
 		}
 		if int64(len(content)) > maxArchiveEntrySize {
-			return nil, fmt.Errorf("zip entry %s exceeds maximum size", file.Name)
+			return fmt.Errorf("zip entry %s exceeds maximum size", file.Name)
 		}
 
-		if strings.Contains(file.Name, ".metadata.") {
-			metadataFiles[file.Name] = string(content)
-		} else if hasSchemaExtension(file.Name) {
-			schemaFiles[file.Name] = string(content)
-		}
-	}
-
-	for path, content := range schemaFiles {
-		schema, err := parseSchemaFromArchive(path, content, metadataFiles)
-		if err != nil {
-			output.Warning("Skipping %s: %v", path, err)
-			continue
+		if err := fn(file.Name, content); err != nil {
+			return err
 		}
-		schemas = append(schemas, schema)
 	}
 
-	return schemas, nil
+	return nil
 }
 
 func hasSchemaExtension(path string) bool {
@@ -421,27 +492,17 @@ func hasSchemaExtension(path string) bool {
 
 // rewriteSubjectContexts rewrites subject names and references to use a new context
 func rewriteSubjectContexts(schemas []schemaToImport, targetContext string) {
-	// Build mapping from old subject names to new subject names
-	rewriteMap := make(map[string]string)
-
-	for i := range schemas {
-		oldSubject := schemas[i].Subject
-		newSubject := rewriteSubjectContext(oldSubject, targetContext)
-		rewriteMap[oldSubject] = newSubject
-		schemas[i].Subject = newSubject
-	}
+	renameImportSubjects(schemas, func(subject string) string {
+		return rewriteSubjectContext(subject, targetContext)
+	})
+}
 
-	// Rewrite references to use new subject names
+// renameImportSubjects renames subjects and the references to them, including
+// references to subjects outside the import set
+func renameImportSubjects(schemas []schemaToImport, rename func(string) string) {
 	for i := range schemas {
-		for j := range schemas[i].References {
-			oldRefSubject := schemas[i].References[j].Subject
-			if newRefSubject, ok := rewriteMap[oldRefSubject]; ok {
-				schemas[i].References[j].Subject = newRefSubject
-			} else {
-				// Reference to a subject not in our import set - try to rewrite anyway
-				schemas[i].References[j].Subject = rewriteSubjectContext(oldRefSubject, targetContext)
-			}
-		}
+		schemas[i].Subject = rename(schemas[i].Subject)
+		renameReferences(schemas[i].References, rename)
 	}
 }
 
@@ -451,17 +512,7 @@ func rewriteSubjectContext(subject string, targetContext string) string {
 	// - "subject-name" (no context, default context)
 	// - ":.context:subject-name" (context-prefixed)
 
-	baseName := subject
-
-	// Check if subject has a context prefix (:.context:name format)
-	if strings.HasPrefix(subject, ":.") {
-		// Find the second colon after the context
-		afterFirstColon := subject[2:] // Skip ":."
-		colonIdx := strings.Index(afterFirstColon, ":")
-		if colonIdx != -1 {
-			baseName = afterFirstColon[colonIdx+1:] // Extract name after context
-		}
-	}
+	_, baseName, _ := client.SplitQualifiedSubject(subject)
 
 	// Create new subject name with target context
 	if targetContext == "" || targetContext == "." {
@@ -477,60 +528,61 @@ func rewriteSubjectContext(subject string, targetContext string) string {
 	return fmt.Sprintf(":%s:%s", ctx, baseName)
 }
 
+// subjectVersionFromPath reads the subject and version from the parts of a
+// schema file's path. nested is <context>/<subject>/v<version>.<ext>; flat is
+// <subject>.<ext>, with version 0 so the registry assigns it; auto picks
+// nested for files named v<version>.<ext> and flat otherwise.
+func subjectVersionFromPath(parts []string, layout string) (subject string, version int) {
+	filename := parts[len(parts)-1]
+	base := strings.TrimSuffix(filename, filepath.Ext(filename))
+	v, isVersion := parseVersionFileName(base)
+
+	if layout == "flat" || (layout == "auto" && !isVersion) {
+		return base, 0
+	}
+	if len(parts) < 2 {
+		return "", 0
+	}
+	return parts[len(parts)-2], v
+}
+
+// parseVersionFileName parses a file name such as "v3"
+func parseVersionFileName(base string) (int, bool) {
+	if !strings.HasPrefix(base, "v") {
+		return 0, false
+	}
+	v, err := strconv.Atoi(base[1:])
+	return v, err == nil
+}
+
+// importSchemaType is the type of a schema file without metadata: Protobuf
+// for .proto, Avro for .avsc, and for .json whichever the content parses as
+func importSchemaType(content, path string) string {
+	switch filepath.Ext(path) {
+	case ".proto":
+		return "PROTOBUF"
+	case ".json":
+		return detectSchemaType(content, path)
+	default:
+		return "AVRO"
+	}
+}
+
 func parseSchemaFromArchive(path, content string, metadataFiles map[string]string) (schemaToImport, error) {
 	var schema schemaToImport
 	schema.FilePath = path
 	schema.Schema = content
 
 	ext := filepath.Ext(path)
-	switch ext {
-	case ".proto":
-		schema.SchemaType = "PROTOBUF"
-	case ".json":
-		if strings.Contains(content, `"$schema"`) {
-			schema.SchemaType = "JSON"
-		} else {
-			schema.SchemaType = "AVRO"
-		}
-	default:
-		schema.SchemaType = "AVRO"
-	}
+	schema.SchemaType = importSchemaType(content, path)
 
 	// Parse path
-	parts := strings.Split(path, "/")
-	if len(parts) >= 2 {
-		schema.Subject = parts[len(parts)-2]
-
-		filename := parts[len(parts)-1]
-		filename = strings.TrimSuffix(filename, ext)
-		if strings.HasPrefix(filename, "v") {
-			if v, err := strconv.Atoi(filename[1:]); err == nil {
-				schema.Version = v
-			}
-		}
-	}
+	schema.Subject, schema.Version = subjectVersionFromPath(strings.Split(path, "/"), importLayout)
 
 	// Try metadata
 	metadataPath := strings.TrimSuffix(path, ext) + ".metadata.json"
 	if metadataContent, ok := metadataFiles[metadataPath]; ok {
-		var metadata struct {
-			Subject    string                   `json:"subject"`
-			Version    int                      `json:"version"`
-			SchemaType string                   `json:"schemaType"`
-			References []client.SchemaReference `json:"references"`
-		}
-		if json.Unmarshal([]byte(metadataContent), &metadata) == nil {
-			if metadata.Subject != "" {
-				schema.Subject = metadata.Subject
-			}
-			if metadata.Version > 0 {
-				schema.Version = metadata.Version
-			}
-			if metadata.SchemaType != "" {
-				schema.SchemaType = metadata.SchemaType
-			}
-			schema.References = metadata.References
-		}
+		applySchemaFileMetadata(&schema, []byte(metadataContent))
 	}
 
 	if schema.Subject == "" {
@@ -540,6 +592,28 @@ func parseSchemaFromArchive(path, content string, metadataFiles map[string]strin
 	return schema, nil
 }
 
+// applySchemaFileMetadata overlays a v<version>.metadata.json sidecar onto a
+// schema parsed from its file path. Malformed sidecars are ignored.
+func applySchemaFileMetadata(schema *schemaToImport, content []byte) {
+	var metadata schemaFileMetadata
+	if json.Unmarshal(content, &metadata) != nil {
+		return
+	}
+	if metadata.Subject != "" {
+		schema.Subject = metadata.Subject
+	}
+	if metadata.Version > 0 {
+		schema.Version = metadata.Version
+	}
+	if metadata.SchemaType != "" {
+		schema.SchemaType = metadata.SchemaType
+	}
+	schema.SchemaID = metadata.SchemaID
+	schema.References = metadata.References
+	schema.Metadata = metadata.Metadata
+	schema.RuleSet = metadata.RuleSet
+}
+
 func sortSchemasByDependencies(schemas []schemaToImport) {
 	// Build a map of subject -> schemas for that subject
 	subjectSchemas := make(map[string][]schemaToImport)
@@ -681,16 +755,53 @@ func getStatusIcon(status string) string {
 	}
 }
 
-func performImport(c *client.SchemaRegistryClient, schemas []schemaToImport, existingSubjects map[string]bool) error {
+// findImportVersionGaps reports subjects that don't exist in the registry
+// yet and whose versions in the import set don't run 1, 2, 3, ...; the
+// registry would renumber them
+func findImportVersionGaps(schemas []schemaToImport, existingSubjects map[string]bool) []string {
+	versions := make(map[string][]int)
+	var subjects []string
+	for _, s := range schemas {
+		if s.Version <= 0 || existingSubjects[s.Subject] {
+			continue
+		}
+		if _, ok := versions[s.Subject]; !ok {
+			subjects = append(subjects, s.Subject)
+		}
+		versions[s.Subject] = append(versions[s.Subject], s.Version)
+	}
+
+	var gaps []string
+	for _, subject := range subjects {
+		vs := versions[subject]
+		sort.Ints(vs)
+		for i, v := range vs {
+			if v != i+1 {
+				gaps = append(gaps, fmt.Sprintf("%s: v%d would be registered as v%d (versions %s in the import set)", subject, v, i+1, formatVersionList(vs)))
+				break
+			}
+		}
+	}
+	return gaps
+}
+
+// formatVersionList formats versions as "1, 2, 5"
+func formatVersionList(versions []int) string {
+	parts := make([]string, len(versions))
+	for i, v := range versions {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func performImport(c client.SchemaRegistryClientInterface, schemas []schemaToImport, existingSubjects map[string]bool) error {
 	output.Step("Importing schemas...")
 
-	bar := progressbar.NewOptions(len(schemas),
-		progressbar.OptionSetDescription("Importing"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(schemas), "Importing")
 
-	var imported, skipped, failed int
+	var imported, skipped, failed, mismatched, compatible, incompatible int
+	stopped := make(map[string]bool)     // subjects whose versions stopped lining up with --strict-versions
+	hasVersions := make(map[string]bool) // subjects this run registered a version under
 
 	for _, s := range schemas {
 		// Skip if exists and flag set
@@ -699,6 +810,11 @@ func performImport(c *client.SchemaRegistryClient, schemas []schemaToImport, exi
 			bar.Add(1)
 			continue
 		}
+		if stopped[s.Subject] {
+			skipped++
+			bar.Add(1)
+			continue
+		}
 
 		// Set compatibility if specified
 		if importCompatibility != "" {
@@ -710,14 +826,57 @@ func performImport(c *client.SchemaRegistryClient, schemas []schemaToImport, exi
 			Schema:     s.Schema,
 			SchemaType: s.SchemaType,
 			References: s.References,
+			Metadata:   s.Metadata,
+			RuleSet:    s.RuleSet,
+		}
+		if importPreserveIDs {
+			clientSchema.ID = s.SchemaID
+			clientSchema.Version = s.Version
+		}
+
+		// A subject that has no versions yet accepts any schema
+		if importCheckCompat && (existingSubjects[s.Subject] || hasVersions[s.Subject]) {
+			ok, err := c.CheckCompatibility(s.Subject, clientSchema, "latest")
+			if err != nil {
+				output.Warning("Failed to check compatibility of %s v%d: %v", s.Subject, s.Version, err)
+				failed++
+				bar.Add(1)
+				continue
+			}
+			if !ok {
+				output.Warning("Skipping %s v%d: not compatible with the latest version", s.Subject, s.Version)
+				incompatible++
+				bar.Add(1)
+				continue
+			}
+			compatible++
 		}
 
-		_, err := c.RegisterSchema(s.Subject, clientSchema)
+		id, err := c.RegisterSchema(s.Subject, clientSchema)
 		if err != nil {
 			output.Warning("Failed to import %s v%d: %v", s.Subject, s.Version, err)
 			failed++
-		} else {
-			imported++
+			bar.Add(1)
+			continue
+		}
+		imported++
+		hasVersions[s.Subject] = true
+
+		// The registry assigns its own version; check it's the intended one
+		if s.Version > 0 {
+			version, err := registeredVersion(c, s.Subject, id)
+			switch {
+			case err != nil:
+				output.Warning("Imported %s v%d but could not check its registered version: %v", s.Subject, s.Version, err)
+			case version != s.Version:
+				mismatched++
+				if importStrictVersion {
+					output.Error("%s v%d was registered as v%d; skipping its remaining versions", s.Subject, s.Version, version)
+					stopped[s.Subject] = true
+				} else {
+					output.Warning("%s v%d was registered as v%d", s.Subject, s.Version, version)
+				}
+			}
 		}
 
 		bar.Add(1)
@@ -726,18 +885,31 @@ func performImport(c *client.SchemaRegistryClient, schemas []schemaToImport, exi
 	bar.Finish()
 
 	output.Header("Import Complete")
-	output.PrintTable(
-		[]string{"Status", "Count"},
-		[][]string{
-			{"Imported", strconv.Itoa(imported)},
-			{"Skipped", strconv.Itoa(skipped)},
-			{"Failed", strconv.Itoa(failed)},
-		},
-	)
+	rows := [][]string{
+		{"Imported", strconv.Itoa(imported)},
+		{"Skipped", strconv.Itoa(skipped)},
+		{"Failed", strconv.Itoa(failed)},
+	}
+	if mismatched > 0 {
+		rows = append(rows, []string{"Version mismatches", strconv.Itoa(mismatched)})
+	}
+	if importCheckCompat {
+		rows = append(rows,
+			[]string{"Compatible", strconv.Itoa(compatible)},
+			[]string{"Incompatible (skipped)", strconv.Itoa(incompatible)},
+		)
+	}
+	output.PrintTable([]string{"Status", "Count"}, rows)
 
 	if failed > 0 {
 		return fmt.Errorf("%d schemas failed to import", failed)
 	}
+	if incompatible > 0 {
+		return fmt.Errorf("%d schemas were not compatible and were not imported", incompatible)
+	}
+	if mismatched > 0 && importStrictVersion {
+		return fmt.Errorf("%d schema version(s) were registered under a different version (--strict-versions)", mismatched)
+	}
 
 	return nil
 }
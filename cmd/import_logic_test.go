@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/srctl/srctl/internal/client"
@@ -173,3 +174,137 @@ func TestFilterVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestFindImportVersionGaps(t *testing.T) {
+	schemas := []schemaToImport{
+		{Subject: "orders-value", Version: 1},
+		{Subject: "orders-value", Version: 2},
+		{Subject: "users-value", Version: 1},
+		{Subject: "users-value", Version: 5},
+		{Subject: "payments-value", Version: 4},
+		{Subject: "unversioned", Version: 0},
+	}
+
+	gaps := findImportVersionGaps(schemas, map[string]bool{"payments-value": true})
+	if len(gaps) != 1 || !strings.Contains(gaps[0], "users-value: v5 would be registered as v2") {
+		t.Errorf("unexpected gaps: %v", gaps)
+	}
+}
+
+func TestPerformImportVersionMismatch(t *testing.T) {
+	schemas := []schemaToImport{
+		{Subject: "users-value", Version: 1, SchemaType: "AVRO", Schema: `{"type":"record","name":"User","fields":[]}`},
+		{Subject: "users-value", Version: 3, SchemaType: "AVRO", Schema: `{"type":"record","name":"User","fields":[{"name":"id","type":"int","default":0}]}`},
+		{Subject: "users-value", Version: 4, SchemaType: "AVRO", Schema: `{"type":"record","name":"User","fields":[{"name":"id","type":"int","default":0},{"name":"n","type":"string","default":""}]}`},
+	}
+
+	saved := importStrictVersion
+	defer func() { importStrictVersion = saved }()
+
+	importStrictVersion = false
+	mock := client.NewMockClient()
+	if err := performImport(mock, schemas, nil); err != nil {
+		t.Fatalf("a mismatch should only warn: %v", err)
+	}
+	if len(mock.Subjects["users-value"]) != 3 {
+		t.Errorf("expected all 3 versions imported, got %d", len(mock.Subjects["users-value"]))
+	}
+
+	importStrictVersion = true
+	mock = client.NewMockClient()
+	if err := performImport(mock, schemas, nil); err == nil {
+		t.Fatal("expected --strict-versions to fail on a mismatch")
+	}
+	if len(mock.Subjects["users-value"]) != 2 {
+		t.Errorf("expected the import to stop after the mismatched version, got %d versions", len(mock.Subjects["users-value"]))
+	}
+}
+
+func TestPerformImportCheckCompatibility(t *testing.T) {
+	saved := importCheckCompat
+	defer func() { importCheckCompat = saved }()
+	importCheckCompat = true
+
+	mock := client.NewMockClient()
+	mock.AddSubject("orders-value", []client.Schema{{Subject: "orders-value", Version: 1, ID: 1, SchemaType: "AVRO", Schema: `"string"`}})
+	mock.Incompatible = map[string]bool{"orders-value": true}
+
+	schemas := []schemaToImport{
+		{Subject: "orders-value", SchemaType: "AVRO", Schema: `"int"`},
+		{Subject: "users-value", SchemaType: "AVRO", Schema: `"string"`},
+		{Subject: "users-value", SchemaType: "AVRO", Schema: `["null","string"]`},
+	}
+	err := performImport(mock, schemas, map[string]bool{"orders-value": true})
+	if err == nil || !strings.Contains(err.Error(), "1 schemas were not compatible") {
+		t.Fatalf("expected the incompatible schema to be reported, got %v", err)
+	}
+	if len(mock.Subjects["orders-value"]) != 1 {
+		t.Error("the incompatible schema should not have been registered")
+	}
+	if len(mock.Subjects["users-value"]) != 2 {
+		t.Errorf("expected both users-value versions imported, got %d", len(mock.Subjects["users-value"]))
+	}
+
+	var checked []string
+	for _, call := range mock.Calls {
+		if call.Method == "CheckCompatibility" {
+			checked = append(checked, call.Args[0].(string))
+		}
+	}
+	// The first version of a new subject has nothing to be checked against
+	if strings.Join(checked, ",") != "orders-value,users-value" {
+		t.Errorf("unexpected compatibility checks: %v", checked)
+	}
+}
+
+func TestSubjectVersionFromPath(t *testing.T) {
+	tests := []struct {
+		path        string
+		layout      string
+		wantSubject string
+		wantVersion int
+	}{
+		{"default/orders-value/v3.avsc", "auto", "orders-value", 3},
+		{"orders-value.avsc", "auto", "orders-value", 0},
+		{"team-a/users-value.json", "auto", "users-value", 0},
+		{"default/orders-value/v3.avsc", "nested", "orders-value", 3},
+		{"orders-value.avsc", "nested", "", 0},
+		{"default/orders-value/v3.avsc", "flat", "v3", 0},
+		{"payments-value.proto", "flat", "payments-value", 0},
+	}
+	for _, tt := range tests {
+		subject, version := subjectVersionFromPath(strings.Split(tt.path, "/"), tt.layout)
+		if subject != tt.wantSubject || version != tt.wantVersion {
+			t.Errorf("%s (%s): got %q v%d, want %q v%d", tt.path, tt.layout, subject, version, tt.wantSubject, tt.wantVersion)
+		}
+	}
+}
+
+func TestPerformImportPreserveIDs(t *testing.T) {
+	saved := importPreserveIDs
+	defer func() { importPreserveIDs = saved }()
+	importPreserveIDs = true
+
+	schemas := []schemaToImport{
+		{Subject: "users-value", Version: 1, SchemaID: 42, SchemaType: "AVRO", Schema: `"string"`, FilePath: "users-value/v1.avsc"},
+		{Subject: "users-value", Version: 3, SchemaID: 57, SchemaType: "AVRO", Schema: `["null","string"]`, FilePath: "users-value/v3.avsc"},
+	}
+	if err := checkImportIDs(schemas); err != nil {
+		t.Fatalf("checkImportIDs: %v", err)
+	}
+
+	mock := client.NewMockClient()
+	if err := performImport(mock, schemas, nil); err != nil {
+		t.Fatalf("performImport: %v", err)
+	}
+	for i, registered := range mock.Subjects["users-value"] {
+		if registered.ID != schemas[i].SchemaID || registered.Version != schemas[i].Version {
+			t.Errorf("registered v%d with ID %d, want v%d with ID %d", registered.Version, registered.ID, schemas[i].Version, schemas[i].SchemaID)
+		}
+	}
+
+	schemas = append(schemas, schemaToImport{Subject: "orders-value", Version: 1, FilePath: "orders-value/v1.avsc"})
+	if err := checkImportIDs(schemas); err == nil || !strings.Contains(err.Error(), "orders-value/v1.avsc") {
+		t.Errorf("expected an error naming the schema without an ID, got %v", err)
+	}
+}
@@ -42,6 +42,25 @@ func TestImportFromDirectory(t *testing.T) {
 	}
 }
 
+func TestReadFromDirectoryFlatLayout(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "orders-value.avsc"), []byte(`{"type":"record","name":"Order","fields":[]}`), 0644)
+	os.MkdirAll(filepath.Join(dir, "default", "users-value"), 0755)
+	os.WriteFile(filepath.Join(dir, "default", "users-value", "v2.avsc"), []byte(`"string"`), 0644)
+
+	schemas, err := readFromDirectory(dir)
+	if err != nil {
+		t.Fatalf("readFromDirectory: %v", err)
+	}
+	got := make(map[string]int)
+	for _, s := range schemas {
+		got[s.Subject] = s.Version
+	}
+	if len(got) != 2 || got["orders-value"] != 0 || got["users-value"] != 2 {
+		t.Errorf("unexpected subjects and versions: %v", got)
+	}
+}
+
 func TestParseSchemaFile(t *testing.T) {
 	dir, cleanup := createTempDir()
 	defer cleanup()
@@ -79,6 +98,40 @@ func TestParseSchemaFile(t *testing.T) {
 	}
 }
 
+func TestParseSchemaFileDetectsType(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]struct {
+		content string
+		want    string
+	}{
+		"avro-doc/v1.json":    {`{"type":"record","name":"User","doc":"See \"$schema\" docs","fields":[]}`, "AVRO"},
+		"json-plain/v1.json":  {`{"type":"object","properties":{"id":{"type":"string"}}}`, "JSON"},
+		"avro-file/v1.avsc":   {`{"type":"record","name":"User","fields":[]}`, "AVRO"},
+		"proto-file/v1.proto": {`syntax = "proto3"; message User {}`, "PROTOBUF"},
+	}
+	for name, f := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(f.content), 0644)
+
+		schema, err := parseSchemaFile(dir, path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if schema.SchemaType != f.want {
+			t.Errorf("%s: type %s, want %s", name, schema.SchemaType, f.want)
+		}
+
+		archived, err := parseSchemaFromArchive(name, f.content, nil)
+		if err != nil {
+			t.Fatalf("%s (archive): %v", name, err)
+		}
+		if archived.SchemaType != f.want {
+			t.Errorf("%s (archive): type %s, want %s", name, archived.SchemaType, f.want)
+		}
+	}
+}
+
 func TestImportSchema(t *testing.T) {
 	mock := client.NewMockClient()
 
@@ -244,3 +297,33 @@ func TestImportOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestReadSchemasFromArchiveRoundTrip(t *testing.T) {
+	dir, cleanup := createTempDir()
+	defer cleanup()
+
+	schemas := []schemaExport{
+		{Subject: "test-subject", Version: 1, SchemaID: 100, SchemaType: "AVRO", Schema: `{"type":"string"}`},
+	}
+
+	for _, archive := range []string{"test.tar.gz", "test.zip"} {
+		path := filepath.Join(dir, archive)
+		var err error
+		if archive == "test.zip" {
+			err = exportToZip(schemas, path)
+		} else {
+			err = exportToTar(schemas, path)
+		}
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", archive, err)
+		}
+
+		imported, err := readSchemasFromArchive(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", archive, err)
+		}
+		if len(imported) != 1 || imported[0].Subject != "test-subject" {
+			t.Errorf("%s: expected test-subject, got %+v", archive, imported)
+		}
+	}
+}
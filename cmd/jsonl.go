@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srctl/srctl/internal/output"
+)
+
+// jsonlOutput is set by --jsonl on bulk commands
+var jsonlOutput bool
+
+// resultOut is the process's real stdout, which receives --jsonl events and
+// -o json/yaml summaries. While either is on, everything else that would
+// print to stdout goes to stderr instead, so stdout stays machine-readable.
+var (
+	jsonlMu   sync.Mutex
+	resultOut io.Writer = os.Stdout
+	diverted  bool
+)
+
+// SubjectEvent reports one subject processed by a bulk command. Status is
+// command-specific (e.g. ok, unchanged, deleted, cloned, different, failed,
+// skipped); Error is set for failed and partially failed subjects.
+type SubjectEvent struct {
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"`
+	Subject  string    `json:"subject"`
+	Status   string    `json:"status"`
+	Versions int       `json:"versions,omitempty"`
+	Details  []string  `json:"details,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// addJSONLFlag adds --jsonl to a bulk command
+func addJSONLFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&jsonlOutput, "jsonl", false, "Stream one JSON object per subject to stdout as it completes (other output goes to stderr)")
+}
+
+// startJSONL points stdout at stderr when --jsonl is set, keeping the real
+// stdout for events
+func startJSONL() {
+	if jsonlOutput {
+		divertStdout()
+	}
+}
+
+// startSummary points stdout at stderr when -o json/yaml is set, for commands
+// that print a single summary with printSummary when they finish
+func startSummary() {
+	if structuredOutput() {
+		divertStdout()
+	}
+}
+
+func divertStdout() {
+	if diverted {
+		return
+	}
+	diverted = true
+	resultOut = os.Stdout
+	os.Stdout = os.Stderr
+	output.SetStdoutColor(os.Stderr)
+}
+
+// structuredOutput reports whether -o asks for json or yaml
+func structuredOutput() bool {
+	f := strings.ToLower(outputFormat)
+	return f == "json" || f == "yaml"
+}
+
+// printSummary prints a command's result with the -o printer to the real
+// stdout. With --jsonl, stdout belongs to the events and it goes to stderr.
+func printSummary(v interface{}) error {
+	var w io.Writer = os.Stdout
+	if diverted && !jsonlOutput {
+		w = resultOut
+	}
+	return output.NewPrinter(outputFormat).WithWriter(w).Print(v)
+}
+
+// emitSubjectEvent writes ev as one JSON line when --jsonl is set. It is safe
+// to call from worker goroutines.
+func emitSubjectEvent(command string, ev SubjectEvent) {
+	if !jsonlOutput {
+		return
+	}
+	ev.Command = command
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+
+	jsonlMu.Lock()
+	defer jsonlMu.Unlock()
+	if err := json.NewEncoder(resultOut).Encode(ev); err != nil {
+		output.Warning("Failed to write event for %s: %v", ev.Subject, err)
+	}
+}
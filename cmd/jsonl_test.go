@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// captureJSONL turns --jsonl on with events going to a buffer, restoring the
+// previous state when the test ends
+func captureJSONL(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	savedOn, savedOut := jsonlOutput, resultOut
+	t.Cleanup(func() { jsonlOutput, resultOut = savedOn, savedOut })
+
+	var buf bytes.Buffer
+	jsonlOutput, resultOut = true, &buf
+	return &buf
+}
+
+func TestEmitSubjectEvent(t *testing.T) {
+	buf := captureJSONL(t)
+
+	emitSubjectEvent("delete", SubjectEvent{Subject: "orders-value", Status: "deleted", Versions: 2})
+	emitSubjectEvent("delete", SubjectEvent{Subject: "users-value", Status: "failed", Error: "boom"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", buf.String())
+	}
+	var ev SubjectEvent
+	if err := json.Unmarshal([]byte(lines[1]), &ev); err != nil {
+		t.Fatalf("invalid JSON line %q: %v", lines[1], err)
+	}
+	if ev.Command != "delete" || ev.Subject != "users-value" || ev.Status != "failed" || ev.Error != "boom" || ev.Time.IsZero() {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+	if strings.Contains(lines[0], `"error"`) {
+		t.Errorf("empty error should be omitted: %s", lines[0])
+	}
+
+	// Off by default: nothing is written
+	buf.Reset()
+	jsonlOutput = false
+	emitSubjectEvent("delete", SubjectEvent{Subject: "orders-value", Status: "deleted"})
+	if buf.Len() != 0 {
+		t.Errorf("expected no output without --jsonl, got %q", buf.String())
+	}
+}
+
+func TestBackupEvent(t *testing.T) {
+	tests := []struct {
+		r      backupResult
+		status string
+	}{
+		{backupResult{Subject: "a", VersionCount: 3}, "ok"},
+		{backupResult{Subject: "a", VersionCount: 3, Unchanged: true}, "unchanged"},
+		{backupResult{Subject: "a", VersionCount: 2, Failures: []operationFailure{{Subject: "a", Version: 3, Error: "timeout"}}}, "partial"},
+		{backupResult{Subject: "a", Error: errors.New("not found")}, "failed"},
+	}
+	for _, tt := range tests {
+		ev := backupEvent(tt.r)
+		if ev.Status != tt.status {
+			t.Errorf("backupEvent(%+v).Status = %s, want %s", tt.r, ev.Status, tt.status)
+		}
+		if (tt.status == "partial" || tt.status == "failed") && ev.Error == "" {
+			t.Errorf("expected an error for %s", tt.status)
+		}
+	}
+}
+
+func TestCompareEvent(t *testing.T) {
+	tests := []struct {
+		r       CompareResult
+		status  string
+		details int
+	}{
+		{CompareResult{Subject: "a", SourceVers: 2, TargetVers: 2}, "identical", 0},
+		{CompareResult{Subject: "a", VersionDiff: true, ConfigDiff: true, SourceVers: 3, TargetVers: 2}, "different", 2},
+		{CompareResult{Subject: "a", SourceOnly: true, Versions: []int{1, 2}}, "source-only", 0},
+		{CompareResult{Subject: "a", TargetOnly: true}, "target-only", 0},
+		{CompareResult{Subject: "a", Error: "connection refused"}, "failed", 0},
+	}
+	for _, tt := range tests {
+		ev := compareEvent(tt.r)
+		if ev.Status != tt.status || len(ev.Details) != tt.details {
+			t.Errorf("compareEvent(%+v) = %+v, want status %s with %d detail(s)", tt.r, ev, tt.status, tt.details)
+		}
+	}
+}
+
+func TestCloneProgressEvent(t *testing.T) {
+	ev := cloneProgress{Subject: "a", Cloned: 2, Skipped: 1}.event()
+	if ev.Status != "cloned" || ev.Versions != 2 || len(ev.Details) != 1 {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+
+	failed := []operationFailure{{Subject: "a", Version: 1, Error: "409"}, {Subject: "a", Version: 2, Error: "409"}}
+	ev = cloneProgress{Subject: "a", Failures: failed}.event()
+	if ev.Status != "failed" || ev.Error != "v1: 409 (+1 more)" {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+	ev = cloneProgress{Subject: "a", Cloned: 1, Failures: failed[:1]}.event()
+	if ev.Status != "partial" {
+		t.Errorf("status = %s, want partial", ev.Status)
+	}
+}
+
+// captureSummary sets -o to format with summaries going to a buffer,
+// restoring the previous state when the test ends
+func captureSummary(t *testing.T, format string) *bytes.Buffer {
+	t.Helper()
+	savedFormat, savedDiverted, savedOut := outputFormat, diverted, resultOut
+	t.Cleanup(func() { outputFormat, diverted, resultOut = savedFormat, savedDiverted, savedOut })
+
+	var buf bytes.Buffer
+	outputFormat, diverted, resultOut = format, true, &buf
+	return &buf
+}
+
+func TestPrintDeletePlanYAML(t *testing.T) {
+	buf := captureSummary(t, "yaml")
+
+	if err := printDeletePlan([]plannedDelete{{Subject: "orders-value", Versions: []int{1, 2}, Permanent: true}}); err != nil {
+		t.Fatalf("printDeletePlan: %v", err)
+	}
+	want := "- subject: orders-value\n  versions:\n    - 1\n    - 2\n  permanent: true\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestBuildCompareReport(t *testing.T) {
+	savedSource, savedTarget, savedDiffOnly := compareSource, compareTarget, compareDiffOnly
+	defer func() { compareSource, compareTarget, compareDiffOnly = savedSource, savedTarget, savedDiffOnly }()
+	compareSource, compareTarget = "dev", "prod"
+
+	results := []CompareResult{
+		{Subject: "users-value", SourceVers: 1, TargetVers: 1},
+		{Subject: "orders-value", VersionDiff: true, SourceVers: 3, TargetVers: 2},
+		{Subject: "new-value", SourceOnly: true, Versions: []int{1, 2}},
+	}
+	report := buildCompareReport(results, 1, 1, 1, 0)
+	if report.Source != "dev" || report.Target != "prod" || report.Total != 3 || len(report.Subjects) != 3 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	first := report.Subjects[0]
+	if first.Subject != "new-value" || first.Status != "source-only" || first.SourceVersions != 2 {
+		t.Errorf("unexpected first subject: %+v", first)
+	}
+	if d := report.Subjects[1].Differences; len(d) != 1 || d[0] != "versions (3/2)" {
+		t.Errorf("unexpected differences: %v", d)
+	}
+
+	compareDiffOnly = true
+	if report = buildCompareReport(results, 1, 1, 1, 0); len(report.Subjects) != 2 {
+		t.Errorf("--diff-only should drop identical subjects: %+v", report.Subjects)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/srctl/srctl/internal/client"
@@ -52,20 +51,49 @@ Examples:
   srctl list --context .mycontext
 
   # Limit results
-  srctl list --limit 10`,
-	RunE: runList,
+  srctl list --limit 10
+
+  # Explicit subcommands
+  srctl list subjects --filter "user-*"
+  srctl list versions user-events --deleted`,
+	Annotations: readOnlyAnnotations,
+	RunE:        runList,
+}
+
+var listSubjectsCmd = &cobra.Command{
+	Use:         "subjects",
+	Short:       "List subjects (same as 'srctl list')",
+	Args:        cobra.NoArgs,
+	Annotations: readOnlyAnnotations,
+	RunE:        runList,
+}
+
+var listVersionsSubCmd = &cobra.Command{
+	Use:         "versions <subject>",
+	Short:       "List versions of a subject (same as 'srctl versions')",
+	Args:        cobra.ExactArgs(1),
+	Annotations: readOnlyAnnotations,
+	RunE:        runListVersions,
 }
 
 func init() {
-	listCmd.Flags().StringVarP(&listFilter, "filter", "f", "", "Filter subjects by pattern (supports * wildcard)")
-	listCmd.Flags().BoolVarP(&listIncludeDeleted, "deleted", "d", false, "Include soft-deleted subjects")
-	listCmd.Flags().BoolVarP(&listShowVersions, "versions", "V", false, "Show version count for each subject")
-	listCmd.Flags().StringVar(&listSortBy, "sort", "name", "Sort by: name, versions (requires --versions)")
-	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Limit number of results (0 = no limit)")
+	addListFlags(listCmd)
+	addListFlags(listSubjectsCmd)
+	listVersionsSubCmd.Flags().BoolVarP(&versionsIncludeDeleted, "deleted", "d", false, "Include soft-deleted versions")
 
+	listCmd.AddCommand(listSubjectsCmd, listVersionsSubCmd)
 	rootCmd.AddCommand(listCmd)
 }
 
+// addListFlags registers the subject listing flags shared by list and list subjects
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&listFilter, "filter", "f", "", "Filter subjects by pattern (supports * wildcard)")
+	cmd.Flags().BoolVarP(&listIncludeDeleted, "deleted", "d", false, "Include soft-deleted subjects")
+	cmd.Flags().BoolVarP(&listShowVersions, "versions", "V", false, "Show version count for each subject")
+	cmd.Flags().StringVar(&listSortBy, "sort", "name", "Sort by: name, versions (requires --versions)")
+	cmd.Flags().IntVar(&listLimit, "limit", 0, "Limit number of results (0 = no limit)")
+}
+
 func runList(cmd *cobra.Command, args []string) error {
 	c, err := GetClient()
 	if err != nil {
@@ -131,58 +159,6 @@ func runList(cmd *cobra.Command, args []string) error {
 	return printer.Print(names)
 }
 
-func filterSubjects(subjects []string, pattern string) []string {
-	// Convert glob pattern to simple matching
-	var filtered []string
-	pattern = strings.ToLower(pattern)
-
-	for _, subj := range subjects {
-		if matchGlob(strings.ToLower(subj), pattern) {
-			filtered = append(filtered, subj)
-		}
-	}
-
-	return filtered
-}
-
-func matchGlob(s, pattern string) bool {
-	// Simple glob matching supporting * wildcard
-	if pattern == "*" {
-		return true
-	}
-
-	parts := strings.Split(pattern, "*")
-	if len(parts) == 1 {
-		// No wildcard, exact match
-		return s == pattern
-	}
-
-	// Check prefix
-	if parts[0] != "" && !strings.HasPrefix(s, parts[0]) {
-		return false
-	}
-
-	// Check suffix
-	if parts[len(parts)-1] != "" && !strings.HasSuffix(s, parts[len(parts)-1]) {
-		return false
-	}
-
-	// Check middle parts
-	idx := len(parts[0])
-	for i := 1; i < len(parts)-1; i++ {
-		if parts[i] == "" {
-			continue
-		}
-		newIdx := strings.Index(s[idx:], parts[i])
-		if newIdx < 0 {
-			return false
-		}
-		idx += newIdx + len(parts[i])
-	}
-
-	return true
-}
-
 func sortSubjectResults(results []subjectInfo, sortBy string) {
 	switch sortBy {
 	case "versions":
@@ -246,8 +222,9 @@ Examples:
 
   # Output as JSON
   srctl versions user-events -o json`,
-	Args: cobra.ExactArgs(1),
-	RunE: runListVersions,
+	Args:        cobra.ExactArgs(1),
+	Annotations: readOnlyAnnotations,
+	RunE:        runListVersions,
 }
 
 var versionsIncludeDeleted bool
@@ -213,3 +213,25 @@ func TestSubjectInfo(t *testing.T) {
 		t.Errorf("expected latest ID 100, got %d", info.LatestID)
 	}
 }
+
+func TestListSubcommands(t *testing.T) {
+	for _, args := range [][]string{{"list", "subjects"}, {"list", "versions", "user-events"}} {
+		cmd, rest, err := rootCmd.Find(args)
+		if err != nil {
+			t.Fatalf("failed to find %v: %v", args, err)
+		}
+		if cmd.Parent() != listCmd {
+			t.Errorf("expected %v to resolve to a list subcommand, got %s", args, cmd.CommandPath())
+		}
+		if err := cmd.Args(cmd, rest); err != nil {
+			t.Errorf("unexpected args error for %v: %v", args, err)
+		}
+	}
+
+	if listSubjectsCmd.Flags().Lookup("filter") == nil || listSubjectsCmd.Flags().Lookup("deleted") == nil {
+		t.Error("expected list subjects to accept --filter and --deleted")
+	}
+	if listVersionsSubCmd.Flags().Lookup("deleted") == nil {
+		t.Error("expected list versions to accept --deleted")
+	}
+}
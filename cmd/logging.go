@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+var (
+	logLevel   string
+	verboseLog bool
+	logBodies  bool
+
+	// requestLogger receives the clients' request logs; nil when logging is off
+	requestLogger *slog.Logger
+)
+
+// setupLogging builds requestLogger from --log-level, --verbose and
+// --log-bodies. Logging is off unless one of them is set; --verbose and
+// --log-bodies alone mean debug. Logs go to stderr.
+func setupLogging() error {
+	requestLogger = nil
+	name := logLevel
+	if name == "" {
+		if !verboseLog && !logBodies {
+			return nil
+		}
+		name = "debug"
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return fmt.Errorf("invalid --log-level %q: must be debug, info, warn or error", logLevel)
+	}
+	requestLogger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+	return nil
+}
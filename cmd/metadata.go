@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var metadataCmd = &cobra.Command{
+	Use:     "metadata",
+	Aliases: []string{"business-metadata"},
+	Short:   "Manage catalog business metadata on subjects and schema versions",
+	GroupID: groupContract,
+	Long: `List business metadata definitions and attach or detach business metadata
+on subjects and schema versions.
+
+Business metadata needs the Stream Catalog (Confluent Platform or Confluent
+Cloud). It is catalog metadata, separate from the schema metadata set with
+'srctl contract'. Definitions are registry-wide; assignments follow --context.
+
+A target is a subject, or subject:version for a single schema version
+(version may be "latest").
+
+Examples:
+  # List business metadata definitions
+  srctl metadata list
+
+  # Define business metadata with string attributes
+  srctl metadata create Ownership --attributes team,email --description "Owning team"
+
+  # Attach it to a subject, or one version of it
+  srctl metadata assign user-events Ownership --attr team=payments,email=pay@example.com
+  srctl metadata assign user-events:latest Ownership --attr team=payments
+
+  # Detach it
+  srctl metadata remove user-events Ownership
+
+  # Show a subject's business metadata, per version
+  srctl metadata show user-events -o json`,
+}
+
+var metadataListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List business metadata definitions",
+	Args:        cobra.NoArgs,
+	Annotations: readOnlyAnnotations,
+	RunE:        runMetadataList,
+}
+
+var metadataCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a business metadata definition",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMetadataCreate,
+}
+
+var metadataAssignCmd = &cobra.Command{
+	Use:   "assign <subject>[:version] <name>",
+	Short: "Attach business metadata to a subject or schema version",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runMetadataAssign,
+}
+
+var metadataRemoveCmd = &cobra.Command{
+	Use:   "remove <subject>[:version] <name>",
+	Short: "Detach business metadata from a subject or schema version",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runMetadataRemove,
+}
+
+var metadataShowCmd = &cobra.Command{
+	Use:         "show <subject>",
+	Short:       "Show the business metadata on a subject and each of its versions",
+	Args:        cobra.ExactArgs(1),
+	Annotations: readOnlyAnnotations,
+	RunE:        runMetadataShow,
+}
+
+var (
+	metadataDescription string
+	metadataAttrDefs    []string
+	metadataAttributes  map[string]string
+)
+
+func init() {
+	metadataCreateCmd.Flags().StringVar(&metadataDescription, "description", "", "Definition description")
+	metadataCreateCmd.Flags().StringSliceVar(&metadataAttrDefs, "attributes", nil, "Attribute names (comma-separated); all are optional strings")
+	metadataAssignCmd.Flags().StringToStringVar(&metadataAttributes, "attr", nil, "Attribute values as key=value (comma-separated or repeated)")
+
+	metadataCmd.AddCommand(metadataListCmd, metadataCreateCmd, metadataAssignCmd, metadataRemoveCmd, metadataShowCmd)
+	rootCmd.AddCommand(metadataCmd)
+}
+
+// SubjectBusinessMetadata is the business metadata on a subject and on each
+// of its versions
+type SubjectBusinessMetadata struct {
+	Subject  string                    `json:"subject" yaml:"subject"`
+	Metadata []client.BusinessMetadata `json:"metadata" yaml:"metadata"`
+	Versions []VersionBusinessMetadata `json:"versions" yaml:"versions"`
+}
+
+// VersionBusinessMetadata is the business metadata on one schema version
+type VersionBusinessMetadata struct {
+	Version  int                       `json:"version" yaml:"version"`
+	Metadata []client.BusinessMetadata `json:"metadata" yaml:"metadata"`
+}
+
+func runMetadataList(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	defs, err := c.GetBusinessMetadataDefs()
+	if err != nil {
+		return fmt.Errorf("failed to get business metadata definitions: %w", err)
+	}
+
+	if outputFormat == "table" {
+		output.Header("Business Metadata Definitions")
+		if len(defs) == 0 {
+			output.Info("No business metadata defined")
+			return nil
+		}
+		rows := make([][]string, len(defs))
+		for i, def := range defs {
+			attrs := make([]string, len(def.AttributeDefs))
+			for j, a := range def.AttributeDefs {
+				attrs[j] = a.Name
+			}
+			rows[i] = []string{def.Name, strings.Join(attrs, ", "), def.Description}
+		}
+		output.PrintTable([]string{"Name", "Attributes", "Description"}, rows)
+		fmt.Printf("\nTotal: %d definition(s)\n", len(defs))
+		return nil
+	}
+
+	return output.NewPrinter(outputFormat).Print(defs)
+}
+
+func runMetadataCreate(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	def := newBusinessMetadataDef(args[0], metadataDescription, metadataAttrDefs)
+	if err := c.CreateBusinessMetadataDef(def); err != nil {
+		if client.IsConflict(err) {
+			return fmt.Errorf("business metadata %s already exists", def.Name)
+		}
+		return fmt.Errorf("failed to create business metadata definition: %w", err)
+	}
+	output.Success("Created business metadata %s", def.Name)
+	return nil
+}
+
+func runMetadataAssign(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+	target, err := resolveCatalogTarget(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	name := args[1]
+	if target.Version == 0 {
+		err = c.AssignBusinessMetadataToSubject(target.Subject, name, metadataAttributes)
+	} else {
+		err = c.AssignBusinessMetadataToSchema(target.Subject, target.Version, name, metadataAttributes)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to attach business metadata %s to %s: %w", name, target, err)
+	}
+	output.Success("Attached business metadata %s to %s", name, target)
+	return nil
+}
+
+func runMetadataRemove(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+	target, err := resolveCatalogTarget(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	name := args[1]
+	if target.Version == 0 {
+		err = c.RemoveBusinessMetadataFromSubject(target.Subject, name)
+	} else {
+		err = c.RemoveBusinessMetadataFromSchema(target.Subject, target.Version, name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to detach business metadata %s from %s: %w", name, target, err)
+	}
+	output.Success("Detached business metadata %s from %s", name, target)
+	return nil
+}
+
+func runMetadataShow(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	bm, err := getSubjectBusinessMetadata(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "table" {
+		output.Header("Business Metadata for: %s", bm.Subject)
+		var rows [][]string
+		for _, m := range bm.Metadata {
+			rows = append(rows, []string{"subject", m.TypeName, formatAttributes(m.Attributes)})
+		}
+		for _, v := range bm.Versions {
+			for _, m := range v.Metadata {
+				rows = append(rows, []string{strconv.Itoa(v.Version), m.TypeName, formatAttributes(m.Attributes)})
+			}
+		}
+		if len(rows) == 0 {
+			output.Info("No business metadata attached")
+			return nil
+		}
+		output.PrintTable([]string{"Version", "Name", "Attributes"}, rows)
+		return nil
+	}
+
+	return output.NewPrinter(outputFormat).Print(bm)
+}
+
+// newBusinessMetadataDef builds a definition whose attributes are optional
+// strings, the common case for governance metadata
+func newBusinessMetadataDef(name, description string, attributes []string) *client.BusinessMetadataDef {
+	def := &client.BusinessMetadataDef{Name: name, Description: description}
+	for _, attr := range attributes {
+		def.AttributeDefs = append(def.AttributeDefs, client.BusinessMetadataAttributeDef{
+			Name:        attr,
+			TypeName:    "string",
+			IsOptional:  true,
+			Cardinality: "SINGLE",
+		})
+	}
+	return def
+}
+
+// getSubjectBusinessMetadata collects the business metadata on a subject and
+// its live versions
+func getSubjectBusinessMetadata(c client.SchemaRegistryClientInterface, subject string) (*SubjectBusinessMetadata, error) {
+	attached, err := c.GetSubjectBusinessMetadata(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subject business metadata: %w", err)
+	}
+	result := &SubjectBusinessMetadata{Subject: subject, Metadata: attached, Versions: []VersionBusinessMetadata{}}
+
+	versions, err := c.GetVersions(subject, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions: %w", err)
+	}
+	for _, v := range versions {
+		attached, err := c.GetSchemaBusinessMetadata(subject, v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get business metadata for version %d: %w", v, err)
+		}
+		result.Versions = append(result.Versions, VersionBusinessMetadata{Version: v, Metadata: attached})
+	}
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/srctl/srctl/internal/client"
+)
+
+func TestNewBusinessMetadataDef(t *testing.T) {
+	def := newBusinessMetadataDef("Ownership", "Owning team", []string{"team", "email"})
+
+	if def.Name != "Ownership" || def.Description != "Owning team" {
+		t.Errorf("unexpected definition %+v", def)
+	}
+	if len(def.AttributeDefs) != 2 {
+		t.Fatalf("expected 2 attribute definitions, got %d", len(def.AttributeDefs))
+	}
+	for _, a := range def.AttributeDefs {
+		if a.TypeName != "string" || !a.IsOptional {
+			t.Errorf("expected an optional string attribute, got %+v", a)
+		}
+	}
+}
+
+func TestGetSubjectBusinessMetadata(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders-value", 2)
+	mock.AssignBusinessMetadataToSubject("orders-value", "Ownership", map[string]string{"team": "payments"})
+	mock.AssignBusinessMetadataToSchema("orders-value", 2, "Retention", nil)
+
+	bm, err := getSubjectBusinessMetadata(mock, "orders-value")
+	if err != nil {
+		t.Fatalf("getSubjectBusinessMetadata() error: %v", err)
+	}
+
+	if len(bm.Metadata) != 1 || bm.Metadata[0].TypeName != "Ownership" || bm.Metadata[0].Attributes["team"] != "payments" {
+		t.Errorf("unexpected subject metadata %+v", bm.Metadata)
+	}
+	if len(bm.Versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(bm.Versions))
+	}
+	if len(bm.Versions[0].Metadata) != 0 {
+		t.Errorf("expected no metadata on version 1, got %+v", bm.Versions[0].Metadata)
+	}
+	if len(bm.Versions[1].Metadata) != 1 || bm.Versions[1].Metadata[0].TypeName != "Retention" {
+		t.Errorf("unexpected version 2 metadata %+v", bm.Versions[1].Metadata)
+	}
+}
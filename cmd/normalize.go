@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var (
+	normalizeType    string
+	normalizeWrite   bool
+	normalizeSubject string
+	normalizeVersion string
+)
+
+var normalizeCmd = &cobra.Command{
+	Use:     "normalize [file]",
+	Short:   "Print a schema in canonical form",
+	GroupID: groupSchema,
+	Long: `Canonicalize a schema so semantically identical schemas compare equal.
+
+Avro and JSON schemas are re-encoded with sorted keys and no insignificant
+whitespace. Protobuf schemas have blank lines and surrounding whitespace
+removed.
+
+Examples:
+  # Print the canonical form of a local file
+  srctl normalize ./schemas/user.avsc
+
+  # Rewrite the file in place
+  srctl normalize ./schemas/user.avsc --write
+
+  # Canonicalize a registered schema
+  srctl normalize --subject user-events --version 3`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: readOnlyAnnotations,
+	RunE:        runNormalize,
+}
+
+func init() {
+	normalizeCmd.Flags().StringVarP(&normalizeType, "type", "t", "", "Schema type: AVRO, PROTOBUF, JSON (auto-detected if not set)")
+	normalizeCmd.Flags().BoolVarP(&normalizeWrite, "write", "w", false, "Write the canonical form back to the file")
+	normalizeCmd.Flags().StringVarP(&normalizeSubject, "subject", "s", "", "Normalize a registered schema instead of a file")
+	normalizeCmd.Flags().StringVarP(&normalizeVersion, "version", "v", "latest", "Version to normalize with --subject")
+
+	rootCmd.AddCommand(normalizeCmd)
+}
+
+func runNormalize(cmd *cobra.Command, args []string) error {
+	if (len(args) == 0) == (normalizeSubject == "") {
+		return fmt.Errorf("provide either a schema file or --subject")
+	}
+	if normalizeWrite && normalizeSubject != "" {
+		return fmt.Errorf("--write only applies to files")
+	}
+
+	var content, schemaType, file string
+	if normalizeSubject != "" {
+		c, err := GetClient()
+		if err != nil {
+			return err
+		}
+		schema, err := c.GetSchema(normalizeSubject, normalizeVersion)
+		if err != nil {
+			return fmt.Errorf("failed to get schema: %w", err)
+		}
+		content, schemaType = schema.Schema, schema.SchemaType
+	} else {
+		file = args[0]
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read schema file: %w", err)
+		}
+		content = string(data)
+	}
+
+	if normalizeType != "" {
+		schemaType = strings.ToUpper(normalizeType)
+	}
+	if schemaType == "" {
+		schemaType = detectSchemaType(content, file)
+	}
+
+	normalized, err := canonicalizeSchema(content, schemaType)
+	if err != nil {
+		return fmt.Errorf("failed to normalize schema: %w", err)
+	}
+
+	if normalizeWrite {
+		if normalized == strings.TrimSpace(content) {
+			output.Info("%s is already normalized", file)
+			return nil
+		}
+		if err := os.WriteFile(file, []byte(normalized+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write schema file: %w", err)
+		}
+		output.Success("Normalized %s", file)
+		return nil
+	}
+
+	if outputFormat != "table" {
+		return output.NewPrinter(outputFormat).Print(map[string]interface{}{
+			"schemaType": schemaType,
+			"schema":     normalized,
+			"changed":    normalized != strings.TrimSpace(content),
+		})
+	}
+
+	fmt.Println(normalized)
+	return nil
+}
+
+// canonicalizeSchema returns the canonical form of a schema: sorted keys and
+// no insignificant whitespace for Avro and JSON, trimmed non-blank lines for
+// Protobuf
+func canonicalizeSchema(content, schemaType string) (string, error) {
+	switch strings.ToUpper(schemaType) {
+	case "PROTOBUF":
+		var lines []string
+		for _, line := range strings.Split(content, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		return strings.Join(lines, "\n"), nil
+	default: // "", AVRO, JSON
+		return normalizeAvroSchema(content)
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import "testing"
+
+func TestCanonicalizeSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		schemaType string
+		want       string
+	}{
+		{
+			name:       "avro key order and whitespace",
+			content:    "{\n  \"type\": \"record\",\n  \"name\": \"User\",\n  \"fields\": [{\"type\": \"long\", \"name\": \"id\", \"default\": 9007199254740993}]\n}",
+			schemaType: "AVRO",
+			want:       `{"fields":[{"default":9007199254740993,"name":"id","type":"long"}],"name":"User","type":"record"}`,
+		},
+		{
+			name:       "json schema keeps special characters",
+			content:    `{"type": "string", "pattern": "<a&b>"}`,
+			schemaType: "JSON",
+			want:       `{"pattern":"<a&b>","type":"string"}`,
+		},
+		{
+			name:       "protobuf drops blank lines and indentation",
+			content:    "syntax = \"proto3\";\n\nmessage User {\n    string id = 1;\n}\n",
+			schemaType: "PROTOBUF",
+			want:       "syntax = \"proto3\";\nmessage User {\nstring id = 1;\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := canonicalizeSchema(tt.content, tt.schemaType)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := canonicalizeSchema(`{"type":`, "AVRO"); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
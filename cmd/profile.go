@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srctl/srctl/internal/config"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var profileCmd = &cobra.Command{
+	Use:     "profile",
+	Aliases: []string{"profiles"},
+	Short:   "List the config profiles",
+	GroupID: groupConfig,
+	Long: `List the profiles defined in srctl.yaml.
+
+A profile bundles a set of registries (URL, auth and context for each) and a
+default context under one name, so switching environments is one flag instead
+of several. Select it with --profile, or with SRCTL_PROFILE for a whole shell
+session; the flag wins over the variable. Within a profile, --registry and
+'compare'/'clone' --source/--target names refer to the profile's registries.
+A profile without registries keeps the top-level ones and only changes the
+default context.
+
+With a profile active, 'srctl registry add/remove/use' change the profile's
+registries.
+
+Config:
+  profiles:
+    staging:
+      default_context: .staging
+      registries:
+        - name: main
+          url: https://sr-staging.example.com
+          username: STAGING_KEY
+          password: STAGING_SECRET
+        - name: dr
+          url: https://sr-staging-dr.example.com
+
+Examples:
+  # List profiles
+  srctl profile list
+
+  # Run a command against a profile
+  srctl --profile staging compare --source main --target dr
+
+  # Use a profile for every command in this shell
+  export SRCTL_PROFILE=staging
+  srctl list`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the config profiles",
+	Args:  cobra.NoArgs,
+	RunE:  runProfileList,
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+	rootCmd.AddCommand(profileCmd)
+}
+
+// ProfileInfo summarizes a profile without its secrets
+type ProfileInfo struct {
+	Name           string   `json:"name" yaml:"name"`
+	Registries     []string `json:"registries" yaml:"registries"`
+	DefaultContext string   `json:"defaultContext,omitempty" yaml:"defaultContext,omitempty"`
+	Active         bool     `json:"active" yaml:"active"`
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	infos := profileInfos(config.AppConfig.Profiles, config.ActiveProfile())
+
+	if outputFormat == "table" {
+		output.Header("Config Profiles")
+		if len(infos) == 0 {
+			output.Info("No profiles configured; define them under 'profiles:' in srctl.yaml")
+			return nil
+		}
+		rows := make([][]string, len(infos))
+		for i, p := range infos {
+			active := ""
+			if p.Active {
+				active = "*"
+			}
+			registries := strings.Join(p.Registries, ", ")
+			if registries == "" {
+				registries = "(top-level)"
+			}
+			rows[i] = []string{active, p.Name, registries, p.DefaultContext}
+		}
+		output.PrintTable([]string{"Active", "Name", "Registries", "Default Context"}, rows)
+		return nil
+	}
+
+	return output.NewPrinter(outputFormat).Print(infos)
+}
+
+// profileInfos describes each profile, sorted by name
+func profileInfos(profiles map[string]config.Profile, active string) []ProfileInfo {
+	infos := make([]ProfileInfo, 0, len(profiles))
+	for name, p := range profiles {
+		names := make([]string, len(p.Registries))
+		for i, r := range p.Registries {
+			names[i] = r.Name
+		}
+		infos = append(infos, ProfileInfo{
+			Name:           name,
+			Registries:     names,
+			DefaultContext: p.DefaultContext,
+			Active:         name == active,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
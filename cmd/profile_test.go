@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/srctl/srctl/internal/config"
+)
+
+// withProfiles installs a config with two top-level registries and two
+// profiles, restoring the previous config when the test ends
+func withProfiles(t *testing.T) {
+	t.Helper()
+	saved := config.AppConfig
+	t.Cleanup(func() {
+		_ = config.UseProfile("")
+		config.AppConfig = saved
+	})
+	config.AppConfig = config.Config{
+		DefaultOutput:  "table",
+		DefaultContext: ".",
+		Registries: []config.Registry{
+			{Name: "local", URL: "http://localhost:8081", Default: true},
+		},
+		Profiles: map[string]config.Profile{
+			"staging": {
+				DefaultContext: ".staging",
+				Registries: []config.Registry{
+					{Name: "main", URL: "https://sr-staging.example.com"},
+					{Name: "dr", URL: "https://sr-staging-dr.example.com"},
+				},
+			},
+			"tenant": {DefaultContext: ".tenant"},
+		},
+	}
+}
+
+func TestUseProfile(t *testing.T) {
+	withProfiles(t)
+
+	if err := config.UseProfile("staging"); err != nil {
+		t.Fatalf("UseProfile: %v", err)
+	}
+	if config.ActiveProfile() != "staging" {
+		t.Errorf("active profile = %q, want staging", config.ActiveProfile())
+	}
+	if config.GetRegistry("local") != nil || config.GetRegistry("dr") == nil {
+		t.Errorf("profile registries not applied: %+v", config.AppConfig.Registries)
+	}
+	if got := config.GetDefaultRegistry().Name; got != "main" {
+		t.Errorf("default registry = %s, want main", got)
+	}
+	if config.AppConfig.DefaultContext != ".staging" {
+		t.Errorf("default context = %s, want .staging", config.AppConfig.DefaultContext)
+	}
+
+	// A profile without registries keeps the top-level ones
+	if err := config.UseProfile("tenant"); err != nil {
+		t.Fatalf("UseProfile: %v", err)
+	}
+	if config.GetRegistry("local") == nil || config.AppConfig.DefaultContext != ".tenant" {
+		t.Errorf("unexpected config for tenant: %+v", config.AppConfig)
+	}
+
+	if err := config.UseProfile("missing"); err == nil {
+		t.Error("expected error for an unknown profile")
+	}
+	if err := config.UseProfile(""); err != nil {
+		t.Fatalf("UseProfile(\"\"): %v", err)
+	}
+	if config.ActiveProfile() != "" || config.AppConfig.DefaultContext != "." {
+		t.Errorf("top-level config not restored: %+v", config.AppConfig)
+	}
+}
+
+func TestSelectProfile(t *testing.T) {
+	withProfiles(t)
+	saved := profileName
+	defer func() { profileName = saved }()
+
+	profileName = ""
+	t.Setenv("SRCTL_PROFILE", "staging")
+	if err := selectProfile(); err != nil {
+		t.Fatalf("selectProfile: %v", err)
+	}
+	if config.ActiveProfile() != "staging" {
+		t.Errorf("SRCTL_PROFILE not applied, active = %q", config.ActiveProfile())
+	}
+
+	// The flag wins over the environment
+	profileName = "tenant"
+	if err := selectProfile(); err != nil {
+		t.Fatalf("selectProfile: %v", err)
+	}
+	if config.ActiveProfile() != "tenant" {
+		t.Errorf("--profile not applied, active = %q", config.ActiveProfile())
+	}
+
+	profileName = "missing"
+	if err := selectProfile(); err == nil {
+		t.Error("expected error for an unknown profile")
+	}
+}
+
+func TestProfileDefaultContext(t *testing.T) {
+	withProfiles(t)
+	if err := config.UseProfile("staging"); err != nil {
+		t.Fatalf("UseProfile: %v", err)
+	}
+
+	c, err := GetClientForRegistry("main")
+	if err != nil {
+		t.Fatalf("GetClientForRegistry: %v", err)
+	}
+	if c.Context != ".staging" {
+		t.Errorf("context = %q, want the profile default .staging", c.Context)
+	}
+
+	config.GetRegistry("dr").Context = ".dr"
+	c, err = GetClientForRegistry("dr")
+	if err != nil {
+		t.Fatalf("GetClientForRegistry: %v", err)
+	}
+	if c.Context != ".dr" {
+		t.Errorf("context = %q, want the registry's own .dr", c.Context)
+	}
+}
+
+func TestProfileInfos(t *testing.T) {
+	withProfiles(t)
+
+	infos := profileInfos(config.AppConfig.Profiles, "tenant")
+	if len(infos) != 2 || infos[0].Name != "staging" || infos[1].Name != "tenant" {
+		t.Fatalf("unexpected profiles: %+v", infos)
+	}
+	if len(infos[0].Registries) != 2 || infos[0].Registries[0] != "main" || infos[0].Active {
+		t.Errorf("unexpected staging info: %+v", infos[0])
+	}
+	if !infos[1].Active || len(infos[1].Registries) != 0 {
+		t.Errorf("unexpected tenant info: %+v", infos[1])
+	}
+}
+
+func TestSaveConfigWithProfile(t *testing.T) {
+	withProfiles(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if err := config.UseProfile("staging"); err != nil {
+		t.Fatalf("UseProfile: %v", err)
+	}
+	if err := config.AddRegistry(config.Registry{Name: "test", URL: "https://sr-test.example.com"}); err != nil {
+		t.Fatalf("AddRegistry: %v", err)
+	}
+	if err := config.SaveConfig(); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(home, ".srctl", "srctl.yaml"))
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	var loaded config.Config
+	if err := v.Unmarshal(&loaded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if len(loaded.Registries) != 1 || loaded.Registries[0].Name != "local" {
+		t.Errorf("top-level registries changed: %+v", loaded.Registries)
+	}
+	if loaded.DefaultContext != "." {
+		t.Errorf("top-level default_context = %q, want .", loaded.DefaultContext)
+	}
+	staging := loaded.Profiles["staging"]
+	if len(staging.Registries) != 3 || staging.Registries[2].Name != "test" || staging.DefaultContext != ".staging" {
+		t.Errorf("staging profile not saved: %+v", staging)
+	}
+	if loaded.Profiles["tenant"].DefaultContext != ".tenant" {
+		t.Errorf("tenant profile lost: %+v", loaded.Profiles)
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/schollz/progressbar/v3"
+
+	"github.com/srctl/srctl/internal/output"
+)
+
+// newProgressBar returns the progress bar bulk commands share, drawn on stdout
+func newProgressBar(max int, description string) *progressbar.ProgressBar {
+	return newProgressBarTo(os.Stdout, max, description)
+}
+
+// newProgressBarTo returns a progress bar drawn on w. It stays hidden with
+// --quiet and when w is not a terminal (CI logs, pipes, files), where each
+// redraw would land in the log as another line.
+func newProgressBarTo(w *os.File, max int, description string) *progressbar.ProgressBar {
+	return progressbar.NewOptions(max,
+		progressbar.OptionSetWriter(w),
+		progressbar.OptionSetDescription(description),
+		progressbar.OptionShowCount(),
+		progressbar.OptionSetWidth(40),
+		progressbar.OptionClearOnFinish(),
+		progressbar.OptionSetVisibility(showProgress(w)),
+	)
+}
+
+func showProgress(w *os.File) bool {
+	if quietOutput || !output.IsTerminal(w) {
+		return false
+	}
+	// On the real stdout a bar would mix into -o json/yaml/plain output
+	return w != resultOut || outputFormat == "table"
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestShowProgress(t *testing.T) {
+	savedQuiet, savedFormat := quietOutput, outputFormat
+	defer func() { quietOutput, outputFormat = savedQuiet, savedFormat }()
+
+	// A file is not a terminal, like CI logs and pipes
+	f, err := os.Create(filepath.Join(t.TempDir(), "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	quietOutput, outputFormat = false, "table"
+	if showProgress(f) {
+		t.Error("progress should be hidden when not writing to a terminal")
+	}
+
+	bar := newProgressBarTo(f, 3, "Testing")
+	bar.Add(3)
+	bar.Finish()
+	if info, err := f.Stat(); err != nil || info.Size() != 0 {
+		t.Errorf("expected nothing written to a non-terminal, got %d bytes", info.Size())
+	}
+}
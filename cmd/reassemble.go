@@ -0,0 +1,630 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var (
+	reassembleManifest string
+	reassembleOutFile  string
+)
+
+// splitReassembleCmd inlines a schema's references into one schema
+var splitReassembleCmd = &cobra.Command{
+	Use:     "reassemble [subject] [version]",
+	Aliases: []string{"inline"},
+	Short:   "Inline referenced schemas into one self-contained schema",
+	Long: `Resolve a schema's references recursively and inline them, producing a
+single schema without references, for tools and consumers that don't
+support them. This is the inverse of 'split'.
+
+The schema is read from the registry (version defaults to latest), with each
+reference fetched by subject and version, or from a manifest.json written by
+'split extract'.
+
+  AVRO      Each referenced named type is defined at its first use
+  JSON      Referenced schemas are added under $defs (definitions for draft-07
+            and earlier) and their $refs pointed there
+  PROTOBUF  Messages and enums of imported files are appended and the
+            imports removed; all files must share the root's package
+
+Examples:
+  # Inline the latest version of a subject
+  srctl split reassemble orders-value
+
+  # A specific version, written to a file
+  srctl split reassemble orders-value 3 --output-file orders-flat.avsc
+
+  # Rebuild the original schema from split output
+  srctl split inline --manifest ./split-schemas/manifest.json`,
+	Args:        cobra.RangeArgs(0, 2),
+	Annotations: readOnlyAnnotations,
+	RunE:        runSplitReassemble,
+}
+
+func init() {
+	splitReassembleCmd.Flags().StringVar(&reassembleManifest, "manifest", "", "Reassemble from a manifest.json written by 'split extract' instead of the registry")
+	splitReassembleCmd.Flags().StringVar(&reassembleOutFile, "output-file", "", "Write the schema to this file instead of stdout")
+
+	splitCmd.AddCommand(splitReassembleCmd)
+}
+
+// inlineSchema is a schema together with the schemas its references resolve
+// to, in the order they are declared
+type inlineSchema struct {
+	key    string
+	schema *client.Schema
+	refs   []inlineReference
+}
+
+type inlineReference struct {
+	name   string
+	target *inlineSchema
+}
+
+func runSplitReassemble(cmd *cobra.Command, args []string) error {
+	var root *inlineSchema
+	var source string
+
+	switch {
+	case reassembleManifest != "" && len(args) > 0:
+		return fmt.Errorf("specify either a subject or --manifest, not both")
+	case reassembleManifest != "":
+		data, err := os.ReadFile(reassembleManifest)
+		if err != nil {
+			return fmt.Errorf("failed to read manifest: %w", err)
+		}
+		var result SplitResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return fmt.Errorf("failed to parse manifest %s: %w", reassembleManifest, err)
+		}
+		root, err = resolveManifestReferences(&result)
+		if err != nil {
+			return err
+		}
+		source = reassembleManifest
+	case len(args) > 0:
+		c, err := GetClient()
+		if err != nil {
+			return err
+		}
+		version := "latest"
+		if len(args) > 1 {
+			version = args[1]
+		}
+		schema, err := c.GetSchema(args[0], version)
+		if err != nil {
+			return fmt.Errorf("failed to get schema: %w", err)
+		}
+		if schema.Subject == "" {
+			schema.Subject = args[0]
+		}
+		root, err = resolveRegistryReferences(c, schema)
+		if err != nil {
+			return err
+		}
+		source = fmt.Sprintf("%s v%d", args[0], schema.Version)
+	default:
+		return fmt.Errorf("specify a subject or --manifest")
+	}
+
+	inlined, err := inlineReferences(root)
+	if err != nil {
+		return fmt.Errorf("failed to inline references of %s: %w", source, err)
+	}
+
+	if reassembleOutFile == "" {
+		fmt.Println(inlined)
+		return nil
+	}
+	if err := os.WriteFile(reassembleOutFile, []byte(inlined+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", reassembleOutFile, err)
+	}
+	output.Success("Written %s (%s, %d referenced schema(s) inlined)", reassembleOutFile, source, len(inlineOrder(root))-1)
+	return nil
+}
+
+// resolveRegistryReferences fetches everything schema references, directly
+// or transitively. A subject version referenced more than once is fetched once.
+func resolveRegistryReferences(c client.SchemaRegistryClientInterface, schema *client.Schema) (*inlineSchema, error) {
+	resolved := make(map[string]*inlineSchema)
+	var resolve func(s *client.Schema, key string) (*inlineSchema, error)
+	resolve = func(s *client.Schema, key string) (*inlineSchema, error) {
+		node := &inlineSchema{key: key, schema: s}
+		resolved[key] = node
+		for _, ref := range s.References {
+			refKey := fmt.Sprintf("%s:%d", ref.Subject, ref.Version)
+			target, ok := resolved[refKey]
+			if !ok {
+				refSchema, err := c.GetSchema(ref.Subject, strconv.Itoa(ref.Version))
+				if err != nil {
+					return nil, fmt.Errorf("failed to get reference %s (%s v%d): %w", ref.Name, ref.Subject, ref.Version, err)
+				}
+				if target, err = resolve(refSchema, refKey); err != nil {
+					return nil, err
+				}
+			}
+			node.refs = append(node.refs, inlineReference{name: ref.Name, target: target})
+		}
+		return node, nil
+	}
+	return resolve(schema, fmt.Sprintf("%s:%d", schema.Subject, schema.Version))
+}
+
+// resolveManifestReferences builds the reference tree of a split result, with
+// the reference names the parts would be registered with
+func resolveManifestReferences(result *SplitResult) (*inlineSchema, error) {
+	if len(result.Cycles) > 0 {
+		return nil, fmt.Errorf("manifest has circular references: %s", formatCycle(result.Cycles[0]))
+	}
+	schemaType := strings.ToUpper(result.SchemaType)
+	typeMap := make(map[string]*ExtractedType)
+	var rootName string
+	for i := range result.Types {
+		typeMap[result.Types[i].Name] = &result.Types[i]
+		if result.Types[i].IsRoot {
+			rootName = result.Types[i].Name
+		}
+	}
+	if rootName == "" {
+		return nil, fmt.Errorf("manifest has no root schema")
+	}
+
+	resolved := make(map[string]*inlineSchema)
+	var resolve func(name string) (*inlineSchema, error)
+	resolve = func(name string) (*inlineSchema, error) {
+		if node, ok := resolved[name]; ok {
+			return node, nil
+		}
+		t, ok := typeMap[name]
+		if !ok {
+			return nil, fmt.Errorf("manifest references %s, which is not one of its types", name)
+		}
+		node := &inlineSchema{key: name, schema: &client.Schema{Subject: t.Subject, SchemaType: schemaType, Schema: t.Schema}}
+		resolved[name] = node
+		for _, depName := range t.References {
+			target, err := resolve(depName)
+			if err != nil {
+				return nil, err
+			}
+			node.refs = append(node.refs, inlineReference{name: getReferenceName(typeMap[depName], schemaType), target: target})
+		}
+		return node, nil
+	}
+	return resolve(rootName)
+}
+
+// inlineOrder lists root and every schema it reaches, dependencies first
+func inlineOrder(root *inlineSchema) []*inlineSchema {
+	var order []*inlineSchema
+	visited := make(map[string]bool)
+	var visit func(n *inlineSchema)
+	visit = func(n *inlineSchema) {
+		if visited[n.key] {
+			return
+		}
+		visited[n.key] = true
+		for _, ref := range n.refs {
+			visit(ref.target)
+		}
+		order = append(order, n)
+	}
+	visit(root)
+	return order
+}
+
+// inlineReferences returns root's schema with all of its references inlined
+func inlineReferences(root *inlineSchema) (string, error) {
+	if len(root.refs) == 0 {
+		return root.schema.Schema, nil
+	}
+	switch strings.ToUpper(root.schema.SchemaType) {
+	case "", "AVRO":
+		return inlineAvroReferences(root)
+	case "JSON":
+		return inlineJSONReferences(root)
+	case "PROTOBUF":
+		return inlineProtobufReferences(root)
+	default:
+		return "", fmt.Errorf("unsupported schema type: %s", root.schema.SchemaType)
+	}
+}
+
+// ========================
+// Avro
+// ========================
+
+// inlineAvroReferences defines each referenced named type at its first use,
+// as Avro requires, and leaves later uses as names
+func inlineAvroReferences(root *inlineSchema) (string, error) {
+	parts := make(map[string]interface{})
+	for _, n := range inlineOrder(root) {
+		if n == root {
+			continue
+		}
+		dec := json.NewDecoder(strings.NewReader(n.schema.Schema))
+		dec.UseNumber() // keep long defaults exact
+		var parsed interface{}
+		if err := dec.Decode(&parsed); err != nil {
+			return "", fmt.Errorf("referenced schema %s is not valid JSON: %w", n.key, err)
+		}
+		named := []interface{}{parsed}
+		if union, ok := parsed.([]interface{}); ok {
+			named = union
+		}
+		for _, t := range named {
+			m, ok := t.(map[string]interface{})
+			if !ok || !isAvroNamedType(m) {
+				return "", fmt.Errorf("referenced schema %s is not a named Avro type", n.key)
+			}
+			parts[getAvroFullName(m)] = m
+		}
+	}
+
+	dec := json.NewDecoder(strings.NewReader(root.schema.Schema))
+	dec.UseNumber()
+	var schema interface{}
+	if err := dec.Decode(&schema); err != nil {
+		return "", fmt.Errorf("invalid Avro schema: %w", err)
+	}
+
+	defined := make(map[string]bool)
+	var inline func(node interface{}, ns string) interface{}
+	inline = func(node interface{}, ns string) interface{} {
+		switch n := node.(type) {
+		case string:
+			full := avroFullName(n, ns)
+			if _, ok := parts[full]; !ok && !strings.Contains(n, ".") {
+				full = n // names not found in the namespace fall back to the null namespace
+			}
+			part, ok := parts[full].(map[string]interface{})
+			if !ok || defined[full] {
+				return n
+			}
+			defined[full] = true
+			if _, hasNS := part["namespace"]; !hasNS && !strings.Contains(full, ".") && ns != "" {
+				// Keep a type from the null namespace out of the enclosing one
+				part = copyAvroNode(part, "namespace", "")
+			}
+			return inline(part, ns)
+		case []interface{}:
+			out := make([]interface{}, len(n))
+			for i, v := range n {
+				out[i] = inline(v, ns)
+			}
+			return out
+		case map[string]interface{}:
+			out := make(map[string]interface{}, len(n))
+			for k, v := range n {
+				out[k] = v
+			}
+			switch {
+			case isAvroNamedType(n):
+				if childNS, ok := n["namespace"].(string); ok {
+					ns = childNS
+				}
+				full := avroFullName(n["name"].(string), ns)
+				defined[full] = true
+				ns = ""
+				if i := strings.LastIndex(full, "."); i >= 0 {
+					ns = full[:i]
+				}
+				if fields, ok := n["fields"].([]interface{}); ok {
+					newFields := make([]interface{}, len(fields))
+					for i, f := range fields {
+						field, ok := f.(map[string]interface{})
+						if !ok {
+							newFields[i] = f
+							continue
+						}
+						newFields[i] = copyAvroNode(field, "type", inline(field["type"], ns))
+					}
+					out["fields"] = newFields
+				}
+			case n["type"] == "array":
+				out["items"] = inline(n["items"], ns)
+			case n["type"] == "map":
+				out["values"] = inline(n["values"], ns)
+			default:
+				if _, ok := n["type"].(string); !ok {
+					out["type"] = inline(n["type"], ns)
+				}
+			}
+			return out
+		}
+		return node
+	}
+
+	ns := ""
+	if m, ok := schema.(map[string]interface{}); ok {
+		ns, _ = m["namespace"].(string)
+	}
+	inlined, err := json.MarshalIndent(inline(schema, ns), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(inlined), nil
+}
+
+// isAvroNamedType reports whether m defines a record, enum or fixed
+func isAvroNamedType(m map[string]interface{}) bool {
+	switch m["type"] {
+	case "record", "enum", "fixed":
+		_, ok := m["name"].(string)
+		return ok
+	}
+	return false
+}
+
+// avroFullName resolves a type name used inside namespace ns
+func avroFullName(name, ns string) string {
+	if strings.Contains(name, ".") || ns == "" {
+		return name
+	}
+	return ns + "." + name
+}
+
+// ========================
+// JSON Schema
+// ========================
+
+// inlineJSONReferences adds each referenced schema to the root's $defs and
+// points the $refs that name it there. $refs local to a referenced schema are
+// rebased onto its new location.
+func inlineJSONReferences(root *inlineSchema) (string, error) {
+	parsed := make(map[string]interface{})
+	for _, n := range inlineOrder(root) {
+		dec := json.NewDecoder(strings.NewReader(n.schema.Schema))
+		dec.UseNumber() // keep large bounds and consts exact
+		var schema interface{}
+		if err := dec.Decode(&schema); err != nil {
+			return "", fmt.Errorf("schema %s is not valid JSON: %w", n.key, err)
+		}
+		parsed[n.key] = schema
+	}
+	rootSchema, ok := parsed[root.key].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("root schema must be a JSON object to hold definitions")
+	}
+
+	defsKey := jsonDefsKeyword(rootSchema)
+	defs, _ := rootSchema[defsKey].(map[string]interface{})
+	if defs == nil {
+		defs = make(map[string]interface{})
+	}
+
+	// Name each referenced schema after the first reference to it
+	names := make(map[string]string)
+	pointers := make(map[string]string)
+	taken := make(map[string]bool)
+	for name := range defs {
+		taken[name] = true
+	}
+	for _, n := range inlineOrder(root) {
+		for _, ref := range n.refs {
+			if _, ok := names[ref.target.key]; ok {
+				continue
+			}
+			base := jsonDefinitionName(ref.name)
+			name := base
+			for i := 2; taken[name]; i++ {
+				name = fmt.Sprintf("%s_%d", base, i)
+			}
+			taken[name] = true
+			names[ref.target.key] = name
+			pointers[ref.target.key] = "#/" + defsKey + "/" + url.PathEscape(strings.NewReplacer("~", "~0", "/", "~1").Replace(name))
+		}
+	}
+
+	var rewriteErr error
+	var rewrite func(node interface{}, n *inlineSchema) interface{}
+	rewrite = func(node interface{}, n *inlineSchema) interface{} {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			out := make(map[string]interface{}, len(v))
+			for k, child := range v {
+				out[k] = rewrite(child, n)
+			}
+			ref, ok := v["$ref"].(string)
+			if !ok {
+				return out
+			}
+			if strings.HasPrefix(ref, "#") {
+				if n != root {
+					out["$ref"] = pointers[n.key] + ref[1:]
+				}
+				return out
+			}
+			base, fragment, _ := strings.Cut(ref, "#")
+			for _, r := range n.refs {
+				if !sameJSONRef(base, r.name) {
+					continue
+				}
+				if fragment != "" && !strings.HasPrefix(fragment, "/") {
+					rewriteErr = fmt.Errorf("cannot inline $ref %q: only JSON pointer fragments are supported", ref)
+				}
+				out["$ref"] = pointers[r.target.key] + fragment
+				break
+			}
+			return out
+		case []interface{}:
+			out := make([]interface{}, len(v))
+			for i, child := range v {
+				out[i] = rewrite(child, n)
+			}
+			return out
+		default:
+			return node
+		}
+	}
+
+	for _, n := range inlineOrder(root) {
+		if n == root {
+			continue
+		}
+		def := rewrite(parsed[n.key], n)
+		if m, ok := def.(map[string]interface{}); ok {
+			// An embedded $id would change the base URI its $refs resolve against
+			delete(m, "$id")
+			delete(m, "$schema")
+		}
+		defs[names[n.key]] = def
+	}
+	inlined := rewrite(rootSchema, root).(map[string]interface{})
+	inlined[defsKey] = defs
+	if rewriteErr != nil {
+		return "", rewriteErr
+	}
+
+	data, err := json.MarshalIndent(inlined, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// jsonDefsKeyword is where definitions go: "definitions" for drafts before
+// 2019-09 and schemas that already use it, "$defs" otherwise
+func jsonDefsKeyword(schema map[string]interface{}) string {
+	if _, ok := schema["$defs"]; ok {
+		return "$defs"
+	}
+	if _, ok := schema["definitions"]; ok {
+		return "definitions"
+	}
+	draft, _ := schema["$schema"].(string)
+	for _, old := range []string{"draft-04", "draft-06", "draft-07"} {
+		if strings.Contains(draft, old) {
+			return "definitions"
+		}
+	}
+	return "$defs"
+}
+
+// jsonDefinitionName turns a reference name such as "Address.json" or
+// "https://example.com/schemas/address.json" into a definition name
+func jsonDefinitionName(refName string) string {
+	name := refName
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	name = strings.TrimSuffix(path.Base(name), ".json")
+	if name == "" || name == "." || name == "/" {
+		return "ref"
+	}
+	return name
+}
+
+// sameJSONRef reports whether a $ref (without fragment) names the reference,
+// comparing them unescaped
+func sameJSONRef(ref, name string) bool {
+	if ref == name {
+		return true
+	}
+	a, errA := url.PathUnescape(ref)
+	b, errB := url.PathUnescape(name)
+	return errA == nil && errB == nil && a == b
+}
+
+// ========================
+// Protobuf
+// ========================
+
+var protoStatementRe = regexp.MustCompile(`^(syntax|package|import|option)\b`)
+var protoImportPathRe = regexp.MustCompile(`^import\s+(?:public\s+|weak\s+)?"([^"]+)"`)
+
+// inlineProtobufReferences appends the definitions of every imported file
+// that is a reference to the root file and removes those imports. Imports
+// the referenced files need that aren't references, such as well-known
+// types, are added to the root.
+func inlineProtobufReferences(root *inlineSchema) (string, error) {
+	pkg := extractProtobufPackage(root.schema.Schema)
+	syntax := extractProtobufSyntax(root.schema.Schema)
+
+	inlined := make(map[string]bool)
+	for _, n := range inlineOrder(root) {
+		for _, ref := range n.refs {
+			inlined[ref.name] = true
+		}
+	}
+
+	var imports []string
+	seenImports := make(map[string]bool)
+	var bodies []string
+	for _, n := range inlineOrder(root) {
+		if n == root {
+			continue
+		}
+		content := n.schema.Schema
+		if p := extractProtobufPackage(content); p != pkg {
+			return "", fmt.Errorf("cannot inline %s: its package %q differs from %q", n.key, p, pkg)
+		}
+		if s := extractProtobufSyntax(content); s != syntax {
+			return "", fmt.Errorf("cannot inline %s: it uses %s, not %s", n.key, s, syntax)
+		}
+		var body []string
+		for _, line := range strings.Split(content, "\n") {
+			if !protoStatementRe.MatchString(line) {
+				body = append(body, line)
+				continue
+			}
+			if m := protoImportPathRe.FindStringSubmatch(line); m != nil && !inlined[m[1]] && !seenImports[m[1]] {
+				seenImports[m[1]] = true
+				imports = append(imports, strings.TrimSpace(line))
+			}
+		}
+		bodies = append(bodies, strings.TrimSpace(strings.Join(body, "\n")))
+	}
+
+	// Drop the root's imports of inlined files, and add the others after its
+	// last import, or its package or syntax statement
+	var lines []string
+	afterImports, afterHeader := -1, 0
+	for _, line := range strings.Split(strings.TrimRight(root.schema.Schema, "\n"), "\n") {
+		if m := protoImportPathRe.FindStringSubmatch(line); m != nil {
+			if inlined[m[1]] {
+				continue
+			}
+			seenImports[m[1]] = false
+			afterImports = len(lines) + 1
+		} else if strings.HasPrefix(line, "syntax") || strings.HasPrefix(line, "package") {
+			afterHeader = len(lines) + 1
+		}
+		lines = append(lines, line)
+	}
+	var extra []string
+	for _, imp := range imports {
+		if m := protoImportPathRe.FindStringSubmatch(imp); seenImports[m[1]] {
+			extra = append(extra, imp)
+		}
+	}
+	if len(extra) > 0 {
+		insertAt := afterImports
+		if insertAt < 0 {
+			insertAt = afterHeader
+		}
+		lines = append(lines[:insertAt], append(extra, lines[insertAt:]...)...)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Join(lines, "\n"))
+	for _, body := range bodies {
+		if body == "" {
+			continue
+		}
+		sb.WriteString("\n\n")
+		sb.WriteString(body)
+	}
+	return sb.String(), nil
+}
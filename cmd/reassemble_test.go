@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/srctl/srctl/internal/client"
+)
+
+func TestInlineAvroReferencesFromRegistry(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.AddSubject("zip", []client.Schema{{Subject: "zip", Version: 1, ID: 1,
+		Schema: `{"type":"fixed","name":"Zip","size":5}`}})
+	mock.AddSubject("address", []client.Schema{{Subject: "address", Version: 1, ID: 2,
+		Schema:     `{"type":"record","name":"Address","namespace":"com.example.types","fields":[{"name":"zip","type":"Zip"}]}`,
+		References: []client.SchemaReference{{Name: "Zip", Subject: "zip", Version: 1}}}})
+	mock.AddSubject("orders-value", []client.Schema{{Subject: "orders-value", Version: 1, ID: 3,
+		Schema:     `{"type":"record","name":"Order","namespace":"com.example","fields":[{"name":"home","type":"com.example.types.Address"},{"name":"work","type":["null","com.example.types.Address"]}]}`,
+		References: []client.SchemaReference{{Name: "com.example.types.Address", Subject: "address", Version: 1}}}})
+
+	schema, _ := mock.GetSchema("orders-value", "latest")
+	root, err := resolveRegistryReferences(mock, schema)
+	if err != nil {
+		t.Fatalf("resolveRegistryReferences: %v", err)
+	}
+	inlined, err := inlineReferences(root)
+	if err != nil {
+		t.Fatalf("inlineReferences: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(inlined), &got); err != nil {
+		t.Fatalf("inlined schema is not valid JSON: %v\n%s", err, inlined)
+	}
+	fields := got["fields"].([]interface{})
+	home := fields[0].(map[string]interface{})["type"].(map[string]interface{})
+	if home["name"] != "Address" || home["namespace"] != "com.example.types" {
+		t.Fatalf("Address should be defined at its first use, got %v", home)
+	}
+	zip := home["fields"].([]interface{})[0].(map[string]interface{})["type"].(map[string]interface{})
+	if zip["name"] != "Zip" || zip["namespace"] != "" {
+		t.Errorf("Zip should be defined in the null namespace, got %v", zip)
+	}
+	work := fields[1].(map[string]interface{})["type"].([]interface{})
+	if work[1] != "com.example.types.Address" {
+		t.Errorf("later uses should stay names, got %v", work)
+	}
+}
+
+func TestInlineReferencesRoundTrip(t *testing.T) {
+	jsonSchema := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "id": {"type": "string"},
+    "shipping": {"$ref": "#/$defs/Address"},
+    "billing": {"$ref": "#/$defs/Address"}
+  },
+  "$defs": {
+    "Address": {
+      "type": "object",
+      "properties": {"street": {"type": "string"}, "zip": {"type": "string"}}
+    }
+  }
+}`
+	result, err := splitJSONSchema(jsonSchema, 0, "")
+	if err != nil {
+		t.Fatalf("splitJSONSchema: %v", err)
+	}
+	root, err := resolveManifestReferences(result)
+	if err != nil {
+		t.Fatalf("resolveManifestReferences: %v", err)
+	}
+	inlined, err := inlineReferences(root)
+	if err != nil {
+		t.Fatalf("inlineReferences: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(inlined), &got); err != nil {
+		t.Fatalf("inlined schema is not valid JSON: %v", err)
+	}
+	props := got["properties"].(map[string]interface{})
+	for _, p := range []string{"shipping", "billing"} {
+		if ref := props[p].(map[string]interface{})["$ref"]; ref != "#/$defs/Address" {
+			t.Errorf("%s: expected $ref #/$defs/Address, got %v", p, ref)
+		}
+	}
+	address := got["$defs"].(map[string]interface{})["Address"].(map[string]interface{})
+	if _, ok := address["$id"]; ok || address["type"] != "object" {
+		t.Errorf("unexpected Address definition: %v", address)
+	}
+	if refs := schemaExpectedReferences(inlined, "JSON"); len(refs) != 0 {
+		t.Errorf("inlined schema still has external $refs: %v", refs)
+	}
+
+	protoSchema := `syntax = "proto3";
+package com.example;
+
+import "google/protobuf/timestamp.proto";
+
+message Order {
+  string id = 1;
+  Customer customer = 2;
+}
+
+message Customer {
+  string name = 1;
+  google.protobuf.Timestamp since = 2;
+}
+`
+	result, err = splitProtobufSchema(protoSchema, "order.proto", 0, "")
+	if err != nil {
+		t.Fatalf("splitProtobufSchema: %v", err)
+	}
+	if root, err = resolveManifestReferences(result); err != nil {
+		t.Fatalf("resolveManifestReferences: %v", err)
+	}
+	if inlined, err = inlineReferences(root); err != nil {
+		t.Fatalf("inlineReferences: %v", err)
+	}
+	if strings.Count(inlined, `import "google/protobuf/timestamp.proto";`) != 1 {
+		t.Errorf("expected the well-known import once:\n%s", inlined)
+	}
+	for _, want := range []string{"message Order {", "message Customer {"} {
+		if !strings.Contains(inlined, want) {
+			t.Errorf("expected %q in:\n%s", want, inlined)
+		}
+	}
+	if strings.Contains(inlined, `import "customer.proto"`) || strings.Count(inlined, "package com.example;") != 1 {
+		t.Errorf("inlined imports and packages should be dropped:\n%s", inlined)
+	}
+}
+
+func TestInlineProtobufPackageMismatch(t *testing.T) {
+	root := &inlineSchema{key: "orders-value:1", schema: &client.Schema{SchemaType: "PROTOBUF",
+		Schema: "syntax = \"proto3\";\npackage orders;\nimport \"money.proto\";\nmessage Order { billing.Money total = 1; }\n"}}
+	root.refs = []inlineReference{{name: "money.proto", target: &inlineSchema{key: "money:1", schema: &client.Schema{SchemaType: "PROTOBUF",
+		Schema: "syntax = \"proto3\";\npackage billing;\nmessage Money { int64 units = 1; }\n"}}}}
+
+	if _, err := inlineReferences(root); err == nil || !strings.Contains(err.Error(), "package") {
+		t.Errorf("expected a package mismatch error, got %v", err)
+	}
+}
+
+func TestInlineReferencesKeepsLargeNumbersExact(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.AddSubject("counter", []client.Schema{{Subject: "counter", Version: 1, ID: 1,
+		Schema: `{"type":"record","name":"Counter","namespace":"com.example","fields":[{"name":"value","type":"long","default":9007199254740993}]}`}})
+	mock.AddSubject("totals-value", []client.Schema{{Subject: "totals-value", Version: 1, ID: 2,
+		Schema:     `{"type":"record","name":"Totals","namespace":"com.example","fields":[{"name":"count","type":"Counter"},{"name":"max","type":"long","default":9223372036854775807}]}`,
+		References: []client.SchemaReference{{Name: "com.example.Counter", Subject: "counter", Version: 1}}}})
+	mock.AddSubject("limit", []client.Schema{{Subject: "limit", Version: 1, ID: 3, SchemaType: "JSON",
+		Schema: `{"type":"integer","maximum":9007199254740993}`}})
+	mock.AddSubject("quota-value", []client.Schema{{Subject: "quota-value", Version: 1, ID: 4, SchemaType: "JSON",
+		Schema:     `{"type":"object","properties":{"limit":{"$ref":"limit.json"}}}`,
+		References: []client.SchemaReference{{Name: "limit.json", Subject: "limit", Version: 1}}}})
+
+	for _, tc := range []struct {
+		subject string
+		want    []string
+	}{
+		{"totals-value", []string{`"default": 9007199254740993`, `"default": 9223372036854775807`}},
+		{"quota-value", []string{`"maximum": 9007199254740993`}},
+	} {
+		schema, _ := mock.GetSchema(tc.subject, "latest")
+		root, err := resolveRegistryReferences(mock, schema)
+		if err != nil {
+			t.Fatalf("%s: resolveRegistryReferences: %v", tc.subject, err)
+		}
+		inlined, err := inlineReferences(root)
+		if err != nil {
+			t.Fatalf("%s: inlineReferences: %v", tc.subject, err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(inlined, want) {
+				t.Errorf("%s: expected %s in the inlined schema, got\n%s", tc.subject, want, inlined)
+			}
+		}
+	}
+}
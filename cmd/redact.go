@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Keywords whose value maps names to subschemas in a JSON Schema; their keys
+// are property names, not keywords, so a property called "description" stays
+var jsonSchemaNameMaps = map[string]bool{
+	"properties":        true,
+	"patternProperties": true,
+	"definitions":       true,
+	"$defs":             true,
+	"dependentSchemas":  true,
+}
+
+// Keywords holding instance data rather than schemas
+var jsonSchemaDataKeywords = map[string]bool{
+	"default": true,
+	"const":   true,
+	"enum":    true,
+}
+
+// redactSchemaDocs strips documentation from a schema while keeping its
+// structure: Avro doc attributes, and JSON Schema description and examples
+// keywords. Protobuf schemas, and schemas that don't parse, are returned
+// unchanged. The result is re-serialized, so it is not byte-identical to the
+// input even when nothing was removed.
+func redactSchemaDocs(schemaType, schema string) string {
+	var strip func(interface{}) interface{}
+	switch strings.ToUpper(schemaType) {
+	case "", "AVRO":
+		strip = redactAvroDocs
+	case "JSON":
+		strip = redactJSONSchemaDocs
+	default:
+		return schema
+	}
+
+	dec := json.NewDecoder(strings.NewReader(schema))
+	dec.UseNumber() // keep long defaults exact
+	var parsed interface{}
+	if err := dec.Decode(&parsed); err != nil {
+		return schema
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(strip(parsed)); err != nil {
+		return schema
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// redactAvroDocs removes "doc" from every record, field, enum and fixed,
+// leaving default values alone
+func redactAvroDocs(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		delete(n, "doc")
+		for k, v := range n {
+			if k != "default" {
+				n[k] = redactAvroDocs(v)
+			}
+		}
+	case []interface{}:
+		for i, v := range n {
+			n[i] = redactAvroDocs(v)
+		}
+	}
+	return node
+}
+
+// redactJSONSchemaDocs removes "description" and "examples" from every
+// subschema, leaving data keywords such as default and enum alone
+func redactJSONSchemaDocs(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		delete(n, "description")
+		delete(n, "examples")
+		for k, v := range n {
+			switch {
+			case jsonSchemaDataKeywords[k]:
+			case jsonSchemaNameMaps[k]:
+				if named, ok := v.(map[string]interface{}); ok {
+					for name, sub := range named {
+						named[name] = redactJSONSchemaDocs(sub)
+					}
+				}
+			default:
+				n[k] = redactJSONSchemaDocs(v)
+			}
+		}
+	case []interface{}:
+		for i, v := range n {
+			n[i] = redactJSONSchemaDocs(v)
+		}
+	}
+	return node
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRedactSchemaDocs(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		schema     string
+		want       string
+	}{
+		{
+			name:       "avro record and fields",
+			schemaType: "AVRO",
+			schema: `{"type":"record","name":"User","doc":"PII: customer record","fields":[
+				{"name":"ssn","type":"string","doc":"e.g. 123-45-6789"},
+				{"name":"doc","type":{"type":"enum","name":"Kind","doc":"kinds","symbols":["A"]}},
+				{"name":"meta","type":{"type":"map","values":"string"},"default":{"doc":"kept"}},
+				{"name":"big","type":"long","default":9007199254740993}]}`,
+			want: `{"type":"record","name":"User","fields":[
+				{"name":"ssn","type":"string"},
+				{"name":"doc","type":{"type":"enum","name":"Kind","symbols":["A"]}},
+				{"name":"meta","type":{"type":"map","values":"string"},"default":{"doc":"kept"}},
+				{"name":"big","type":"long","default":9007199254740993}]}`,
+		},
+		{
+			name:       "default type is avro",
+			schemaType: "",
+			schema:     `["null",{"type":"fixed","name":"Hash","size":16,"doc":"md5"}]`,
+			want:       `["null",{"type":"fixed","name":"Hash","size":16}]`,
+		},
+		{
+			name:       "json schema",
+			schemaType: "JSON",
+			schema: `{"type":"object","description":"an order","examples":[{"id":"A-1"}],
+				"properties":{"description":{"type":"string","description":"free text"},
+				"status":{"enum":[{"description":"kept"}],"default":"NEW"}},
+				"$defs":{"money":{"type":"number","description":"amount"}}}`,
+			want: `{"type":"object",
+				"properties":{"description":{"type":"string"},
+				"status":{"enum":[{"description":"kept"}],"default":"NEW"}},
+				"$defs":{"money":{"type":"number"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactSchemaDocs(tt.schemaType, tt.schema)
+			if !jsonEqual(t, got, tt.want) {
+				t.Errorf("redactSchemaDocs() = %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("big long default stays exact", func(t *testing.T) {
+		got := redactSchemaDocs("AVRO", `{"type":"long","default":9007199254740993}`)
+		if got != `{"default":9007199254740993,"type":"long"}` {
+			t.Errorf("redactSchemaDocs() = %s", got)
+		}
+	})
+
+	for _, unchanged := range []struct{ schemaType, schema string }{
+		{"PROTOBUF", `syntax = "proto3"; // doc comment
+message A { string id = 1; }`},
+		{"AVRO", `not json`},
+	} {
+		if got := redactSchemaDocs(unchanged.schemaType, unchanged.schema); got != unchanged.schema {
+			t.Errorf("redactSchemaDocs(%s) changed the schema: %s", unchanged.schemaType, got)
+		}
+	}
+}
+
+func jsonEqual(t *testing.T, a, b string) bool {
+	t.Helper()
+	var va, vb interface{}
+	if err := json.Unmarshal([]byte(a), &va); err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+	if err := json.Unmarshal([]byte(b), &vb); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	return reflect.DeepEqual(va, vb)
+}
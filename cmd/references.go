@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var referencesRecursive bool
+
+var referencesCmd = &cobra.Command{
+	Use:     "references <subject> [version]",
+	Short:   "Show which schemas reference a subject version",
+	GroupID: groupSchema,
+	Long: `Show the schemas that reference a subject version, resolved to their
+subjects and versions. Use this to see the blast radius of a delete before
+running it.
+
+The version defaults to the latest. With --recursive, schemas that reference
+the referencing schemas are included too, with their distance from the
+original subject.
+
+Examples:
+  # Who references the latest version of a subject
+  srctl references address-value
+
+  # A specific version
+  srctl references address-value 2
+
+  # Everything that depends on it, directly or transitively
+  srctl references address-value --recursive
+
+  # Output as JSON
+  srctl references address-value -o json`,
+	Args:        cobra.RangeArgs(1, 2),
+	Annotations: readOnlyAnnotations,
+	RunE:        runReferences,
+}
+
+func init() {
+	referencesCmd.Flags().BoolVarP(&referencesRecursive, "recursive", "r", false, "Include schemas that reference the referencing schemas")
+
+	rootCmd.AddCommand(referencesCmd)
+}
+
+// ReferencingSchema is a subject version that references another schema
+type ReferencingSchema struct {
+	ID      int    `json:"id"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+	Depth   int    `json:"depth"`
+}
+
+func runReferences(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	subject := args[0]
+	version := "latest"
+	if len(args) > 1 {
+		version = args[1]
+	}
+	schema, err := c.GetSchema(subject, version)
+	if err != nil {
+		return fmt.Errorf("failed to get schema: %w", err)
+	}
+
+	refs, err := findReferencingSchemas(c, subject, schema.Version, referencesRecursive)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat != "table" {
+		return output.NewPrinter(outputFormat).Print(map[string]interface{}{
+			"subject":      subject,
+			"version":      schema.Version,
+			"id":           schema.ID,
+			"referencedBy": refs,
+		})
+	}
+
+	output.Header("References to %s v%d", subject, schema.Version)
+	if len(refs) == 0 {
+		output.Success("No schemas reference %s v%d", subject, schema.Version)
+		return nil
+	}
+
+	headers := []string{"Schema ID", "Subject", "Version"}
+	if referencesRecursive {
+		headers = append(headers, "Depth")
+	}
+	var rows [][]string
+	for _, r := range refs {
+		row := []string{strconv.Itoa(r.ID), r.Subject, strconv.Itoa(r.Version)}
+		if referencesRecursive {
+			row = append(row, strconv.Itoa(r.Depth))
+		}
+		rows = append(rows, row)
+	}
+	output.PrintTable(headers, rows)
+	fmt.Printf("\nTotal: %d referencing schema version(s)\n", len(refs))
+	return nil
+}
+
+// findReferencingSchemas resolves the IDs from the referencedby endpoint to
+// subject versions. Depth 1 references the given version directly; with
+// recursive, each referencing version is searched in turn.
+func findReferencingSchemas(c client.SchemaRegistryClientInterface, subject string, version int, recursive bool) ([]ReferencingSchema, error) {
+	type target struct {
+		subject string
+		version int
+		depth   int
+	}
+
+	var result []ReferencingSchema
+	visited := map[string]bool{fmt.Sprintf("%s:%d", subject, version): true}
+	queue := []target{{subject, version, 1}}
+
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+
+		ids, err := c.GetSchemaReferencedBy(t.subject, t.version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get references to %s v%d: %w", t.subject, t.version, err)
+		}
+
+		for _, id := range ids {
+			subjectVersions, err := c.GetSchemaSubjectVersionsByID(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve schema ID %d: %w", id, err)
+			}
+			for _, sv := range subjectVersions {
+				key := fmt.Sprintf("%s:%d", sv.Subject, sv.Version)
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+				result = append(result, ReferencingSchema{ID: id, Subject: sv.Subject, Version: sv.Version, Depth: t.depth})
+				if recursive {
+					queue = append(queue, target{sv.Subject, sv.Version, t.depth + 1})
+				}
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Depth != result[j].Depth {
+			return result[i].Depth < result[j].Depth
+		}
+		if result[i].Subject != result[j].Subject {
+			return result[i].Subject < result[j].Subject
+		}
+		return result[i].Version < result[j].Version
+	})
+	return result, nil
+}
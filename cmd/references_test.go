@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/srctl/srctl/internal/client"
+)
+
+func TestFindReferencingSchemas(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.AddSubject("address", []client.Schema{{Subject: "address", Version: 1, ID: 1, Schema: `"string"`}})
+	mock.AddSubject("customer", []client.Schema{
+		{Subject: "customer", Version: 1, ID: 2, References: []client.SchemaReference{{Name: "Address", Subject: "address", Version: 1}}},
+	})
+	mock.AddSubject("order", []client.Schema{
+		{Subject: "order", Version: 1, ID: 3, References: []client.SchemaReference{{Name: "Customer", Subject: "customer", Version: 1}}},
+		{Subject: "order", Version: 2, ID: 4, References: []client.SchemaReference{{Name: "Address", Subject: "address", Version: 1}}},
+	})
+
+	direct, err := findReferencingSchemas(mock, "address", 1, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(direct) != 2 || direct[0].Subject != "customer" || direct[1].Subject != "order" || direct[1].Version != 2 {
+		t.Errorf("unexpected direct references: %+v", direct)
+	}
+
+	all, err := findReferencingSchemas(mock, "address", 1, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected 3 references, got %+v", all)
+	}
+	if last := all[2]; last.Subject != "order" || last.Version != 1 || last.Depth != 2 || last.ID != 3 {
+		t.Errorf("expected order v1 at depth 2, got %+v", last)
+	}
+
+	none, err := findReferencingSchemas(mock, "order", 2, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("expected no references, got %+v", none)
+	}
+}
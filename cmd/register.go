@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -18,6 +19,8 @@ var (
 	registerReferences []string
 	registerDryRun     bool
 	registerNormalize  bool
+	registerRefsJSON   string
+	registerCheck      bool
 )
 
 var registerCmd = &cobra.Command{
@@ -42,6 +45,14 @@ Examples:
   # Register in specific context
   srctl register user-events --file ./schemas/user.avsc --context .mycontext
 
+  # References as a JSON array, inline or from a file
+  srctl register user-events --file ./schemas/user.avsc \
+    --references '[{"name":"common.Address","subject":"address-value","version":1}]'
+  srctl register user-events --file ./schemas/user.avsc --references refs.json
+
+  # Check compatibility first and only register if compatible
+  srctl register user-events --file ./schemas/user.avsc --check-compat
+
   # Dry run - check compatibility without registering
   srctl register user-events --file ./schemas/user.avsc --dry-run
 
@@ -55,8 +66,10 @@ func init() {
 	registerCmd.Flags().StringVarP(&registerFile, "file", "f", "", "Path to schema file")
 	registerCmd.Flags().StringVarP(&registerSchemaType, "type", "t", "", "Schema type: AVRO, PROTOBUF, JSON")
 	registerCmd.Flags().StringArrayVar(&registerReferences, "ref", nil, "Schema references (format: name=subject:version)")
+	registerCmd.Flags().StringVar(&registerRefsJSON, "references", "", "Schema references as a JSON array, inline or a path to a JSON file")
+	registerCmd.Flags().BoolVar(&registerCheck, "check-compat", false, "Check compatibility with the latest version before registering")
 	registerCmd.Flags().BoolVar(&registerDryRun, "dry-run", false, "Check compatibility without registering")
-	registerCmd.Flags().BoolVar(&registerNormalize, "normalize", false, "Normalize schema before registering")
+	registerCmd.Flags().BoolVar(&registerNormalize, "normalize", false, "Normalize schema before registering (locally for Avro and on the registry)")
 
 	rootCmd.AddCommand(registerCmd)
 }
@@ -98,6 +111,13 @@ func runRegister(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("invalid reference format: %w", err)
 	}
+	if registerRefsJSON != "" {
+		jsonRefs, err := loadReferences(registerRefsJSON)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, jsonRefs...)
+	}
 
 	// Normalize if requested
 	if registerNormalize && schemaType == "AVRO" {
@@ -118,6 +138,7 @@ func runRegister(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	c.Normalize = registerNormalize
 
 	// Dry run - just check compatibility
 	if registerDryRun {
@@ -125,27 +146,14 @@ func runRegister(cmd *cobra.Command, args []string) error {
 		output.Info("Subject: %s", subject)
 		output.Info("Type: %s", schemaType)
 
-		// Check if subject exists
-		versions, err := c.GetVersions(subject, false)
-		if err != nil || len(versions) == 0 {
-			output.Success("Subject does not exist - schema will be registered as version 1")
-			return nil
-		}
-
-		// Check compatibility
-		compatible, err := c.CheckCompatibility(subject, schema, "latest")
-		if err != nil {
-			return fmt.Errorf("compatibility check failed: %w", err)
-		}
+		return checkRegisterCompatibility(c, subject, schema)
+	}
 
-		if compatible {
-			output.Success("Schema is compatible with latest version")
-		} else {
-			output.Error("Schema is NOT compatible with latest version")
-			return fmt.Errorf("schema is not compatible")
+	if registerCheck {
+		output.Step("Checking compatibility for subject: %s", subject)
+		if err := checkRegisterCompatibility(c, subject, schema); err != nil {
+			return err
 		}
-
-		return nil
 	}
 
 	// Actually register the schema
@@ -158,6 +166,9 @@ func runRegister(cmd *cobra.Command, args []string) error {
 	}
 
 	id, err := c.RegisterSchema(subject, schema)
+	if client.IsIncompatible(err) {
+		return fmt.Errorf("schema is incompatible with existing versions of %s (check with --dry-run): %w", subject, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to register schema: %w", err)
 	}
@@ -183,6 +194,29 @@ func runRegister(cmd *cobra.Command, args []string) error {
 	})
 }
 
+// checkRegisterCompatibility checks a schema against the subject's latest version
+func checkRegisterCompatibility(c client.SchemaRegistryClientInterface, subject string, schema *client.Schema) error {
+	// Check if subject exists
+	versions, err := c.GetVersions(subject, false)
+	if err != nil || len(versions) == 0 {
+		output.Success("Subject does not exist - schema will be registered as version 1")
+		return nil
+	}
+
+	// Check compatibility
+	compatible, err := c.CheckCompatibility(subject, schema, "latest")
+	if err != nil {
+		return fmt.Errorf("compatibility check failed: %w", err)
+	}
+
+	if !compatible {
+		output.Error("Schema is NOT compatible with latest version")
+		return fmt.Errorf("schema is not compatible")
+	}
+	output.Success("Schema is compatible with latest version")
+	return nil
+}
+
 func detectSchemaType(content, filename string) string {
 	// Try to detect from file extension
 	if filename != "" {
@@ -194,8 +228,8 @@ func detectSchemaType(content, filename string) string {
 			return "PROTOBUF"
 		case ".json":
 			// Could be AVRO or JSON Schema, try to detect
-			if strings.Contains(content, `"$schema"`) || strings.Contains(content, `"$ref"`) {
-				return "JSON"
+			if schemaType, ok := jsonDocumentSchemaType(content); ok {
+				return schemaType
 			}
 			return "AVRO"
 		}
@@ -203,6 +237,9 @@ func detectSchemaType(content, filename string) string {
 
 	// Try to detect from content
 	content = strings.TrimSpace(content)
+	if schemaType, ok := jsonDocumentSchemaType(content); ok {
+		return schemaType
+	}
 
 	// Protobuf detection
 	if strings.HasPrefix(content, "syntax") || strings.Contains(content, "message ") {
@@ -218,6 +255,67 @@ func detectSchemaType(content, filename string) string {
 	return "AVRO"
 }
 
+// jsonSchemaKeywords are top-level keywords only JSON Schema uses
+var jsonSchemaKeywords = []string{
+	"$id", "$ref", "$defs", "definitions", "properties", "patternProperties",
+	"additionalProperties", "required", "allOf", "anyOf", "oneOf", "not", "const",
+	"enum", "minimum", "maximum", "minLength", "maxLength", "pattern", "format",
+	"title", "description",
+}
+
+// jsonDocumentSchemaType tells an Avro schema from a JSON Schema by parsing
+// it and looking at its top-level keywords, so text in docs and descriptions
+// doesn't count. ok is false when content isn't JSON.
+func jsonDocumentSchemaType(content string) (schemaType string, ok bool) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(content), &doc); err != nil {
+		return "", false
+	}
+	return jsonValueSchemaType(doc), true
+}
+
+func jsonValueSchemaType(doc interface{}) string {
+	switch d := doc.(type) {
+	case bool:
+		return "JSON" // true and false are JSON Schemas
+	case map[string]interface{}:
+		if _, ok := d["$schema"]; ok {
+			return "JSON"
+		}
+		switch t := d["type"].(type) {
+		case string:
+			switch t {
+			case "record", "enum", "fixed", "map", "int", "long", "float", "double", "bytes":
+				return "AVRO"
+			case "object", "integer", "number":
+				return "JSON"
+			case "array":
+				// Avro items may be a type name; JSON Schema items are schemas
+				if items, ok := d["items"].(map[string]interface{}); ok {
+					return jsonValueSchemaType(items)
+				}
+				if _, ok := d["items"].(bool); ok {
+					return "JSON"
+				}
+			}
+		case []interface{}:
+			return "JSON" // Avro unions are bare arrays, never a "type" list
+		case map[string]interface{}:
+			return "AVRO"
+		}
+		if _, ok := d["fields"]; ok {
+			return "AVRO"
+		}
+		for _, k := range jsonSchemaKeywords {
+			if _, ok := d[k]; ok {
+				return "JSON"
+			}
+		}
+	}
+	// A type name, a union, or a primitive such as {"type": "string"}
+	return "AVRO"
+}
+
 func parseReferences(refs []string) ([]client.SchemaReference, error) {
 	var result []client.SchemaReference
 
@@ -257,18 +355,46 @@ func parseReferences(refs []string) ([]client.SchemaReference, error) {
 	return result, nil
 }
 
+// loadReferences parses a JSON array of references given inline or as a file path
+func loadReferences(value string) ([]client.SchemaReference, error) {
+	data := []byte(strings.TrimSpace(value))
+	if !strings.HasPrefix(string(data), "[") {
+		content, err := os.ReadFile(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read references file: %w", err)
+		}
+		data = content
+	}
+
+	var refs []client.SchemaReference
+	if err := json.Unmarshal(data, &refs); err != nil {
+		return nil, fmt.Errorf("failed to parse references: %w", err)
+	}
+	for _, ref := range refs {
+		if ref.Name == "" || ref.Subject == "" || ref.Version <= 0 {
+			return nil, fmt.Errorf("invalid reference %+v: name, subject and version are required", ref)
+		}
+	}
+	return refs, nil
+}
+
+// normalizeAvroSchema re-encodes a JSON-based schema (Avro or JSON Schema)
+// with sorted keys and no insignificant whitespace
 func normalizeAvroSchema(content string) (string, error) {
+	dec := json.NewDecoder(strings.NewReader(content))
+	dec.UseNumber() // keep large defaults exact
 	var schema interface{}
-	if err := json.Unmarshal([]byte(content), &schema); err != nil {
+	if err := dec.Decode(&schema); err != nil {
 		return content, err
 	}
 
 	// Re-marshal with consistent formatting
-	normalized, err := json.Marshal(schema)
-	if err != nil {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(schema); err != nil {
 		return content, err
 	}
 
-	return string(normalized), nil
+	return strings.TrimSuffix(buf.String(), "\n"), nil
 }
-
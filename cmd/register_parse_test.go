@@ -139,6 +139,48 @@ func TestDetectSchemaType(t *testing.T) {
 			filename: "",
 			want:     "AVRO",
 		},
+		{
+			name:     "avro mentioning $schema in a doc",
+			content:  `{"type":"record","name":"Test","doc":"Mirrors the \"$schema\" of the source","fields":[{"name":"message ","type":"string"}]}`,
+			filename: "test.json",
+			want:     "AVRO",
+		},
+		{
+			name:     "json schema without $schema",
+			content:  `{"type":"object","properties":{"id":{"type":"integer"}},"required":["id"]}`,
+			filename: "test.json",
+			want:     "JSON",
+		},
+		{
+			name:     "json schema array of objects",
+			content:  `{"type":"array","items":{"type":"object","properties":{"id":{"type":"string"}}}}`,
+			filename: "test.json",
+			want:     "JSON",
+		},
+		{
+			name:     "avro array of records",
+			content:  `{"type":"array","items":{"type":"record","name":"Line","fields":[]}}`,
+			filename: "test.json",
+			want:     "AVRO",
+		},
+		{
+			name:     "json schema with a type list",
+			content:  `{"type":["string","null"]}`,
+			filename: "test.json",
+			want:     "JSON",
+		},
+		{
+			name:     "avro union",
+			content:  `["null","string"]`,
+			filename: "test.json",
+			want:     "AVRO",
+		},
+		{
+			name:     "avro record with protobuf-like doc, no extension",
+			content:  `{"type":"record","name":"Test","doc":"syntax of the message ","fields":[]}`,
+			filename: "",
+			want:     "AVRO",
+		},
 	}
 
 	for _, tt := range tests {
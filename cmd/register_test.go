@@ -210,3 +210,56 @@ func TestReadSchemaFromFile(t *testing.T) {
 		t.Errorf("schema content mismatch")
 	}
 }
+
+func TestLoadReferences(t *testing.T) {
+	inline := `[{"name":"common.Address","subject":"address-value","version":1}]`
+	refs, err := loadReferences(inline)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(refs) != 1 || refs[0].Subject != "address-value" || refs[0].Version != 1 {
+		t.Errorf("unexpected references: %+v", refs)
+	}
+
+	dir, cleanup := createTempDir()
+	defer cleanup()
+	path := dir + "/refs.json"
+	os.WriteFile(path, []byte(`[
+  {"name": "a", "subject": "a-value", "version": 2},
+  {"name": "b", "subject": "b-value", "version": 3}
+]`), 0644)
+	refs, err = loadReferences(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(refs) != 2 || refs[1].Name != "b" {
+		t.Errorf("unexpected references from file: %+v", refs)
+	}
+
+	for _, bad := range []string{`[{"name":"a","subject":"a-value"}]`, `[not json`, dir + "/missing.json"} {
+		if _, err := loadReferences(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestCheckRegisterCompatibility(t *testing.T) {
+	mock := client.NewMockClient()
+	schema := &client.Schema{SchemaType: "AVRO", Schema: `{"type":"string"}`}
+
+	// New subjects skip the check
+	if err := checkRegisterCompatibility(mock, "new-subject", schema); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.GetCallCount("CheckCompatibility") != 0 {
+		t.Error("expected no compatibility check for a new subject")
+	}
+
+	addTestSubject(mock, "user-events", 1)
+	if err := checkRegisterCompatibility(mock, "user-events", schema); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.GetCallCount("CheckCompatibility") != 1 {
+		t.Error("expected compatibility check against the latest version")
+	}
+}
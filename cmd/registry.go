@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srctl/srctl/internal/config"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var registryCmd = &cobra.Command{
+	Use:     "registry",
+	Aliases: []string{"registries"},
+	Short:   "Manage named registries in the config file",
+	GroupID: groupConfig,
+	Long: `Add, list, remove and select the named registries in srctl.yaml.
+
+A named registry stores a URL, credentials and an optional default context, so
+any command can reach it with --registry <name> (or -r <name>) instead of
+repeating connection flags. Commands without --registry or --url use the
+default registry. 'srctl clone', 'compare' and 'replicate' take registry names
+for --source and --target.
+
+'registry add' reads the same connection flags every command accepts (--url,
+--username, --password, --context, --auth-mode, --oauth-*, --client-cert,
+--client-key, --ca-cert, --insecure, --proxy, --timeout) and saves them under the given name.
+
+Changes are written to the config file that was loaded, or to
+~/.srctl/srctl.yaml when there is none. The file is written with 0600
+permissions since it may contain secrets.
+
+Examples:
+  # Register dev and prod once
+  srctl registry add dev --url http://localhost:8081 --default
+  srctl registry add prod --url https://psrc-xxxxx.confluent.cloud \
+    --username API_KEY --password API_SECRET --context .prod
+
+  # Use them by name
+  srctl list --registry prod
+  srctl clone --source dev --target prod
+
+  # Switch the default registry
+  srctl registry use prod
+
+  # List and remove
+  srctl registry list
+  srctl registry remove dev`,
+}
+
+var registryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the registries in the config file",
+	Args:  cobra.NoArgs,
+	RunE:  runRegistryList,
+}
+
+var registryAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a named registry to the config file",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRegistryAdd,
+}
+
+var registryRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a named registry from the config file",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runRegistryRemove,
+}
+
+var registryUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a named registry the default",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRegistryUse,
+}
+
+var registryAddDefault bool
+
+func init() {
+	registryAddCmd.Flags().BoolVar(&registryAddDefault, "default", false, "Make this the default registry")
+
+	registryCmd.AddCommand(registryListCmd, registryAddCmd, registryRemoveCmd, registryUseCmd)
+	rootCmd.AddCommand(registryCmd)
+}
+
+// RegistryInfo is a configured registry without its secrets
+type RegistryInfo struct {
+	Name     string `json:"name" yaml:"name"`
+	URL      string `json:"url" yaml:"url"`
+	Auth     string `json:"auth" yaml:"auth"`
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+	Context  string `json:"context,omitempty" yaml:"context,omitempty"`
+	Default  bool   `json:"default" yaml:"default"`
+}
+
+func runRegistryList(cmd *cobra.Command, args []string) error {
+	infos := registryInfos(config.AppConfig.Registries)
+
+	if outputFormat == "table" {
+		if profile := config.ActiveProfile(); profile != "" {
+			output.Header("Configured Registries (profile: %s)", profile)
+		} else {
+			output.Header("Configured Registries")
+		}
+		if len(infos) == 0 {
+			output.Info("No registries configured; add one with 'srctl registry add <name> --url <url>'")
+			return nil
+		}
+		rows := make([][]string, len(infos))
+		for i, r := range infos {
+			def := ""
+			if r.Default {
+				def = "*"
+			}
+			rows[i] = []string{def, r.Name, r.URL, r.Auth, r.Context}
+		}
+		output.PrintTable([]string{"Default", "Name", "URL", "Auth", "Context"}, rows)
+		return nil
+	}
+
+	return output.NewPrinter(outputFormat).Print(infos)
+}
+
+func runRegistryAdd(cmd *cobra.Command, args []string) error {
+	reg, err := registryFromFlags(args[0])
+	if err != nil {
+		return err
+	}
+	// The first registry is the default either way; mark it so the file says so
+	reg.Default = registryAddDefault || len(config.AppConfig.Registries) == 0
+
+	if err := config.AddRegistry(reg); err != nil {
+		return err
+	}
+	path, err := saveRegistryConfig()
+	if err != nil {
+		return err
+	}
+	output.Success("Added registry %s (%s) to %s", reg.Name, reg.URL, path)
+	if reg.Default {
+		output.Info("%s is the default registry", reg.Name)
+	}
+	return nil
+}
+
+func runRegistryRemove(cmd *cobra.Command, args []string) error {
+	wasDefault := false
+	if r := config.GetRegistry(args[0]); r != nil {
+		wasDefault = r.Default
+	}
+	if err := config.RemoveRegistry(args[0]); err != nil {
+		return err
+	}
+	path, err := saveRegistryConfig()
+	if err != nil {
+		return err
+	}
+	output.Success("Removed registry %s from %s", args[0], path)
+	if wasDefault {
+		if r := config.GetDefaultRegistry(); r != nil {
+			output.Warning("%s was the default registry; '%s' is used until you run 'srctl registry use <name>'", args[0], r.Name)
+		}
+	}
+	return nil
+}
+
+func runRegistryUse(cmd *cobra.Command, args []string) error {
+	if err := config.SetDefaultRegistry(args[0]); err != nil {
+		return err
+	}
+	if _, err := saveRegistryConfig(); err != nil {
+		return err
+	}
+	output.Success("Default registry is now %s", args[0])
+	return nil
+}
+
+// registryFromFlags builds a registry entry from the global connection flags
+func registryFromFlags(name string) (config.Registry, error) {
+	if strings.TrimSpace(name) == "" {
+		return config.Registry{}, fmt.Errorf("registry name is required")
+	}
+	if registryURL == "" {
+		return config.Registry{}, fmt.Errorf("--url is required")
+	}
+
+	reg := config.Registry{
+		Name:     name,
+		URL:      strings.TrimRight(registryURL, "/"),
+		AuthMode: authMode,
+		Username: username,
+		Password: password,
+		OAuth: config.OAuthConfig{
+			TokenURL:     oauthTokenURL,
+			ClientID:     oauthClientID,
+			ClientSecret: oauthClientSecret,
+			Scope:        oauthScope,
+		},
+		TLS: config.RegistryTLSConfig{
+			CertFile: clientCertFile,
+			KeyFile:  clientKeyFile,
+			CAFile:   caCertFile,
+
+			InsecureSkipVerify: insecureTLS,
+		},
+		Timeout: requestTimeout,
+		Proxy:   proxyURL,
+		Context: srContext,
+	}
+	// Validate the auth settings the same way a client would be built
+	if _, err := buildAuthConfig(&reg); err != nil {
+		return config.Registry{}, err
+	}
+	return reg, nil
+}
+
+// registryInfos describes each registry, marking the one GetClient would use
+// when neither --registry nor --url is given
+func registryInfos(registries []config.Registry) []RegistryInfo {
+	defaultName := ""
+	if r := config.GetDefaultRegistry(); r != nil {
+		defaultName = r.Name
+	}
+	infos := make([]RegistryInfo, len(registries))
+	for i, r := range registries {
+		infos[i] = RegistryInfo{
+			Name:     r.Name,
+			URL:      r.URL,
+			Auth:     registryAuth(r),
+			Username: r.Username,
+			Context:  r.Context,
+			Default:  r.Name == defaultName,
+		}
+	}
+	return infos
+}
+
+// registryAuth names the authentication a registry uses, e.g. "basic+mtls"
+func registryAuth(r config.Registry) string {
+	auth := "none"
+	switch {
+	case strings.EqualFold(r.AuthMode, "oauth"):
+		auth = "oauth"
+	case r.Username != "":
+		auth = "basic"
+	}
+	if r.TLS.CertFile != "" {
+		if auth == "none" {
+			return "mtls"
+		}
+		auth += "+mtls"
+	}
+	return auth
+}
+
+// saveRegistryConfig writes the config file, filling in the defaults LoadConfig
+// leaves unset when no file existed yet, and returns its path
+func saveRegistryConfig() (string, error) {
+	if config.AppConfig.DefaultOutput == "" {
+		config.AppConfig.DefaultOutput = "table"
+	}
+	if config.AppConfig.DefaultContext == "" {
+		config.AppConfig.DefaultContext = "."
+	}
+	if err := config.SaveConfig(); err != nil {
+		return "", err
+	}
+	return config.ConfigFilePath()
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/srctl/srctl/internal/config"
+)
+
+func TestRegistryAddRemoveUse(t *testing.T) {
+	saved := config.AppConfig.Registries
+	defer func() { config.AppConfig.Registries = saved }()
+	config.AppConfig.Registries = nil
+
+	if err := config.AddRegistry(config.Registry{Name: "dev", URL: "http://localhost:8081", Default: true}); err != nil {
+		t.Fatalf("add dev: %v", err)
+	}
+	if err := config.AddRegistry(config.Registry{Name: "prod", URL: "https://sr.example.com"}); err != nil {
+		t.Fatalf("add prod: %v", err)
+	}
+	if err := config.AddRegistry(config.Registry{Name: "dev", URL: "http://other"}); err == nil {
+		t.Error("expected error adding a duplicate name")
+	}
+	if got := config.GetDefaultRegistry().Name; got != "dev" {
+		t.Errorf("default = %s, want dev", got)
+	}
+
+	if err := config.SetDefaultRegistry("prod"); err != nil {
+		t.Fatalf("use prod: %v", err)
+	}
+	if config.GetRegistry("dev").Default {
+		t.Error("dev should no longer be the default")
+	}
+	if got := config.GetDefaultRegistry().Name; got != "prod" {
+		t.Errorf("default = %s, want prod", got)
+	}
+	if err := config.SetDefaultRegistry("missing"); err == nil {
+		t.Error("expected error selecting an unknown registry")
+	}
+
+	if err := config.RemoveRegistry("prod"); err != nil {
+		t.Fatalf("remove prod: %v", err)
+	}
+	if err := config.RemoveRegistry("prod"); err == nil {
+		t.Error("expected error removing a registry twice")
+	}
+	if len(config.AppConfig.Registries) != 1 || config.AppConfig.Registries[0].Name != "dev" {
+		t.Errorf("registries = %+v, want only dev", config.AppConfig.Registries)
+	}
+}
+
+func TestRegistryFromFlags(t *testing.T) {
+	savedURL, savedUser, savedPass, savedCtx, savedMode := registryURL, username, password, srContext, authMode
+	defer func() {
+		registryURL, username, password, srContext, authMode = savedURL, savedUser, savedPass, savedCtx, savedMode
+	}()
+
+	registryURL, username, password, srContext, authMode = "https://sr.example.com/", "key", "secret", ".prod", ""
+	reg, err := registryFromFlags("prod")
+	if err != nil {
+		t.Fatalf("registryFromFlags: %v", err)
+	}
+	if reg.URL != "https://sr.example.com" || reg.Username != "key" || reg.Password != "secret" || reg.Context != ".prod" {
+		t.Errorf("unexpected registry: %+v", reg)
+	}
+
+	authMode = "oauth"
+	if _, err := registryFromFlags("prod"); err == nil {
+		t.Error("expected error for oauth without a token URL")
+	}
+
+	authMode, registryURL = "", ""
+	if _, err := registryFromFlags("prod"); err == nil {
+		t.Error("expected error without --url")
+	}
+}
+
+func TestRegistryAuth(t *testing.T) {
+	tests := []struct {
+		reg  config.Registry
+		want string
+	}{
+		{config.Registry{}, "none"},
+		{config.Registry{Username: "key"}, "basic"},
+		{config.Registry{AuthMode: "oauth"}, "oauth"},
+		{config.Registry{TLS: config.RegistryTLSConfig{CertFile: "c.pem"}}, "mtls"},
+		{config.Registry{Username: "key", TLS: config.RegistryTLSConfig{CertFile: "c.pem"}}, "basic+mtls"},
+	}
+	for _, tt := range tests {
+		if got := registryAuth(tt.reg); got != tt.want {
+			t.Errorf("registryAuth(%+v) = %s, want %s", tt.reg, got, tt.want)
+		}
+	}
+}
+
+func TestSaveConfigRoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	saved := config.AppConfig
+	defer func() { config.AppConfig = saved }()
+	config.AppConfig = config.Config{DefaultOutput: "table"}
+
+	want := config.Registry{
+		Name:     "prod",
+		URL:      "https://sr.example.com",
+		Username: "key",
+		Password: "secret",
+		Context:  ".prod",
+		Timeout:  30 * time.Second,
+		Default:  true,
+		Kafka: config.KafkaConfig{
+			Brokers: []string{"broker:9092"},
+			TLS:     config.KafkaTLSConfig{Enabled: true, SkipVerify: true},
+		},
+	}
+	if err := config.AddRegistry(want); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := config.SaveConfig(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	path := filepath.Join(home, ".srctl", "srctl.yaml")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("config file not written: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("config file mode = %o, want 0600", info.Mode().Perm())
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	var loaded config.Config
+	if err := v.Unmarshal(&loaded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(loaded.Registries) != 1 {
+		t.Fatalf("loaded %d registries, want 1", len(loaded.Registries))
+	}
+	got := loaded.Registries[0]
+	if got.Name != want.Name || got.URL != want.URL || got.Password != want.Password || got.Context != want.Context ||
+		got.Timeout != want.Timeout || !got.Default || !got.Kafka.TLS.SkipVerify || len(got.Kafka.Brokers) != 1 {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, want)
+	}
+}
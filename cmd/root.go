@@ -3,11 +3,15 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/srctl/srctl/internal/client"
 	"github.com/srctl/srctl/internal/config"
+	"github.com/srctl/srctl/internal/output"
 )
 
 var (
@@ -16,8 +20,46 @@ var (
 	username     string
 	password     string
 	registryName string
+	profileName  string
 	srContext    string
 	outputFormat string
+	quietOutput  bool
+
+	// OAuth2 flags
+	authMode          string
+	oauthTokenURL     string
+	oauthClientID     string
+	oauthClientSecret string
+	oauthScope        string
+
+	// mTLS flags
+	clientCertFile string
+	clientKeyFile  string
+	caCertFile     string
+	insecureTLS    bool
+
+	// Explicit proxy URL (default: HTTP_PROXY/HTTPS_PROXY/NO_PROXY)
+	proxyURL string
+
+	// HTTP request timeout (0 = registry config, else client default)
+	requestTimeout time.Duration
+
+	// Retry policy for transient HTTP errors
+	maxRetries   int
+	retryBackoff time.Duration
+
+	// Client-side request rate limit (requests/sec, 0 = unlimited)
+	rateLimit float64
+
+	// Page size for subject and schema listings (0 = single request)
+	pageSize int
+
+	// Subject prefixes treated as internal/system subjects
+	internalPrefixes []string
+
+	// cacheResponses enables the client GET cache; set for commands marked
+	// with annotationCacheReads
+	cacheResponses bool
 
 	rootCmd = &cobra.Command{
 		Use:   "srctl",
@@ -28,6 +70,9 @@ advanced capabilities beyond the standard SR CLI.
 Configure your registries in ~/.srctl/srctl.yaml or use environment variables:
   SCHEMA_REGISTRY_URL, SCHEMA_REGISTRY_BASIC_AUTH_USER_INFO
 
+Select a profile (a named set of registries and a default context) with
+--profile or SRCTL_PROFILE.
+
 For large registries with many subjects, increase --workers for faster operations.
 See 'srctl [command] --help' for command-specific options.`,
 		Version: "dev",
@@ -35,12 +80,26 @@ See 'srctl [command] --help' for command-specific options.`,
 		// succeeded, so runtime errors returned by RunE don't trigger it.
 		// Genuine flag/arg parse errors still show usage because they occur
 		// before this hook runs. Propagates to subcommands.
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
+			cacheResponses = cmd.Annotations[annotationCacheReads] == "true"
+			output.SetQuiet(quietOutput)
+			startJSONL()
+			if err := setupLogging(); err != nil {
+				return err
+			}
+			return selectProfile()
 		},
 	}
 )
 
+// annotationCacheReads marks read-only commands whose clients cache repeated
+// GET responses for the duration of the run
+const annotationCacheReads = "srctl/cache-reads"
+
+// readOnlyAnnotations enables response caching for a read-only command
+var readOnlyAnnotations = map[string]string{annotationCacheReads: "true"}
+
 // Command group IDs
 const (
 	groupSchema   = "schema"
@@ -67,8 +126,29 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&username, "username", "", "Basic auth username")
 	rootCmd.PersistentFlags().StringVar(&password, "password", "", "Basic auth password")
 	rootCmd.PersistentFlags().StringVarP(&registryName, "registry", "r", "", "Registry name from config")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Config profile to use (default: $SRCTL_PROFILE)")
 	rootCmd.PersistentFlags().StringVarP(&srContext, "context", "c", "", "Schema Registry context (e.g., '.mycontext')")
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, json, yaml, plain")
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "Hide progress bars, headers and info messages; print only results, warnings and errors")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "Log registry requests to stderr at this level: debug, info, warn, error (default: off)")
+	rootCmd.PersistentFlags().BoolVar(&verboseLog, "verbose", false, "Log every registry request to stderr (same as --log-level debug)")
+	rootCmd.PersistentFlags().BoolVar(&logBodies, "log-bodies", false, "Include request and response bodies in request logs, with secrets redacted")
+	rootCmd.PersistentFlags().StringVar(&authMode, "auth-mode", "", "Authentication mode: basic or oauth (default: from config, else basic)")
+	rootCmd.PersistentFlags().StringVar(&oauthTokenURL, "oauth-token-url", "", "OAuth2 token endpoint URL")
+	rootCmd.PersistentFlags().StringVar(&oauthClientID, "oauth-client-id", "", "OAuth2 client ID")
+	rootCmd.PersistentFlags().StringVar(&oauthClientSecret, "oauth-client-secret", "", "OAuth2 client secret")
+	rootCmd.PersistentFlags().StringVar(&oauthScope, "oauth-scope", "", "OAuth2 scope to request")
+	rootCmd.PersistentFlags().StringVar(&clientCertFile, "client-cert", "", "Client certificate file (PEM) for mutual TLS")
+	rootCmd.PersistentFlags().StringVar(&clientKeyFile, "client-key", "", "Client private key file (PEM) for mutual TLS")
+	rootCmd.PersistentFlags().StringVar(&caCertFile, "ca-cert", "", "CA certificate file (PEM) used to verify the registry")
+	rootCmd.PersistentFlags().StringVar(&proxyURL, "proxy", "", "Proxy URL for registry requests, e.g. http://proxy:3128 (default: HTTP_PROXY/HTTPS_PROXY)")
+	rootCmd.PersistentFlags().BoolVar(&insecureTLS, "insecure", false, "Skip verification of the registry's TLS certificate (self-signed development registries only)")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", client.DefaultMaxRetries, "Retries for transient errors (429, 5xx); 0 disables retries")
+	rootCmd.PersistentFlags().DurationVar(&retryBackoff, "retry-backoff", client.DefaultRetryBackoff, "Base delay between retries, doubled on each attempt")
+	rootCmd.PersistentFlags().Float64Var(&rateLimit, "rate-limit", 0, "Maximum requests per second across all workers (0 = unlimited)")
+	rootCmd.PersistentFlags().IntVar(&pageSize, "page-size", client.DefaultPageSize, "Subjects/schemas fetched per page when listing; all pages are always fetched (0 = single request)")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "timeout", 0, "HTTP request timeout, e.g. 30s, 2m, 500ms (default: registry config, else 120s)")
+	rootCmd.PersistentFlags().StringSliceVar(&internalPrefixes, "internal-prefixes", defaultInternalPrefixes, "Subject prefixes treated as internal by stats, dangling, backup and delete --all (empty = none)")
 }
 
 func initConfig() {
@@ -78,6 +158,20 @@ func initConfig() {
 	}
 }
 
+// selectProfile applies --profile, or SRCTL_PROFILE when the flag is unset.
+// An unknown profile is an error rather than a silent fallback to the
+// top-level registries, which may point somewhere else entirely.
+func selectProfile() error {
+	name := profileName
+	if name == "" {
+		name = os.Getenv("SRCTL_PROFILE")
+	}
+	if name == "" || name == config.ActiveProfile() {
+		return nil
+	}
+	return config.UseProfile(name)
+}
+
 // SetVersionInfo sets version information from build-time ldflags
 func SetVersionInfo(version, commit, date string) {
 	rootCmd.Version = fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date)
@@ -92,41 +186,30 @@ func Execute() {
 
 // GetClient returns a configured Schema Registry client based on flags and config
 func GetClient() (*client.SchemaRegistryClient, error) {
-	var url, user, pass, ctx string
+	var reg config.Registry
 
 	// Priority: CLI flags > specific registry from config > default registry > env vars
 
 	if registryURL != "" {
-		url = registryURL
-		user = username
-		pass = password
+		reg.URL = registryURL
 	} else if registryName != "" {
-		reg := config.GetRegistry(registryName)
-		if reg == nil {
+		r := config.GetRegistry(registryName)
+		if r == nil {
 			return nil, fmt.Errorf("registry '%s' not found in config", registryName)
 		}
-		url = reg.URL
-		user = reg.Username
-		pass = reg.Password
-		ctx = reg.Context
+		reg = *r
+	} else if r := config.GetDefaultRegistry(); r != nil {
+		reg = *r
 	} else {
-		reg := config.GetDefaultRegistry()
-		if reg != nil {
-			url = reg.URL
-			user = reg.Username
-			pass = reg.Password
-			ctx = reg.Context
-		} else {
-			// Try environment variables
-			url = os.Getenv("SCHEMA_REGISTRY_URL")
-			if authInfo := os.Getenv("SCHEMA_REGISTRY_BASIC_AUTH_USER_INFO"); authInfo != "" {
-				// Format: user:password — split on first colon
-				for i, c := range authInfo {
-					if c == ':' {
-						user = authInfo[:i]
-						pass = authInfo[i+1:]
-						break
-					}
+		// Try environment variables
+		reg.URL = os.Getenv("SCHEMA_REGISTRY_URL")
+		if authInfo := os.Getenv("SCHEMA_REGISTRY_BASIC_AUTH_USER_INFO"); authInfo != "" {
+			// Format: user:password — split on first colon
+			for i, c := range authInfo {
+				if c == ':' {
+					reg.Username = authInfo[:i]
+					reg.Password = authInfo[i+1:]
+					break
 				}
 			}
 		}
@@ -134,42 +217,183 @@ func GetClient() (*client.SchemaRegistryClient, error) {
 
 	// Override user/pass from flags if provided
 	if username != "" {
-		user = username
+		reg.Username = username
 	}
 	if password != "" {
-		pass = password
+		reg.Password = password
+	}
+
+	// Override OAuth settings from flags if provided
+	if authMode != "" {
+		reg.AuthMode = authMode
+	}
+	if oauthTokenURL != "" {
+		reg.OAuth.TokenURL = oauthTokenURL
+	}
+	if oauthClientID != "" {
+		reg.OAuth.ClientID = oauthClientID
+	}
+	if oauthClientSecret != "" {
+		reg.OAuth.ClientSecret = oauthClientSecret
+	}
+	if oauthScope != "" {
+		reg.OAuth.Scope = oauthScope
+	}
+
+	// Override TLS settings from flags if provided
+	if clientCertFile != "" {
+		reg.TLS.CertFile = clientCertFile
+	}
+	if clientKeyFile != "" {
+		reg.TLS.KeyFile = clientKeyFile
+	}
+	if caCertFile != "" {
+		reg.TLS.CAFile = caCertFile
+	}
+	if insecureTLS {
+		reg.TLS.InsecureSkipVerify = true
+	}
+	if proxyURL != "" {
+		reg.Proxy = proxyURL
 	}
 
 	// Context flag overrides config
 	if srContext != "" {
-		ctx = srContext
+		reg.Context = srContext
 	}
 
-	if url == "" {
+	if reg.URL == "" {
 		return nil, fmt.Errorf("no Schema Registry URL configured. Use --url flag, set SCHEMA_REGISTRY_URL env var, or configure in ~/.srctl/srctl.yaml")
 	}
 
-	var auth *client.AuthConfig
-	if user != "" {
-		auth = &client.AuthConfig{
-			Username: user,
-			Password: pass,
-		}
-		// Warn (but don't fail) when sending credentials over plaintext http,
-		// so localhost testing still works.
-		if strings.HasPrefix(strings.ToLower(url), "http://") {
-			fmt.Fprintln(os.Stderr, "warning: sending credentials over plaintext http")
-		}
+	return newClientForRegistry(&reg)
+}
+
+// newClientForRegistry builds a client from fully-resolved registry settings
+func newClientForRegistry(reg *config.Registry) (*client.SchemaRegistryClient, error) {
+	auth, err := buildAuthConfig(reg)
+	if err != nil {
+		return nil, err
+	}
+
+	c := client.NewClient(reg.URL, auth)
+	if err := c.ConfigureTLS(); err != nil {
+		return nil, err
+	}
+	if err := c.ConfigureProxy(reg.Proxy); err != nil {
+		return nil, err
+	}
+
+	// --timeout applies to every client; otherwise use the per-registry value
+	timeout := reg.Timeout
+	if requestTimeout != 0 {
+		timeout = requestTimeout
+	}
+	if timeout < 0 {
+		return nil, fmt.Errorf("invalid timeout %s: must be positive", timeout)
+	}
+	if timeout > 0 {
+		c.HTTPClient.Timeout = timeout
+	}
+
+	if maxRetries < 0 {
+		return nil, fmt.Errorf("invalid --max-retries %d: must be 0 or greater", maxRetries)
 	}
+	c.MaxRetries = maxRetries
+	c.RetryBackoff = retryBackoff
 
-	c := client.NewClient(url, auth)
-	if ctx != "" {
+	if rateLimit < 0 {
+		return nil, fmt.Errorf("invalid --rate-limit %g: must be 0 or greater", rateLimit)
+	}
+	c.SetRateLimit(rateLimit)
+
+	if pageSize < 0 {
+		return nil, fmt.Errorf("invalid --page-size %d: must be 0 or greater", pageSize)
+	}
+	c.PageSize = pageSize
+	if cacheResponses {
+		c.EnableCache()
+	}
+	if requestLogger != nil {
+		c.EnableRequestLog(requestLogger, logBodies)
+	}
+	// The registry's own context wins over default_context (top-level or profile)
+	ctx := reg.Context
+	if ctx == "" {
+		ctx = config.AppConfig.DefaultContext
+	}
+	if ctx != "" && ctx != "." {
 		c = c.WithContext(ctx)
 	}
 
 	return c, nil
 }
 
+// buildAuthConfig resolves the auth mode and TLS settings into a
+// client.AuthConfig. It returns nil when nothing is configured.
+func buildAuthConfig(reg *config.Registry) (*client.AuthConfig, error) {
+	auth := &client.AuthConfig{
+		ClientCertFile: reg.TLS.CertFile,
+		ClientKeyFile:  reg.TLS.KeyFile,
+		CACertFile:     reg.TLS.CAFile,
+
+		InsecureSkipVerify: reg.TLS.InsecureSkipVerify,
+	}
+	hasCredentials := false
+
+	switch strings.ToLower(reg.AuthMode) {
+	case "", "basic":
+		if reg.Username != "" {
+			auth.Username = reg.Username
+			auth.Password = reg.Password
+			hasCredentials = true
+		}
+	case "oauth":
+		if reg.OAuth.TokenURL == "" || reg.OAuth.ClientID == "" {
+			return nil, fmt.Errorf("--auth-mode oauth requires a token URL and client ID (--oauth-token-url, --oauth-client-id or the registry's oauth config)")
+		}
+		auth.TokenURL = reg.OAuth.TokenURL
+		auth.ClientID = reg.OAuth.ClientID
+		auth.ClientSecret = reg.OAuth.ClientSecret
+		auth.Scope = reg.OAuth.Scope
+		hasCredentials = true
+	default:
+		return nil, fmt.Errorf("invalid auth mode '%s': must be basic or oauth", reg.AuthMode)
+	}
+
+	if auth.InsecureSkipVerify {
+		fmt.Fprintln(os.Stderr, "warning: TLS certificate verification is disabled")
+	}
+
+	if !hasCredentials && !auth.UsesTLS() {
+		return nil, nil
+	}
+
+	// Warn (but don't fail) when sending credentials over plaintext http,
+	// so localhost testing still works.
+	if hasCredentials && strings.HasPrefix(strings.ToLower(reg.URL), "http://") {
+		fmt.Fprintln(os.Stderr, "warning: sending credentials over plaintext http")
+	}
+
+	return auth, nil
+}
+
+// reportThrottling prints how long requests were held back by --rate-limit.
+// It prints nothing when no limit is set, no request had to wait, or the
+// output is machine-readable.
+func reportThrottling(clients ...*client.SchemaRegistryClient) {
+	if structuredOutput() {
+		return
+	}
+	var total time.Duration
+	for _, c := range clients {
+		total += c.ThrottledTime()
+	}
+	if total > 0 {
+		output.Info("Time spent waiting on --rate-limit: %s (summed across workers)", total.Round(time.Millisecond))
+	}
+}
+
 // clampWorkers ensures worker count is at least 1 to prevent deadlocks
 func clampWorkers(n int) int {
 	if n < 1 {
@@ -178,6 +402,46 @@ func clampWorkers(n int) int {
 	return n
 }
 
+// maxFailuresShown caps the failures table printed after bulk operations
+const maxFailuresShown = 20
+
+// operationFailure is a subject (or subject version) a bulk operation failed on
+type operationFailure struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version,omitempty"` // 0 for subject-level failures
+	Error   string `json:"error"`
+}
+
+// printFailures prints failures sorted by subject and version, capped at maxFailuresShown
+func printFailures(failures []operationFailure) {
+	if len(failures) == 0 {
+		return
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Subject != failures[j].Subject {
+			return failures[i].Subject < failures[j].Subject
+		}
+		return failures[i].Version < failures[j].Version
+	})
+
+	output.SubHeader("Failures")
+	rows := make([][]string, 0, maxFailuresShown)
+	for i, f := range failures {
+		if i == maxFailuresShown {
+			break
+		}
+		version := "-"
+		if f.Version > 0 {
+			version = strconv.Itoa(f.Version)
+		}
+		rows = append(rows, []string{f.Subject, version, f.Error})
+	}
+	output.PrintTable([]string{"Subject", "Version", "Error"}, rows)
+	if len(failures) > maxFailuresShown {
+		output.Error("... and %d more failures (%d total)", len(failures)-maxFailuresShown, len(failures))
+	}
+}
+
 // GetClientForRegistry returns a client for a specific registry by name
 func GetClientForRegistry(name string) (*client.SchemaRegistryClient, error) {
 	reg := config.GetRegistry(name)
@@ -185,20 +449,9 @@ func GetClientForRegistry(name string) (*client.SchemaRegistryClient, error) {
 		return nil, fmt.Errorf("registry '%s' not found in config", name)
 	}
 
-	var auth *client.AuthConfig
-	if reg.Username != "" {
-		auth = &client.AuthConfig{
-			Username: reg.Username,
-			Password: reg.Password,
-		}
-		if strings.HasPrefix(strings.ToLower(reg.URL), "http://") {
-			fmt.Fprintln(os.Stderr, "warning: sending credentials over plaintext http")
-		}
-	}
-
-	c := client.NewClient(reg.URL, auth)
-	if reg.Context != "" {
-		c = c.WithContext(reg.Context)
+	c, err := newClientForRegistry(reg)
+	if err != nil {
+		return nil, fmt.Errorf("registry '%s': %w", name, err)
 	}
 
 	return c, nil
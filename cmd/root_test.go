@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/config"
+)
+
+func TestBuildAuthConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		reg       config.Registry
+		wantNil   bool
+		wantOAuth bool
+		wantErr   bool
+	}{
+		{
+			name:    "no credentials",
+			reg:     config.Registry{URL: "https://sr"},
+			wantNil: true,
+		},
+		{
+			name: "basic auth",
+			reg:  config.Registry{URL: "https://sr", Username: "u", Password: "p"},
+		},
+		{
+			name: "oauth",
+			reg: config.Registry{URL: "https://sr", AuthMode: "oauth", OAuth: config.OAuthConfig{
+				TokenURL: "https://idp/token", ClientID: "id", ClientSecret: "secret",
+			}},
+			wantOAuth: true,
+		},
+		{
+			name:    "oauth without token url",
+			reg:     config.Registry{URL: "https://sr", AuthMode: "oauth"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown mode",
+			reg:     config.Registry{URL: "https://sr", AuthMode: "kerberos"},
+			wantErr: true,
+		},
+		{
+			name: "client certificate only",
+			reg:  config.Registry{URL: "https://sr", TLS: config.RegistryTLSConfig{CertFile: "c.crt", KeyFile: "c.key"}},
+		},
+		{
+			name: "insecure skip verify only",
+			reg:  config.Registry{URL: "https://sr", TLS: config.RegistryTLSConfig{InsecureSkipVerify: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := buildAuthConfig(&tt.reg)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantNil != (auth == nil) {
+				t.Fatalf("expected nil auth = %v, got %+v", tt.wantNil, auth)
+			}
+			if auth.UsesOAuth() != tt.wantOAuth {
+				t.Errorf("expected UsesOAuth = %v", tt.wantOAuth)
+			}
+		})
+	}
+}
+
+func TestNewClientForRegistryTimeout(t *testing.T) {
+	defer func() { requestTimeout = 0 }()
+
+	c, err := newClientForRegistry(&config.Registry{URL: "http://sr", Timeout: 45 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.HTTPClient.Timeout != 45*time.Second {
+		t.Errorf("expected per-registry timeout 45s, got %s", c.HTTPClient.Timeout)
+	}
+
+	// --timeout overrides the registry value
+	requestTimeout = 500 * time.Millisecond
+	c, err = newClientForRegistry(&config.Registry{URL: "http://sr", Timeout: 45 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.HTTPClient.Timeout != 500*time.Millisecond {
+		t.Errorf("expected flag timeout 500ms, got %s", c.HTTPClient.Timeout)
+	}
+
+	requestTimeout = -time.Second
+	if _, err := newClientForRegistry(&config.Registry{URL: "http://sr"}); err == nil {
+		t.Error("expected error for negative timeout")
+	}
+}
+
+func TestPrintFailuresSortsBySubjectAndVersion(t *testing.T) {
+	failures := []operationFailure{
+		{Subject: "users", Version: 2, Error: "boom"},
+		{Subject: "orders", Error: "subject failed"},
+		{Subject: "users", Version: 1, Error: "boom"},
+	}
+	printFailures(failures)
+
+	if failures[0].Subject != "orders" || failures[1].Version != 1 || failures[2].Version != 2 {
+		t.Errorf("expected failures sorted by subject then version, got %+v", failures)
+	}
+
+	// Nothing to print
+	printFailures(nil)
+}
+
+func TestCacheReadsAnnotation(t *testing.T) {
+	for _, cmd := range []*cobra.Command{statsCmd, listCmd, searchCmd, compareCmd, getCmd, referencesCmd} {
+		if cmd.Annotations[annotationCacheReads] != "true" {
+			t.Errorf("expected %s to cache reads", cmd.Name())
+		}
+	}
+	for _, cmd := range []*cobra.Command{deleteCmd, cloneCmd, registerCmd, restoreCmd, importCmd} {
+		if cmd.Annotations[annotationCacheReads] == "true" {
+			t.Errorf("expected mutating command %s not to cache reads", cmd.Name())
+		}
+	}
+}
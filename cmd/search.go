@@ -9,7 +9,6 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/srctl/srctl/internal/client"
 	"github.com/srctl/srctl/internal/output"
@@ -25,6 +24,7 @@ or content. Useful for governance, discovery, and impact analysis.
 Search modes:
   --field     Search for field/property names (supports glob: *.address)
   --text      Full-text search in schema content
+  --contains  Find schemas that use or reference a named type
   --tag       Search for schemas with specific tags
 
 Examples:
@@ -40,6 +40,9 @@ Examples:
   # Full-text search
   srctl search --text customerId
 
+  # Find schemas that use or reference a type
+  srctl search --contains com.example.Money --version all
+
   # Search for tagged schemas
   srctl search --tag PII
 
@@ -51,7 +54,8 @@ Examples:
 
   # Output as JSON for scripting
   srctl search --field email -o json`,
-	RunE: runSearch,
+	Annotations: readOnlyAnnotations,
+	RunE:        runSearch,
 }
 
 var (
@@ -59,6 +63,7 @@ var (
 	searchFieldType string
 	searchText      string
 	searchTag       string
+	searchContains  string
 	searchVersion   string
 	searchFilter    string
 	searchWorkers   int
@@ -68,6 +73,7 @@ func init() {
 	searchCmd.Flags().StringVar(&searchField, "field", "", "Search for field names (supports glob patterns)")
 	searchCmd.Flags().StringVar(&searchFieldType, "field-type", "", "Filter by field type (used with --field)")
 	searchCmd.Flags().StringVar(&searchText, "text", "", "Full-text search in schema content")
+	searchCmd.Flags().StringVar(&searchContains, "contains", "", "Find schemas that use or reference a named type (e.g. com.example.Money)")
 	searchCmd.Flags().StringVar(&searchTag, "tag", "", "Search for schemas with specific tags")
 	searchCmd.Flags().StringVar(&searchVersion, "version", "latest", "Which versions to search: 'all' or 'latest'")
 	searchCmd.Flags().StringVar(&searchFilter, "filter", "", "Subject name filter (glob pattern)")
@@ -94,8 +100,8 @@ type SearchMatch struct {
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
-	if searchField == "" && searchText == "" && searchTag == "" {
-		return fmt.Errorf("at least one search criteria required: --field, --text, or --tag")
+	if searchField == "" && searchText == "" && searchTag == "" && searchContains == "" {
+		return fmt.Errorf("at least one search criteria required: --field, --text, --contains, or --tag")
 	}
 
 	c, err := GetClient()
@@ -113,6 +119,9 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	if searchText != "" {
 		output.Info("Text search: %s", searchText)
 	}
+	if searchContains != "" {
+		output.Info("Type usage: %s", searchContains)
+	}
 	if searchTag != "" {
 		output.Info("Tag search: %s", searchTag)
 	}
@@ -190,12 +199,7 @@ func searchSubjectsParallel(c *client.SchemaRegistryClient, subjects []string, n
 	jobs := make(chan string, len(subjects))
 	results := make(chan []SearchResult, len(subjects))
 
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Searching"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	bar := newProgressBar(len(subjects), "Searching")
 
 	var wg sync.WaitGroup
 	for i := 0; i < numWorkers; i++ {
@@ -269,6 +273,10 @@ func searchSubject(c *client.SchemaRegistryClient, subject string) []SearchResul
 			matches = append(matches, textMatches...)
 		}
 
+		if searchContains != "" {
+			matches = append(matches, searchSchemaContains(schema, schemaType, searchContains)...)
+		}
+
 		if searchTag != "" {
 			tagMatches := searchSchemaTags(c, subject, v, searchTag)
 			matches = append(matches, tagMatches...)
@@ -516,6 +524,112 @@ func searchSchemaText(content, searchTerm string) []SearchMatch {
 	return matches
 }
 
+// searchSchemaContains finds references to, and fields typed with, a named type.
+// Avro is matched structurally; JSON Schema and Protobuf fall back to the raw
+// schema content.
+func searchSchemaContains(schema *client.Schema, schemaType, typeName string) []SearchMatch {
+	var matches []SearchMatch
+
+	for _, ref := range schema.References {
+		if matchFieldPattern(ref.Name, typeName) || strings.EqualFold(ref.Subject, typeName) {
+			matches = append(matches, SearchMatch{
+				FieldPath: ref.Name,
+				FieldType: fmt.Sprintf("%s v%d", ref.Subject, ref.Version),
+				MatchType: "reference",
+			})
+		}
+	}
+
+	if strings.ToUpper(schemaType) == "AVRO" {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(schema.Schema), &parsed); err == nil {
+			return append(matches, findAvroTypeUsages(parsed, "", "", typeName)...)
+		}
+	}
+
+	for _, m := range searchSchemaText(schema.Schema, typeName) {
+		m.MatchType = "contains"
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// findAvroTypeUsages walks an Avro type and reports fields whose type is, or
+// contains, the named type. A root type with that name is a definition.
+func findAvroTypeUsages(t interface{}, path, namespace, typeName string) []SearchMatch {
+	qualify := func(name, ns string) string {
+		if ns == "" || strings.Contains(name, ".") {
+			return name
+		}
+		return ns + "." + name
+	}
+	usage := func(fullName string) []SearchMatch {
+		if !matchFieldPattern(fullName, typeName) {
+			return nil
+		}
+		if path == "" {
+			return []SearchMatch{{FieldPath: fullName, FieldType: fullName, MatchType: "definition"}}
+		}
+		return []SearchMatch{{FieldPath: path, FieldType: fullName, MatchType: "type"}}
+	}
+
+	switch v := t.(type) {
+	case string:
+		if avroPrimitiveTypes[v] {
+			return nil
+		}
+		return usage(qualify(v, namespace))
+	case []interface{}:
+		var matches []SearchMatch
+		for _, ut := range v {
+			matches = append(matches, findAvroTypeUsages(ut, path, namespace, typeName)...)
+		}
+		return matches
+	case map[string]interface{}:
+		typ, _ := v["type"].(string)
+		switch typ {
+		case "record", "error":
+			ns := namespace
+			if n, ok := v["namespace"].(string); ok {
+				ns = n
+			}
+			name, _ := v["name"].(string)
+			matches := usage(qualify(name, ns))
+			fields, _ := v["fields"].([]interface{})
+			for _, f := range fields {
+				field, ok := f.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				fieldName, _ := field["name"].(string)
+				fieldPath := fieldName
+				if path != "" {
+					fieldPath = path + "." + fieldName
+				}
+				matches = append(matches, findAvroTypeUsages(field["type"], fieldPath, ns, typeName)...)
+			}
+			return matches
+		case "enum", "fixed":
+			ns := namespace
+			if n, ok := v["namespace"].(string); ok {
+				ns = n
+			}
+			name, _ := v["name"].(string)
+			return usage(qualify(name, ns))
+		case "array":
+			return findAvroTypeUsages(v["items"], path+"[]", namespace, typeName)
+		case "map":
+			return findAvroTypeUsages(v["values"], path, namespace, typeName)
+		case "":
+			// Nested type definition, e.g. {"type": {"type": "record", ...}}
+			return findAvroTypeUsages(v["type"], path, namespace, typeName)
+		default:
+			return findAvroTypeUsages(typ, path, namespace, typeName)
+		}
+	}
+	return nil
+}
+
 func searchSchemaTags(c *client.SchemaRegistryClient, subject string, version int, tagName string) []SearchMatch {
 	var matches []SearchMatch
 
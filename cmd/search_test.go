@@ -3,6 +3,8 @@ package cmd
 import (
 	"encoding/json"
 	"testing"
+
+	"github.com/srctl/srctl/internal/client"
 )
 
 func TestSearchAvroFieldByName(t *testing.T) {
@@ -337,3 +339,51 @@ func TestCountTotalMatches(t *testing.T) {
 		t.Errorf("expected 3 total matches, got %d", count)
 	}
 }
+
+func TestSearchSchemaContainsAvro(t *testing.T) {
+	schema := &client.Schema{Schema: `{
+		"type": "record", "name": "Order", "namespace": "com.example",
+		"fields": [
+			{"name": "id", "type": "string"},
+			{"name": "total", "type": {"type": "record", "name": "Money", "fields": [{"name": "amount", "type": "long"}]}},
+			{"name": "refunds", "type": {"type": "array", "items": "Money"}},
+			{"name": "tax", "type": ["null", "com.example.Money"]},
+			{"name": "address", "type": "com.other.Address"}
+		]
+	}`, References: []client.SchemaReference{{Name: "com.other.Address", Subject: "address-value", Version: 2}}}
+
+	matches := searchSchemaContains(schema, "AVRO", "com.example.Money")
+	var paths []string
+	for _, m := range matches {
+		paths = append(paths, m.MatchType+":"+m.FieldPath)
+	}
+	want := []string{"type:total", "type:refunds[]", "type:tax"}
+	if len(paths) != len(want) {
+		t.Fatalf("expected %v, got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, paths)
+		}
+	}
+
+	// Simple names and references
+	matches = searchSchemaContains(schema, "AVRO", "Address")
+	if len(matches) != 2 || matches[0].MatchType != "reference" || matches[1].FieldPath != "address" {
+		t.Errorf("expected reference and field match for Address, got %+v", matches)
+	}
+
+	// The root record is reported as a definition
+	matches = searchSchemaContains(schema, "AVRO", "com.example.Order")
+	if len(matches) != 1 || matches[0].MatchType != "definition" {
+		t.Errorf("expected root definition match, got %+v", matches)
+	}
+}
+
+func TestSearchSchemaContainsProtobuf(t *testing.T) {
+	schema := &client.Schema{Schema: "syntax = \"proto3\";\nmessage Order {\n  com.example.Money total = 1;\n  string id = 2;\n}"}
+	matches := searchSchemaContains(schema, "PROTOBUF", "com.example.Money")
+	if len(matches) != 1 || matches[0].MatchType != "contains" || matches[0].FieldPath != "line 3" {
+		t.Errorf("expected raw content match on line 3, got %+v", matches)
+	}
+}
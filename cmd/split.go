@@ -3,9 +3,11 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -27,9 +29,10 @@ or when you want to share types across multiple topics.
 Supports Avro, Protobuf, and JSON Schema formats.
 
 Subcommands:
-  analyze   - Analyze a schema and show extractable types
-  extract   - Split schema and write sub-schemas to files
-  register  - Split schema and register all parts to Schema Registry
+  analyze    - Analyze a schema and show extractable types
+  extract    - Split schema and write sub-schemas to files
+  register   - Split schema and register all parts to Schema Registry
+  reassemble - Inline a schema's references into one schema (alias: inline)
 
 See 'srctl split [command] --help' for command-specific options.
 For a comprehensive guide, see docs/schema-splitting-guide.md`,
@@ -64,7 +67,10 @@ Examples:
   srctl split analyze --file order.proto --type PROTOBUF
 
   # Analyze a JSON schema
-  srctl split analyze --file order.json --type JSON`,
+  srctl split analyze --file order.json --type JSON
+
+  # Machine-readable analysis for CI
+  srctl split analyze --file order.avsc -o json`,
 	RunE: runSplitAnalyze,
 }
 
@@ -106,15 +112,22 @@ Examples:
   srctl split register --file order.avsc --subject orders-value --dry-run
 
   # With explicit type
-  srctl split register --file order.proto --type PROTOBUF --subject orders-value`,
+  srctl split register --file order.proto --type PROTOBUF --subject orders-value
+
+  # Name subjects the way serializers look them up
+  srctl split register --file order.avsc --subject-strategy topic --topic orders
+  srctl split register --file order.avsc --subject-strategy record`,
 	RunE: runSplitRegister,
 }
 
 var (
-	splitOutputDir     string
-	splitSubject       string
-	splitDryRun        bool
-	splitCompatibility string
+	splitOutputDir       string
+	splitSubject         string
+	splitDryRun          bool
+	splitCompatibility   string
+	splitSubjectStrategy string
+	splitTopic           string
+	splitKey             bool
 )
 
 func init() {
@@ -131,6 +144,9 @@ func init() {
 	splitExtractCmd.Flags().IntVar(&splitDepth, "depth", 0, "Extraction depth: 1 = top-level fields only, 0 = all levels (default 0)")
 	splitExtractCmd.Flags().StringVar(&splitOutputDir, "output-dir", "", "Directory to write split schemas")
 	splitExtractCmd.Flags().StringVar(&splitSubjectPrefix, "subject-prefix", "", "Prefix for extracted type subject names")
+	splitExtractCmd.Flags().StringVar(&splitSubjectStrategy, "subject-strategy", "", "Subject naming strategy: topic, record, topic-record (TopicNameStrategy, RecordNameStrategy, TopicRecordNameStrategy)")
+	splitExtractCmd.Flags().StringVar(&splitTopic, "topic", "", "Topic name for the topic and topic-record subject strategies")
+	splitExtractCmd.Flags().BoolVar(&splitKey, "key", false, "The schema is for record keys (topic strategy subject ends in -key)")
 	_ = splitExtractCmd.MarkFlagRequired("file")
 	_ = splitExtractCmd.MarkFlagRequired("output-dir")
 
@@ -138,12 +154,14 @@ func init() {
 	splitRegisterCmd.Flags().StringVarP(&splitSchemaType, "type", "t", "", "Schema type: AVRO, PROTOBUF, JSON")
 	splitRegisterCmd.Flags().IntVar(&splitMinSize, "min-size", 0, "Minimum type size in bytes to extract (0 = extract all)")
 	splitRegisterCmd.Flags().IntVar(&splitDepth, "depth", 0, "Extraction depth: 1 = top-level fields only, 0 = all levels (default 0)")
-	splitRegisterCmd.Flags().StringVar(&splitSubject, "subject", "", "Subject name for the root schema")
+	splitRegisterCmd.Flags().StringVar(&splitSubject, "subject", "", "Subject name for the root schema (required unless --subject-strategy is set)")
 	splitRegisterCmd.Flags().StringVar(&splitSubjectPrefix, "subject-prefix", "", "Prefix for extracted type subject names")
 	splitRegisterCmd.Flags().BoolVar(&splitDryRun, "dry-run", false, "Show what would be registered without registering")
 	splitRegisterCmd.Flags().StringVar(&splitCompatibility, "compatibility", "BACKWARD", "Compatibility level for extracted subjects")
+	splitRegisterCmd.Flags().StringVar(&splitSubjectStrategy, "subject-strategy", "", "Subject naming strategy: topic, record, topic-record (TopicNameStrategy, RecordNameStrategy, TopicRecordNameStrategy)")
+	splitRegisterCmd.Flags().StringVar(&splitTopic, "topic", "", "Topic name for the topic and topic-record subject strategies")
+	splitRegisterCmd.Flags().BoolVar(&splitKey, "key", false, "The schema is for record keys (topic strategy subject ends in -key)")
 	_ = splitRegisterCmd.MarkFlagRequired("file")
-	_ = splitRegisterCmd.MarkFlagRequired("subject")
 
 	// Add subcommands
 	splitCmd.AddCommand(splitAnalyzeCmd)
@@ -160,23 +178,24 @@ func init() {
 
 // ExtractedType represents a named type extracted from a schema
 type ExtractedType struct {
-	Name       string   `json:"name"`       // Fully qualified name (e.g., com.example.types.Address)
-	Subject    string   `json:"subject"`    // Subject to register under
-	Schema     string   `json:"schema"`     // The extracted schema content
-	SchemaType string   `json:"schemaType"` // AVRO, PROTOBUF, JSON
-	Size       int      `json:"size"`       // Size in bytes
-	References []string `json:"references"` // Names of types this depends on
-	IsRoot     bool     `json:"isRoot"`     // Whether this is the root schema
-	Order      int      `json:"order"`      // Registration order (0-based)
+	Name       string   `json:"name" yaml:"name"`             // Fully qualified name (e.g., com.example.types.Address)
+	Subject    string   `json:"subject" yaml:"subject"`       // Subject to register under
+	Schema     string   `json:"schema" yaml:"schema"`         // The extracted schema content
+	SchemaType string   `json:"schemaType" yaml:"schemaType"` // AVRO, PROTOBUF, JSON
+	Size       int      `json:"size" yaml:"size"`             // Size in bytes
+	References []string `json:"references" yaml:"references"` // Names of types this depends on
+	IsRoot     bool     `json:"isRoot" yaml:"isRoot"`         // Whether this is the root schema
+	Order      int      `json:"order" yaml:"order"`           // Registration order (0-based)
 }
 
 // SplitResult contains the full result of a schema split operation
 type SplitResult struct {
-	OriginalSize      int             `json:"originalSize"`
-	OriginalFile      string          `json:"originalFile"`
-	SchemaType        string          `json:"schemaType"`
-	Types             []ExtractedType `json:"types"`
-	RegistrationOrder []string        `json:"registrationOrder"`
+	OriginalSize      int             `json:"originalSize" yaml:"originalSize"`
+	OriginalFile      string          `json:"originalFile" yaml:"originalFile"`
+	SchemaType        string          `json:"schemaType" yaml:"schemaType"`
+	Types             []ExtractedType `json:"types" yaml:"types"`
+	RegistrationOrder []string        `json:"registrationOrder" yaml:"registrationOrder"`
+	Cycles            [][]string      `json:"cycles,omitempty" yaml:"cycles,omitempty"` // Circular dependencies between extracted types
 }
 
 // ========================
@@ -198,6 +217,12 @@ func runSplitAnalyze(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to analyze schema: %w", err)
 	}
+	result.OriginalFile = splitFile
+
+	printer := output.NewPrinter(outputFormat)
+	if outputFormat != "table" {
+		return printer.Print(result)
+	}
 
 	output.Header("Schema Analysis: %s", splitFile)
 	output.Info("Schema type: %s", schemaType)
@@ -246,6 +271,15 @@ func runSplitAnalyze(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
+	if len(result.Cycles) > 0 {
+		output.SubHeader("Circular References")
+		for _, cycle := range result.Cycles {
+			output.Warning("%s", formatCycle(cycle))
+		}
+		output.Warning("Types in a cycle cannot be registered as separate subjects; recursive types must stay inlined in one schema")
+		fmt.Println()
+	}
+
 	// Size summary
 	var totalSplit int
 	var maxPart int
@@ -284,6 +318,11 @@ func runSplitExtract(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to split schema: %w", err)
 	}
+	if splitSubjectStrategy != "" {
+		if err := applySubjectStrategy(result, schemaType, splitSubjectStrategy, splitTopic, splitKey); err != nil {
+			return err
+		}
+	}
 
 	// Create output directory
 	if err := os.MkdirAll(splitOutputDir, 0755); err != nil {
@@ -293,6 +332,9 @@ func runSplitExtract(cmd *cobra.Command, args []string) error {
 	output.Header("Extracting Schema: %s", splitFile)
 	output.Info("Schema type: %s", schemaType)
 	output.Info("Output directory: %s", splitOutputDir)
+	for _, cycle := range result.Cycles {
+		output.Warning("Circular reference: %s (these parts cannot be registered separately)", formatCycle(cycle))
+	}
 	fmt.Println()
 
 	// Write each type to a file
@@ -332,6 +374,10 @@ func runSplitExtract(cmd *cobra.Command, args []string) error {
 }
 
 func runSplitRegister(cmd *cobra.Command, args []string) error {
+	if splitSubject == "" && splitSubjectStrategy == "" {
+		return fmt.Errorf("--subject is required unless --subject-strategy is set")
+	}
+
 	content, err := os.ReadFile(splitFile)
 	if err != nil {
 		return fmt.Errorf("failed to read schema file: %w", err)
@@ -347,16 +393,34 @@ func runSplitRegister(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to split schema: %w", err)
 	}
 
-	// Set root subject
+	if len(result.Cycles) > 0 {
+		for _, cycle := range result.Cycles {
+			output.Error("Circular reference: %s", formatCycle(cycle))
+		}
+		return fmt.Errorf("cannot register split schema: %d circular reference(s) between extracted types; recursive types must stay inlined in one schema (see 'srctl split analyze')", len(result.Cycles))
+	}
+
+	if splitSubjectStrategy != "" {
+		if err := applySubjectStrategy(result, schemaType, splitSubjectStrategy, splitTopic, splitKey); err != nil {
+			return err
+		}
+	}
+
+	// Set root subject; --subject overrides the one a strategy derives
+	rootSubject := splitSubject
 	for i := range result.Types {
-		if result.Types[i].IsRoot {
-			result.Types[i].Subject = splitSubject
+		if !result.Types[i].IsRoot {
+			continue
 		}
+		if rootSubject == "" {
+			rootSubject = result.Types[i].Subject
+		}
+		result.Types[i].Subject = rootSubject
 	}
 
 	output.Header("Split & Register: %s", splitFile)
 	output.Info("Schema type: %s", schemaType)
-	output.Info("Root subject: %s", splitSubject)
+	output.Info("Root subject: %s", rootSubject)
 	output.Info("Parts to register: %d", len(result.Types))
 	fmt.Println()
 
@@ -370,9 +434,6 @@ func runSplitRegister(cmd *cobra.Command, args []string) error {
 						role = "root"
 					}
 					subject := t.Subject
-					if t.IsRoot {
-						subject = splitSubject
-					}
 					deps := "none"
 					if len(t.References) > 0 {
 						deps = strings.Join(t.References, ", ")
@@ -392,6 +453,9 @@ func runSplitRegister(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := registerSplitParts(c, result, schemaType); err != nil {
+		return err
+	}
 
 	// Build a map for quick lookup
 	typeMap := make(map[string]*ExtractedType)
@@ -399,6 +463,49 @@ func runSplitRegister(cmd *cobra.Command, args []string) error {
 		typeMap[result.Types[i].Name] = &result.Types[i]
 	}
 
+	fmt.Println()
+	output.Success("All %d schemas registered successfully", len(result.Types))
+
+	// Show summary table
+	fmt.Println()
+	headers := []string{"Subject", "Role", "Size", "References"}
+	var rows [][]string
+	for _, name := range result.RegistrationOrder {
+		t := typeMap[name]
+		subject := t.Subject
+		role := "Reference"
+		if t.IsRoot {
+			role = "Root"
+		}
+		refStr := "-"
+		if len(t.References) > 0 {
+			refStr = fmt.Sprintf("%d", len(t.References))
+		}
+		rows = append(rows, []string{subject, role, output.FormatBytes(int64(t.Size)), refStr})
+	}
+	output.PrintTable(headers, rows)
+
+	return nil
+}
+
+// registerSplitParts registers the parts of a split schema in dependency
+// order, each with references to the parts it uses. For JSON Schema the
+// reference names must be exactly the $refs in the referring schema, which is
+// checked before anything is registered.
+func registerSplitParts(c client.SchemaRegistryClientInterface, result *SplitResult, schemaType string) error {
+	typeMap := make(map[string]*ExtractedType)
+	for i := range result.Types {
+		typeMap[result.Types[i].Name] = &result.Types[i]
+	}
+
+	if strings.ToUpper(schemaType) == "JSON" {
+		for i := range result.Types {
+			if err := checkJSONReferenceNames(&result.Types[i], typeMap); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Track registered versions for building references
 	registeredVersions := make(map[string]int) // subject -> version
 
@@ -406,18 +513,15 @@ func runSplitRegister(cmd *cobra.Command, args []string) error {
 	for i, name := range result.RegistrationOrder {
 		t := typeMap[name]
 		subject := t.Subject
-		if t.IsRoot {
-			subject = splitSubject
-		}
 
 		// Build references
 		var refs []client.SchemaReference
 		for _, depName := range t.References {
 			dep := typeMap[depName]
 			refName := getReferenceName(dep, schemaType)
-			version := registeredVersions[dep.Subject]
-			if version == 0 {
-				version = 1 // default to 1 if not yet registered
+			version, err := resolveReferenceVersion(c, dep.Subject, registeredVersions)
+			if err != nil {
+				return fmt.Errorf("failed to resolve reference %s for %s: %w", refName, subject, err)
 			}
 			refs = append(refs, client.SchemaReference{
 				Name:    refName,
@@ -440,59 +544,311 @@ func runSplitRegister(cmd *cobra.Command, args []string) error {
 		}
 
 		// Get the version that was registered
-		versions, err := c.GetVersions(subject, false)
-		if err == nil && len(versions) > 0 {
-			registeredVersions[subject] = versions[len(versions)-1]
-		} else {
-			registeredVersions[subject] = 1
+		version, err := registeredVersion(c, subject, id)
+		if err != nil {
+			return fmt.Errorf("registered %s with schema ID %d but failed to determine its version: %w", subject, id, err)
 		}
+		registeredVersions[subject] = version
 
 		output.Success("  Registered with schema ID %d", id)
 	}
+	return nil
+}
 
-	fmt.Println()
-	output.Success("All %d schemas registered successfully", len(result.Types))
-
-	// Show summary table
-	fmt.Println()
-	headers := []string{"Subject", "Role", "Size", "References"}
-	var rows [][]string
-	for _, name := range result.RegistrationOrder {
-		t := typeMap[name]
-		subject := t.Subject
-		if t.IsRoot {
-			subject = splitSubject
+// checkJSONReferenceNames makes sure the reference names a split JSON schema
+// will be registered with are the $refs it contains, and nothing else
+func checkJSONReferenceNames(t *ExtractedType, typeMap map[string]*ExtractedType) error {
+	used := make(map[string]bool)
+	for _, ref := range schemaExpectedReferences(t.Schema, "JSON") {
+		used[ref] = true
+	}
+	declared := make(map[string]bool)
+	for _, depName := range t.References {
+		dep, ok := typeMap[depName]
+		if !ok {
+			return fmt.Errorf("%s references %s, which is not part of the split", t.Name, depName)
 		}
-		role := "Reference"
-		if t.IsRoot {
-			role = "Root"
+		name := getReferenceName(dep, "JSON")
+		if !used[name] {
+			return fmt.Errorf("reference name %q for %s does not match any $ref in %s", name, dep.Subject, t.Name)
 		}
-		refStr := "-"
-		if len(t.References) > 0 {
-			refStr = fmt.Sprintf("%d", len(t.References))
+		declared[name] = true
+	}
+	for ref := range used {
+		if !declared[ref] {
+			return fmt.Errorf("%s uses $ref %q, which has no matching reference", t.Name, ref)
 		}
-		rows = append(rows, []string{subject, role, output.FormatBytes(int64(t.Size)), refStr})
 	}
-	output.PrintTable(headers, rows)
+	return nil
+}
+
+// applySubjectStrategy names the subjects of a split schema the way the
+// Confluent serializers would look them up:
+//
+//	topic         root: <topic>-value (or -key); parts: their reference names
+//	record        every part: its fully-qualified record name
+//	topic-record  every part: <topic>-<fully-qualified record name>
+//
+// Names for the strategies are accepted in Confluent's form too, e.g.
+// RecordNameStrategy.
+func applySubjectStrategy(result *SplitResult, schemaType, strategy, topic string, key bool) error {
+	kind, err := parseSubjectStrategy(strategy)
+	if err != nil {
+		return err
+	}
+	if splitSubjectPrefix != "" {
+		return fmt.Errorf("--subject-prefix cannot be combined with --subject-strategy")
+	}
+	if kind != "record" && topic == "" {
+		return fmt.Errorf("--topic is required for the %s subject strategy", kind)
+	}
 
+	seen := make(map[string]string)
+	for i := range result.Types {
+		t := &result.Types[i]
+		switch {
+		case kind == "topic" && t.IsRoot:
+			t.Subject = topic + "-value"
+			if key {
+				t.Subject = topic + "-key"
+			}
+		case kind == "topic":
+			t.Subject = getReferenceName(t, schemaType)
+		default:
+			name, err := splitRecordName(t, schemaType)
+			if err != nil {
+				return err
+			}
+			t.Subject = name
+			if kind == "topic-record" {
+				t.Subject = topic + "-" + name
+			}
+		}
+		if other, ok := seen[t.Subject]; ok {
+			return fmt.Errorf("%s and %s would both be registered under subject %s", other, t.Name, t.Subject)
+		}
+		seen[t.Subject] = t.Name
+	}
 	return nil
 }
 
+// parseSubjectStrategy normalizes a strategy name to topic, record or
+// topic-record
+func parseSubjectStrategy(strategy string) (string, error) {
+	s := strategy
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		s = s[i+1:] // io.confluent.kafka.serializers.subject.RecordNameStrategy
+	}
+	s = strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(s))
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "strategy"), "name")
+	switch s {
+	case "topic":
+		return "topic", nil
+	case "record":
+		return "record", nil
+	case "topicrecord":
+		return "topic-record", nil
+	}
+	return "", fmt.Errorf("unknown subject strategy %q (use topic, record or topic-record)", strategy)
+}
+
+// splitRecordName is the record name a serializer derives from a split part:
+// the full name of an Avro named type, the first message of a Protobuf file
+// qualified with its package, or a JSON schema's title (its extracted name
+// when it has none)
+func splitRecordName(t *ExtractedType, schemaType string) (string, error) {
+	switch strings.ToUpper(schemaType) {
+	case "PROTOBUF":
+		name := t.Name
+		for _, m := range parseProtobufMessages(t.Schema) {
+			if m.Kind == "message" {
+				name = m.Name
+				break
+			}
+		}
+		if pkg := extractProtobufPackage(t.Schema); pkg != "" {
+			name = pkg + "." + name
+		}
+		return name, nil
+	case "JSON":
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(t.Schema), &schema); err == nil {
+			if title, ok := schema["title"].(string); ok && title != "" {
+				return title, nil
+			}
+		}
+		return strings.TrimSuffix(t.Name, ".json"), nil
+	default:
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(t.Schema), &schema); err != nil {
+			return "", fmt.Errorf("%s is not a named Avro type, so it has no record name", t.Name)
+		}
+		if _, ok := schema["name"].(string); !ok {
+			return "", fmt.Errorf("%s is not a named Avro type, so it has no record name", t.Name)
+		}
+		return getAvroFullName(schema), nil
+	}
+}
+
+// resolveReferenceVersion returns the version of subject a reference should
+// point to: the version registered earlier in this run, or else the latest
+// version already in the registry. A subject that doesn't exist is an error
+// rather than a guess, since the registry would reject the reference anyway.
+func resolveReferenceVersion(c client.SchemaRegistryClientInterface, subject string, registered map[string]int) (int, error) {
+	if v := registered[subject]; v > 0 {
+		return v, nil
+	}
+	versions, err := c.GetVersions(subject, false)
+	if err != nil {
+		return 0, fmt.Errorf("referenced subject '%s' is not registered: %w", subject, err)
+	}
+	if len(versions) == 0 {
+		return 0, fmt.Errorf("referenced subject '%s' has no versions", subject)
+	}
+	return versions[len(versions)-1], nil
+}
+
+// registeredVersion returns the version of subject holding schema id. When
+// the schema was already registered, that can be older than the latest
+// version, so the subject's versions are only used as a fallback.
+func registeredVersion(c client.SchemaRegistryClientInterface, subject string, id int) (int, error) {
+	if svs, err := c.GetSchemaSubjectVersionsByID(id); err == nil {
+		version := 0
+		for _, sv := range svs {
+			if sv.Subject == subject && sv.Version > version {
+				version = sv.Version
+			}
+		}
+		if version > 0 {
+			return version, nil
+		}
+	}
+
+	versions, err := c.GetVersions(subject, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, fmt.Errorf("subject '%s' has no versions", subject)
+	}
+	return versions[len(versions)-1], nil
+}
+
 // ========================
 // Schema splitting logic
 // ========================
 
 func splitSchema(content, schemaType, filename string, minSize int, subjectPrefix string, depth int) (*SplitResult, error) {
+	var result *SplitResult
+	var err error
+
 	switch strings.ToUpper(schemaType) {
 	case "AVRO":
-		return splitAvroSchema(content, minSize, subjectPrefix, depth)
+		result, err = splitAvroSchema(content, minSize, subjectPrefix, depth)
 	case "PROTOBUF":
-		return splitProtobufSchema(content, filename, minSize, subjectPrefix)
+		result, err = splitProtobufSchema(content, filename, minSize, subjectPrefix)
 	case "JSON":
-		return splitJSONSchema(content, minSize, subjectPrefix)
+		result, err = splitJSONSchema(content, minSize, subjectPrefix)
 	default:
 		return nil, fmt.Errorf("unsupported schema type: %s", schemaType)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Cycles = findSplitCycles(result)
+	return result, nil
+}
+
+// findSplitCycles returns the circular dependencies between the types of a
+// split. Schema Registry requires a reference to exist before the schema that
+// uses it, so types in a cycle can't be registered as separate subjects.
+func findSplitCycles(result *SplitResult) [][]string {
+	deps := make(map[string][]string)
+	for _, t := range result.Types {
+		deps[t.Name] = t.References
+	}
+	return findDependencyCycles(deps)
+}
+
+// findDependencyCycles detects cycles in a dependency graph. Each cycle is
+// returned as the path of type names that closes on itself, e.g.
+// [A B A] for A -> B -> A. Self-references are ignored: a recursive type is
+// always kept within a single schema.
+func findDependencyCycles(deps map[string][]string) [][]string {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int)
+	seen := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = inProgress
+		stack = append(stack, name)
+
+		depList := append([]string(nil), deps[name]...)
+		sort.Strings(depList)
+		for _, dep := range depList {
+			if dep == name {
+				continue
+			}
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case inProgress:
+				// Found a back edge: the cycle is the stack from dep to here
+				start := len(stack) - 1
+				for stack[start] != dep {
+					start--
+				}
+				cycle := append(append([]string(nil), stack[start:]...), dep)
+				if key := canonicalCycleKey(cycle); !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, cycle)
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+
+	return cycles
+}
+
+// canonicalCycleKey identifies a cycle independent of its starting point
+func canonicalCycleKey(cycle []string) string {
+	nodes := cycle[:len(cycle)-1]
+	minIdx := 0
+	for i, n := range nodes {
+		if n < nodes[minIdx] {
+			minIdx = i
+		}
+	}
+	rotated := append(append([]string(nil), nodes[minIdx:]...), nodes[:minIdx]...)
+	return strings.Join(rotated, " -> ")
+}
+
+// formatCycle renders a cycle path as "A -> B -> A"
+func formatCycle(cycle []string) string {
+	return strings.Join(cycle, " -> ")
 }
 
 // ========================
@@ -505,9 +861,13 @@ func splitAvroSchema(content string, minSize int, subjectPrefix string, depth in
 		return nil, fmt.Errorf("failed to parse Avro schema: %w", err)
 	}
 
+	// Unions, arrays and maps at the top level have no single named root type
 	schemaMap, ok := schema.(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("expected Avro schema to be a JSON object")
+		return splitAvroAnonymousRoot(schema, content, minSize, subjectPrefix, depth)
+	}
+	if t, _ := schemaMap["type"].(string); t == "array" || t == "map" {
+		return splitAvroAnonymousRoot(schema, content, minSize, subjectPrefix, depth)
 	}
 
 	// Work on a deep copy so the original schema is preserved for min-size filtering.
@@ -590,7 +950,10 @@ func splitAvroSchema(content string, minSize int, subjectPrefix string, depth in
 		}
 
 		// Rebuild from original: walk the original schema tree, only extract
-		// types in survivedTypes, leave everything else inline.
+		// types in survivedTypes, leave everything else inline. The root is
+		// extracted too, so the kept types are replaced by references in it
+		// rather than defined a second time.
+		survivedTypes[rootName] = true
 		var freshCopy interface{}
 		json.Unmarshal(copyBytes, &freshCopy)
 		freshMap := freshCopy.(map[string]interface{})
@@ -624,7 +987,7 @@ func splitAvroSchema(content string, minSize int, subjectPrefix string, depth in
 				if !ok {
 					continue
 				}
-				refs := findAvroStringTypeRefs(field["type"], extractedTypes)
+				refs := findAvroStringTypeRefs(field["type"], avroTypeNamespace(typeSchema, ""), extractedTypes)
 				for _, ref := range refs {
 					if ref != typeName {
 						typeDeps[typeName] = appendUnique(typeDeps[typeName], ref)
@@ -637,7 +1000,13 @@ func splitAvroSchema(content string, minSize int, subjectPrefix string, depth in
 	// Topological sort for registration order
 	regOrder := topologicalSort(typeDeps, rootName)
 
-	// Build result
+	return buildAvroSplitResult(content, rootName, rootSchema, extractedTypes, typeDeps, regOrder, subjectPrefix), nil
+}
+
+// buildAvroSplitResult assembles the SplitResult for an Avro split in
+// registration order. rootSchema is the root with extracted types replaced by
+// references; extractedTypes holds every other extracted named type.
+func buildAvroSplitResult(content, rootName string, rootSchema interface{}, extractedTypes map[string]map[string]interface{}, typeDeps map[string][]string, regOrder []string, subjectPrefix string) *SplitResult {
 	result := &SplitResult{
 		OriginalSize:      len(content),
 		SchemaType:        "AVRO",
@@ -681,14 +1050,123 @@ func splitAvroSchema(content string, minSize int, subjectPrefix string, depth in
 		})
 	}
 
-	return result, nil
+	return result
+}
+
+// splitAvroAnonymousRoot splits a schema whose top level is a union, array or
+// map rather than a named type. The root has no name of its own, so it is
+// given a synthetic one ("root-union", "root-array", "root-map") that can't
+// collide with an Avro name; split register replaces it with --subject.
+func splitAvroAnonymousRoot(schema interface{}, content string, minSize int, subjectPrefix string, depth int) (*SplitResult, error) {
+	rootName := "root-" + avroAnonymousKind(schema)
+
+	unsplit := &SplitResult{
+		OriginalSize:      len(content),
+		SchemaType:        "AVRO",
+		Types:             []ExtractedType{{Name: rootName, Subject: rootName, Schema: content, SchemaType: "AVRO", Size: len(content), IsRoot: true, Order: 0}},
+		RegistrationOrder: []string{rootName},
+	}
+
+	// Candidate types: every named type, or only those directly under the
+	// root when depth-limited. Candidates are measured for --min-size.
+	candidates := make(map[string]map[string]interface{})
+	if depth == 1 {
+		collectAvroTopLevelNamedTypes(schema, candidates)
+	} else {
+		extractAvroFieldDeps(schema, "", candidates, make(map[string][]string))
+	}
+
+	keepSet := make(map[string]bool)
+	for name, typeSchema := range candidates {
+		if minSize > 0 {
+			serialized, _ := json.Marshal(typeSchema)
+			if len(serialized) < minSize {
+				continue
+			}
+		}
+		keepSet[name] = true
+	}
+	if len(keepSet) == 0 {
+		return unsplit, nil
+	}
+
+	// Extract the kept types from the original tree; everything else stays inline
+	extractedTypes := make(map[string]map[string]interface{})
+	typeDeps := make(map[string][]string)
+	for _, dep := range extractAvroFieldDepsSelective(schema, "", extractedTypes, typeDeps, keepSet) {
+		typeDeps[rootName] = appendUnique(typeDeps[rootName], dep)
+	}
+	rootSchema := replaceAvroInlineTypesSelective(schema, "", keepSet)
+	if len(extractedTypes) == 0 {
+		return unsplit, nil
+	}
+
+	// Pick up references by name, both from the root and between extracted types
+	for _, ref := range findAvroStringTypeRefs(schema, "", extractedTypes) {
+		typeDeps[rootName] = appendUnique(typeDeps[rootName], ref)
+	}
+	for typeName, typeSchema := range extractedTypes {
+		if fields, ok := typeSchema["fields"].([]interface{}); ok {
+			for _, f := range fields {
+				field, ok := f.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				for _, ref := range findAvroStringTypeRefs(field["type"], avroTypeNamespace(typeSchema, ""), extractedTypes) {
+					if ref != typeName {
+						typeDeps[typeName] = appendUnique(typeDeps[typeName], ref)
+					}
+				}
+			}
+		}
+	}
+
+	regOrder := topologicalSort(typeDeps, rootName)
+	return buildAvroSplitResult(content, rootName, rootSchema, extractedTypes, typeDeps, regOrder, subjectPrefix), nil
+}
+
+// avroAnonymousKind names the kind of an unnamed top-level Avro schema
+func avroAnonymousKind(schema interface{}) string {
+	switch s := schema.(type) {
+	case []interface{}:
+		return "union"
+	case map[string]interface{}:
+		if t, _ := s["type"].(string); t != "" {
+			return t
+		}
+	case string:
+		return s
+	}
+	return "schema"
+}
+
+// collectAvroTopLevelNamedTypes finds named types reachable from t without
+// passing through another named type (i.e. union branches, array items and
+// map values), keeping their nested types inline.
+func collectAvroTopLevelNamedTypes(t interface{}, out map[string]map[string]interface{}) {
+	switch ft := t.(type) {
+	case map[string]interface{}:
+		typeName, _ := ft["type"].(string)
+		switch typeName {
+		case "record", "enum", "fixed":
+			out[getAvroFullName(ft)] = ft
+		case "array":
+			collectAvroTopLevelNamedTypes(ft["items"], out)
+		case "map":
+			collectAvroTopLevelNamedTypes(ft["values"], out)
+		}
+	case []interface{}:
+		for _, ut := range ft {
+			collectAvroTopLevelNamedTypes(ut, out)
+		}
+	}
 }
 
 // extractAvroTopLevelTypes extracts only the direct field types of the root record.
 // Each extracted type retains all its nested types inline — no recursive flattening.
 func extractAvroTopLevelTypes(rootSchema map[string]interface{}, extracted map[string]map[string]interface{}, deps map[string][]string) {
 	rootName := getAvroFullName(rootSchema)
-	namespace, _ := rootSchema["namespace"].(string)
+	namespace := avroTypeNamespace(rootSchema, "")
 
 	fields, ok := rootSchema["fields"].([]interface{})
 	if !ok {
@@ -747,14 +1225,14 @@ func extractTopLevelFieldType(fieldType interface{}, namespace string) (replacem
 		typeName, _ := ft["type"].(string)
 		switch typeName {
 		case "record", "enum", "fixed":
-			fullName := getAvroFullName(ft)
-			return fullName, ft
+			named, fullName := withAvroNamespace(ft, namespace)
+			return fullName, named
 		case "array":
 			if items, ok := ft["items"].(map[string]interface{}); ok {
 				itemType, _ := items["type"].(string)
 				if itemType == "record" || itemType == "enum" || itemType == "fixed" {
-					fullName := getAvroFullName(items)
-					return map[string]interface{}{"type": "array", "items": fullName}, items
+					named, fullName := withAvroNamespace(items, namespace)
+					return copyAvroNode(ft, "items", fullName), named
 				}
 			}
 			return ft, nil
@@ -769,16 +1247,16 @@ func extractTopLevelFieldType(fieldType interface{}, namespace string) (replacem
 				typeName, _ := utMap["type"].(string)
 				switch typeName {
 				case "record", "enum", "fixed":
-					fullName := getAvroFullName(utMap)
+					named, fullName := withAvroNamespace(utMap, namespace)
 					result = append(result, fullName)
-					extractedType = utMap
+					extractedType = named
 				case "array":
 					if items, ok := utMap["items"].(map[string]interface{}); ok {
 						itemType, _ := items["type"].(string)
 						if itemType == "record" || itemType == "enum" || itemType == "fixed" {
-							fullName := getAvroFullName(items)
-							result = append(result, map[string]interface{}{"type": "array", "items": fullName})
-							extractedType = items
+							named, fullName := withAvroNamespace(items, namespace)
+							result = append(result, copyAvroNode(utMap, "items", fullName))
+							extractedType = named
 						} else {
 							result = append(result, ut)
 						}
@@ -798,6 +1276,34 @@ func extractTopLevelFieldType(fieldType interface{}, namespace string) (replacem
 	}
 }
 
+// withAvroNamespace returns a named type with the namespace it inherits from
+// its parent made explicit, and its full name, so that once extracted into a
+// schema of its own it keeps the same full name
+func withAvroNamespace(named map[string]interface{}, namespace string) (map[string]interface{}, string) {
+	name, _ := named["name"].(string)
+	if _, ok := named["namespace"]; ok || namespace == "" || strings.Contains(name, ".") {
+		return named, getAvroFullName(named)
+	}
+	qualified := make(map[string]interface{}, len(named)+1)
+	for k, v := range named {
+		qualified[k] = v
+	}
+	qualified["namespace"] = namespace
+	return qualified, getAvroFullName(qualified)
+}
+
+// copyAvroNode returns a copy of an array or map type with its items or values
+// (key) replaced, keeping every other attribute: logicalType, connect.*
+// properties and any other custom annotations
+func copyAvroNode(node map[string]interface{}, key string, child interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(node))
+	for k, v := range node {
+		result[k] = v
+	}
+	result[key] = child
+	return result
+}
+
 func getAvroFullName(schema map[string]interface{}) string {
 	name, _ := schema["name"].(string)
 	namespace, _ := schema["namespace"].(string)
@@ -807,12 +1313,23 @@ func getAvroFullName(schema map[string]interface{}) string {
 	return name
 }
 
+// avroTypeNamespace returns the namespace of a named type, which the types
+// defined and referenced inside it inherit: that of a full name, else its
+// namespace attribute, else the enclosing namespace
+func avroTypeNamespace(schema map[string]interface{}, parentNamespace string) string {
+	name, _ := schema["name"].(string)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i]
+	}
+	if namespace, ok := schema["namespace"].(string); ok {
+		return namespace
+	}
+	return parentNamespace
+}
+
 func extractAvroNamedTypes(schema map[string]interface{}, parentNamespace string, extracted map[string]map[string]interface{}, deps map[string][]string) {
 	schemaType, _ := schema["type"].(string)
-	namespace, hasNS := schema["namespace"].(string)
-	if !hasNS {
-		namespace = parentNamespace
-	}
+	namespace := avroTypeNamespace(schema, parentNamespace)
 
 	fullName := getAvroFullName(schema)
 	if fullName == "" {
@@ -833,7 +1350,7 @@ func extractAvroNamedTypes(schema map[string]interface{}, parentNamespace string
 		for k, v := range schema {
 			typeCopy[k] = v
 		}
-		if namespace != "" {
+		if namespace != "" && !strings.Contains(fullName, ".") {
 			typeCopy["namespace"] = namespace
 		}
 
@@ -876,10 +1393,7 @@ func extractAvroNamedTypes(schema map[string]interface{}, parentNamespace string
 // left inline in their parent schema.
 func extractAvroNamedTypesSelective(schema map[string]interface{}, parentNamespace string, extracted map[string]map[string]interface{}, deps map[string][]string, keepSet map[string]bool) {
 	schemaType, _ := schema["type"].(string)
-	namespace, hasNS := schema["namespace"].(string)
-	if !hasNS {
-		namespace = parentNamespace
-	}
+	namespace := avroTypeNamespace(schema, parentNamespace)
 
 	fullName := getAvroFullName(schema)
 
@@ -892,7 +1406,7 @@ func extractAvroNamedTypesSelective(schema map[string]interface{}, parentNamespa
 			for k, v := range schema {
 				typeCopy[k] = v
 			}
-			if namespace != "" {
+			if namespace != "" && !strings.Contains(fullName, ".") {
 				typeCopy["namespace"] = namespace
 			}
 
@@ -1016,17 +1530,15 @@ func replaceAvroInlineTypesSelective(fieldType interface{}, namespace string, ke
 			}
 			return ft // Leave inline
 		case "array":
-			result := map[string]interface{}{"type": "array"}
 			if items, ok := ft["items"]; ok {
-				result["items"] = replaceAvroInlineTypesSelective(items, namespace, keepSet)
+				return copyAvroNode(ft, "items", replaceAvroInlineTypesSelective(items, namespace, keepSet))
 			}
-			return result
+			return ft
 		case "map":
-			result := map[string]interface{}{"type": "map"}
 			if values, ok := ft["values"]; ok {
-				result["values"] = replaceAvroInlineTypesSelective(values, namespace, keepSet)
+				return copyAvroNode(ft, "values", replaceAvroInlineTypesSelective(values, namespace, keepSet))
 			}
-			return result
+			return ft
 		default:
 			return ft
 		}
@@ -1074,14 +1586,21 @@ func extractAvroFieldDeps(fieldType interface{}, namespace string, extracted map
 }
 
 // findAvroStringTypeRefs finds string-based references to extracted types
-// in a field's type definition. This catches cases like a field typed as
-// "com.example.types.Money" where Money was already extracted elsewhere.
-func findAvroStringTypeRefs(fieldType interface{}, extracted map[string]map[string]interface{}) []string {
+// (including the root) in a field's type definition. This catches cases like a
+// field typed as "com.example.types.Money" where Money was already extracted
+// elsewhere, or a back-reference to an enclosing record by its short name.
+// Names are resolved against namespace, the enclosing type's namespace.
+func findAvroStringTypeRefs(fieldType interface{}, namespace string, extracted map[string]map[string]interface{}) []string {
 	var refs []string
 
 	switch ft := fieldType.(type) {
 	case string:
-		if _, exists := extracted[ft]; exists {
+		if avroPrimitiveTypes[ft] {
+			break
+		}
+		if full := avroFullName(ft, namespace); extracted[full] != nil {
+			refs = append(refs, full)
+		} else if extracted[ft] != nil {
 			refs = append(refs, ft)
 		}
 	case map[string]interface{}:
@@ -1089,16 +1608,16 @@ func findAvroStringTypeRefs(fieldType interface{}, extracted map[string]map[stri
 		switch typeName {
 		case "array":
 			if items, ok := ft["items"]; ok {
-				refs = append(refs, findAvroStringTypeRefs(items, extracted)...)
+				refs = append(refs, findAvroStringTypeRefs(items, namespace, extracted)...)
 			}
 		case "map":
 			if values, ok := ft["values"]; ok {
-				refs = append(refs, findAvroStringTypeRefs(values, extracted)...)
+				refs = append(refs, findAvroStringTypeRefs(values, namespace, extracted)...)
 			}
 		}
 	case []interface{}:
 		for _, ut := range ft {
-			refs = append(refs, findAvroStringTypeRefs(ut, extracted)...)
+			refs = append(refs, findAvroStringTypeRefs(ut, namespace, extracted)...)
 		}
 	}
 
@@ -1120,17 +1639,15 @@ func replaceAvroInlineTypes(fieldType interface{}, namespace string, extracted m
 			}
 			return ft
 		case "array":
-			result := map[string]interface{}{"type": "array"}
 			if items, ok := ft["items"]; ok {
-				result["items"] = replaceAvroInlineTypes(items, namespace, extracted)
+				return copyAvroNode(ft, "items", replaceAvroInlineTypes(items, namespace, extracted))
 			}
-			return result
+			return ft
 		case "map":
-			result := map[string]interface{}{"type": "map"}
 			if values, ok := ft["values"]; ok {
-				result["values"] = replaceAvroInlineTypes(values, namespace, extracted)
+				return copyAvroNode(ft, "values", replaceAvroInlineTypes(values, namespace, extracted))
 			}
-			return result
+			return ft
 		default:
 			return ft
 		}
@@ -1146,6 +1663,10 @@ func replaceAvroInlineTypes(fieldType interface{}, namespace string, extracted m
 	}
 }
 
+// buildAvroRootSchema returns the root record with extracted types replaced by
+// references. Its fields, like those of every extracted record, keep their
+// order and all their attributes (default, doc, order, aliases); only field
+// types are rewritten, since Avro encoding depends on field order.
 func buildAvroRootSchema(original map[string]interface{}, extracted map[string]map[string]interface{}, rootName string) map[string]interface{} {
 	if rootSchema, ok := extracted[rootName]; ok {
 		return rootSchema
@@ -1158,7 +1679,7 @@ func buildAvroRootSchema(original map[string]interface{}, extracted map[string]m
 // ========================
 
 func splitProtobufSchema(content, filename string, minSize int, subjectPrefix string) (*SplitResult, error) {
-	// Parse protobuf messages
+	// Parse top-level messages and enums; nested types stay inside their parent
 	messages := parseProtobufMessages(content)
 
 	if len(messages) <= 1 {
@@ -1174,9 +1695,11 @@ func splitProtobufSchema(content, filename string, minSize int, subjectPrefix st
 		}, nil
 	}
 
-	// Extract package and syntax
+	// Extract package, syntax and the file-level header every split file needs
 	pkg := extractProtobufPackage(content)
 	syntax := extractProtobufSyntax(content)
+	imports := extractProtobufImports(content)
+	options := extractProtobufOptions(content)
 
 	// Build individual .proto files for each message
 	var types []ExtractedType
@@ -1189,10 +1712,15 @@ func splitProtobufSchema(content, filename string, minSize int, subjectPrefix st
 	}
 
 	for _, msg := range messages {
-		// Check which other messages this one references
+		if msg.Kind == "enum" {
+			deps[msg.Name] = nil
+			continue
+		}
+		// Check which other messages this one references from its field types
+		refs := protoFieldTypeRefs(msg.Body, pkg, msgNames)
 		var msgDeps []string
 		for _, other := range messages {
-			if other.Name != msg.Name && strings.Contains(msg.Body, other.Name) {
+			if other.Name != msg.Name && refs[other.Name] {
 				msgDeps = append(msgDeps, other.Name)
 			}
 		}
@@ -1208,23 +1736,49 @@ func splitProtobufSchema(content, filename string, minSize int, subjectPrefix st
 		}
 	}
 	for _, msg := range messages {
-		if !referencedBy[msg.Name] {
+		if msg.Kind == "message" && !referencedBy[msg.Name] {
 			rootName = msg.Name
 			break
 		}
 	}
 
+	// Messages smaller than --min-size stay in the file of the message using them
+	owner := groupProtobufMessages(messages, deps, rootName, minSize)
+	unitDeps := make(map[string][]string)
+	for _, msg := range messages {
+		unit := owner[msg.Name]
+		if _, ok := unitDeps[unit]; !ok {
+			unitDeps[unit] = nil
+		}
+		for _, d := range deps[msg.Name] {
+			if owner[d] != unit {
+				unitDeps[unit] = appendUnique(unitDeps[unit], owner[d])
+			}
+		}
+	}
+
+	// If filtering left only the root, return original
+	if len(unitDeps) <= 1 {
+		return &SplitResult{
+			OriginalSize:      len(content),
+			SchemaType:        "PROTOBUF",
+			Types:             []ExtractedType{{Name: rootName, Subject: rootName, Schema: content, SchemaType: "PROTOBUF", Size: len(content), IsRoot: true, Order: 0}},
+			RegistrationOrder: []string{rootName},
+		}, nil
+	}
+
 	// Topological sort
-	regOrder := topologicalSort(deps, rootName)
+	regOrder := topologicalSort(unitDeps, rootName)
 
-	// Build proto file for each message
+	// Build proto file for each extracted message and the ones kept with it
 	for i, name := range regOrder {
 		isRoot := name == rootName
-		var msg protoMessage
+		var members []protoMessage
+		hasMessage := false
 		for _, m := range messages {
-			if m.Name == name {
-				msg = m
-				break
+			if owner[m.Name] == name {
+				members = append(members, m)
+				hasMessage = hasMessage || m.Kind == "message"
 			}
 		}
 
@@ -1234,28 +1788,41 @@ func splitProtobufSchema(content, filename string, minSize int, subjectPrefix st
 			sb.WriteString(fmt.Sprintf("package %s;\n\n", pkg))
 		}
 
-		// Add imports for dependencies
-		for _, dep := range deps[name] {
-			fileName := toSnakeCase(dep) + ".proto"
-			sb.WriteString(fmt.Sprintf("import \"%s\";\n", fileName))
+		// Add imports for dependencies, then the original file's imports
+		// (well-known types etc.) so every type used in a message resolves
+		fileImports := make([]string, 0, len(unitDeps[name])+len(imports))
+		for _, dep := range unitDeps[name] {
+			fileImports = append(fileImports, fmt.Sprintf("import \"%s\";", protoFileName(dep)))
+		}
+		if hasMessage {
+			fileImports = append(fileImports, imports...)
+		}
+		for _, imp := range fileImports {
+			sb.WriteString(imp + "\n")
+		}
+		if len(fileImports) > 0 {
+			sb.WriteString("\n")
+		}
+
+		for _, opt := range options {
+			sb.WriteString(opt + "\n")
 		}
-		if len(deps[name]) > 0 {
+		if len(options) > 0 {
 			sb.WriteString("\n")
 		}
 
-		sb.WriteString(fmt.Sprintf("message %s {\n%s}\n", msg.Name, msg.Body))
+		for j, m := range members {
+			if j > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(protoDefinition(m))
+		}
 
 		schemaContent := sb.String()
 
-		subject := toSnakeCase(name) + ".proto"
+		subject := protoFileName(name)
 		if subjectPrefix != "" && !isRoot {
-			subject = subjectPrefix + toSnakeCase(name) + ".proto"
-		}
-
-		// Filter deps to only include extracted types
-		var filteredDeps []string
-		for _, d := range deps[name] {
-			filteredDeps = append(filteredDeps, d)
+			subject = subjectPrefix + protoFileName(name)
 		}
 
 		types = append(types, ExtractedType{
@@ -1264,7 +1831,7 @@ func splitProtobufSchema(content, filename string, minSize int, subjectPrefix st
 			Schema:     schemaContent,
 			SchemaType: "PROTOBUF",
 			Size:       len(schemaContent),
-			References: filteredDeps,
+			References: unitDeps[name],
 			IsRoot:     isRoot,
 			Order:      i,
 		})
@@ -1278,50 +1845,276 @@ func splitProtobufSchema(content, filename string, minSize int, subjectPrefix st
 	}, nil
 }
 
+// groupProtobufMessages maps every message to the message whose file will
+// define it. With minSize > 0, a message whose definition is smaller than
+// minSize is kept in the file of the single message referencing it (or the
+// root file if nothing references it). Small messages used by several others
+// are still extracted, since defining them twice in one package would clash.
+func groupProtobufMessages(messages []protoMessage, deps map[string][]string, rootName string, minSize int) map[string]string {
+	owner := make(map[string]string)
+	for _, m := range messages {
+		owner[m.Name] = m.Name
+	}
+	if minSize <= 0 {
+		return owner
+	}
+
+	referrers := make(map[string][]string)
+	for _, m := range messages {
+		for _, d := range deps[m.Name] {
+			referrers[d] = append(referrers[d], m.Name)
+		}
+	}
+
+	resolve := func(name string) string {
+		for owner[name] != name {
+			name = owner[name]
+		}
+		return name
+	}
+
+	for _, m := range messages {
+		if m.Name == rootName || len(protoDefinition(m)) >= minSize {
+			continue
+		}
+		var target string
+		switch len(referrers[m.Name]) {
+		case 0:
+			target = rootName
+		case 1:
+			target = referrers[m.Name][0]
+		default:
+			continue
+		}
+		// Don't merge into a message that is itself kept with this one
+		if resolve(target) != m.Name {
+			owner[m.Name] = target
+		}
+	}
+
+	for name := range owner {
+		owner[name] = resolve(name)
+	}
+	return owner
+}
+
+// protoDefinition renders a top-level message or enum definition
+func protoDefinition(m protoMessage) string {
+	return fmt.Sprintf("%s %s {\n%s}\n", m.Kind, m.Name, m.Body)
+}
+
+// protoFileName returns the file name, and import path, of an extracted
+// Protobuf type
+func protoFileName(name string) string {
+	return toSnakeCase(shortName(name)) + ".proto"
+}
+
+// protoMessage is a top-level message or enum definition
 type protoMessage struct {
+	Kind string // "message" or "enum"
 	Name string
 	Body string // The body between braces
 }
 
+var protoDeclRe = regexp.MustCompile(`^\s+(\w+)\s*\{`)
+
 func parseProtobufMessages(content string) []protoMessage {
 	var messages []protoMessage
 
-	// Match top-level message definitions
-	re := regexp.MustCompile(`(?m)^message\s+(\w+)\s*\{`)
-	matches := re.FindAllStringSubmatchIndex(content, -1)
-
-	for _, match := range matches {
-		name := content[match[2]:match[3]]
-		braceStart := match[0]
-		// Find matching closing brace
-		depth := 0
-		bodyStart := -1
-		bodyEnd := -1
-		for i := braceStart; i < len(content); i++ {
-			if content[i] == '{' {
-				if depth == 0 {
-					bodyStart = i + 1
-				}
-				depth++
-			} else if content[i] == '}' {
-				depth--
-				if depth == 0 {
-					bodyEnd = i
-					break
-				}
-			}
+	// Walk the file tracking brace depth so only top-level definitions are
+	// matched; nested messages and enums remain part of their parent's body
+	depth := 0
+	for i := 0; i < len(content); {
+		if next := skipProtoCommentOrString(content, i); next != i {
+			i = next
+			continue
 		}
-		if bodyStart >= 0 && bodyEnd >= 0 {
+
+		c := content[i]
+		switch {
+		case c == '{':
+			depth++
+			i++
+		case c == '}':
+			depth--
+			i++
+		case c == '_' || isASCIIAlnum(c):
+			start := i
+			for i < len(content) && (content[i] == '_' || content[i] == '.' || isASCIIAlnum(content[i])) {
+				i++
+			}
+			word := content[start:i]
+			if depth != 0 || (word != "message" && word != "enum") {
+				continue
+			}
+			m := protoDeclRe.FindStringSubmatchIndex(content[i:])
+			if m == nil {
+				continue
+			}
+			bodyStart := i + m[1]
+			bodyEnd := findProtoBlockEnd(content, bodyStart)
+			if bodyEnd < 0 {
+				return messages
+			}
 			messages = append(messages, protoMessage{
-				Name: name,
+				Kind: word,
+				Name: content[i+m[2] : i+m[3]],
 				Body: content[bodyStart:bodyEnd],
 			})
+			i = bodyEnd + 1
+		default:
+			i++
 		}
 	}
 
 	return messages
 }
 
+// findProtoBlockEnd returns the index of the brace closing the block whose
+// body starts at start, or -1 if the block is unterminated
+func findProtoBlockEnd(content string, start int) int {
+	depth := 1
+	for i := start; i < len(content); {
+		if next := skipProtoCommentOrString(content, i); next != i {
+			i = next
+			continue
+		}
+		switch content[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+		i++
+	}
+	return -1
+}
+
+// skipProtoCommentOrString returns the index just past the comment or string
+// literal starting at i, or i itself if there is none
+func skipProtoCommentOrString(src string, i int) int {
+	c := src[i]
+	switch {
+	case c == '/' && i+1 < len(src) && src[i+1] == '/':
+		for i < len(src) && src[i] != '\n' {
+			i++
+		}
+		return i
+	case c == '/' && i+1 < len(src) && src[i+1] == '*':
+		end := strings.Index(src[i+2:], "*/")
+		if end < 0 {
+			return len(src)
+		}
+		return i + end + 4
+	case c == '"' || c == '\'':
+		i++
+		for i < len(src) && src[i] != c {
+			if src[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i < len(src) {
+			i++
+		}
+		return i
+	}
+	return i
+}
+
+// protoFieldTypeRefs returns the top-level messages referenced as field types
+// in a message body. Only the type position of a field declaration counts
+// ("Type name = N", including repeated/optional and map<K, V> values), so
+// field names, comments, string literals and option values never match.
+// Qualified names are resolved against pkg, and "Outer.Inner" refers to Outer.
+func protoFieldTypeRefs(body, pkg string, topLevel map[string]bool) map[string]bool {
+	tokens := tokenizeProto(body)
+
+	// Nested messages and enums shadow top-level types of the same name
+	nested := make(map[string]bool)
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i] == "message" || tokens[i] == "enum" {
+			nested[tokens[i+1]] = true
+		}
+	}
+
+	refs := make(map[string]bool)
+	addRef := func(typeName string) {
+		qualified := strings.HasPrefix(typeName, ".") || strings.Contains(typeName, ".")
+		name := strings.TrimPrefix(typeName, ".")
+		if pkg != "" && strings.HasPrefix(name, pkg+".") {
+			name = strings.TrimPrefix(name, pkg+".")
+		} else if strings.HasPrefix(typeName, ".") && pkg != "" {
+			return // fully-qualified name from another package
+		}
+		if i := strings.Index(name, "."); i >= 0 {
+			name = name[:i]
+		}
+		if !qualified && nested[name] {
+			return
+		}
+		if topLevel[name] {
+			refs[name] = true
+		}
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		switch {
+		case tokens[i] == "map" && i+5 < len(tokens) && tokens[i+1] == "<" && tokens[i+3] == "," && tokens[i+5] == ">":
+			// map<KeyType, ValueType> name = N;
+			addRef(tokens[i+4])
+			i += 5
+		case i+2 < len(tokens) && isProtoIdent(tokens[i]) && isProtoIdent(tokens[i+1]) && tokens[i+2] == "=":
+			// [label] Type name = N;
+			if tokens[i] != "option" {
+				addRef(tokens[i])
+			}
+		}
+	}
+	return refs
+}
+
+// tokenizeProto splits protobuf source into identifiers (including dotted
+// names) and single-character symbols, dropping comments and string literals
+func tokenizeProto(src string) []string {
+	var tokens []string
+	for i := 0; i < len(src); {
+		if next := skipProtoCommentOrString(src, i); next != i {
+			i = next
+			continue
+		}
+		c := src[i]
+		switch {
+		case c == '_' || c == '.' || isASCIIAlnum(c):
+			start := i
+			for i < len(src) && (src[i] == '_' || src[i] == '.' || isASCIIAlnum(src[i])) {
+				i++
+			}
+			tokens = append(tokens, src[start:i])
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens
+}
+
+func isASCIIAlnum(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// isProtoIdent reports whether tok is a (possibly dotted) identifier rather
+// than a symbol or number
+func isProtoIdent(tok string) bool {
+	c := tok[0]
+	return c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
 func extractProtobufPackage(content string) string {
 	re := regexp.MustCompile(`(?m)^package\s+([\w.]+)\s*;`)
 	matches := re.FindStringSubmatch(content)
@@ -1331,6 +2124,18 @@ func extractProtobufPackage(content string) string {
 	return ""
 }
 
+// extractProtobufImports returns the file's import statements
+func extractProtobufImports(content string) []string {
+	re := regexp.MustCompile(`(?m)^import\s+(?:public\s+|weak\s+)?"[^"]+"\s*;`)
+	return re.FindAllString(content, -1)
+}
+
+// extractProtobufOptions returns the file-level option statements
+func extractProtobufOptions(content string) []string {
+	re := regexp.MustCompile(`(?m)^option\s+[^;]+;`)
+	return re.FindAllString(content, -1)
+}
+
 func extractProtobufSyntax(content string) string {
 	re := regexp.MustCompile(`syntax\s*=\s*"(proto[23])"\s*;`)
 	matches := re.FindStringSubmatch(content)
@@ -1350,16 +2155,37 @@ func splitJSONSchema(content string, minSize int, subjectPrefix string) (*SplitR
 		return nil, fmt.Errorf("failed to parse JSON schema: %w", err)
 	}
 
-	// Extract named object types from properties
-	extracted := make(map[string]map[string]interface{})
-	deps := make(map[string][]string)
 	rootName := "root"
 	if id, ok := schema["$id"].(string); ok {
 		rootName = id
 	}
 
-	// Walk properties and extract nested objects
-	extractJSONSchemaTypes(schema, rootName, extracted, deps, "")
+	// Definitions in $defs/definitions become schemas of their own, with the
+	// local $refs to them rewritten to external ones
+	defs := newJSONDefinitions(schema, minSize)
+	schema = defs.rewrite(schema, "").(map[string]interface{})
+
+	// Walk properties and extract nested objects, leaving those in inline in
+	// place. A root that isn't an object, e.g. {"$ref": "#/$defs/Order"},
+	// is kept whole.
+	var extracted map[string]map[string]interface{}
+	var deps map[string][]string
+	walk := func(inline map[string]bool) {
+		extracted = make(map[string]map[string]interface{})
+		deps = make(map[string][]string)
+		extractJSONSchemaTypes(schema, rootName, extracted, deps, "", inline)
+		if extracted[rootName] == nil {
+			root := make(map[string]interface{}, len(schema))
+			for k, v := range schema {
+				if k != "$defs" && k != "definitions" {
+					root[k] = v
+				}
+			}
+			extracted[rootName] = root
+		}
+		defs.extract(extracted, rootName)
+	}
+	walk(nil)
 
 	if len(extracted) <= 1 {
 		return &SplitResult{
@@ -1370,20 +2196,36 @@ func splitJSONSchema(content string, minSize int, subjectPrefix string) (*SplitR
 		}, nil
 	}
 
-	// Filter by min size
+	// Filter by min size. Small types are inlined again by re-walking the
+	// original schema, so no $ref is left pointing at a schema that isn't
+	// extracted. Definitions were already sized by newJSONDefinitions.
 	if minSize > 0 {
+		inline := make(map[string]bool)
 		for name, typeSchema := range extracted {
-			if name == rootName {
+			if name == rootName || defs.defs[name] != nil {
 				continue
 			}
 			serialized, _ := json.Marshal(typeSchema)
 			if len(serialized) < minSize {
-				delete(extracted, name)
-				delete(deps, name)
+				inline[name] = true
 			}
 		}
+		if len(inline) > 0 {
+			walk(inline)
+		}
+
+		if len(extracted) <= 1 {
+			return &SplitResult{
+				OriginalSize:      len(content),
+				SchemaType:        "JSON",
+				Types:             []ExtractedType{{Name: rootName, Subject: rootName, Schema: content, SchemaType: "JSON", Size: len(content), IsRoot: true, Order: 0}},
+				RegistrationOrder: []string{rootName},
+			}, nil
+		}
 	}
 
+	defs.addDependencies(extracted, deps)
+
 	// Topological sort
 	regOrder := topologicalSort(deps, rootName)
 
@@ -1428,78 +2270,341 @@ func splitJSONSchema(content string, minSize int, subjectPrefix string) (*SplitR
 	return result, nil
 }
 
-func extractJSONSchemaTypes(schema map[string]interface{}, name string, extracted map[string]map[string]interface{}, deps map[string][]string, parentName string) {
+// extractJSONSchemaTypes extracts name and its nested object types into
+// extracted. Nested objects named in inline are left in place (with their own
+// nested types still extracted) instead of being replaced by a $ref.
+func extractJSONSchemaTypes(schema map[string]interface{}, name string, extracted map[string]map[string]interface{}, deps map[string][]string, parentName string, inline map[string]bool) {
 	schemaType, _ := schema["type"].(string)
 
 	if schemaType == "object" {
-		// Build the extracted schema
-		extractedSchema := make(map[string]interface{})
-		extractedSchema["$schema"] = "http://json-schema.org/draft-07/schema#"
-
-		refName := name
-		if !strings.HasSuffix(refName, ".json") {
-			refName += ".json"
+		// Build the extracted schema, keeping every keyword except the
+		// properties, which are rewritten below, and the definitions, which
+		// newJSONDefinitions handles
+		extractedSchema := map[string]interface{}{"$schema": "http://json-schema.org/draft-07/schema#"}
+		for k, v := range schema {
+			if k != "properties" && k != "$defs" && k != "definitions" {
+				extractedSchema[k] = v
+			}
 		}
-		extractedSchema["$id"] = refName
-		extractedSchema["type"] = "object"
 
-		// Copy additional properties
-		for _, key := range []string{"required", "additionalProperties", "description", "title"} {
-			if v, ok := schema[key]; ok {
-				extractedSchema[key] = v
-			}
+		// Extracted schemas are identified by the $ref that points at them;
+		// the root keeps its own $id, which relative $refs resolve against
+		if _, ok := schema["$id"].(string); !ok || parentName != "" {
+			extractedSchema["$id"] = jsonSchemaRef(name)
 		}
 
 		// Process properties
 		if props, ok := schema["properties"].(map[string]interface{}); ok {
-			newProps := make(map[string]interface{})
-			for propName, propValue := range props {
-				propMap, ok := propValue.(map[string]interface{})
-				if !ok {
+			extractedSchema["properties"] = extractJSONProperties(props, name, extracted, deps, inline)
+		}
+
+		extracted[name] = extractedSchema
+	}
+}
+
+// extractJSONProperties rewrites the properties of the object owned by name,
+// replacing nested objects with $refs to extracted schemas
+func extractJSONProperties(props map[string]interface{}, name string, extracted map[string]map[string]interface{}, deps map[string][]string, inline map[string]bool) map[string]interface{} {
+	// extractChild extracts a nested object, or keeps it in place if it is
+	// too small to stand alone, and returns what replaces it in the parent
+	extractChild := func(childName string, child map[string]interface{}) interface{} {
+		if inline[childName] {
+			kept := make(map[string]interface{}, len(child))
+			for k, v := range child {
+				kept[k] = v
+			}
+			if childProps, ok := child["properties"].(map[string]interface{}); ok {
+				kept["properties"] = extractJSONProperties(childProps, name, extracted, deps, inline)
+			}
+			return kept
+		}
+		extractJSONSchemaTypes(child, childName, extracted, deps, name, inline)
+		deps[name] = appendUnique(deps[name], childName)
+		return map[string]interface{}{"$ref": jsonSchemaRef(childName)}
+	}
+
+	newProps := make(map[string]interface{})
+	for propName, propValue := range props {
+		propMap, ok := propValue.(map[string]interface{})
+		if !ok {
+			newProps[propName] = propValue
+			continue
+		}
+
+		propType, _ := propMap["type"].(string)
+		if propType == "object" && len(propMap) > 1 {
+			// Extract this nested object as a separate schema
+			newProps[propName] = extractChild(propName, propMap)
+		} else if propType == "array" {
+			if items, ok := propMap["items"].(map[string]interface{}); ok {
+				itemType, _ := items["type"].(string)
+				if itemType == "object" && len(items) > 1 {
+					newProps[propName] = map[string]interface{}{
+						"type":  "array",
+						"items": extractChild(propName+"_item", items),
+					}
+				} else {
 					newProps[propName] = propValue
-					continue
 				}
+			} else {
+				newProps[propName] = propValue
+			}
+		} else {
+			newProps[propName] = propValue
+		}
+	}
+	return newProps
+}
 
-				propType, _ := propMap["type"].(string)
-				if propType == "object" && len(propMap) > 1 {
-					// Extract this nested object as a separate schema
-					childName := propName
-					extractJSONSchemaTypes(propMap, childName, extracted, deps, name)
-					childRef := childName
-					if !strings.HasSuffix(childRef, ".json") {
-						childRef += ".json"
-					}
-					newProps[propName] = map[string]interface{}{"$ref": childRef}
-					deps[name] = appendUnique(deps[name], childName)
-				} else if propType == "array" {
-					if items, ok := propMap["items"].(map[string]interface{}); ok {
-						itemType, _ := items["type"].(string)
-						if itemType == "object" && len(items) > 1 {
-							childName := propName + "_item"
-							extractJSONSchemaTypes(items, childName, extracted, deps, name)
-							childRef := childName
-							if !strings.HasSuffix(childRef, ".json") {
-								childRef += ".json"
-							}
-							newProps[propName] = map[string]interface{}{
-								"type":  "array",
-								"items": map[string]interface{}{"$ref": childRef},
-							}
-							deps[name] = appendUnique(deps[name], childName)
-						} else {
-							newProps[propName] = propValue
+// jsonDefinitionKeys hold a JSON schema's reusable types: $defs since draft
+// 2019-09, definitions before it
+var jsonDefinitionKeys = []string{"$defs", "definitions"}
+
+// jsonDefinitions are the root schema's reusable types and how the split
+// treats each: used ones are extracted, except that with --min-size a small
+// one that isn't recursive is copied in place of its $refs instead. Unused
+// ones stay in the root schema.
+type jsonDefinitions struct {
+	defs      map[string]interface{}
+	key       map[string]string // "$defs" or "definitions"
+	used      map[string]bool   // reachable through $refs from the root schema
+	inline    map[string]bool
+	schemaURI string // root $schema, for the extracted definitions
+}
+
+func newJSONDefinitions(schema map[string]interface{}, minSize int) *jsonDefinitions {
+	d := &jsonDefinitions{
+		defs:      make(map[string]interface{}),
+		key:       make(map[string]string),
+		used:      make(map[string]bool),
+		inline:    make(map[string]bool),
+		schemaURI: "http://json-schema.org/draft-07/schema#",
+	}
+	if uri, ok := schema["$schema"].(string); ok {
+		d.schemaURI = uri
+	}
+	for _, key := range jsonDefinitionKeys {
+		if m, ok := schema[key].(map[string]interface{}); ok {
+			for name, def := range m {
+				d.defs[name] = def
+				d.key[name] = key
+			}
+		}
+	}
+	if len(d.defs) == 0 {
+		return d
+	}
+
+	// Which definitions each one refers to, and which are referred to with a
+	// pointer into them (e.g. #/$defs/Address/properties/zip)
+	refs := make(map[string]map[string]bool)
+	deep := make(map[string]bool)
+	for name, def := range d.defs {
+		refs[name] = d.localRefs(def, deep)
+	}
+	root := make(map[string]interface{}, len(schema))
+	for k, v := range schema {
+		if k != "$defs" && k != "definitions" {
+			root[k] = v
+		}
+	}
+
+	var visit func(name string)
+	visit = func(name string) {
+		if d.used[name] {
+			return
+		}
+		d.used[name] = true
+		for dep := range refs[name] {
+			visit(dep)
+		}
+	}
+	for name := range d.localRefs(root, deep) {
+		visit(name)
+	}
+
+	for name := range d.used {
+		if _, isObject := d.defs[name].(map[string]interface{}); !isObject {
+			d.inline[name] = true // e.g. a boolean schema
+			continue
+		}
+		if minSize <= 0 || deep[name] || reachesJSONDefinition(refs, name) {
+			continue
+		}
+		if serialized, _ := json.Marshal(d.defs[name]); len(serialized) < minSize {
+			d.inline[name] = true
+		}
+	}
+	return d
+}
+
+// localRefs returns the definitions node refers to with local $refs, adding
+// those referred to with a pointer below them to deep
+func (d *jsonDefinitions) localRefs(node interface{}, deep map[string]bool) map[string]bool {
+	found := make(map[string]bool)
+	var walk func(n interface{})
+	walk = func(n interface{}) {
+		switch t := n.(type) {
+		case map[string]interface{}:
+			for k, v := range t {
+				if ref, ok := v.(string); ok && k == "$ref" {
+					if name, rest, ok := parseJSONDefRef(ref); ok && d.defs[name] != nil {
+						found[name] = true
+						if rest != "" {
+							deep[name] = true
 						}
-					} else {
-						newProps[propName] = propValue
 					}
-				} else {
-					newProps[propName] = propValue
+					continue
+				}
+				walk(v)
+			}
+		case []interface{}:
+			for _, v := range t {
+				walk(v)
+			}
+		}
+	}
+	walk(node)
+	return found
+}
+
+// reachesJSONDefinition reports whether name refers back to itself, directly
+// or through other definitions
+func reachesJSONDefinition(refs map[string]map[string]bool, name string) bool {
+	seen := make(map[string]bool)
+	var walk func(n string) bool
+	walk = func(n string) bool {
+		for dep := range refs[n] {
+			if dep == name {
+				return true
+			}
+			if !seen[dep] {
+				seen[dep] = true
+				if walk(dep) {
+					return true
 				}
 			}
-			extractedSchema["properties"] = newProps
 		}
+		return false
+	}
+	return walk(name)
+}
 
-		extracted[name] = extractedSchema
+// parseJSONDefRef splits a local $ref such as "#/$defs/Address/properties/zip"
+// into the definition name and the JSON pointer below it ("/properties/zip")
+func parseJSONDefRef(ref string) (name, rest string, ok bool) {
+	for _, key := range jsonDefinitionKeys {
+		prefix := "#/" + key + "/"
+		if !strings.HasPrefix(ref, prefix) {
+			continue
+		}
+		name, rest, _ = strings.Cut(ref[len(prefix):], "/")
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			name = unescaped
+		}
+		name = strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+		if rest != "" {
+			rest = "/" + rest
+		}
+		return name, rest, true
+	}
+	return "", "", false
+}
+
+// rewrite returns a copy of node with $refs to extracted definitions pointed
+// at their schemas, and $refs to inlined ones replaced by a copy of the
+// definition. self is the definition node belongs to, whose $refs to itself
+// stay local; $refs to unused definitions are left as they are.
+func (d *jsonDefinitions) rewrite(node interface{}, self string) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(n))
+		for k, v := range n {
+			out[k] = d.rewrite(v, self)
+		}
+		ref, _ := n["$ref"].(string)
+		name, rest, ok := parseJSONDefRef(ref)
+		if !ok || !d.used[name] {
+			return out
+		}
+		switch {
+		case name == self:
+			out["$ref"] = "#" + rest
+		case d.inline[name]:
+			def := d.rewrite(d.defs[name], self)
+			defMap, isObject := def.(map[string]interface{})
+			if !isObject {
+				return def
+			}
+			// Keywords next to the $ref apply on top of the definition
+			delete(out, "$ref")
+			for k, v := range out {
+				defMap[k] = v
+			}
+			return defMap
+		default:
+			out["$ref"] = jsonSchemaRef(name)
+			if rest != "" {
+				out["$ref"] = jsonSchemaRef(name) + "#" + rest
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(n))
+		for i, v := range n {
+			out[i] = d.rewrite(v, self)
+		}
+		return out
+	default:
+		return node
+	}
+}
+
+// extract adds each used, non-inlined definition to extracted as a schema of
+// its own, and puts unused ones back into the root schema
+func (d *jsonDefinitions) extract(extracted map[string]map[string]interface{}, rootName string) {
+	for name, def := range d.defs {
+		if !d.used[name] {
+			root := extracted[rootName]
+			if root == nil {
+				continue
+			}
+			kept, _ := root[d.key[name]].(map[string]interface{})
+			if kept == nil {
+				kept = make(map[string]interface{})
+				root[d.key[name]] = kept
+			}
+			kept[name] = d.rewrite(def, "")
+			continue
+		}
+		if d.inline[name] {
+			continue
+		}
+		schema := d.rewrite(def, name).(map[string]interface{})
+		schema["$schema"] = d.schemaURI
+		schema["$id"] = jsonSchemaRef(name)
+		extracted[name] = schema
+	}
+}
+
+// addDependencies records which extracted definitions each extracted schema
+// refers to
+func (d *jsonDefinitions) addDependencies(extracted map[string]map[string]interface{}, deps map[string][]string) {
+	byRef := make(map[string]string)
+	for name := range d.used {
+		if !d.inline[name] {
+			byRef[jsonSchemaRef(name)] = name
+		}
+	}
+	if len(byRef) == 0 {
+		return
+	}
+	for name, schema := range extracted {
+		collectJSONSchemaRefs(schema, func(ref string) {
+			if dep, ok := byRef[ref]; ok && dep != name {
+				deps[name] = appendUnique(deps[name], dep)
+			}
+		})
 	}
 }
 
@@ -1527,7 +2632,9 @@ func topologicalSort(deps map[string][]string, rootName string) []string {
 			return
 		}
 		if inStack[name] {
-			// Circular dependency - skip
+			// Circular dependency: break the edge here so the sort terminates.
+			// findDependencyCycles reports these so callers can refuse to
+			// register an order that Schema Registry would reject.
 			return
 		}
 		inStack[name] = true
@@ -1597,18 +2704,25 @@ func getReferenceName(t *ExtractedType, schemaType string) string {
 	case "AVRO":
 		return t.Name // Fully qualified type name
 	case "PROTOBUF":
-		return toSnakeCase(shortName(t.Name)) + ".proto" // Import path
+		return protoFileName(t.Name) // Import path
 	case "JSON":
-		name := t.Name
-		if !strings.HasSuffix(name, ".json") {
-			name += ".json"
-		}
-		return name // $ref URI
+		return jsonSchemaRef(t.Name) // $ref URI
 	default:
 		return t.Name
 	}
 }
 
+// jsonSchemaRef is the $ref that points at the extracted JSON schema name,
+// and so also the name of the reference registered for it. Characters that
+// aren't allowed in a URI reference are escaped, so the registry resolves the
+// $ref to the same URI as the reference name.
+func jsonSchemaRef(name string) string {
+	if !strings.HasSuffix(name, ".json") {
+		name += ".json"
+	}
+	return strings.ReplaceAll(url.PathEscape(name), ":", "%3A")
+}
+
 func toSnakeCase(name string) string {
 	// Convert CamelCase to snake_case
 	var result strings.Builder
@@ -4,7 +4,12 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/srctl/srctl/internal/client"
 )
 
 func TestSplitAvroSchema(t *testing.T) {
@@ -158,6 +163,255 @@ func TestSplitAvroSchemaWithMinSize(t *testing.T) {
 	}
 }
 
+func TestSplitAvroSchemaKeepsLogicalTypes(t *testing.T) {
+	schema := `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "createdAt", "type": {"type": "long", "logicalType": "timestamp-millis"}, "aliases": ["created"], "doc": "Order time"},
+    {"name": "total", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+    {"name": "lines", "default": [], "type": {
+      "type": "array",
+      "connect.name": "com.example.Lines",
+      "items": {
+        "type": "record",
+        "name": "Line",
+        "fields": [
+          {"name": "sku", "type": "string", "aliases": ["productId"]},
+          {"name": "price", "type": {"type": "bytes", "logicalType": "decimal", "precision": 9, "scale": 2}},
+          {"name": "shippedAt", "type": ["null", {"type": "long", "logicalType": "timestamp-millis"}], "default": null}
+        ]
+      }
+    }},
+    {"name": "attributes", "type": {
+      "type": "map",
+      "connect.name": "com.example.Attributes",
+      "values": {"type": "record", "name": "Attribute", "fields": [{"name": "value", "type": "string"}]}
+    }},
+    {"name": "returns", "default": null, "type": ["null", {
+      "type": "array",
+      "x-owner": "returns-team",
+      "items": {"type": "record", "name": "Return", "fields": [{"name": "reason", "type": "string"}]}
+    }]}
+  ]
+}`
+
+	tests := []struct {
+		name    string
+		minSize int
+		depth   int
+	}{
+		{"all named types", 0, 0},
+		{"top-level only", 0, 1},
+		{"min size", 120, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := splitAvroSchema(schema, tt.minSize, "", tt.depth)
+			if err != nil {
+				t.Fatalf("splitAvroSchema: %v", err)
+			}
+			if len(result.Types) < 2 {
+				t.Fatalf("expected the schema to be split, got %v", result.RegistrationOrder)
+			}
+
+			var all strings.Builder
+			for _, typ := range result.Types {
+				all.WriteString(typ.Schema)
+			}
+			joined := all.String()
+			for _, want := range []string{
+				`"logicalType": "timestamp-millis"`,
+				`"logicalType": "decimal"`,
+				`"precision": 9`,
+				`"connect.name": "com.example.Lines"`,
+				`"connect.name": "com.example.Attributes"`,
+				`"x-owner": "returns-team"`,
+				`"productId"`,
+				`"created"`,
+			} {
+				if !strings.Contains(joined, want) {
+					t.Errorf("%s lost from the split schemas", want)
+				}
+			}
+			// Each named type is defined once, in its own schema or inline
+			for _, name := range []string{"Line", "Attribute", "Return"} {
+				if n := strings.Count(joined, `"name": "`+name+`"`); n != 1 {
+					t.Errorf("%s defined %d times across the split schemas", name, n)
+				}
+			}
+
+			var root map[string]interface{}
+			for _, typ := range result.Types {
+				if typ.IsRoot {
+					json.Unmarshal([]byte(typ.Schema), &root)
+				}
+			}
+			fields := make(map[string]map[string]interface{})
+			for _, f := range root["fields"].([]interface{}) {
+				field := f.(map[string]interface{})
+				fields[field["name"].(string)] = field
+			}
+			if _, ok := fields["lines"]["default"]; !ok {
+				t.Error("field default dropped from lines")
+			}
+			lines, ok := fields["lines"]["type"].(map[string]interface{})
+			if !ok || lines["connect.name"] != "com.example.Lines" {
+				t.Errorf("array attributes dropped from lines: %v", fields["lines"]["type"])
+			}
+			total := fields["total"]["type"].(map[string]interface{})
+			if total["logicalType"] != "decimal" || total["scale"] != float64(2) {
+				t.Errorf("decimal type changed: %v", total)
+			}
+		})
+	}
+}
+
+func TestSplitAvroSchemaRoundTrip(t *testing.T) {
+	schema := `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.example.events",
+  "doc": "An order",
+  "fields": [
+    {"name": "orderId", "type": "string", "doc": "Primary key", "order": "descending"},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "PAID", "SHIPPED"], "default": "NEW"}, "default": "NEW"},
+    {"name": "customer", "aliases": ["buyer"], "type": {
+      "type": "record",
+      "name": "Customer",
+      "namespace": "com.example.types",
+      "fields": [
+        {"name": "name", "type": "string", "default": ""},
+        {"name": "tier", "type": "int", "default": 1, "order": "ignore"},
+        {"name": "address", "type": ["null", {
+          "type": "record",
+          "name": "Address",
+          "fields": [
+            {"name": "street", "type": "string"},
+            {"name": "zip", "type": {"type": "fixed", "name": "Zip", "size": 5}}
+          ]
+        }], "default": null}
+      ]
+    }},
+    {"name": "lines", "type": {"type": "array", "items": {
+      "type": "record",
+      "name": "Line",
+      "fields": [
+        {"name": "sku", "type": "string"},
+        {"name": "qty", "type": "int", "default": 1},
+        {"name": "status", "type": "Status"}
+      ]
+    }}, "default": []},
+    {"name": "billingAddress", "type": ["null", "com.example.types.Address"], "default": null},
+    {"name": "note", "type": ["null", "string"], "default": null, "doc": "Free text"}
+  ]
+}`
+
+	tests := []struct {
+		name    string
+		minSize int
+		depth   int
+	}{
+		{"all named types", 0, 0},
+		{"top-level only", 0, 1},
+		{"min size", 150, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := splitAvroSchema(schema, tt.minSize, "", tt.depth)
+			if err != nil {
+				t.Fatalf("splitAvroSchema: %v", err)
+			}
+			if len(result.Types) < 2 {
+				t.Fatalf("expected the schema to be split, got %v", result.RegistrationOrder)
+			}
+
+			root, err := resolveManifestReferences(result)
+			if err != nil {
+				t.Fatalf("resolveManifestReferences: %v", err)
+			}
+			assembled, err := inlineReferences(root)
+			if err != nil {
+				t.Fatalf("inlineReferences: %v", err)
+			}
+			want := canonicalAvroForTest(t, schema)
+			if got := canonicalAvroForTest(t, assembled); got != want {
+				t.Errorf("reassembled schema differs from the original\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+// canonicalAvroForTest fully qualifies names and drops namespaces, so
+// equivalent schemas compare equal, keeping field order and attributes
+func canonicalAvroForTest(t *testing.T, content string) string {
+	t.Helper()
+	var schema interface{}
+	if err := json.Unmarshal([]byte(content), &schema); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+	var walk func(node interface{}, ns string) interface{}
+	walk = func(node interface{}, ns string) interface{} {
+		switch n := node.(type) {
+		case string:
+			if avroPrimitiveTypes[n] {
+				return n
+			}
+			return avroFullName(n, ns)
+		case []interface{}:
+			out := make([]interface{}, len(n))
+			for i, v := range n {
+				out[i] = walk(v, ns)
+			}
+			return out
+		case map[string]interface{}:
+			out := make(map[string]interface{}, len(n))
+			for k, v := range n {
+				if k != "namespace" {
+					out[k] = v
+				}
+			}
+			switch n["type"] {
+			case "record", "enum", "fixed":
+				if childNS, ok := n["namespace"].(string); ok {
+					ns = childNS
+				}
+				full := avroFullName(n["name"].(string), ns)
+				out["name"] = full
+				if i := strings.LastIndex(full, "."); i >= 0 {
+					ns = full[:i]
+				}
+				if fields, ok := n["fields"].([]interface{}); ok {
+					newFields := make([]interface{}, len(fields))
+					for i, f := range fields {
+						field := f.(map[string]interface{})
+						newField := make(map[string]interface{}, len(field))
+						for k, v := range field {
+							newField[k] = v
+						}
+						newField["type"] = walk(field["type"], ns)
+						newFields[i] = newField
+					}
+					out["fields"] = newFields
+				}
+			case "array":
+				out["items"] = walk(n["items"], ns)
+			case "map":
+				out["values"] = walk(n["values"], ns)
+			}
+			return out
+		}
+		return node
+	}
+	canonical, _ := json.Marshal(walk(schema, ""))
+	normalized, err := normalizeAvroSchema(string(canonical))
+	if err != nil {
+		t.Fatalf("normalizeAvroSchema: %v", err)
+	}
+	return normalized
+}
+
 func TestSplitAvroSchemaWithUnion(t *testing.T) {
 	schema := `{
   "type": "record",
@@ -235,6 +489,261 @@ message LineItem {
 	}
 }
 
+func TestSplitProtobufSchemaWithMinSize(t *testing.T) {
+	schema := `syntax = "proto3";
+package com.example.orders;
+
+enum Currency {
+  CURRENCY_UNKNOWN = 0;
+}
+
+message Money {
+  int64 units = 1;
+  Currency currency = 2;
+}
+
+message Order {
+  string order_id = 1;
+  Money total = 2;
+  Customer customer = 3;
+  Currency currency = 4;
+}
+
+message Customer {
+  string customer_id = 1;
+  string name = 2;
+  string email = 3;
+  string phone = 4;
+  string billing_address = 5;
+  string shipping_address = 6;
+  Currency preferred_currency = 7;
+}`
+
+	result, err := splitProtobufSchema(schema, "orders.proto", 100, "")
+	if err != nil {
+		t.Fatalf("splitProtobufSchema failed: %v", err)
+	}
+
+	// Money is small and only used by Order, so it stays in order.proto.
+	// Currency is small but shared by three messages, so it is still extracted.
+	byName := make(map[string]ExtractedType)
+	for _, typ := range result.Types {
+		byName[typ.Name] = typ
+	}
+	if len(byName) != 3 {
+		t.Fatalf("expected Currency, Customer and Order, got %v", result.RegistrationOrder)
+	}
+	if _, ok := byName["Money"]; ok {
+		t.Error("small message Money should stay inline")
+	}
+
+	order := byName["Order"]
+	if !order.IsRoot {
+		t.Error("expected Order to be the root")
+	}
+	if !strings.Contains(order.Schema, "message Money {") || !strings.Contains(order.Schema, "message Order {") {
+		t.Errorf("order.proto should define both Order and Money:\n%s", order.Schema)
+	}
+	if strings.Join(order.References, ",") != "Currency,Customer" {
+		t.Errorf("unexpected Order references: %v", order.References)
+	}
+	if strings.Contains(order.Schema, "money.proto") {
+		t.Errorf("order.proto should not import the inlined message:\n%s", order.Schema)
+	}
+}
+
+func TestSplitProtobufSchemaSubstringNames(t *testing.T) {
+	schema := `syntax = "proto3";
+package com.example.users;
+
+message Account {
+  // Owned by a User; see UserProfile for display data
+  string user_id = 1;
+  UserId owner = 2;
+  string note = 3 [json_name = "User"];
+}
+
+message User {
+  string name = 1;
+  repeated com.example.users.UserProfile profiles = 2;
+  map<string, .com.example.users.UserId> aliases = 3;
+}
+
+message UserId {
+  string value = 1;
+}
+
+message UserProfile {
+  string display_name = 1;
+}`
+
+	result, err := splitProtobufSchema(schema, "users.proto", 0, "")
+	if err != nil {
+		t.Fatalf("splitProtobufSchema failed: %v", err)
+	}
+
+	refs := make(map[string][]string)
+	for _, typ := range result.Types {
+		refs[typ.Name] = typ.References
+	}
+
+	expected := map[string][]string{
+		"Account":     {"UserId"},
+		"User":        {"UserId", "UserProfile"},
+		"UserId":      nil,
+		"UserProfile": nil,
+	}
+	for name, want := range expected {
+		got, ok := refs[name]
+		if !ok {
+			t.Errorf("type %s not extracted", name)
+			continue
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: expected references %v, got %v", name, want, got)
+		}
+	}
+}
+
+func TestSplitProtobufSchemaEnumsAndNested(t *testing.T) {
+	schema := `syntax = "proto3";
+package com.example.orders;
+
+import "google/protobuf/timestamp.proto";
+
+option java_package = "com.example.orders";
+
+enum Status {
+  STATUS_UNKNOWN = 0;
+  STATUS_OPEN = 1;
+}
+
+message Order {
+  string order_id = 1;
+  Status status = 2;
+  google.protobuf.Timestamp created_at = 3;
+
+  message Line {
+    string sku = 1;
+    Status status = 2;
+  }
+  repeated Line lines = 4;
+}
+
+message Refund {
+  Order.Line line = 1;
+}`
+
+	result, err := splitProtobufSchema(schema, "orders.proto", 0, "")
+	if err != nil {
+		t.Fatalf("splitProtobufSchema failed: %v", err)
+	}
+
+	if len(result.Types) != 3 {
+		t.Fatalf("expected 3 types (nested Line stays inline), got %d", len(result.Types))
+	}
+	if strings.Join(result.RegistrationOrder, ",") != "Status,Order,Refund" {
+		t.Errorf("unexpected registration order: %v", result.RegistrationOrder)
+	}
+
+	byName := make(map[string]ExtractedType)
+	for _, typ := range result.Types {
+		byName[typ.Name] = typ
+	}
+
+	status := byName["Status"]
+	if !strings.Contains(status.Schema, "enum Status {") {
+		t.Errorf("enum not emitted as enum:\n%s", status.Schema)
+	}
+	if strings.Contains(status.Schema, "import") {
+		t.Errorf("enum file should not import anything:\n%s", status.Schema)
+	}
+	if status.Subject != "status.proto" {
+		t.Errorf("expected subject status.proto, got %s", status.Subject)
+	}
+
+	order := byName["Order"]
+	for _, want := range []string{
+		`import "status.proto";`,
+		`import "google/protobuf/timestamp.proto";`,
+		`option java_package = "com.example.orders";`,
+		"message Line {",
+	} {
+		if !strings.Contains(order.Schema, want) {
+			t.Errorf("Order file missing %q:\n%s", want, order.Schema)
+		}
+	}
+
+	refund := byName["Refund"]
+	if !refund.IsRoot {
+		t.Error("expected Refund to be the root")
+	}
+	if strings.Join(refund.References, ",") != "Order" {
+		t.Errorf("expected Refund to reference Order, got %v", refund.References)
+	}
+	if !strings.Contains(refund.Schema, `import "order.proto";`) {
+		t.Errorf("Refund file missing order import:\n%s", refund.Schema)
+	}
+}
+
+func TestParseProtobufMessagesTopLevelOnly(t *testing.T) {
+	content := `syntax = "proto3";
+// message Commented { }
+message Outer {
+  string note = 1 [json_name = "}"];
+message Inner {
+  int32 x = 1;
+}
+  enum Kind { KIND_UNKNOWN = 0; }
+}
+enum Color { COLOR_UNKNOWN = 0; }
+`
+	messages := parseProtobufMessages(content)
+
+	var got []string
+	for _, m := range messages {
+		got = append(got, m.Kind+" "+m.Name)
+	}
+	if strings.Join(got, ",") != "message Outer,enum Color" {
+		t.Errorf("unexpected definitions: %v", got)
+	}
+}
+
+func TestProtoFieldTypeRefs(t *testing.T) {
+	topLevel := map[string]bool{"User": true, "UserId": true, "Status": true}
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"field name only", "string user = 1;\n  int64 UserId_count = 2;", nil},
+		{"comment", "// User and UserId\n  /* Status */ string s = 1;", nil},
+		{"string literal", `string s = 1 [default = "User"];`, nil},
+		{"plain and repeated", "User u = 1;\n  repeated UserId ids = 2;", []string{"User", "UserId"}},
+		{"optional", "optional Status status = 1;", []string{"Status"}},
+		{"map value", "map<string, User> users = 1;", []string{"User"}},
+		{"qualified", "pkg.User u = 1;\n  .pkg.UserId id = 2;", []string{"User", "UserId"}},
+		{"other package", ".other.User u = 1;", nil},
+		{"nested shadows top-level", "message User { string n = 1; }\n  User u = 1;", nil},
+		{"oneof", "oneof owner {\n    User user = 1;\n  }", []string{"User"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs := protoFieldTypeRefs(tt.body, "pkg", topLevel)
+			var got []string
+			for name := range refs {
+				got = append(got, name)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestSplitJSONSchema(t *testing.T) {
 	schema := `{
   "$schema": "http://json-schema.org/draft-07/schema#",
@@ -295,6 +804,56 @@ func TestSplitJSONSchema(t *testing.T) {
 	}
 }
 
+func TestSplitJSONSchemaWithMinSize(t *testing.T) {
+	schema := `{
+  "$id": "order.json",
+  "type": "object",
+  "properties": {
+    "orderId": {"type": "string"},
+    "note": {"type": "object", "properties": {"text": {"type": "string"}}},
+    "customer": {
+      "type": "object",
+      "description": "The customer who placed the order, with contact and billing details",
+      "properties": {
+        "customerId": {"type": "string"},
+        "name": {"type": "string"},
+        "email": {"type": "string", "format": "email"},
+        "phone": {"type": "string"},
+        "billingAddress": {"type": "string"}
+      }
+    }
+  }
+}`
+
+	result, err := splitJSONSchema(schema, 200, "")
+	if err != nil {
+		t.Fatalf("splitJSONSchema failed: %v", err)
+	}
+
+	if len(result.Types) != 2 {
+		t.Fatalf("expected root and customer, got %v", result.RegistrationOrder)
+	}
+
+	var root ExtractedType
+	for _, typ := range result.Types {
+		if typ.IsRoot {
+			root = typ
+		}
+	}
+	if strings.Join(root.References, ",") != "customer" {
+		t.Errorf("expected root to reference only customer, got %v", root.References)
+	}
+	if strings.Contains(root.Schema, "note.json") {
+		t.Errorf("small type should be inlined, not referenced:\n%s", root.Schema)
+	}
+	if !strings.Contains(root.Schema, `"text"`) {
+		t.Errorf("inlined type lost its properties:\n%s", root.Schema)
+	}
+	if !strings.Contains(root.Schema, `"$ref": "customer.json"`) {
+		t.Errorf("large type should be referenced:\n%s", root.Schema)
+	}
+}
+
 func TestTopologicalSort(t *testing.T) {
 	deps := map[string][]string{
 		"A": {"B", "C"},
@@ -429,6 +988,12 @@ func TestGetReferenceName(t *testing.T) {
 			schemaType: "JSON",
 			expected:   "address.json",
 		},
+		{
+			name:       "JSON reference escaped as a URI",
+			typ:        ExtractedType{Name: "ship to:address"},
+			schemaType: "JSON",
+			expected:   "ship%20to%3Aaddress.json",
+		},
 	}
 
 	for _, tt := range tests {
@@ -441,6 +1006,223 @@ func TestGetReferenceName(t *testing.T) {
 	}
 }
 
+func TestSplitJSONSchemaDefinitions(t *testing.T) {
+	schema := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "order.json",
+  "type": "object",
+  "properties": {
+    "billing": {"$ref": "#/$defs/Address"},
+    "shipping": {"$ref": "#/$defs/Address", "description": "Where to ship"},
+    "total": {"$ref": "#/$defs/Money"},
+    "categories": {"$ref": "#/$defs/Node"}
+  },
+  "$defs": {
+    "Address": {"type": "object", "properties": {"street": {"type": "string"}, "zip": {"type": "string"}}, "required": ["street"]},
+    "Money": {"type": "object", "properties": {"amount": {"type": "number"}, "currency": {"$ref": "#/$defs/Currency"}}},
+    "Currency": {"type": "string", "enum": ["EUR", "USD"]},
+    "Node": {"type": "object", "properties": {"name": {"type": "string"}, "children": {"type": "array", "items": {"$ref": "#/$defs/Node"}}}},
+    "Unused": {"type": "string"}
+  }
+}`
+
+	result, err := splitJSONSchema(schema, 0, "")
+	if err != nil {
+		t.Fatalf("splitJSONSchema: %v", err)
+	}
+	types := make(map[string]ExtractedType)
+	for _, typ := range result.Types {
+		types[typ.Name] = typ
+	}
+	for _, name := range []string{"order.json", "Address", "Money", "Currency", "Node"} {
+		if _, ok := types[name]; !ok {
+			t.Fatalf("expected %s to be extracted, got %v", name, result.RegistrationOrder)
+		}
+	}
+	if _, ok := types["Unused"]; ok {
+		t.Error("an unused definition should not be extracted")
+	}
+
+	var root map[string]interface{}
+	json.Unmarshal([]byte(types["order.json"].Schema), &root)
+	props := root["properties"].(map[string]interface{})
+	if ref := props["billing"].(map[string]interface{})["$ref"]; ref != "Address.json" {
+		t.Errorf("billing $ref = %v, want Address.json", ref)
+	}
+	if shipping := props["shipping"].(map[string]interface{}); shipping["$ref"] != "Address.json" || shipping["description"] != "Where to ship" {
+		t.Errorf("unexpected shipping: %v", shipping)
+	}
+	if root["$schema"] != "https://json-schema.org/draft/2020-12/schema" {
+		t.Errorf("root $schema = %v", root["$schema"])
+	}
+	kept, _ := root["$defs"].(map[string]interface{})
+	if len(kept) != 1 || kept["Unused"] == nil {
+		t.Errorf("only the unused definition should stay in the root, got %v", kept)
+	}
+
+	var node map[string]interface{}
+	json.Unmarshal([]byte(types["Node"].Schema), &node)
+	children := node["properties"].(map[string]interface{})["children"].(map[string]interface{})
+	if ref := children["items"].(map[string]interface{})["$ref"]; ref != "#" || node["$id"] != "Node.json" {
+		t.Errorf("Node should refer to itself locally, got $ref %v, $id %v", ref, node["$id"])
+	}
+	if len(types["Node"].References) != 0 {
+		t.Errorf("a self-reference is not a schema reference: %v", types["Node"].References)
+	}
+	if refs := types["Money"].References; len(refs) != 1 || refs[0] != "Currency" {
+		t.Errorf("Money references = %v, want [Currency]", refs)
+	}
+
+	order := make(map[string]int)
+	for i, name := range result.RegistrationOrder {
+		order[name] = i
+	}
+	if order["Currency"] > order["Money"] || order["order.json"] != len(result.RegistrationOrder)-1 {
+		t.Errorf("unexpected registration order: %v", result.RegistrationOrder)
+	}
+
+	for i := range result.Types {
+		if result.Types[i].IsRoot {
+			result.Types[i].Subject = "orders-value"
+		}
+	}
+	if err := registerSplitParts(client.NewMockClient(), result, "JSON"); err != nil {
+		t.Errorf("registerSplitParts: %v", err)
+	}
+
+	// Small definitions are copied into place instead, unless recursive
+	result, err = splitJSONSchema(schema, 1000, "")
+	if err != nil {
+		t.Fatalf("splitJSONSchema with min size: %v", err)
+	}
+	types = make(map[string]ExtractedType)
+	for _, typ := range result.Types {
+		types[typ.Name] = typ
+	}
+	if got := strings.Join(result.RegistrationOrder, ","); got != "Node,order.json" {
+		t.Errorf("only the recursive Node definition should stay extracted, got %s", got)
+	}
+	if root := types["order.json"].Schema; !strings.Contains(root, `"USD"`) || !strings.Contains(root, `"Where to ship"`) {
+		t.Errorf("Money, Currency and Address should be copied into the root:\n%s", root)
+	}
+}
+
+func TestSplitJSONSchemaLegacyDefinitions(t *testing.T) {
+	schema := `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$ref": "#/definitions/Customer",
+  "definitions": {
+    "Customer": {"type": "object", "properties": {"name": {"type": "string"}, "zip": {"$ref": "#/definitions/Address/properties/zip"}, "home": {"$ref": "#/definitions/Address"}}},
+    "Address": {"type": "object", "properties": {"zip": {"type": "string"}}}
+  }
+}`
+	result, err := splitJSONSchema(schema, 0, "")
+	if err != nil {
+		t.Fatalf("splitJSONSchema: %v", err)
+	}
+	if got := strings.Join(result.RegistrationOrder, ","); got != "Address,Customer,root" {
+		t.Fatalf("registration order = %s", got)
+	}
+	for _, typ := range result.Types {
+		switch typ.Name {
+		case "root":
+			if !strings.Contains(typ.Schema, `"$ref": "Customer.json"`) || strings.Contains(typ.Schema, "definitions") {
+				t.Errorf("unexpected root schema:\n%s", typ.Schema)
+			}
+		case "Customer":
+			if !strings.Contains(typ.Schema, `"Address.json#/properties/zip"`) || len(typ.References) != 1 {
+				t.Errorf("unexpected Customer schema (references %v):\n%s", typ.References, typ.Schema)
+			}
+		}
+	}
+}
+
+func TestSplitRegisterJSONReferences(t *testing.T) {
+	schema := `{
+  "$id": "https://example.com/schemas/order.json",
+  "type": "object",
+  "properties": {
+    "orderId": {"type": "string"},
+    "ship to": {
+      "type": "object",
+      "properties": {
+        "street": {"type": "string"},
+        "geo": {"type": "object", "properties": {"lat": {"type": "number"}, "lon": {"type": "number"}}}
+      }
+    },
+    "lines": {
+      "type": "array",
+      "items": {"type": "object", "properties": {"sku": {"type": "string"}, "qty": {"type": "integer"}}}
+    }
+  }
+}`
+	result, err := splitJSONSchema(schema, 0, "orders-")
+	if err != nil {
+		t.Fatalf("splitJSONSchema: %v", err)
+	}
+	for i := range result.Types {
+		if result.Types[i].IsRoot {
+			result.Types[i].Subject = "orders-value"
+		}
+	}
+
+	mock := client.NewMockClient()
+	if err := registerSplitParts(mock, result, "JSON"); err != nil {
+		t.Fatalf("registerSplitParts: %v", err)
+	}
+	if len(mock.Subjects) != 4 {
+		t.Fatalf("expected 4 subjects, got %v", mock.Subjects)
+	}
+
+	for subject, versions := range mock.Subjects {
+		registered := versions[len(versions)-1]
+		var refNames []string
+		for _, ref := range registered.References {
+			refNames = append(refNames, ref.Name)
+			target, ok := mock.Subjects[ref.Subject]
+			if !ok || target[len(target)-1].Version != ref.Version {
+				t.Errorf("%s: reference %+v does not point at a registered version", subject, ref)
+				continue
+			}
+			// The referenced schema is identified by the same name
+			var referenced map[string]interface{}
+			json.Unmarshal([]byte(target[len(target)-1].Schema), &referenced)
+			if referenced["$id"] != ref.Name {
+				t.Errorf("%s: reference %s points at a schema with $id %v", subject, ref.Name, referenced["$id"])
+			}
+		}
+		sort.Strings(refNames)
+		if want := schemaExpectedReferences(registered.Schema, "JSON"); strings.Join(refNames, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: reference names %v, want the $refs %v", subject, refNames, want)
+		}
+	}
+
+	root := mock.Subjects["orders-value"][0]
+	var rootSchema map[string]interface{}
+	json.Unmarshal([]byte(root.Schema), &rootSchema)
+	if rootSchema["$id"] != "https://example.com/schemas/order.json" {
+		t.Errorf("root $id changed: %v", rootSchema["$id"])
+	}
+	props := rootSchema["properties"].(map[string]interface{})
+	if ref := props["ship to"].(map[string]interface{})["$ref"]; ref != "ship%20to.json" {
+		t.Errorf("unexpected $ref for 'ship to': %v", ref)
+	}
+
+	// A reference that doesn't match a $ref is refused before registering
+	for i := range result.Types {
+		if result.Types[i].IsRoot {
+			result.Types[i].Schema = strings.Replace(result.Types[i].Schema, "ship%20to.json", "ship to.json", 1)
+		}
+	}
+	mock = client.NewMockClient()
+	if err := registerSplitParts(mock, result, "JSON"); err == nil {
+		t.Error("expected an error for a reference name that doesn't match the $ref")
+	}
+	if len(mock.Subjects) != 0 {
+		t.Errorf("nothing should be registered, got %v", mock.Subjects)
+	}
+}
+
 func TestToSnakeCase(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -478,3 +1260,324 @@ func TestSanitizeFilename(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitAvroSchemaUnionRoot(t *testing.T) {
+	schema := `[
+  "null",
+  {
+    "type": "record",
+    "name": "Address",
+    "namespace": "com.example",
+    "fields": [{"name": "street", "type": "string"}]
+  },
+  {
+    "type": "record",
+    "name": "Customer",
+    "namespace": "com.example",
+    "fields": [
+      {"name": "name", "type": "string"},
+      {"name": "address", "type": "com.example.Address"},
+      {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["ACTIVE", "INACTIVE"]}}
+    ]
+  }
+]`
+
+	result, err := splitAvroSchema(schema, 0, "", 0)
+	if err != nil {
+		t.Fatalf("splitAvroSchema failed: %v", err)
+	}
+
+	// Address, Customer, Status + the synthetic root
+	if len(result.Types) != 4 {
+		t.Fatalf("expected 4 types, got %d", len(result.Types))
+	}
+
+	root := result.Types[len(result.Types)-1]
+	if !root.IsRoot || root.Name != "root-union" {
+		t.Errorf("expected synthetic root-union registered last, got %s (root=%v)", root.Name, root.IsRoot)
+	}
+
+	var rootUnion []interface{}
+	if err := json.Unmarshal([]byte(root.Schema), &rootUnion); err != nil {
+		t.Fatalf("root schema should still be a union: %v", err)
+	}
+	expected := []interface{}{"null", "com.example.Address", "com.example.Customer"}
+	if len(rootUnion) != len(expected) {
+		t.Fatalf("expected root union %v, got %v", expected, rootUnion)
+	}
+	for i := range expected {
+		if rootUnion[i] != expected[i] {
+			t.Errorf("expected branch %d to be %v, got %v", i, expected[i], rootUnion[i])
+		}
+	}
+
+	order := make(map[string]int)
+	for i, name := range result.RegistrationOrder {
+		order[name] = i
+	}
+	if order["com.example.Address"] > order["com.example.Customer"] {
+		t.Error("Address must be registered before Customer, which references it by name")
+	}
+	if order["Status"] > order["com.example.Customer"] {
+		t.Error("Status must be registered before Customer")
+	}
+}
+
+func TestSplitAvroSchemaArrayRoot(t *testing.T) {
+	schema := `{"type": "array", "items": {
+  "type": "record",
+  "name": "Item",
+  "fields": [{"name": "sku", "type": "string"}]
+}}`
+
+	result, err := splitAvroSchema(schema, 0, "", 0)
+	if err != nil {
+		t.Fatalf("splitAvroSchema failed: %v", err)
+	}
+	if len(result.Types) != 2 {
+		t.Fatalf("expected 2 types, got %d", len(result.Types))
+	}
+	if result.RegistrationOrder[1] != "root-array" {
+		t.Errorf("expected root-array last, got %v", result.RegistrationOrder)
+	}
+	if !strings.Contains(result.Types[1].Schema, `"items": "Item"`) {
+		t.Errorf("expected root array items to reference Item, got %s", result.Types[1].Schema)
+	}
+
+	// A primitive map has nothing to extract
+	result, err = splitAvroSchema(`{"type": "map", "values": "long"}`, 0, "", 0)
+	if err != nil {
+		t.Fatalf("splitAvroSchema failed: %v", err)
+	}
+	if len(result.Types) != 1 || result.Types[0].Name != "root-map" {
+		t.Errorf("expected unsplit root-map, got %+v", result.Types)
+	}
+}
+
+func TestSplitAvroSchemaDetectsCycles(t *testing.T) {
+	schema := `{
+  "type": "record",
+  "name": "Root",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "employee", "type": {
+      "type": "record",
+      "name": "Employee",
+      "namespace": "com.example",
+      "fields": [
+        {"name": "department", "type": ["null", {
+          "type": "record",
+          "name": "Department",
+          "namespace": "com.example",
+          "fields": [
+            {"name": "manager", "type": ["null", "com.example.Employee"]}
+          ]
+        }]}
+      ]
+    }}
+  ]
+}`
+
+	result, err := splitSchema(schema, "AVRO", "root.avsc", 0, "", 0)
+	if err != nil {
+		t.Fatalf("splitSchema failed: %v", err)
+	}
+
+	if len(result.Cycles) != 1 {
+		t.Fatalf("expected 1 cycle, got %v", result.Cycles)
+	}
+	got := formatCycle(result.Cycles[0])
+	if got != "com.example.Department -> com.example.Employee -> com.example.Department" {
+		t.Errorf("unexpected cycle path: %s", got)
+	}
+}
+
+func TestSplitAvroSchemaDetectsNamespaceRelativeCycles(t *testing.T) {
+	// Child refers back to the root by its short name, resolved in com.x
+	for _, root := range []string{`"name": "com.x.Node"`, `"name": "Node", "namespace": "com.x"`} {
+		schema := `{"type": "record", ` + root + `, "fields": [
+  {"name": "child", "type": {"type": "record", "name": "Child", "fields": [
+    {"name": "parent", "type": ["null", "Node"]}
+  ]}}
+]}`
+		for _, depth := range []int{0, 1} {
+			result, err := splitSchema(schema, "AVRO", "node.avsc", 0, "", depth)
+			if err != nil {
+				t.Fatalf("splitSchema failed: %v", err)
+			}
+			// Depth 1 names nested types by their full name
+			child := "Child"
+			if depth == 1 {
+				child = "com.x.Child"
+			}
+			want := child + " -> com.x.Node -> " + child
+			if len(result.Cycles) != 1 || formatCycle(result.Cycles[0]) != want {
+				t.Errorf("%s, depth %d: expected cycle %s, got %v", root, depth, want, result.Cycles)
+			}
+			for _, typ := range result.Types {
+				if typ.Name == child && !reflect.DeepEqual(typ.References, []string{"com.x.Node"}) {
+					t.Errorf("%s, depth %d: expected Child to reference com.x.Node, got %v", root, depth, typ.References)
+				}
+			}
+		}
+	}
+}
+
+func TestFindDependencyCycles(t *testing.T) {
+	tests := []struct {
+		name string
+		deps map[string][]string
+		want []string
+	}{
+		{
+			name: "acyclic",
+			deps: map[string][]string{"Root": {"A", "B"}, "A": {"B"}, "B": nil},
+		},
+		{
+			name: "self reference is not a cycle",
+			deps: map[string][]string{"Root": {"Tree"}, "Tree": {"Tree"}},
+		},
+		{
+			name: "three-way cycle",
+			deps: map[string][]string{"Root": {"A"}, "A": {"B"}, "B": {"C"}, "C": {"A"}},
+			want: []string{"A -> B -> C -> A"},
+		},
+		{
+			name: "two independent cycles",
+			deps: map[string][]string{"Root": {"A", "X"}, "A": {"B"}, "B": {"A"}, "X": {"Y"}, "Y": {"X"}},
+			want: []string{"A -> B -> A", "X -> Y -> X"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycles := findDependencyCycles(tt.deps)
+			if len(cycles) != len(tt.want) {
+				t.Fatalf("expected %d cycles, got %v", len(tt.want), cycles)
+			}
+			for i, c := range cycles {
+				if formatCycle(c) != tt.want[i] {
+					t.Errorf("expected cycle %q, got %q", tt.want[i], formatCycle(c))
+				}
+			}
+		})
+	}
+}
+
+func TestResolveReferenceVersion(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "address", 3)
+
+	// Registered earlier in this run
+	v, err := resolveReferenceVersion(mock, "address", map[string]int{"address": 2})
+	if err != nil || v != 2 {
+		t.Errorf("expected version 2 from this run, got %d (err %v)", v, err)
+	}
+
+	// Already in the registry: latest version, not 1
+	v, err = resolveReferenceVersion(mock, "address", map[string]int{})
+	if err != nil || v != 3 {
+		t.Errorf("expected latest version 3, got %d (err %v)", v, err)
+	}
+
+	if _, err := resolveReferenceVersion(mock, "missing", map[string]int{}); err == nil {
+		t.Error("expected error for a referenced subject that doesn't exist")
+	}
+}
+
+func TestRegisteredVersion(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "address", 3) // IDs 100, 101, 102
+
+	// Re-registering an existing schema returns its ID; the version must be
+	// the one holding that ID, not the subject's latest
+	v, err := registeredVersion(mock, "address", 101)
+	if err != nil || v != 2 {
+		t.Errorf("expected version 2, got %d (err %v)", v, err)
+	}
+
+	// Unknown ID falls back to the latest version
+	v, err = registeredVersion(mock, "address", 999)
+	if err != nil || v != 3 {
+		t.Errorf("expected fallback to latest version 3, got %d (err %v)", v, err)
+	}
+}
+
+func TestApplySubjectStrategy(t *testing.T) {
+	schema := `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "customer", "type": {"type": "record", "name": "Customer", "namespace": "com.example.types", "fields": [{"name": "name", "type": "string"}]}},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "DONE"]}}
+  ]
+}`
+	subjects := func(result *SplitResult) map[string]string {
+		m := make(map[string]string)
+		for _, typ := range result.Types {
+			m[typ.Name] = typ.Subject
+		}
+		return m
+	}
+
+	tests := []struct {
+		strategy string
+		key      bool
+		want     map[string]string
+	}{
+		{"topic", false, map[string]string{"com.example.Order": "orders-value", "com.example.types.Customer": "com.example.types.Customer", "Status": "Status"}},
+		{"TopicNameStrategy", true, map[string]string{"com.example.Order": "orders-key", "com.example.types.Customer": "com.example.types.Customer", "Status": "Status"}},
+		{"io.confluent.kafka.serializers.subject.RecordNameStrategy", false, map[string]string{"com.example.Order": "com.example.Order", "com.example.types.Customer": "com.example.types.Customer", "Status": "com.example.Status"}},
+		{"topic-record", false, map[string]string{"com.example.Order": "orders-com.example.Order", "com.example.types.Customer": "orders-com.example.types.Customer", "Status": "orders-com.example.Status"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			result, err := splitAvroSchema(schema, 0, "", 0)
+			if err != nil {
+				t.Fatalf("splitAvroSchema: %v", err)
+			}
+			if err := applySubjectStrategy(result, "AVRO", tt.strategy, "orders", tt.key); err != nil {
+				t.Fatalf("applySubjectStrategy: %v", err)
+			}
+			got := subjects(result)
+			for name, subject := range tt.want {
+				if got[name] != subject {
+					t.Errorf("%s: subject %q, want %q", name, got[name], subject)
+				}
+			}
+		})
+	}
+
+	result, _ := splitAvroSchema(schema, 0, "", 0)
+	if err := applySubjectStrategy(result, "AVRO", "topic-record", "", false); err == nil {
+		t.Error("expected an error without --topic")
+	}
+	if err := applySubjectStrategy(result, "AVRO", "wire-name", "orders", false); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+
+	proto := `syntax = "proto3";
+package com.example;
+
+message Order {
+  string id = 1;
+  Customer customer = 2;
+}
+
+message Customer {
+  string name = 1;
+}
+`
+	result, err := splitProtobufSchema(proto, "order.proto", 0, "")
+	if err != nil {
+		t.Fatalf("splitProtobufSchema: %v", err)
+	}
+	if err := applySubjectStrategy(result, "PROTOBUF", "record", "", false); err != nil {
+		t.Fatalf("applySubjectStrategy: %v", err)
+	}
+	got := subjects(result)
+	if got["Order"] != "com.example.Order" || got["Customer"] != "com.example.Customer" {
+		t.Errorf("unexpected Protobuf subjects: %v", got)
+	}
+}
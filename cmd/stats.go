@@ -2,14 +2,18 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
-	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/config"
 	"github.com/srctl/srctl/internal/output"
 )
 
@@ -35,30 +39,59 @@ Examples:
   # Show stats for specific context
   srctl stats --context .production
 
+  # Only subjects starting with a prefix, filtered by the registry
+  srctl stats --prefix orders-
+
   # Output as JSON
   srctl stats -o json
 
-  # Show detailed breakdown
+  # Show detailed breakdown, including schema age where the registry
+  # returns version timestamps
   srctl stats --detailed
+
+  # Flag subjects not updated in the last 180 days
+  srctl stats --detailed --stale-days 180
   
   # Control parallelism
-  srctl stats --workers 50`,
-	RunE: runStats,
+  srctl stats --workers 50
+
+  # Fetch every version individually instead of using the bulk endpoint
+  srctl stats --no-bulk
+
+  # Write Prometheus metrics for the node_exporter textfile collector (cron)
+  srctl stats --export-prometheus /var/lib/node_exporter/srctl.prom
+
+  # Prometheus metrics on stdout (progress goes to stderr)
+  srctl stats --export-prometheus -`,
+	Annotations: readOnlyAnnotations,
+	RunE:        runStats,
 }
 
 var (
-	statsDetailed bool
-	statsWorkers  int
+	statsDetailed  bool
+	statsWorkers   int
+	statsNoBulk    bool
+	statsStaleDays int
+	statsPrefix    string
+
+	statsExportPrometheus string
 )
 
+// statsBulkPageSize is the number of schemas requested per bulk page
+const statsBulkPageSize = 1000
+
 func init() {
 	statsCmd.Flags().BoolVar(&statsDetailed, "detailed", false, "Show detailed breakdown")
 	statsCmd.Flags().IntVar(&statsWorkers, "workers", 20, "Number of parallel workers for fetching schemas")
+	statsCmd.Flags().BoolVar(&statsNoBulk, "no-bulk", false, "Fetch each version individually instead of using the bulk /schemas endpoint")
+	statsCmd.Flags().StringVar(&statsPrefix, "prefix", "", "Only include subjects starting with this prefix (filtered by the registry, case-sensitive)")
+	statsCmd.Flags().IntVar(&statsStaleDays, "stale-days", 90, "With --detailed, report subjects whose latest version is older than this many days")
+	statsCmd.Flags().StringVar(&statsExportPrometheus, "export-prometheus", "", "Write the statistics as Prometheus text-format metrics to a file (- for stdout)")
 	rootCmd.AddCommand(statsCmd)
 }
 
 type RegistryStats struct {
-	// Subject counts (excludes internal subjects like _confluent-ksql-*)
+	// Subject counts (excludes internal subjects, see --internal-prefixes)
 	ActiveSubjects   int `json:"activeSubjects"`
 	DeletedSubjects  int `json:"deletedSubjects"`
 	TotalSubjects    int `json:"totalSubjects"`
@@ -94,6 +127,23 @@ type RegistryStats struct {
 	// Top subjects
 	TopByVersions []SubjectVersionCount `json:"topByVersions,omitempty"`
 	TopBySize     []SubjectSizeInfo     `json:"topBySize,omitempty"`
+
+	// Age metrics (--detailed, registries that return version timestamps)
+	VersionsWithTimestamps int              `json:"versionsWithTimestamps,omitempty"`
+	OldestSchema           string           `json:"oldestSchema,omitempty"`
+	OldestSchemaTime       *time.Time       `json:"oldestSchemaTime,omitempty"`
+	NewestSchema           string           `json:"newestSchema,omitempty"`
+	NewestSchemaTime       *time.Time       `json:"newestSchemaTime,omitempty"`
+	StaleDays              int              `json:"staleDays,omitempty"`
+	StaleSubjectCount      int              `json:"staleSubjectCount,omitempty"`
+	StaleSubjects          []SubjectAgeInfo `json:"staleSubjects,omitempty"`
+}
+
+// SubjectAgeInfo is a subject whose latest version is older than --stale-days
+type SubjectAgeInfo struct {
+	Subject      string    `json:"subject"`
+	LastModified time.Time `json:"lastModified"`
+	AgeDays      int       `json:"ageDays"`
 }
 
 type SubjectVersionCount struct {
@@ -122,13 +172,42 @@ type subjectResult struct {
 	MinSize          int64
 	MaxSize          int64
 	MaxSizeInfo      string
+	References       []client.SchemaReference // References of every version, with duplicates
 	Errors           []string
 	IsInternal       bool
+
+	// Version timestamps in epoch milliseconds, 0 when the registry omits them
+	TimestampCount   int
+	FirstCreated     int64
+	FirstCreatedInfo string
+	LastModified     int64
+	LastModifiedInfo string
 }
 
-// isInternalSubject checks if a subject is an internal/system subject
+// defaultInternalPrefixes cover Confluent components (ksqlDB, Control Center,
+// ...), the registry's own _schemas topic and double-underscore system names
+var defaultInternalPrefixes = []string{"_confluent-", "_schemas", "__"}
+
+// isInternalSubject checks if a subject is an internal/system subject, based
+// on --internal-prefixes
 func isInternalSubject(subject string) bool {
-	return strings.HasPrefix(subject, "_confluent-ksql-")
+	for _, prefix := range internalPrefixes {
+		if prefix != "" && strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutInternalSubjects drops internal subjects and returns how many there were
+func withoutInternalSubjects(subjects []string) ([]string, int) {
+	var kept []string
+	for _, s := range subjects {
+		if !isInternalSubject(s) {
+			kept = append(kept, s)
+		}
+	}
+	return kept, len(subjects) - len(kept)
 }
 
 func runStats(cmd *cobra.Command, args []string) error {
@@ -136,24 +215,32 @@ func runStats(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defer reportThrottling(c)
+
+	metricsOut := os.Stdout
+	if statsExportPrometheus == "-" {
+		// Keep stdout for the metrics; progress messages go to stderr
+		os.Stdout = os.Stderr
+		defer func() { os.Stdout = metricsOut }()
+	}
 
 	output.Header("Schema Registry Statistics")
 
 	// Get all subjects (active)
 	output.Step("Fetching active subjects...")
-	allActiveSubjects, err := c.GetSubjects(false)
+	allActiveSubjects, err := listSubjects(c, statsPrefix, false)
 	if err != nil {
 		return fmt.Errorf("failed to get subjects: %w", err)
 	}
 
 	// Get all subjects including deleted
 	output.Step("Fetching all subjects (including deleted)...")
-	allSubjectsIncludingDeleted, err := c.GetSubjects(true)
+	allSubjectsIncludingDeleted, err := listSubjects(c, statsPrefix, true)
 	if err != nil {
 		return fmt.Errorf("failed to get all subjects: %w", err)
 	}
 
-	// Filter out internal subjects (_confluent-ksql-*)
+	// Filter out internal subjects (--internal-prefixes)
 	var activeSubjects, allSubjects []string
 	var internalActiveCount, internalAllCount int
 	for _, s := range allActiveSubjects {
@@ -182,15 +269,30 @@ func runStats(cmd *cobra.Command, args []string) error {
 
 	if stats.TotalSubjects == 0 {
 		output.Info("Registry is empty")
+		if statsExportPrometheus != "" {
+			stats.MinSchemaID, stats.MinSchemaSize = 0, 0
+			return exportPrometheusStats(metricsOut, stats)
+		}
 		return nil
 	}
 
 	output.Info("Found %d subjects (%d active, %d deleted) - excluding %d internal subjects", stats.TotalSubjects, stats.ActiveSubjects, stats.DeletedSubjects, stats.InternalSubjects)
 
+	// Pull schemas in bulk; subjects missing from the bulk result are fetched per version
+	var bulk map[string][]client.Schema
+	if !statsNoBulk {
+		output.Step("Fetching schemas in bulk...")
+		bulk, err = fetchSchemasBulk(c, statsPrefix, statsBulkPageSize)
+		if err != nil {
+			output.Warning("Bulk schema fetch unavailable, falling back to per-version fetches: %v", err)
+			bulk = nil
+		}
+	}
+
 	// Analyze schemas using worker pool
 	output.Step("Analyzing schemas with %d workers...", statsWorkers)
 
-	results := analyzeSubjectsParallel(c, allSubjects, statsWorkers)
+	results := analyzeSubjectsParallel(c, allSubjects, statsWorkers, bulk)
 
 	// Aggregate results
 	schemaIDs := make(map[int]bool)
@@ -268,6 +370,14 @@ func runStats(cmd *cobra.Command, args []string) error {
 	}
 	stats.DeletedVersions = stats.TotalVersions - stats.ActiveVersions
 
+	if statsDetailed {
+		activeSet := make(map[string]bool, len(activeSubjects))
+		for _, subj := range activeSubjects {
+			activeSet[subj] = true
+		}
+		stats.addAgeMetrics(results, activeSet, statsStaleDays, time.Now())
+	}
+
 	stats.UniqueSchemaIDs = len(schemaIDs)
 
 	if stats.TotalVersions > 0 {
@@ -337,6 +447,10 @@ func runStats(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output
+	if statsExportPrometheus != "" {
+		return exportPrometheusStats(metricsOut, stats)
+	}
+
 	printer := output.NewPrinter(outputFormat)
 
 	if outputFormat != "table" {
@@ -419,25 +533,198 @@ func runStats(cmd *cobra.Command, args []string) error {
 			})
 		}
 		output.PrintTable([]string{"Subject", "Total Size", "Avg Size", "Versions"}, sizeRows)
+
+		output.SubHeader("Schema Age")
+		if stats.OldestSchemaTime == nil {
+			output.Info("The registry does not return version timestamps")
+		} else {
+			output.PrintTable(
+				[]string{"Metric", "Value"},
+				[][]string{
+					{"Oldest Schema", fmt.Sprintf("%s, %s", stats.OldestSchema, stats.OldestSchemaTime.Format(time.DateOnly))},
+					{"Newest Schema", fmt.Sprintf("%s, %s", stats.NewestSchema, stats.NewestSchemaTime.Format(time.DateOnly))},
+					{fmt.Sprintf("Subjects Not Updated in %d Days", stats.StaleDays), strconv.Itoa(stats.StaleSubjectCount)},
+				},
+			)
+			if stats.VersionsWithTimestamps < stats.TotalVersions {
+				output.Info("(%d of %d versions have timestamps)", stats.VersionsWithTimestamps, stats.TotalVersions)
+			}
+
+			if len(stats.StaleSubjects) > 0 {
+				output.SubHeader("Top 10 Stalest Subjects")
+				var staleRows [][]string
+				for _, s := range stats.StaleSubjects[:min(10, len(stats.StaleSubjects))] {
+					staleRows = append(staleRows, []string{s.Subject, s.LastModified.Format(time.DateOnly), strconv.Itoa(s.AgeDays)})
+				}
+				output.PrintTable([]string{"Subject", "Last Modified", "Age (days)"}, staleRows)
+			}
+		}
+	}
+
+	return nil
+}
+
+// addAgeMetrics fills the age fields from version timestamps. Only active
+// subjects count as stale; the list is ordered oldest first.
+func (stats *RegistryStats) addAgeMetrics(results []subjectResult, active map[string]bool, staleDays int, now time.Time) {
+	var oldest, newest int64
+	cutoff := now.AddDate(0, 0, -staleDays).UnixMilli()
+	stats.StaleDays = staleDays
+
+	for _, r := range results {
+		if r.IsInternal || r.TimestampCount == 0 {
+			continue
+		}
+		stats.VersionsWithTimestamps += r.TimestampCount
+		if oldest == 0 || r.FirstCreated < oldest {
+			oldest = r.FirstCreated
+			stats.OldestSchema = r.FirstCreatedInfo
+		}
+		if r.LastModified > newest {
+			newest = r.LastModified
+			stats.NewestSchema = r.LastModifiedInfo
+		}
+		if active[r.Subject] && r.LastModified < cutoff {
+			modified := time.UnixMilli(r.LastModified).UTC()
+			stats.StaleSubjects = append(stats.StaleSubjects, SubjectAgeInfo{
+				Subject:      r.Subject,
+				LastModified: modified,
+				AgeDays:      int(now.Sub(modified).Hours() / 24),
+			})
+		}
+	}
+
+	if oldest > 0 {
+		o, n := time.UnixMilli(oldest).UTC(), time.UnixMilli(newest).UTC()
+		stats.OldestSchemaTime, stats.NewestSchemaTime = &o, &n
+	}
+	sort.Slice(stats.StaleSubjects, func(i, j int) bool {
+		return stats.StaleSubjects[i].LastModified.Before(stats.StaleSubjects[j].LastModified)
+	})
+	stats.StaleSubjectCount = len(stats.StaleSubjects)
+}
+
+// exportPrometheusStats writes the metrics to stdout for "-", otherwise to the
+// --export-prometheus file. The file is replaced atomically so a textfile
+// collector never reads a partial write.
+func exportPrometheusStats(stdout io.Writer, stats RegistryStats) error {
+	now := time.Now()
+	if statsExportPrometheus == "-" {
+		return writePrometheusStats(stdout, stats, srContext, now)
 	}
 
+	tmp, err := os.CreateTemp(filepath.Dir(statsExportPrometheus), ".srctl-stats-*.prom")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := writePrometheusStats(tmp, stats, srContext, now); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+	// CreateTemp uses 0600; the collector usually runs as another user
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), statsExportPrometheus); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	output.Success("Wrote Prometheus metrics to %s", statsExportPrometheus)
 	return nil
 }
 
+// writePrometheusStats renders stats in the Prometheus text exposition format.
+// Every sample carries a context label when a context is set.
+func writePrometheusStats(w io.Writer, stats RegistryStats, context string, now time.Time) error {
+	var b strings.Builder
+
+	labels := func(pairs ...string) string {
+		if context != "" {
+			pairs = append([]string{"context", context}, pairs...)
+		}
+		if len(pairs) == 0 {
+			return ""
+		}
+		var parts []string
+		for i := 0; i+1 < len(pairs); i += 2 {
+			parts = append(parts, fmt.Sprintf("%s=%q", pairs[i], pairs[i+1]))
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	}
+	metric := func(name, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+	sample := func(name string, value float64, pairs ...string) {
+		fmt.Fprintf(&b, "%s%s %s\n", name, labels(pairs...), strconv.FormatFloat(value, 'f', -1, 64))
+	}
+
+	metric("srctl_registry_subjects_total", "Subjects in the registry, excluding internal subjects")
+	sample("srctl_registry_subjects_total", float64(stats.ActiveSubjects), "state", "active")
+	sample("srctl_registry_subjects_total", float64(stats.DeletedSubjects), "state", "deleted")
+
+	metric("srctl_registry_internal_subjects_total", "Internal subjects (see --internal-prefixes)")
+	sample("srctl_registry_internal_subjects_total", float64(stats.InternalSubjects))
+
+	metric("srctl_registry_schema_versions_total", "Schema versions, excluding internal subjects")
+	sample("srctl_registry_schema_versions_total", float64(stats.ActiveVersions), "state", "active")
+	sample("srctl_registry_schema_versions_total", float64(stats.DeletedVersions), "state", "deleted")
+
+	metric("srctl_registry_internal_schema_versions_total", "Schema versions of internal subjects")
+	sample("srctl_registry_internal_schema_versions_total", float64(stats.InternalVersions))
+
+	metric("srctl_registry_schemas_by_type", "Schema versions by schema type")
+	sample("srctl_registry_schemas_by_type", float64(stats.AvroSchemas), "type", "AVRO")
+	sample("srctl_registry_schemas_by_type", float64(stats.ProtobufSchemas), "type", "PROTOBUF")
+	sample("srctl_registry_schemas_by_type", float64(stats.JSONSchemas), "type", "JSON")
+
+	metric("srctl_registry_unique_schema_ids", "Distinct schema IDs in use")
+	sample("srctl_registry_unique_schema_ids", float64(stats.UniqueSchemaIDs))
+
+	metric("srctl_registry_max_schema_id", "Highest schema ID in use")
+	sample("srctl_registry_max_schema_id", float64(stats.MaxSchemaID))
+
+	metric("srctl_registry_schema_size_bytes", "Schema size in bytes")
+	sample("srctl_registry_schema_size_bytes", float64(stats.TotalSchemaSize), "stat", "total")
+	sample("srctl_registry_schema_size_bytes", stats.AvgSchemaSize, "stat", "avg")
+	sample("srctl_registry_schema_size_bytes", float64(stats.MinSchemaSize), "stat", "min")
+	sample("srctl_registry_schema_size_bytes", float64(stats.MaxSchemaSize), "stat", "max")
+
+	metric("srctl_registry_schemas_with_references", "Schema versions that have references")
+	sample("srctl_registry_schemas_with_references", float64(stats.SchemasWithRefs))
+
+	metric("srctl_registry_references_total", "References across all schema versions")
+	sample("srctl_registry_references_total", float64(stats.TotalReferences))
+
+	if stats.OldestSchemaTime != nil {
+		metric("srctl_registry_schema_created_timestamp_seconds", "Creation time of the oldest and newest schema versions")
+		sample("srctl_registry_schema_created_timestamp_seconds", float64(stats.OldestSchemaTime.Unix()), "schema", "oldest")
+		sample("srctl_registry_schema_created_timestamp_seconds", float64(stats.NewestSchemaTime.Unix()), "schema", "newest")
+
+		metric("srctl_registry_stale_subjects", "Active subjects whose latest version is older than --stale-days")
+		sample("srctl_registry_stale_subjects", float64(stats.StaleSubjectCount), "days", strconv.Itoa(stats.StaleDays))
+	}
+
+	metric("srctl_registry_stats_timestamp_seconds", "Unix time the statistics were collected")
+	sample("srctl_registry_stats_timestamp_seconds", float64(now.Unix()))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
 // analyzeSubjectsParallel analyzes subjects using a worker pool
-func analyzeSubjectsParallel(c *client.SchemaRegistryClient, subjects []string, numWorkers int) []subjectResult {
+func analyzeSubjectsParallel(c client.SchemaRegistryClientInterface, subjects []string, numWorkers int, bulk map[string][]client.Schema) []subjectResult {
 	numWorkers = clampWorkers(numWorkers)
 	// Create channels
 	jobs := make(chan string, len(subjects))
 	results := make(chan subjectResult, len(subjects))
 
-	// Progress tracking
-	bar := progressbar.NewOptions(len(subjects),
-		progressbar.OptionSetDescription("Analyzing"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetWidth(40),
-		progressbar.OptionClearOnFinish(),
-	)
+	// Progress tracking goes to stderr so JSON and graph output stay clean
+	bar := newProgressBarTo(os.Stderr, len(subjects), "Analyzing")
 
 	// Start workers
 	var wg sync.WaitGroup
@@ -446,7 +733,7 @@ func analyzeSubjectsParallel(c *client.SchemaRegistryClient, subjects []string,
 		go func() {
 			defer wg.Done()
 			for subject := range jobs {
-				result := analyzeSubject(c, subject)
+				result := analyzeSubject(c, subject, bulk[subject])
 				results <- result
 				bar.Add(1)
 			}
@@ -477,8 +764,47 @@ func analyzeSubjectsParallel(c *client.SchemaRegistryClient, subjects []string,
 	return allResults
 }
 
-// analyzeSubject analyzes a single subject by fetching ALL versions
-func analyzeSubject(c *client.SchemaRegistryClient, subject string) subjectResult {
+// fetchSchemasBulk pages through the bulk /schemas endpoint, including
+// soft-deleted versions of subjects starting with prefix, and groups the
+// schemas by subject. Paging stops once
+// a page adds nothing new, which also copes with registries that ignore
+// offset/limit and return everything at once.
+func fetchSchemasBulk(c client.SchemaRegistryClientInterface, prefix string, pageSize int) (map[string][]client.Schema, error) {
+	bySubject := make(map[string][]client.Schema)
+	seen := make(map[string]bool)
+
+	for offset := 0; ; offset += pageSize {
+		page, err := c.GetSchemasPage(prefix, true, offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, schema := range page {
+			key := fmt.Sprintf("%s:%d", schema.Subject, schema.Version)
+			if schema.Subject == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			bySubject[schema.Subject] = append(bySubject[schema.Subject], schema)
+			added++
+		}
+
+		if added == 0 || len(page) < pageSize {
+			break
+		}
+	}
+
+	for subject := range bySubject {
+		schemas := bySubject[subject]
+		sort.Slice(schemas, func(i, j int) bool { return schemas[i].Version < schemas[j].Version })
+	}
+	return bySubject, nil
+}
+
+// analyzeSubject analyzes every version of a subject, using prefetched schemas
+// when available and fetching each version individually otherwise
+func analyzeSubject(c client.SchemaRegistryClientInterface, subject string, prefetched []client.Schema) subjectResult {
 	result := subjectResult{
 		Subject:    subject,
 		TypeCounts: make(map[string]int),
@@ -488,6 +814,14 @@ func analyzeSubject(c *client.SchemaRegistryClient, subject string) subjectResul
 		IsInternal: isInternalSubject(subject),
 	}
 
+	if len(prefetched) > 0 {
+		result.VersionCount = len(prefetched)
+		for i := range prefetched {
+			result.addSchema(&prefetched[i], prefetched[i].Version)
+		}
+		return result
+	}
+
 	// Get all versions
 	versions, err := c.GetVersions(subject, true)
 	if err != nil {
@@ -508,37 +842,55 @@ func analyzeSubject(c *client.SchemaRegistryClient, subject string) subjectResul
 				continue
 			}
 		}
+		result.addSchema(schema, v)
+	}
 
-		// Track schema type
-		schemaType := strings.ToUpper(schema.SchemaType)
-		if schemaType == "" {
-			schemaType = "AVRO"
-		}
-		result.TypeCounts[schemaType]++
+	return result
+}
 
-		// Track schema ID
-		result.SchemaIDs = append(result.SchemaIDs, schema.ID)
+// addSchema folds one schema version into the subject's totals
+func (result *subjectResult) addSchema(schema *client.Schema, version int) {
+	// Track schema type
+	schemaType := strings.ToUpper(schema.SchemaType)
+	if schemaType == "" {
+		schemaType = "AVRO"
+	}
+	result.TypeCounts[schemaType]++
 
-		// Track size
-		schemaSize := int64(len(schema.Schema))
-		result.TotalSize += schemaSize
+	// Track schema ID
+	result.SchemaIDs = append(result.SchemaIDs, schema.ID)
 
-		if schemaSize < result.MinSize {
-			result.MinSize = schemaSize
-		}
-		if schemaSize > result.MaxSize {
-			result.MaxSize = schemaSize
-			result.MaxSizeInfo = fmt.Sprintf("%s (v%d)", subject, v)
-		}
+	// Track size
+	schemaSize := int64(len(schema.Schema))
+	result.TotalSize += schemaSize
 
-		// Track references
-		if len(schema.References) > 0 {
-			result.TotalRefCount += len(schema.References)
-			result.VersionsWithRefs++
-		}
+	if schemaSize < result.MinSize {
+		result.MinSize = schemaSize
+	}
+	if schemaSize > result.MaxSize {
+		result.MaxSize = schemaSize
+		result.MaxSizeInfo = fmt.Sprintf("%s (v%d)", result.Subject, version)
 	}
 
-	return result
+	// Track references
+	if len(schema.References) > 0 {
+		result.TotalRefCount += len(schema.References)
+		result.VersionsWithRefs++
+		result.References = append(result.References, schema.References...)
+	}
+
+	// Track creation time
+	if ts := schema.Timestamp; ts > 0 {
+		result.TimestampCount++
+		if result.FirstCreated == 0 || ts < result.FirstCreated {
+			result.FirstCreated = ts
+			result.FirstCreatedInfo = fmt.Sprintf("%s (v%d)", result.Subject, version)
+		}
+		if ts > result.LastModified {
+			result.LastModified = ts
+			result.LastModifiedInfo = fmt.Sprintf("%s (v%d)", result.Subject, version)
+		}
+	}
 }
 
 // Health command
@@ -553,6 +905,10 @@ Performs various checks:
   • Basic operations (list subjects)
   • Response times
 
+Each API call is timed and the min/avg/max latency is reported. With
+--threshold, the command exits non-zero when any call is slower than the
+threshold, so it can be used as a monitoring probe.
+
 Examples:
   # Check default registry
   srctl health
@@ -560,19 +916,117 @@ Examples:
   # Check specific registry
   srctl health --registry prod
 
-  # Check all configured registries
-  srctl health --all`,
+  # Fail if any call takes longer than 500ms
+  srctl health --threshold 500ms
+
+  # Check all configured registries (exits non-zero if any fails)
+  srctl health --all --threshold 1s`,
 	RunE: runHealth,
 }
 
-var healthAll bool
+var (
+	healthAll       bool
+	healthThreshold time.Duration
+)
 
 func init() {
 	healthCmd.Flags().BoolVar(&healthAll, "all", false, "Check all configured registries")
+	healthCmd.Flags().DurationVar(&healthThreshold, "threshold", 0, "Exit non-zero if any API call takes longer than this, e.g. 500ms (0 = no limit)")
 	rootCmd.AddCommand(healthCmd)
 }
 
+// healthCall is the timing of one health-check API call
+type healthCall struct {
+	Name    string
+	Latency time.Duration
+	Err     error
+}
+
+// timeHealthCall runs fn and records how long it took
+func timeHealthCall(calls *[]healthCall, name string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	*calls = append(*calls, healthCall{Name: name, Latency: time.Since(start), Err: err})
+	return err
+}
+
+// latencySummary returns the min, average and max latency of the calls
+func latencySummary(calls []healthCall) (lo, avg, hi time.Duration) {
+	if len(calls) == 0 {
+		return 0, 0, 0
+	}
+	var total time.Duration
+	lo = calls[0].Latency
+	for _, call := range calls {
+		lo = min(lo, call.Latency)
+		hi = max(hi, call.Latency)
+		total += call.Latency
+	}
+	return lo, total / time.Duration(len(calls)), hi
+}
+
+// slowHealthCalls returns the calls slower than threshold; 0 disables the check
+func slowHealthCalls(calls []healthCall, threshold time.Duration) []healthCall {
+	if threshold <= 0 {
+		return nil
+	}
+	var slow []healthCall
+	for _, call := range calls {
+		if call.Latency > threshold {
+			slow = append(slow, call)
+		}
+	}
+	return slow
+}
+
+// healthProbe is the result of the health-check calls against one registry
+type healthProbe struct {
+	Subjects []string
+	Mode     *client.Mode
+	Config   *client.Config
+	Contexts []string
+	Calls    []healthCall
+}
+
+// probeRegistry times the health-check calls. Only a failure to list subjects
+// is returned as an error; mode, config and contexts are optional.
+func probeRegistry(c client.SchemaRegistryClientInterface) (*healthProbe, error) {
+	p := &healthProbe{}
+
+	err := timeHealthCall(&p.Calls, "GetSubjects", func() (err error) {
+		p.Subjects, err = c.GetSubjects(false)
+		return err
+	})
+	if err != nil {
+		return p, err
+	}
+
+	timeHealthCall(&p.Calls, "GetMode", func() (err error) {
+		p.Mode, err = c.GetMode()
+		return err
+	})
+	timeHealthCall(&p.Calls, "GetConfig", func() (err error) {
+		p.Config, err = c.GetConfig()
+		return err
+	})
+	timeHealthCall(&p.Calls, "GetContexts", func() (err error) {
+		p.Contexts, err = c.GetContexts()
+		return err
+	})
+	return p, nil
+}
+
+// formatLatencies renders the min/avg/max latency of the calls
+func formatLatencies(calls []healthCall) string {
+	lo, avg, hi := latencySummary(calls)
+	return fmt.Sprintf("%s / %s / %s", lo.Round(time.Millisecond), avg.Round(time.Millisecond), hi.Round(time.Millisecond))
+}
+
 func runHealth(cmd *cobra.Command, args []string) error {
+	if healthAll {
+		return runHealthAll()
+	}
+
 	output.Header("Schema Registry Health Check")
 
 	c, err := GetClient()
@@ -581,42 +1035,110 @@ func runHealth(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.Auth.SkipsVerify() {
+		output.Warning("INSECURE: TLS certificate verification is disabled; the registry's identity will NOT be verified")
+		output.Warning("Use --ca-cert with the registry's CA instead of --insecure outside development")
+	}
+
 	// Test connectivity
 	output.Step("Checking connectivity...")
 
-	// Try to list subjects
-	subjects, err := c.GetSubjects(false)
+	probe, err := probeRegistry(c)
 	if err != nil {
 		output.Error("API Error: %v", err)
 		return err
 	}
 
 	output.Success("Connection successful")
-	output.Info("Registry URL: %s", registryURL)
-	output.Info("Subjects found: %d", len(subjects))
-
-	// Check mode
-	mode, err := c.GetMode()
-	if err == nil && mode != nil {
-		output.Info("Mode: %s", mode.Mode)
+	output.Info("Registry URL: %s", c.BaseURL)
+	if proxy := c.ProxyURL(); proxy != "" {
+		output.Info("Proxy: %s", proxy)
+	}
+	if c.Auth.UsesClientCert() {
+		output.Info("Client certificate: %s (mutual TLS)", c.Auth.ClientCertFile)
 	}
+	output.Info("Subjects found: %d", len(probe.Subjects))
 
-	// Check config
-	config, err := c.GetConfig()
-	if err == nil && config != nil {
-		level := config.CompatibilityLevel
+	if probe.Mode != nil {
+		output.Info("Mode: %s", probe.Mode.Mode)
+	}
+	if probe.Config != nil {
+		level := probe.Config.CompatibilityLevel
 		if level == "" {
-			level = config.Compatibility
+			level = probe.Config.Compatibility
 		}
 		output.Info("Compatibility: %s", level)
 	}
+	if probe.Contexts != nil {
+		output.Info("Contexts: %d", len(probe.Contexts))
+	}
 
-	// Try to get contexts
-	contexts, err := c.GetContexts()
-	if err == nil {
-		output.Info("Contexts: %d", len(contexts))
+	// Response times
+	output.SubHeader("Response Times")
+	var rows [][]string
+	for _, call := range probe.Calls {
+		status := "OK"
+		if call.Err != nil {
+			status = "Error"
+		}
+		rows = append(rows, []string{call.Name, call.Latency.Round(time.Millisecond).String(), status})
+	}
+	output.PrintTable([]string{"Call", "Latency", "Status"}, rows)
+	output.Info("Latency min/avg/max: %s", formatLatencies(probe.Calls))
+
+	if slow := slowHealthCalls(probe.Calls, healthThreshold); len(slow) > 0 {
+		for _, call := range slow {
+			output.Error("%s took %s (threshold %s)", call.Name, call.Latency.Round(time.Millisecond), healthThreshold)
+		}
+		return fmt.Errorf("%d API call(s) exceeded the latency threshold of %s", len(slow), healthThreshold)
 	}
 
 	output.Success("All health checks passed")
 	return nil
 }
+
+// runHealthAll checks every registry in the config file and prints one row
+// per registry
+func runHealthAll() error {
+	output.Header("Schema Registry Health Check (all registries)")
+
+	registries := config.AppConfig.Registries
+	if len(registries) == 0 {
+		return fmt.Errorf("no registries configured")
+	}
+
+	var rows [][]string
+	failed := 0
+	for _, reg := range registries {
+		output.Step("Checking %s...", reg.Name)
+		status, subjects, latency, detail := "PASS", "-", "-", ""
+
+		c, err := GetClientForRegistry(reg.Name)
+		if err == nil {
+			var probe *healthProbe
+			probe, err = probeRegistry(c)
+			latency = formatLatencies(probe.Calls)
+			if err == nil {
+				subjects = strconv.Itoa(len(probe.Subjects))
+				if slow := slowHealthCalls(probe.Calls, healthThreshold); len(slow) > 0 {
+					err = fmt.Errorf("%s took %s (threshold %s)", slow[0].Name, slow[0].Latency.Round(time.Millisecond), healthThreshold)
+				}
+			}
+		}
+		if err != nil {
+			status, detail = "FAIL", err.Error()
+			failed++
+		} else if c.Auth.SkipsVerify() {
+			detail = "INSECURE: TLS verification disabled"
+		}
+		rows = append(rows, []string{reg.Name, reg.URL, status, subjects, latency, detail})
+	}
+
+	output.PrintTable([]string{"Registry", "URL", "Status", "Subjects", "Latency (min/avg/max)", "Detail"}, rows)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d registries failed health checks", failed, len(registries))
+	}
+	output.Success("All %d registries healthy", len(registries))
+	return nil
+}
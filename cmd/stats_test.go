@@ -2,9 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/config"
 )
 
 func TestIsInternalSubject(t *testing.T) {
@@ -29,8 +36,18 @@ func TestIsInternalSubject(t *testing.T) {
 			expected: false,
 		},
 		{
-			name:     "regular subject with underscore",
+			name:     "registry schemas topic",
 			subject:  "_schemas",
+			expected: true,
+		},
+		{
+			name:     "double underscore system subject",
+			subject:  "__consumer_offsets-value",
+			expected: true,
+		},
+		{
+			name:     "regular subject with underscore",
+			subject:  "_orders-value",
 			expected: false,
 		},
 		{
@@ -50,6 +67,26 @@ func TestIsInternalSubject(t *testing.T) {
 	}
 }
 
+func TestIsInternalSubjectCustomPrefixes(t *testing.T) {
+	saved := internalPrefixes
+	defer func() { internalPrefixes = saved }()
+
+	internalPrefixes = []string{"connect-", "_schemas"}
+	if !isInternalSubject("connect-offsets-value") {
+		t.Error("expected custom prefix to mark subject internal")
+	}
+	if isInternalSubject("_confluent-ksql-default_query_1") {
+		t.Error("expected default prefixes to be replaced")
+	}
+
+	// No prefixes means nothing is internal
+	internalPrefixes = nil
+	subjects, internal := withoutInternalSubjects([]string{"_schemas", "__x", "orders"})
+	if internal != 0 || len(subjects) != 3 {
+		t.Errorf("expected no internal subjects, got %d (%v)", internal, subjects)
+	}
+}
+
 func TestSubjectResultStruct(t *testing.T) {
 	result := subjectResult{
 		Subject:          "test-subject",
@@ -273,3 +310,249 @@ func TestStatsTypeCounts(t *testing.T) {
 		t.Errorf("expected JSON, got %s", jsonSchema.SchemaType)
 	}
 }
+
+func TestFetchSchemasBulk(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders", 3)
+	addTestSubject(mock, "users", 2)
+
+	bulk, err := fetchSchemasBulk(mock, "", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bulk["orders"]) != 3 || len(bulk["users"]) != 2 {
+		t.Errorf("expected 3 orders and 2 users versions, got %d and %d", len(bulk["orders"]), len(bulk["users"]))
+	}
+	if calls := mock.GetCallCount("GetSchemasPage"); calls != 3 {
+		t.Errorf("expected 3 pages of 2, got %d calls", calls)
+	}
+
+	// Prefetched schemas are analyzed without per-version requests
+	result := analyzeSubject(mock, "orders", bulk["orders"])
+	if result.VersionCount != 3 || len(result.SchemaIDs) != 3 {
+		t.Errorf("expected 3 analyzed versions, got %+v", result)
+	}
+	if mock.GetCallCount("GetVersions") != 0 || mock.GetCallCount("GetSchema") != 0 {
+		t.Error("expected no per-version fetches for prefetched subjects")
+	}
+
+	// Subjects missing from the bulk result fall back to per-version fetches
+	result = analyzeSubject(mock, "users", nil)
+	if result.VersionCount != 2 || mock.GetCallCount("GetSchema") != 2 {
+		t.Errorf("expected per-version fallback for 2 versions, got %+v", result)
+	}
+
+	mock.BulkSchemasError = fmt.Errorf("404 not found")
+	if _, err := fetchSchemasBulk(mock, "", 2); err == nil {
+		t.Error("expected bulk fetch error to be returned for fallback")
+	}
+}
+
+func TestWritePrometheusStats(t *testing.T) {
+	stats := RegistryStats{
+		ActiveSubjects:  3,
+		DeletedSubjects: 1,
+		ActiveVersions:  7,
+		AvroSchemas:     5,
+		JSONSchemas:     2,
+		TotalSchemaSize: 4096,
+		AvgSchemaSize:   585.5,
+	}
+
+	var b strings.Builder
+	if err := writePrometheusStats(&b, stats, "", time.Unix(1700000000, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"# TYPE srctl_registry_subjects_total gauge\n",
+		`srctl_registry_subjects_total{state="active"} 3` + "\n",
+		`srctl_registry_subjects_total{state="deleted"} 1` + "\n",
+		`srctl_registry_schemas_by_type{type="AVRO"} 5` + "\n",
+		`srctl_registry_schema_size_bytes{stat="total"} 4096` + "\n",
+		`srctl_registry_schema_size_bytes{stat="avg"} 585.5` + "\n",
+		"srctl_registry_stats_timestamp_seconds 1700000000\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	// A context is added as a label to every sample
+	b.Reset()
+	writePrometheusStats(&b, stats, ".prod", time.Now())
+	if !strings.Contains(b.String(), `srctl_registry_subjects_total{context=".prod",state="active"} 3`) {
+		t.Errorf("expected context label, got:\n%s", b.String())
+	}
+	if !strings.Contains(b.String(), `srctl_registry_unique_schema_ids{context=".prod"} 0`) {
+		t.Errorf("expected context label on unlabeled metrics, got:\n%s", b.String())
+	}
+}
+
+func TestExportPrometheusStatsFile(t *testing.T) {
+	dir, cleanup := createTempDir()
+	defer cleanup()
+
+	saved := statsExportPrometheus
+	defer func() { statsExportPrometheus = saved }()
+	statsExportPrometheus = filepath.Join(dir, "srctl.prom")
+
+	if err := exportPrometheusStats(nil, RegistryStats{ActiveSubjects: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(statsExportPrometheus)
+	if err != nil {
+		t.Fatalf("expected metrics file: %v", err)
+	}
+	if !strings.Contains(string(data), `srctl_registry_subjects_total{state="active"} 2`) {
+		t.Errorf("unexpected metrics file:\n%s", data)
+	}
+
+	// Only the metrics file is left behind
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only the metrics file, got %d entries", len(entries))
+	}
+}
+
+func TestAddAgeMetrics(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) int64 { return now.AddDate(0, 0, -d).UnixMilli() }
+
+	orders := analyzeSubject(nil, "orders", []client.Schema{
+		{Version: 1, ID: 1, Schema: "{}", Timestamp: daysAgo(400)},
+		{Version: 2, ID: 2, Schema: "{}", Timestamp: daysAgo(200)},
+	})
+	users := analyzeSubject(nil, "users", []client.Schema{
+		{Version: 1, ID: 3, Schema: "{}", Timestamp: daysAgo(10)},
+	})
+	archived := analyzeSubject(nil, "archived", []client.Schema{
+		{Version: 1, ID: 4, Schema: "{}", Timestamp: daysAgo(500)},
+	})
+	untimed := analyzeSubject(nil, "untimed", []client.Schema{
+		{Version: 1, ID: 5, Schema: "{}"},
+	})
+
+	var stats RegistryStats
+	active := map[string]bool{"orders": true, "users": true, "untimed": true}
+	stats.addAgeMetrics([]subjectResult{users, orders, archived, untimed}, active, 90, now)
+
+	if stats.VersionsWithTimestamps != 4 {
+		t.Errorf("expected 4 timestamped versions, got %d", stats.VersionsWithTimestamps)
+	}
+	if stats.OldestSchema != "archived (v1)" || stats.NewestSchema != "users (v1)" {
+		t.Errorf("unexpected oldest/newest: %s / %s", stats.OldestSchema, stats.NewestSchema)
+	}
+	if !stats.OldestSchemaTime.Equal(time.UnixMilli(daysAgo(500))) {
+		t.Errorf("unexpected oldest time %v", stats.OldestSchemaTime)
+	}
+
+	// Soft-deleted subjects and subjects without timestamps are never stale
+	if stats.StaleSubjectCount != 1 || stats.StaleSubjects[0].Subject != "orders" || stats.StaleSubjects[0].AgeDays != 200 {
+		t.Errorf("expected only orders to be stale at 200 days, got %+v", stats.StaleSubjects)
+	}
+
+	// Registries without timestamps leave the age fields empty
+	var empty RegistryStats
+	empty.addAgeMetrics([]subjectResult{untimed}, active, 90, now)
+	if empty.OldestSchemaTime != nil || empty.StaleSubjectCount != 0 {
+		t.Errorf("expected no age metrics, got %+v", empty)
+	}
+}
+
+func TestHealthLatency(t *testing.T) {
+	calls := []healthCall{
+		{Name: "GetSubjects", Latency: 120 * time.Millisecond},
+		{Name: "GetMode", Latency: 20 * time.Millisecond},
+		{Name: "GetConfig", Latency: 40 * time.Millisecond},
+		{Name: "GetContexts", Latency: 20 * time.Millisecond, Err: fmt.Errorf("not supported")},
+	}
+
+	lo, avg, hi := latencySummary(calls)
+	if lo != 20*time.Millisecond || avg != 50*time.Millisecond || hi != 120*time.Millisecond {
+		t.Errorf("expected 20ms/50ms/120ms, got %s/%s/%s", lo, avg, hi)
+	}
+	if lo, avg, hi := latencySummary(nil); lo != 0 || avg != 0 || hi != 0 {
+		t.Error("expected zero summary for no calls")
+	}
+
+	slow := slowHealthCalls(calls, 30*time.Millisecond)
+	if len(slow) != 2 || slow[0].Name != "GetSubjects" || slow[1].Name != "GetConfig" {
+		t.Errorf("expected GetSubjects and GetConfig over threshold, got %+v", slow)
+	}
+	if len(slowHealthCalls(calls, 0)) != 0 {
+		t.Error("expected no threshold check when threshold is 0")
+	}
+
+	var timed []healthCall
+	wantErr := fmt.Errorf("boom")
+	if err := timeHealthCall(&timed, "GetMode", func() error { return wantErr }); err != wantErr {
+		t.Errorf("expected call error to be returned, got %v", err)
+	}
+	if len(timed) != 1 || timed[0].Name != "GetMode" || timed[0].Err != wantErr {
+		t.Errorf("expected recorded call, got %+v", timed)
+	}
+}
+
+func TestProbeRegistry(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders", 1)
+
+	probe, err := probeRegistry(mock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(probe.Subjects) != 1 || probe.Mode == nil || probe.Config == nil {
+		t.Errorf("unexpected probe %+v", probe)
+	}
+	if len(probe.Calls) != 4 {
+		t.Errorf("expected 4 timed calls, got %d", len(probe.Calls))
+	}
+
+	// A registry that can't list subjects stops after the first call
+	mock.ShouldError = true
+	mock.ErrorMessage = "unavailable"
+	probe, err = probeRegistry(mock)
+	if err == nil || len(probe.Calls) != 1 {
+		t.Errorf("expected failure after GetSubjects, got %v with %d calls", err, len(probe.Calls))
+	}
+}
+
+func TestRunHealthAll(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/subjects":
+			w.Write([]byte(`["orders-value"]`))
+		case "/contexts":
+			w.Write([]byte(`["."]`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer healthy.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer broken.Close()
+
+	savedRegistries, savedRetries := config.AppConfig.Registries, maxRetries
+	defer func() { config.AppConfig.Registries, maxRetries = savedRegistries, savedRetries }()
+	maxRetries = 0
+
+	config.AppConfig.Registries = []config.Registry{{Name: "good", URL: healthy.URL}}
+	if err := runHealthAll(); err != nil {
+		t.Errorf("expected healthy registry to pass, got %v", err)
+	}
+
+	config.AppConfig.Registries = append(config.AppConfig.Registries, config.Registry{Name: "bad", URL: broken.URL})
+	err := runHealthAll()
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 registries") {
+		t.Errorf("expected one failed registry, got %v", err)
+	}
+
+	config.AppConfig.Registries = nil
+	if err := runHealthAll(); err == nil {
+		t.Error("expected error when no registries are configured")
+	}
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/output"
+)
+
+// selectSubjects returns the explicit subjects, or all subjects in the registry
+// when none are given, narrowed to those matching pattern (if set)
+func selectSubjects(c client.SchemaRegistryClientInterface, explicit []string, pattern string, regex, includeDeleted bool) ([]string, error) {
+	subjects := explicit
+	if len(subjects) == 0 {
+		var err error
+		subjects, err = listSubjects(c, subjectPrefix(pattern, regex), includeDeleted)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subjects: %w", err)
+		}
+	}
+	return matchSubjects(subjects, pattern, regex)
+}
+
+// listSubjects lists subjects, letting the registry filter by prefix when
+// there is one
+func listSubjects(c client.SchemaRegistryClientInterface, prefix string, includeDeleted bool) ([]string, error) {
+	if prefix == "" {
+		return c.GetSubjects(includeDeleted)
+	}
+	return c.GetSubjectsWithPrefix(prefix, includeDeleted)
+}
+
+// subjectPrefix returns a literal prefix that every subject matching pattern
+// starts with, so the registry can narrow the listing (subjectPrefix) before
+// matchSubjects runs. It returns "" when there is no safe prefix: regexes must
+// be anchored with ^ and free of alternation, and globs only qualify when
+// their prefix has no letters, since glob matching is case-insensitive.
+func subjectPrefix(pattern string, regex bool) string {
+	if !regex {
+		prefix, _, _ := strings.Cut(pattern, "*")
+		if prefix == pattern || strings.ToLower(prefix) != strings.ToUpper(prefix) {
+			return ""
+		}
+		return prefix
+	}
+
+	if !strings.HasPrefix(pattern, "^") || strings.Contains(pattern, "|") {
+		return ""
+	}
+	rest := pattern[1:]
+	end := strings.IndexAny(rest, `\.+*?()[]{}^$`)
+	if end < 0 {
+		end = len(rest)
+	}
+	prefix := rest[:end]
+	// A quantifier that allows zero repetitions makes the last literal optional
+	if end < len(rest) && strings.ContainsRune("*?{", rune(rest[end])) && prefix != "" {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix
+}
+
+// matchSubjects returns the subjects matching pattern: a case-insensitive shell
+// glob with * wildcards (e.g. "user-*"), or a regular expression when regex is
+// set (e.g. "^user-.*-value$"). An empty pattern matches everything.
+func matchSubjects(subjects []string, pattern string, regex bool) ([]string, error) {
+	if pattern == "" {
+		return subjects, nil
+	}
+	if !regex {
+		return filterSubjects(subjects, pattern), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject regex '%s': %w", pattern, err)
+	}
+	var matched []string
+	for _, subj := range subjects {
+		if re.MatchString(subj) {
+			matched = append(matched, subj)
+		}
+	}
+	return matched, nil
+}
+
+// excludeSubjects drops the subjects named in exclude or matching pattern (as
+// in matchSubjects), keeping the order of the rest
+func excludeSubjects(subjects, exclude []string, pattern string, regex bool) ([]string, error) {
+	if len(exclude) == 0 && pattern == "" {
+		return subjects, nil
+	}
+
+	drop := make(map[string]bool, len(exclude))
+	for _, subj := range exclude {
+		drop[subj] = true
+	}
+	if pattern != "" {
+		matched, err := matchSubjects(subjects, pattern, regex)
+		if err != nil {
+			return nil, err
+		}
+		for _, subj := range matched {
+			drop[subj] = true
+		}
+	}
+
+	var kept []string
+	for _, subj := range subjects {
+		if !drop[subj] {
+			kept = append(kept, subj)
+		}
+	}
+	return kept, nil
+}
+
+// subjectMap renames subjects (--map-subject old=new); subjects it does not
+// name keep their name
+type subjectMap map[string]string
+
+// parseSubjectMap parses --map-subject values of the form old=new
+func parseSubjectMap(values []string) (subjectMap, error) {
+	m := make(subjectMap, len(values))
+	sources := make(map[string]string, len(values))
+	for _, v := range values {
+		old, renamed, ok := strings.Cut(v, "=")
+		old, renamed = strings.TrimSpace(old), strings.TrimSpace(renamed)
+		if !ok || old == "" || renamed == "" {
+			return nil, fmt.Errorf("invalid --map-subject %q: use old=new", v)
+		}
+		if prev, dup := m[old]; dup && prev != renamed {
+			return nil, fmt.Errorf("--map-subject renames %s to both %s and %s", old, prev, renamed)
+		}
+		if prev, dup := sources[renamed]; dup && prev != old {
+			return nil, fmt.Errorf("--map-subject renames both %s and %s to %s", prev, old, renamed)
+		}
+		m[old] = renamed
+		sources[renamed] = old
+	}
+	return m, nil
+}
+
+// rename returns the new name of subject
+func (m subjectMap) rename(subject string) string {
+	if renamed, ok := m[subject]; ok {
+		return renamed
+	}
+	return subject
+}
+
+// warnUnmatched warns about mapped subjects that are not among subjects
+func (m subjectMap) warnUnmatched(subjects []string) {
+	present := make(map[string]bool, len(subjects))
+	for _, subj := range subjects {
+		present[subj] = true
+	}
+	var missing []string
+	for old := range m {
+		if !present[old] {
+			missing = append(missing, old)
+		}
+	}
+	sort.Strings(missing)
+	for _, subj := range missing {
+		output.Warning("--map-subject: %s not found, nothing to rename", subj)
+	}
+}
+
+// renameReferences renames the subjects refs point at
+func renameReferences(refs []client.SchemaReference, rename func(string) string) {
+	for i := range refs {
+		refs[i].Subject = rename(refs[i].Subject)
+	}
+}
+
+func filterSubjects(subjects []string, pattern string) []string {
+	// Convert glob pattern to simple matching
+	var filtered []string
+	pattern = strings.ToLower(pattern)
+
+	for _, subj := range subjects {
+		if matchGlob(strings.ToLower(subj), pattern) {
+			filtered = append(filtered, subj)
+		}
+	}
+
+	return filtered
+}
+
+func matchGlob(s, pattern string) bool {
+	// Simple glob matching supporting * wildcard
+	if pattern == "*" {
+		return true
+	}
+
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		// No wildcard, exact match
+		return s == pattern
+	}
+
+	// Check prefix
+	if parts[0] != "" && !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+
+	// Check suffix
+	if parts[len(parts)-1] != "" && !strings.HasSuffix(s, parts[len(parts)-1]) {
+		return false
+	}
+
+	// Check middle parts
+	idx := len(parts[0])
+	for i := 1; i < len(parts)-1; i++ {
+		if parts[i] == "" {
+			continue
+		}
+		newIdx := strings.Index(s[idx:], parts[i])
+		if newIdx < 0 {
+			return false
+		}
+		idx += newIdx + len(parts[i])
+	}
+
+	return true
+}
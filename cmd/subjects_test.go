@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/srctl/srctl/internal/client"
+)
+
+func TestSelectSubjects(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "events.orders", 1)
+	addTestSubject(mock, "events.users", 1)
+	addTestSubject(mock, "payments", 1)
+
+	// All registry subjects matching the pattern
+	subjects, err := selectSubjects(mock, nil, "events.*", false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(subjects)
+	if len(subjects) != 2 || subjects[0] != "events.orders" || subjects[1] != "events.users" {
+		t.Errorf("expected events.* subjects, got %v", subjects)
+	}
+
+	// Explicit subjects are narrowed by the pattern without querying the registry
+	subjects, _ = selectSubjects(mock, []string{"events.orders", "payments"}, "events.*", false, false)
+	if len(subjects) != 1 || subjects[0] != "events.orders" {
+		t.Errorf("expected only events.orders, got %v", subjects)
+	}
+
+	// No pattern returns everything
+	subjects, _ = selectSubjects(mock, nil, "", false, false)
+	if len(subjects) != 3 {
+		t.Errorf("expected 3 subjects, got %v", subjects)
+	}
+
+	mock.ShouldError = true
+	mock.ErrorMessage = "unavailable"
+	if _, err := selectSubjects(mock, nil, "events.*", false, false); err == nil {
+		t.Error("expected error when listing subjects fails")
+	}
+}
+
+func TestMatchSubjects(t *testing.T) {
+	subjects := []string{"user-events-value", "user-events-key", "User-Profile-value", "orders-value"}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		regex    bool
+		expected []string
+	}{
+		{"empty pattern", "", false, subjects},
+		{"glob is case-insensitive", "user-*", false, []string{"user-events-value", "user-events-key", "User-Profile-value"}},
+		{"glob suffix", "*-value", false, []string{"user-events-value", "User-Profile-value", "orders-value"}},
+		{"regex anchored", "^user-.*-value$", true, []string{"user-events-value"}},
+		{"regex case-insensitive flag", "(?i)^user-.*-value$", true, []string{"user-events-value", "User-Profile-value"}},
+		{"regex alternation", "^(orders|user-events)-key$", true, []string{"user-events-key"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchSubjects(subjects, tt.pattern, tt.regex)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("expected %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+
+	if _, err := matchSubjects(subjects, "user-(", true); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
+
+func TestExcludeSubjects(t *testing.T) {
+	subjects := []string{"orders-value", "_schemas", "_audit-value", "users-value", "payments-value"}
+
+	tests := []struct {
+		name     string
+		exclude  []string
+		pattern  string
+		regex    bool
+		expected []string
+	}{
+		{"nothing excluded", nil, "", false, subjects},
+		{"explicit names", []string{"users-value", "missing"}, "", false, []string{"orders-value", "_schemas", "_audit-value", "payments-value"}},
+		{"glob pattern", nil, "_*", false, []string{"orders-value", "users-value", "payments-value"}},
+		{"names and pattern combine", []string{"orders-value"}, "_*", false, []string{"users-value", "payments-value"}},
+		{"regex pattern", nil, "^(users|payments)-", true, []string{"orders-value", "_schemas", "_audit-value"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := excludeSubjects(subjects, tt.exclude, tt.pattern, tt.regex)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("expected %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+
+	if _, err := excludeSubjects(subjects, nil, "user-(", true); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+}
+
+func TestSubjectPrefix(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		regex    bool
+		expected string
+	}{
+		{"", false, ""},
+		{"orders-*", false, ""}, // globs are case-insensitive
+		{"_2024-*", false, "_2024-"},
+		{"_2024-", false, ""}, // exact match, no wildcard
+		{"^orders-", true, "orders-"},
+		{"^orders-.*-value$", true, "orders-"},
+		{"^orders_v2\\.", true, "orders_v2"},
+		{"^orders?", true, "order"},
+		{"^orders+", true, "orders"},
+		{"^orders|users", true, ""},
+		{"orders-.*", true, ""},
+		{"(?i)^orders", true, ""},
+		{"^.*-value$", true, ""},
+	}
+
+	for _, tt := range tests {
+		if got := subjectPrefix(tt.pattern, tt.regex); got != tt.expected {
+			t.Errorf("subjectPrefix(%q, %v) = %q, want %q", tt.pattern, tt.regex, got, tt.expected)
+		}
+	}
+}
+
+func TestSelectSubjectsServerPrefix(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders-value", 1)
+	addTestSubject(mock, "orders-key", 1)
+	addTestSubject(mock, "users-value", 1)
+
+	subjects, err := selectSubjects(mock, nil, "^orders-.*value$", true, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subjects) != 1 || subjects[0] != "orders-value" {
+		t.Errorf("expected orders-value, got %v", subjects)
+	}
+	if mock.GetCallCount("GetSubjectsWithPrefix") != 1 || mock.GetCallCount("GetSubjects") != 0 {
+		t.Error("expected the registry to filter by the regex's literal prefix")
+	}
+
+	// Case-insensitive globs still list everything
+	subjects, _ = selectSubjects(mock, nil, "ORDERS-*", false, false)
+	if len(subjects) != 2 || mock.GetCallCount("GetSubjects") != 1 {
+		t.Errorf("expected a full listing filtered client-side, got %v", subjects)
+	}
+}
+
+func TestParseSubjectMap(t *testing.T) {
+	m, err := parseSubjectMap([]string{"orders-value=orders.v2-value", " users-value = people-value "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.rename("orders-value") != "orders.v2-value" || m.rename("users-value") != "people-value" || m.rename("payments") != "payments" {
+		t.Errorf("unexpected mapping %v", m)
+	}
+
+	for _, values := range [][]string{
+		{"orders-value"},
+		{"=orders-value"},
+		{"a=b", "a=c"},
+		{"a=c", "b=c"},
+	} {
+		if _, err := parseSubjectMap(values); err == nil {
+			t.Errorf("expected an error for %q", values)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var tagsCmd = &cobra.Command{
+	Use:     "tags",
+	Short:   "Manage catalog tags on subjects and schema versions",
+	GroupID: groupContract,
+	Long: `List tag definitions and assign or remove tags on subjects and schema versions.
+
+Tags need the Stream Catalog (Confluent Platform or Confluent Cloud). Tag
+definitions are registry-wide; assignments follow --context.
+
+A target is a subject, or subject:version for a single schema version
+(version may be "latest").
+
+Examples:
+  # List tag definitions
+  srctl tags list
+
+  # Define a tag
+  srctl tags create PII --description "Personally identifiable information"
+
+  # Tag a subject, or one version of it
+  srctl tags assign user-events PII
+  srctl tags assign user-events:3 PII Sensitive
+
+  # Tag with attributes
+  srctl tags assign user-events PII --attr classification=restricted,owner=payments
+
+  # Remove a tag
+  srctl tags remove user-events:3 Sensitive
+
+  # Show a subject's tags, per version
+  srctl tags show user-events -o json`,
+}
+
+var tagsListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List tag definitions",
+	Args:        cobra.NoArgs,
+	Annotations: readOnlyAnnotations,
+	RunE:        runTagsList,
+}
+
+var tagsCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a tag definition",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTagsCreate,
+}
+
+var tagsAssignCmd = &cobra.Command{
+	Use:   "assign <subject>[:version] <tag>...",
+	Short: "Assign tags to a subject or schema version",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runTagsAssign,
+}
+
+var tagsRemoveCmd = &cobra.Command{
+	Use:   "remove <subject>[:version] <tag>...",
+	Short: "Remove tags from a subject or schema version",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runTagsRemove,
+}
+
+var tagsShowCmd = &cobra.Command{
+	Use:         "show <subject>",
+	Short:       "Show the tags on a subject and each of its versions",
+	Args:        cobra.ExactArgs(1),
+	Annotations: readOnlyAnnotations,
+	RunE:        runTagsShow,
+}
+
+var (
+	tagsDescription string
+	tagsAttributes  map[string]string
+)
+
+func init() {
+	tagsCreateCmd.Flags().StringVar(&tagsDescription, "description", "", "Tag description")
+	tagsAssignCmd.Flags().StringToStringVar(&tagsAttributes, "attr", nil, "Tag attributes as key=value (comma-separated or repeated)")
+
+	tagsCmd.AddCommand(tagsListCmd, tagsCreateCmd, tagsAssignCmd, tagsRemoveCmd, tagsShowCmd)
+	rootCmd.AddCommand(tagsCmd)
+}
+
+// catalogTarget is a subject, or one of its versions when Version is set, that
+// tags or business metadata are attached to
+type catalogTarget struct {
+	Subject string
+	Version int
+}
+
+func (t catalogTarget) String() string {
+	if t.Version == 0 {
+		return t.Subject
+	}
+	return fmt.Sprintf("%s:%d", t.Subject, t.Version)
+}
+
+// SubjectTags is the tags on a subject and on each of its versions
+type SubjectTags struct {
+	Subject    string                       `json:"subject" yaml:"subject"`
+	Tags       []string                     `json:"tags" yaml:"tags"`
+	Attributes map[string]map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	Versions   []VersionTags                `json:"versions" yaml:"versions"`
+}
+
+// VersionTags is the tags on one schema version
+type VersionTags struct {
+	Version    int                          `json:"version" yaml:"version"`
+	Tags       []string                     `json:"tags" yaml:"tags"`
+	Attributes map[string]map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+func runTagsList(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	tags, err := c.GetTags()
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	if outputFormat == "table" {
+		output.Header("Tag Definitions")
+		if len(tags) == 0 {
+			output.Info("No tags defined")
+			return nil
+		}
+		rows := make([][]string, len(tags))
+		for i, tag := range tags {
+			rows[i] = []string{tag.Name, tag.Description}
+		}
+		output.PrintTable([]string{"Name", "Description"}, rows)
+		fmt.Printf("\nTotal: %d tag(s)\n", len(tags))
+		return nil
+	}
+
+	return output.NewPrinter(outputFormat).Print(tags)
+}
+
+func runTagsCreate(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	if err := c.CreateTag(&client.Tag{Name: args[0], Description: tagsDescription}); err != nil {
+		if client.IsConflict(err) {
+			return fmt.Errorf("tag %s already exists", args[0])
+		}
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+	output.Success("Created tag %s", args[0])
+	return nil
+}
+
+func runTagsAssign(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+	target, err := resolveCatalogTarget(c, args[0])
+	if err != nil {
+		return err
+	}
+	return assignTags(c, target, args[1:], tagsAttributes)
+}
+
+func runTagsRemove(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+	target, err := resolveCatalogTarget(c, args[0])
+	if err != nil {
+		return err
+	}
+	return removeTags(c, target, args[1:])
+}
+
+func runTagsShow(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	tags, err := getSubjectTags(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "table" {
+		output.Header("Tags for: %s", tags.Subject)
+		rows := [][]string{{"subject", formatTags(tags.Tags, tags.Attributes)}}
+		for _, v := range tags.Versions {
+			rows = append(rows, []string{strconv.Itoa(v.Version), formatTags(v.Tags, v.Attributes)})
+		}
+		output.PrintTable([]string{"Version", "Tags"}, rows)
+		return nil
+	}
+
+	return output.NewPrinter(outputFormat).Print(tags)
+}
+
+// resolveCatalogTarget parses subject[:version], resolving "latest" to a version
+// number. Context-qualified subjects (":.ctx:subject") keep their colons;
+// only a numeric or "latest" suffix is taken as the version.
+func resolveCatalogTarget(c client.SchemaRegistryClientInterface, arg string) (catalogTarget, error) {
+	i := strings.LastIndex(arg, ":")
+	if i <= 0 {
+		return catalogTarget{Subject: arg}, nil
+	}
+
+	subject, version := arg[:i], arg[i+1:]
+	if version == "latest" {
+		schema, err := c.GetSchema(subject, "latest")
+		if err != nil {
+			return catalogTarget{}, fmt.Errorf("failed to get latest version of %s: %w", subject, err)
+		}
+		return catalogTarget{Subject: subject, Version: schema.Version}, nil
+	}
+	v, err := strconv.Atoi(version)
+	if err != nil || v <= 0 {
+		return catalogTarget{Subject: arg}, nil
+	}
+	return catalogTarget{Subject: subject, Version: v}, nil
+}
+
+// assignTags adds each tag to the target, with the same attributes on each
+func assignTags(c client.SchemaRegistryClientInterface, target catalogTarget, tags []string, attributes map[string]string) error {
+	for _, tag := range tags {
+		var err error
+		if target.Version == 0 {
+			err = c.AssignTagToSubject(target.Subject, tag, attributes)
+		} else {
+			err = c.AssignTagToSchema(target.Subject, target.Version, tag, attributes)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to assign tag %s to %s: %w", tag, target, err)
+		}
+		output.Success("Assigned tag %s to %s", tag, target)
+	}
+	return nil
+}
+
+// removeTags removes each tag from the target
+func removeTags(c client.SchemaRegistryClientInterface, target catalogTarget, tags []string) error {
+	for _, tag := range tags {
+		var err error
+		if target.Version == 0 {
+			err = c.RemoveTagFromSubject(target.Subject, tag)
+		} else {
+			err = c.RemoveTagFromSchema(target.Subject, target.Version, tag)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to remove tag %s from %s: %w", tag, target, err)
+		}
+		output.Success("Removed tag %s from %s", tag, target)
+	}
+	return nil
+}
+
+// getSubjectTags collects the tags on a subject and its live versions
+func getSubjectTags(c client.SchemaRegistryClientInterface, subject string) (*SubjectTags, error) {
+	assigned, err := c.GetSubjectTags(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subject tags: %w", err)
+	}
+	result := &SubjectTags{
+		Subject:    subject,
+		Tags:       tagNames(assigned),
+		Attributes: tagAttributes(assigned),
+		Versions:   []VersionTags{},
+	}
+
+	versions, err := c.GetVersions(subject, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions: %w", err)
+	}
+	for _, v := range versions {
+		assigned, err := c.GetSchemaTags(subject, v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags for version %d: %w", v, err)
+		}
+		result.Versions = append(result.Versions, VersionTags{
+			Version:    v,
+			Tags:       tagNames(assigned),
+			Attributes: tagAttributes(assigned),
+		})
+	}
+	return result, nil
+}
+
+func tagNames(assigned []client.TagAssignment) []string {
+	names := make([]string, 0, len(assigned))
+	for _, t := range assigned {
+		names = append(names, t.TypeName)
+	}
+	return names
+}
+
+// tagAttributes maps each tag with attributes to them, or returns nil when
+// none have any
+func tagAttributes(assigned []client.TagAssignment) map[string]map[string]string {
+	var attrs map[string]map[string]string
+	for _, t := range assigned {
+		if len(t.Attributes) == 0 {
+			continue
+		}
+		if attrs == nil {
+			attrs = make(map[string]map[string]string)
+		}
+		attrs[t.TypeName] = t.Attributes
+	}
+	return attrs
+}
+
+// formatTags joins tag names for a table cell, e.g. "PII (owner=payments), Sensitive"
+func formatTags(names []string, attrs map[string]map[string]string) string {
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name
+		if a := attrs[name]; len(a) > 0 {
+			parts[i] += " (" + formatAttributes(a) + ")"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatAttributes joins attributes sorted by key, e.g. "owner=payments, tier=gold"
+func formatAttributes(attrs map[string]string) string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + attrs[k]
+	}
+	return strings.Join(pairs, ", ")
+}
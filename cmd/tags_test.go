@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/srctl/srctl/internal/client"
+)
+
+func TestResolveTagTarget(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders-value", 3)
+
+	tests := []struct {
+		arg  string
+		want catalogTarget
+	}{
+		{"orders-value", catalogTarget{Subject: "orders-value"}},
+		{"orders-value:2", catalogTarget{Subject: "orders-value", Version: 2}},
+		{"orders-value:latest", catalogTarget{Subject: "orders-value", Version: 3}},
+		{":.prod:orders-value", catalogTarget{Subject: ":.prod:orders-value"}},
+		{":.prod:orders-value:1", catalogTarget{Subject: ":.prod:orders-value", Version: 1}},
+		{"orders:v1", catalogTarget{Subject: "orders:v1"}},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveCatalogTarget(mock, tt.arg)
+		if err != nil {
+			t.Errorf("resolveCatalogTarget(%q) returned error: %v", tt.arg, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolveCatalogTarget(%q) = %+v, want %+v", tt.arg, got, tt.want)
+		}
+	}
+
+	if _, err := resolveCatalogTarget(mock, "missing:latest"); err == nil {
+		t.Error("expected an error resolving latest of a missing subject")
+	}
+}
+
+func TestAssignAndRemoveTags(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders-value", 2)
+
+	if err := assignTags(mock, catalogTarget{Subject: "orders-value"}, []string{"PII"}, map[string]string{"owner": "payments"}); err != nil {
+		t.Fatalf("assignTags() error: %v", err)
+	}
+	if err := assignTags(mock, catalogTarget{Subject: "orders-value", Version: 2}, []string{"PII", "Sensitive"}, nil); err != nil {
+		t.Fatalf("assignTags() error: %v", err)
+	}
+	if err := removeTags(mock, catalogTarget{Subject: "orders-value", Version: 2}, []string{"PII"}); err != nil {
+		t.Fatalf("removeTags() error: %v", err)
+	}
+
+	got, err := getSubjectTags(mock, "orders-value")
+	if err != nil {
+		t.Fatalf("getSubjectTags() error: %v", err)
+	}
+	want := &SubjectTags{
+		Subject:    "orders-value",
+		Tags:       []string{"PII"},
+		Attributes: map[string]map[string]string{"PII": {"owner": "payments"}},
+		Versions: []VersionTags{
+			{Version: 1, Tags: []string{}},
+			{Version: 2, Tags: []string{"Sensitive"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getSubjectTags() = %+v, want %+v", got, want)
+	}
+
+	mock.ShouldError = true
+	mock.ErrorMessage = "catalog unavailable"
+	if err := assignTags(mock, catalogTarget{Subject: "orders-value"}, []string{"PII"}, nil); err == nil {
+		t.Error("expected an error when the registry fails")
+	}
+}
+
+func TestFormatTags(t *testing.T) {
+	got := formatTags([]string{"PII", "Sensitive"}, map[string]map[string]string{
+		"PII": {"owner": "payments", "classification": "restricted"},
+	})
+	want := "PII (classification=restricted, owner=payments), Sensitive"
+	if got != want {
+		t.Errorf("formatTags() = %q, want %q", got, want)
+	}
+}
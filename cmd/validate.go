@@ -6,10 +6,14 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/client"
 	"github.com/srctl/srctl/internal/output"
 )
 
@@ -19,10 +23,12 @@ var validateCmd = &cobra.Command{
 	GroupID: groupSchema,
 	Long: `Validate schemas without requiring a running Schema Registry.
 
-Supports three modes:
+Supports four modes:
   1. Syntax validation - check if a schema file is well-formed
   2. Compatibility check - compare two local files for compatibility
   3. Directory validation - validate all schemas in a directory
+  4. Reference validation - check that schema references resolve against
+     a split manifest (manifest.json) or a directory of referenced schemas
 
 When --subject is used, the schema is checked against the latest version
 in the registry (requires connectivity).
@@ -43,8 +49,20 @@ Examples:
   # Validate all schemas in a directory
   srctl validate --dir ./schemas/
 
+  # Validate thousands of schemas with more parallel workers
+  srctl validate --dir ./schemas/ --workers 50
+
+  # Also check each version against the previous one (order.v1.avsc, order.v2.avsc, ...)
+  srctl validate --dir ./schemas/ --check-evolution --compatibility BACKWARD
+
   # Check compatibility against latest version in registry
-  srctl validate --file order-v2.avsc --subject orders-value`,
+  srctl validate --file order-v2.avsc --subject orders-value
+
+  # Check that a schema's references resolve against split output
+  srctl validate --file order.avsc --references ./split-schemas/
+
+  # Validate every schema in a split manifest, including its references
+  srctl validate --references ./split-schemas/manifest.json`,
 	RunE: runValidate,
 }
 
@@ -55,6 +73,9 @@ var (
 	validateCompatibility string
 	validateDir           string
 	validateSubject       string
+	validateReferences    string
+	validateWorkers       int
+	validateEvolution     bool
 )
 
 func init() {
@@ -64,6 +85,9 @@ func init() {
 	validateCmd.Flags().StringVar(&validateCompatibility, "compatibility", "BACKWARD", "Compatibility mode: BACKWARD, FORWARD, FULL, NONE")
 	validateCmd.Flags().StringVar(&validateDir, "dir", "", "Directory of schemas to validate")
 	validateCmd.Flags().StringVar(&validateSubject, "subject", "", "Subject to check compatibility against (requires registry)")
+	validateCmd.Flags().BoolVar(&validateEvolution, "check-evolution", false, "With --dir, check each versioned schema (name.v1, name.v2, ...) against the previous version")
+	validateCmd.Flags().IntVar(&validateWorkers, "workers", 10, "Number of parallel workers for --dir validation")
+	validateCmd.Flags().StringVar(&validateReferences, "references", "", "Split manifest (manifest.json) or directory of referenced schemas to resolve references against")
 
 	rootCmd.AddCommand(validateCmd)
 }
@@ -74,6 +98,7 @@ type ValidationIssue struct {
 	Message  string `json:"message"`
 	Fix      string `json:"fix,omitempty"`
 	Field    string `json:"field,omitempty"`
+	Version  int    `json:"version,omitempty"` // Registry version the issue was found against
 }
 
 // ValidationResult is the outcome of validating a schema
@@ -84,14 +109,32 @@ type ValidationResult struct {
 	Issues     []ValidationIssue `json:"issues,omitempty"`
 }
 
+// addIssues appends issues to the result, marking it invalid on any error
+func (r *ValidationResult) addIssues(issues []ValidationIssue) {
+	r.Issues = append(r.Issues, issues...)
+	for _, issue := range issues {
+		if issue.Severity == "ERROR" {
+			r.Valid = false
+		}
+	}
+}
+
 func runValidate(cmd *cobra.Command, args []string) error {
+	if validateEvolution && validateDir == "" {
+		return fmt.Errorf("--check-evolution requires --dir")
+	}
+
 	// Directory validation mode
 	if validateDir != "" {
 		return runValidateDir(validateDir)
 	}
 
 	if validateFile == "" {
-		return fmt.Errorf("either --file or --dir is required")
+		// Validate a whole split manifest or directory of referenced schemas
+		if validateReferences != "" {
+			return runValidateReferences(validateReferences)
+		}
+		return fmt.Errorf("either --file, --dir or --references is required")
 	}
 
 	content, err := os.ReadFile(validateFile)
@@ -129,6 +172,14 @@ func runValidateSyntax(content, schemaType, filename string) error {
 
 	result := validateSchemaSyntax(content, schemaType, filename)
 
+	if validateReferences != "" {
+		refs, err := loadReferenceSet(validateReferences)
+		if err != nil {
+			return err
+		}
+		result.addIssues(refs.check(content, schemaType))
+	}
+
 	printer := output.NewPrinter(outputFormat)
 	if outputFormat != "table" {
 		return printer.Print(result)
@@ -712,15 +763,6 @@ func runValidateAgainstRegistry(content, schemaType string) error {
 	output.Info("Subject: %s", validateSubject)
 	fmt.Println()
 
-	// Get latest schema from registry
-	schema, err := c.GetSchema(validateSubject, "latest")
-	if err != nil {
-		return fmt.Errorf("failed to get latest schema for '%s': %w", validateSubject, err)
-	}
-
-	output.Info("Comparing against version %d (schema ID %d)", schema.Version, schema.ID)
-	fmt.Println()
-
 	// Get subject compatibility config
 	compat := validateCompatibility
 	config, err := c.GetSubjectConfig(validateSubject, true)
@@ -735,10 +777,24 @@ func runValidateAgainstRegistry(content, schemaType string) error {
 		}
 	}
 
-	issues := checkCompatibility(content, schema.Schema, schemaType, compat)
+	issues, checked, err := checkAgainstRegistryVersions(c, validateSubject, content, schemaType, compat)
+	if err != nil {
+		return err
+	}
+
+	if len(checked) == 1 {
+		output.Info("Compared against version %d", checked[0])
+	} else {
+		output.Info("Compared against all %d versions (%s)", len(checked), strings.ToUpper(compat))
+	}
+	fmt.Println()
 
 	if len(issues) == 0 {
-		output.Success("Schema is compatible with %s@%d (%s)", validateSubject, schema.Version, compat)
+		if len(checked) == 1 {
+			output.Success("Schema is compatible with %s@%d (%s)", validateSubject, checked[0], compat)
+		} else {
+			output.Success("Schema is compatible with all %d versions of %s (%s)", len(checked), validateSubject, compat)
+		}
 		return nil
 	}
 
@@ -746,6 +802,38 @@ func runValidateAgainstRegistry(content, schemaType string) error {
 	return fmt.Errorf("schema is not compatible")
 }
 
+// checkAgainstRegistryVersions checks content against the latest version of
+// subject, or against every version when the mode is *_TRANSITIVE. Issues are
+// tagged with the version they came from. It returns the versions checked.
+func checkAgainstRegistryVersions(c client.SchemaRegistryClientInterface, subject, content, schemaType, mode string) ([]ValidationIssue, []int, error) {
+	versions := []string{"latest"}
+	if strings.HasSuffix(strings.ToUpper(mode), "_TRANSITIVE") {
+		all, err := c.GetVersions(subject, false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get versions for '%s': %w", subject, err)
+		}
+		versions = versions[:0]
+		for _, v := range all {
+			versions = append(versions, strconv.Itoa(v))
+		}
+	}
+
+	var issues []ValidationIssue
+	var checked []int
+	for _, v := range versions {
+		schema, err := c.GetSchema(subject, v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get version %s of '%s': %w", v, subject, err)
+		}
+		for _, issue := range checkCompatibility(content, schema.Schema, schemaType, mode) {
+			issue.Version = schema.Version
+			issues = append(issues, issue)
+		}
+		checked = append(checked, schema.Version)
+	}
+	return issues, checked, nil
+}
+
 // checkCompatibility performs a local compatibility check between two schemas
 func checkCompatibility(newContent, oldContent, schemaType, mode string) []ValidationIssue {
 	switch strings.ToUpper(schemaType) {
@@ -769,39 +857,64 @@ func checkAvroCompatibility(newContent, oldContent, mode string) []ValidationIss
 	newFields := extractAvroFieldsDeep(newSchema, "")
 	oldFields := extractAvroFieldsDeep(oldSchema, "")
 
+	// Named types (enums, fixed, records) by full and short name, so fields
+	// that refer to a type by name are checked against its definition
+	newTypes := collectAvroNamedDefinitions(newSchema)
+	oldTypes := collectAvroNamedDefinitions(oldSchema)
+
 	mode = strings.ToUpper(mode)
+	backward := mode == "BACKWARD" || mode == "BACKWARD_TRANSITIVE" || mode == "FULL" || mode == "FULL_TRANSITIVE"
+	forward := mode == "FORWARD" || mode == "FORWARD_TRANSITIVE" || mode == "FULL" || mode == "FULL_TRANSITIVE"
+
+	seen := make(map[string]bool)
+	addIssues := func(found []ValidationIssue) {
+		for _, issue := range found {
+			key := issue.Field + "|" + issue.Message
+			if !seen[key] {
+				seen[key] = true
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	// A schema whose top level isn't a record (e.g. an enum) has no fields to
+	// compare, so compare the types themselves
+	if !isAvroRecord(newSchema) || !isAvroRecord(oldSchema) {
+		if backward {
+			addIssues(avroReadIssues(newSchema, oldSchema, newTypes, oldTypes, "", true))
+		}
+		if forward {
+			addIssues(avroReadIssues(oldSchema, newSchema, oldTypes, newTypes, "", false))
+		}
+		return issues
+	}
 
 	// BACKWARD: new schema can read data written by old schema
 	// All fields in old must exist in new OR new field must have a default
-	if mode == "BACKWARD" || mode == "BACKWARD_TRANSITIVE" || mode == "FULL" || mode == "FULL_TRANSITIVE" {
+	if backward {
 		for fieldPath, oldField := range oldFields {
 			newField, exists := newFields[fieldPath]
 			if !exists {
-				issues = append(issues, ValidationIssue{
+				addIssues([]ValidationIssue{{
 					Severity: "ERROR",
 					Field:    fieldPath,
 					Message:  fmt.Sprintf("Field '%s' was removed", fieldPath),
 					Fix:      fmt.Sprintf("Keep the field '%s', or change compatibility to NONE", fieldPath),
-				})
-			} else if oldField.Type != newField.Type && !isAvroTypePromotion(oldField.Type, newField.Type) {
-				issues = append(issues, ValidationIssue{
-					Severity: "ERROR",
-					Field:    fieldPath,
-					Message:  fmt.Sprintf("Field '%s' type changed from '%s' to '%s'", fieldPath, oldField.Type, newField.Type),
-					Fix:      fmt.Sprintf("Add a new field instead of changing the type of '%s'", fieldPath),
-				})
+				}})
+			} else {
+				addIssues(avroReadIssues(newField.Schema, oldField.Schema, newTypes, oldTypes, fieldPath, true))
 			}
 		}
 		// New fields should have defaults for backward compat
 		for fieldPath, newField := range newFields {
 			if _, exists := oldFields[fieldPath]; !exists {
 				if !newField.HasDefault && !newField.IsNullable {
-					issues = append(issues, ValidationIssue{
+					addIssues([]ValidationIssue{{
 						Severity: "WARNING",
 						Field:    fieldPath,
 						Message:  fmt.Sprintf("New field '%s' has no default value", fieldPath),
 						Fix:      fmt.Sprintf("Add a default value or make '%s' nullable: [\"null\", \"%s\"]", fieldPath, newField.Type),
-					})
+					}})
 				}
 			}
 		}
@@ -809,27 +922,19 @@ func checkAvroCompatibility(newContent, oldContent, mode string) []ValidationIss
 
 	// FORWARD: old schema can read data written by new schema
 	// All fields in new must exist in old OR old field must have a default
-	if mode == "FORWARD" || mode == "FORWARD_TRANSITIVE" || mode == "FULL" || mode == "FULL_TRANSITIVE" {
+	if forward {
 		for fieldPath, newField := range newFields {
 			oldField, exists := oldFields[fieldPath]
 			if !exists {
 				// Adding a field without the old schema having a default for it
-				issues = append(issues, ValidationIssue{
+				addIssues([]ValidationIssue{{
 					Severity: "ERROR",
 					Field:    fieldPath,
 					Message:  fmt.Sprintf("New field '%s' added (old consumers cannot read it)", fieldPath),
 					Fix:      fmt.Sprintf("Ensure old consumers can ignore unknown field '%s', or use BACKWARD compatibility", fieldPath),
-				})
-			} else if newField.Type != oldField.Type && !isAvroTypePromotion(oldField.Type, newField.Type) {
-				// Already reported in BACKWARD section if FULL
-				if mode == "FORWARD" || mode == "FORWARD_TRANSITIVE" {
-					issues = append(issues, ValidationIssue{
-						Severity: "ERROR",
-						Field:    fieldPath,
-						Message:  fmt.Sprintf("Field '%s' type changed from '%s' to '%s'", fieldPath, oldField.Type, newField.Type),
-						Fix:      fmt.Sprintf("Add a new field instead of changing the type of '%s'", fieldPath),
-					})
-				}
+				}})
+			} else {
+				addIssues(avroReadIssues(oldField.Schema, newField.Schema, oldTypes, newTypes, fieldPath, false))
 			}
 		}
 	}
@@ -837,8 +942,287 @@ func checkAvroCompatibility(newContent, oldContent, mode string) []ValidationIss
 	return issues
 }
 
+// avroReadIssues reports why data written with the writer type can't be read
+// with the reader type, following Avro schema resolution: numeric/string
+// promotions, enum symbols (unless the reader enum has a default), fixed
+// sizes, union branches, and array items and map values. readerIsNew selects
+// the wording: "removed from" for BACKWARD, "added to" for FORWARD.
+func avroReadIssues(reader, writer interface{}, readerTypes, writerTypes map[string]map[string]interface{}, path string, readerIsNew bool) []ValidationIssue {
+	reader = resolveAvroType(reader, readerTypes)
+	writer = resolveAvroType(writer, writerTypes)
+
+	label := path
+	if label == "" {
+		label = "schema"
+	}
+	changed := "removed from"
+	if !readerIsNew {
+		changed = "added to"
+	}
+	typeChanged := func() []ValidationIssue {
+		oldType, newType := formatAvroType(writer), formatAvroType(reader)
+		if !readerIsNew {
+			oldType, newType = newType, oldType
+		}
+		return []ValidationIssue{{
+			Severity: "ERROR",
+			Field:    path,
+			Message:  fmt.Sprintf("Field '%s' type changed from '%s' to '%s'", label, oldType, newType),
+			Fix:      fmt.Sprintf("Add a new field instead of changing the type of '%s'", label),
+		}}
+	}
+
+	// Union writer: every branch it may have written must be readable
+	if writerUnion, ok := writer.([]interface{}); ok {
+		readerUnion, readerIsUnion := reader.([]interface{})
+		var issues []ValidationIssue
+		for _, branch := range writerUnion {
+			if !readerIsUnion {
+				if len(avroReadIssues(reader, branch, readerTypes, writerTypes, path, readerIsNew)) > 0 {
+					return typeChanged()
+				}
+				continue
+			}
+			readerBranch := avroUnionBranchFor(readerUnion, branch, readerTypes, writerTypes)
+			if readerBranch == nil {
+				issues = append(issues, ValidationIssue{
+					Severity: "ERROR",
+					Field:    path,
+					Message:  fmt.Sprintf("Union branch '%s' was %s '%s'", formatAvroType(branch), changed, label),
+					Fix:      fmt.Sprintf("Keep every existing branch of the union in '%s'", label),
+				})
+				continue
+			}
+			issues = append(issues, avroReadIssues(readerBranch, branch, readerTypes, writerTypes, path, readerIsNew)...)
+		}
+		return issues
+	}
+
+	// Union reader, single writer type: some branch must read it
+	if readerUnion, ok := reader.([]interface{}); ok {
+		branch := avroUnionBranchFor(readerUnion, writer, readerTypes, writerTypes)
+		if branch == nil {
+			return typeChanged()
+		}
+		return avroReadIssues(branch, writer, readerTypes, writerTypes, path, readerIsNew)
+	}
+
+	readerKind, writerKind := avroTypeKind(reader), avroTypeKind(writer)
+	if readerKind != writerKind {
+		if isAvroTypePromotion(writerKind, readerKind) {
+			return nil
+		}
+		return typeChanged()
+	}
+
+	r, _ := reader.(map[string]interface{})
+	w, _ := writer.(map[string]interface{})
+	switch readerKind {
+	case "enum":
+		if avroShortName(r) != avroShortName(w) {
+			return typeChanged()
+		}
+		if _, hasDefault := r["default"]; hasDefault {
+			return nil
+		}
+		readerSymbols := make(map[string]bool)
+		for _, s := range avroEnumSymbols(r) {
+			readerSymbols[s] = true
+		}
+		var missing []string
+		for _, s := range avroEnumSymbols(w) {
+			if !readerSymbols[s] {
+				missing = append(missing, s)
+			}
+		}
+		if len(missing) > 0 {
+			return []ValidationIssue{{
+				Severity: "ERROR",
+				Field:    path,
+				Message:  fmt.Sprintf("Enum symbol(s) %s %s '%s'", strings.Join(missing, ", "), changed, label),
+				Fix:      "Keep existing enum symbols, or give the reading enum a \"default\" symbol",
+			}}
+		}
+	case "fixed":
+		if avroShortName(r) != avroShortName(w) {
+			return typeChanged()
+		}
+		if r["size"] != w["size"] {
+			oldSize, newSize := w["size"], r["size"]
+			if !readerIsNew {
+				oldSize, newSize = newSize, oldSize
+			}
+			return []ValidationIssue{{
+				Severity: "ERROR",
+				Field:    path,
+				Message:  fmt.Sprintf("Fixed size of '%s' changed from %v to %v", label, oldSize, newSize),
+				Fix:      "Fixed types must keep their size; add a new field instead",
+			}}
+		}
+	case "record":
+		// Record fields are compared field by field by the caller
+		if avroShortName(r) != avroShortName(w) {
+			return typeChanged()
+		}
+	case "array":
+		return avroReadIssues(r["items"], w["items"], readerTypes, writerTypes, path, readerIsNew)
+	case "map":
+		return avroReadIssues(r["values"], w["values"], readerTypes, writerTypes, path, readerIsNew)
+	}
+	return nil
+}
+
+// avroUnionBranchFor returns the reader union branch that reads the writer
+// type: an exact match first, then a promotion
+func avroUnionBranchFor(readerUnion []interface{}, writer interface{}, readerTypes, writerTypes map[string]map[string]interface{}) interface{} {
+	w := resolveAvroType(writer, writerTypes)
+	writerKind := avroTypeKind(w)
+	for _, branch := range readerUnion {
+		b := resolveAvroType(branch, readerTypes)
+		if avroTypeKind(b) != writerKind {
+			continue
+		}
+		bm, bIsNamed := b.(map[string]interface{})
+		wm, wIsNamed := w.(map[string]interface{})
+		if bIsNamed && wIsNamed && isAvroNamedKind(writerKind) && avroShortName(bm) != avroShortName(wm) {
+			continue
+		}
+		return branch
+	}
+	for _, branch := range readerUnion {
+		if isAvroTypePromotion(writerKind, avroTypeKind(resolveAvroType(branch, readerTypes))) {
+			return branch
+		}
+	}
+	return nil
+}
+
+// resolveAvroType replaces a reference to a named type with its definition
+func resolveAvroType(t interface{}, named map[string]map[string]interface{}) interface{} {
+	if name, ok := t.(string); ok {
+		if def, ok := named[name]; ok {
+			return def
+		}
+	}
+	if m, ok := t.(map[string]interface{}); ok {
+		// {"type": "string", "logicalType": ...} and {"type": "com.example.X"}
+		if inner, ok := m["type"].(string); ok && !avroPrimitiveTypes[inner] && !isAvroComplexKind(inner) {
+			if def, ok := named[inner]; ok {
+				return def
+			}
+		}
+	}
+	return t
+}
+
+// avroTypeKind returns the primitive name or complex kind of an Avro type
+func avroTypeKind(t interface{}) string {
+	switch v := t.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if kind, ok := v["type"].(string); ok {
+			return kind
+		}
+		return avroTypeKind(v["type"])
+	case []interface{}:
+		return "union"
+	}
+	return ""
+}
+
+func isAvroComplexKind(kind string) bool {
+	switch kind {
+	case "record", "error", "enum", "fixed", "array", "map":
+		return true
+	}
+	return false
+}
+
+func isAvroNamedKind(kind string) bool {
+	return kind == "record" || kind == "error" || kind == "enum" || kind == "fixed"
+}
+
+func isAvroRecord(schema interface{}) bool {
+	kind := avroTypeKind(schema)
+	return kind == "record" || kind == "error"
+}
+
+// avroShortName returns a named type's name without its namespace
+func avroShortName(t map[string]interface{}) string {
+	name, _ := t["name"].(string)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func avroEnumSymbols(t map[string]interface{}) []string {
+	var symbols []string
+	list, _ := t["symbols"].([]interface{})
+	for _, s := range list {
+		if str, ok := s.(string); ok {
+			symbols = append(symbols, str)
+		}
+	}
+	return symbols
+}
+
+// collectAvroNamedDefinitions indexes the named types defined in a schema by
+// full name and by short name
+func collectAvroNamedDefinitions(schema interface{}) map[string]map[string]interface{} {
+	named := make(map[string]map[string]interface{})
+
+	var walk func(t interface{}, namespace string)
+	walk = func(t interface{}, namespace string) {
+		switch v := t.(type) {
+		case []interface{}:
+			for _, branch := range v {
+				walk(branch, namespace)
+			}
+		case map[string]interface{}:
+			kind, _ := v["type"].(string)
+			switch {
+			case isAvroNamedKind(kind):
+				name, _ := v["name"].(string)
+				full := name
+				if !strings.Contains(name, ".") {
+					if ns, ok := v["namespace"].(string); ok {
+						namespace = ns
+					}
+					if namespace != "" {
+						full = namespace + "." + name
+					}
+				} else {
+					namespace = name[:strings.LastIndex(name, ".")]
+				}
+				named[full] = v
+				if _, taken := named[avroShortName(v)]; !taken {
+					named[avroShortName(v)] = v
+				}
+				if fields, ok := v["fields"].([]interface{}); ok {
+					for _, f := range fields {
+						if field, ok := f.(map[string]interface{}); ok {
+							walk(field["type"], namespace)
+						}
+					}
+				}
+			case kind == "array":
+				walk(v["items"], namespace)
+			case kind == "map":
+				walk(v["values"], namespace)
+			case kind == "":
+				walk(v["type"], namespace)
+			}
+		}
+	}
+	walk(schema, "")
+	return named
+}
+
 type fieldInfo struct {
 	Type       string
+	Schema     interface{} // The field's raw Avro type
 	HasDefault bool
 	IsNullable bool
 }
@@ -884,6 +1268,7 @@ func extractAvroFieldsDeep(schema interface{}, prefix string) map[string]fieldIn
 
 		fields[path] = fieldInfo{
 			Type:       typeStr,
+			Schema:     field["type"],
 			HasDefault: hasDefault,
 			IsNullable: isNullable,
 		}
@@ -1036,30 +1421,94 @@ func runValidateDir(dir string) error {
 	output.Info("Found %d schema files", len(files))
 	fmt.Println()
 
-	var results []ValidationResult
-	var errorCount int
+	var refs *referenceSet
+	if validateReferences != "" {
+		if refs, err = loadReferenceSet(validateReferences); err != nil {
+			return err
+		}
+	}
 
-	for _, file := range files {
-		content, err := os.ReadFile(file)
-		if err != nil {
-			results = append(results, ValidationResult{
-				File:  file,
-				Valid: false,
-				Issues: []ValidationIssue{{
-					Severity: "ERROR",
-					Message:  fmt.Sprintf("Cannot read file: %v", err),
-				}},
-			})
-			errorCount++
-			continue
+	results, validFiles := validateFilesParallel(dir, files, refs, validateWorkers)
+
+	if validateEvolution {
+		return printEvolutionResults(results, checkSchemaEvolution(validFiles, validateCompatibility))
+	}
+	return printValidationResults(results)
+}
+
+// validateFilesParallel validates files using a worker pool, returning results
+// (and the valid schemas) in the same order as files
+func validateFilesParallel(dir string, files []string, refs *referenceSet, numWorkers int) ([]ValidationResult, []schemaFile) {
+	numWorkers = clampWorkers(numWorkers)
+	results := make([]ValidationResult, len(files))
+	parsed := make([]*schemaFile, len(files))
+	jobs := make(chan int, len(files))
+
+	// Progress tracking
+	bar := newProgressBar(len(files), "Validating")
+
+	// Start workers; each writes only its own index
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx], parsed[idx] = validateDirFile(dir, files[idx], refs)
+				bar.Add(1)
+			}
+		}()
+	}
+
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	bar.Finish()
+
+	var validFiles []schemaFile
+	for _, f := range parsed {
+		if f != nil {
+			validFiles = append(validFiles, *f)
 		}
+	}
+	return results, validFiles
+}
 
-		relPath, _ := filepath.Rel(dir, file)
-		schemaType := detectSchemaType(string(content), file)
-		result := validateSchemaSyntax(string(content), schemaType, relPath)
-		results = append(results, result)
+// validateDirFile validates a single file found by --dir, returning the parsed
+// schema if it is valid
+func validateDirFile(dir, file string, refs *referenceSet) (ValidationResult, *schemaFile) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return ValidationResult{
+			File:  file,
+			Valid: false,
+			Issues: []ValidationIssue{{
+				Severity: "ERROR",
+				Message:  fmt.Sprintf("Cannot read file: %v", err),
+			}},
+		}, nil
+	}
 
-		if !result.Valid {
+	relPath, _ := filepath.Rel(dir, file)
+	schemaType := detectSchemaType(string(content), file)
+	result := validateSchemaSyntax(string(content), schemaType, relPath)
+	if refs != nil {
+		result.addIssues(refs.check(string(content), schemaType))
+	}
+	if !result.Valid {
+		return result, nil
+	}
+	return result, &schemaFile{Path: relPath, Content: string(content), SchemaType: schemaType}
+}
+
+// printValidationResults displays per-schema results and a summary, returning
+// an error if any schema is invalid
+func printValidationResults(results []ValidationResult) error {
+	errorCount := 0
+	for _, r := range results {
+		if !r.Valid {
 			errorCount++
 		}
 	}
@@ -1076,9 +1525,9 @@ func runValidateDir(dir string) error {
 
 	fmt.Println()
 	if errorCount == 0 {
-		output.Success("All %d schemas are valid", len(files))
+		output.Success("All %d schemas are valid", len(results))
 	} else {
-		output.Error("%d of %d schemas have errors", errorCount, len(files))
+		output.Error("%d of %d schemas have errors", errorCount, len(results))
 	}
 
 	if errorCount > 0 {
@@ -1087,6 +1536,499 @@ func runValidateDir(dir string) error {
 	return nil
 }
 
+// ========================
+// Reference validation
+// ========================
+
+// referenceSet holds the schemas loaded from --references and the reference
+// names they resolve: Avro full names, Protobuf import paths and JSON $ref URIs
+type referenceSet struct {
+	source  string
+	names   map[string]bool
+	schemas []referencedSchema
+}
+
+// referencedSchema is one schema in a reference set. Declared references are
+// only known when the set was loaded from a split manifest.
+type referencedSchema struct {
+	file        string
+	schema      string
+	schemaType  string
+	declared    []string
+	hasManifest bool
+}
+
+func runValidateReferences(path string) error {
+	refs, err := loadReferenceSet(path)
+	if err != nil {
+		return err
+	}
+
+	output.Header("Reference Validation: %s", path)
+	output.Info("Found %d schemas", len(refs.schemas))
+	fmt.Println()
+
+	var results []ValidationResult
+	for _, s := range refs.schemas {
+		result := validateSchemaSyntax(s.schema, s.schemaType, s.file)
+		result.addIssues(refs.check(s.schema, s.schemaType))
+		results = append(results, result)
+	}
+
+	return printValidationResults(results)
+}
+
+// loadReferenceSet loads a split manifest, or every schema file in a
+// directory (using its manifest.json when there is one)
+func loadReferenceSet(path string) (*referenceSet, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --references: %w", err)
+	}
+
+	if !info.IsDir() {
+		return loadReferenceManifest(path)
+	}
+	manifestPath := filepath.Join(path, "manifest.json")
+	if _, err := os.Stat(manifestPath); err == nil {
+		return loadReferenceManifest(manifestPath)
+	}
+
+	refs := &referenceSet{source: path, names: make(map[string]bool)}
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(file))
+		if info.IsDir() || (ext != ".avsc" && ext != ".avro" && ext != ".proto" && ext != ".json") {
+			return nil
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		relPath, _ := filepath.Rel(path, file)
+		relPath = filepath.ToSlash(relPath)
+		schemaType := detectSchemaType(string(content), file)
+		refs.schemas = append(refs.schemas, referencedSchema{
+			file:       relPath,
+			schema:     string(content),
+			schemaType: schemaType,
+		})
+
+		switch schemaType {
+		case "AVRO":
+			defined, _ := avroNamedTypeRefs(string(content))
+			for name := range defined {
+				refs.names[name] = true
+			}
+		case "JSON":
+			var schema map[string]interface{}
+			if json.Unmarshal(content, &schema) == nil {
+				if id, ok := schema["$id"].(string); ok {
+					refs.names[id] = true
+				}
+			}
+			refs.names[relPath] = true
+		default:
+			refs.names[relPath] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk --references directory: %w", err)
+	}
+	return refs, nil
+}
+
+// loadReferenceManifest loads the manifest.json written by 'split extract'
+func loadReferenceManifest(path string) (*referenceSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var manifest SplitResult
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+
+	byName := make(map[string]*ExtractedType)
+	for i := range manifest.Types {
+		byName[manifest.Types[i].Name] = &manifest.Types[i]
+	}
+
+	refs := &referenceSet{source: path, names: make(map[string]bool)}
+	for i := range manifest.Types {
+		t := &manifest.Types[i]
+		schemaType := t.SchemaType
+		if schemaType == "" {
+			schemaType = manifest.SchemaType
+		}
+
+		refs.names[getReferenceName(t, schemaType)] = true
+		if strings.ToUpper(schemaType) == "AVRO" {
+			// Named types nested in an extracted schema resolve too
+			defined, _ := avroNamedTypeRefs(t.Schema)
+			for name := range defined {
+				refs.names[name] = true
+			}
+		}
+
+		var declared []string
+		for _, dep := range t.References {
+			if d, ok := byName[dep]; ok {
+				declared = append(declared, getReferenceName(d, schemaType))
+			} else {
+				declared = append(declared, dep)
+			}
+		}
+		refs.schemas = append(refs.schemas, referencedSchema{
+			file:        t.Subject,
+			schema:      t.Schema,
+			schemaType:  schemaType,
+			declared:    declared,
+			hasManifest: true,
+		})
+	}
+	return refs, nil
+}
+
+// check reports references in content that don't resolve against the set.
+// When content is one of the manifest's schemas, its declared reference names
+// must also match the references the schema actually uses.
+func (r *referenceSet) check(content, schemaType string) []ValidationIssue {
+	var issues []ValidationIssue
+
+	expected := schemaExpectedReferences(content, schemaType)
+	for _, ref := range expected {
+		if !r.names[ref] {
+			issues = append(issues, ValidationIssue{
+				Severity: "ERROR",
+				Message:  fmt.Sprintf("Reference '%s' is not defined in %s", ref, r.source),
+				Field:    ref,
+				Fix:      fmt.Sprintf("Add the schema defining '%s' to the references, or inline it", ref),
+			})
+		}
+	}
+
+	var self *referencedSchema
+	for i := range r.schemas {
+		if r.schemas[i].hasManifest && strings.TrimSpace(r.schemas[i].schema) == strings.TrimSpace(content) {
+			self = &r.schemas[i]
+			break
+		}
+	}
+	if self == nil {
+		return issues
+	}
+
+	declared := make(map[string]bool)
+	for _, name := range self.declared {
+		declared[name] = true
+	}
+	used := make(map[string]bool)
+	for _, ref := range expected {
+		used[ref] = true
+		if !declared[ref] {
+			issues = append(issues, ValidationIssue{
+				Severity: "ERROR",
+				Message:  fmt.Sprintf("Schema uses '%s' but the manifest doesn't declare it as a reference", ref),
+				Field:    ref,
+				Fix:      fmt.Sprintf("Add '%s' to the references of '%s' in the manifest", ref, self.file),
+			})
+		}
+	}
+	for _, name := range self.declared {
+		if !used[name] {
+			issues = append(issues, ValidationIssue{
+				Severity: "ERROR",
+				Message:  fmt.Sprintf("Reference name '%s' doesn't match anything the schema uses", name),
+				Field:    name,
+				Fix:      "Reference names must be the Avro full name, Protobuf import path or JSON $ref the schema uses",
+			})
+		}
+	}
+	return issues
+}
+
+// schemaExpectedReferences returns the reference names a schema needs from
+// other schemas: unresolved Avro named types, non-builtin Protobuf imports,
+// and non-local JSON $refs
+func schemaExpectedReferences(content, schemaType string) []string {
+	seen := make(map[string]bool)
+	var refs []string
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			refs = append(refs, name)
+		}
+	}
+
+	switch strings.ToUpper(schemaType) {
+	case "AVRO":
+		defined, used := avroNamedTypeRefs(content)
+		for full, short := range used {
+			if !defined[full] && !defined[short] {
+				add(full)
+			}
+		}
+	case "PROTOBUF":
+		importRe := regexp.MustCompile(`"([^"]+)"`)
+		for _, stmt := range extractProtobufImports(content) {
+			if m := importRe.FindStringSubmatch(stmt); m != nil {
+				// Well-known and Confluent types are built into the registry
+				if !strings.HasPrefix(m[1], "google/protobuf/") && !strings.HasPrefix(m[1], "confluent/") {
+					add(m[1])
+				}
+			}
+		}
+	case "JSON":
+		var schema interface{}
+		if json.Unmarshal([]byte(content), &schema) == nil {
+			collectJSONSchemaRefs(schema, add)
+		}
+	}
+
+	sort.Strings(refs)
+	return refs
+}
+
+func collectJSONSchemaRefs(node interface{}, add func(string)) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, val := range n {
+			if ref, ok := val.(string); ok && key == "$ref" {
+				if !strings.HasPrefix(ref, "#") {
+					add(strings.SplitN(ref, "#", 2)[0])
+				}
+				continue
+			}
+			collectJSONSchemaRefs(val, add)
+		}
+	case []interface{}:
+		for _, v := range n {
+			collectJSONSchemaRefs(v, add)
+		}
+	}
+}
+
+var avroPrimitiveTypes = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true,
+	"float": true, "double": true, "bytes": true, "string": true,
+}
+
+// avroNamedTypeRefs returns the full names of named types an Avro schema
+// defines, and the named types it refers to by name. Referenced names map
+// full name -> name as written, since an unqualified name may also resolve
+// in the null namespace.
+func avroNamedTypeRefs(content string) (defined map[string]bool, used map[string]string) {
+	defined = make(map[string]bool)
+	used = make(map[string]string)
+
+	var schema interface{}
+	if err := json.Unmarshal([]byte(content), &schema); err != nil {
+		return defined, used
+	}
+
+	var walk func(t interface{}, namespace string)
+	walk = func(t interface{}, namespace string) {
+		switch v := t.(type) {
+		case string:
+			if avroPrimitiveTypes[v] {
+				return
+			}
+			full := v
+			if !strings.Contains(v, ".") && namespace != "" {
+				full = namespace + "." + v
+			}
+			used[full] = v
+		case []interface{}:
+			for _, branch := range v {
+				walk(branch, namespace)
+			}
+		case map[string]interface{}:
+			typeName, isString := v["type"].(string)
+			if !isString {
+				walk(v["type"], namespace)
+				return
+			}
+			switch typeName {
+			case "record", "error", "enum", "fixed":
+				name, _ := v["name"].(string)
+				full := name
+				if !strings.Contains(name, ".") {
+					ns := namespace
+					if explicit, ok := v["namespace"].(string); ok {
+						ns = explicit
+					}
+					if ns != "" {
+						full = ns + "." + name
+					}
+				}
+				defined[full] = true
+
+				childNamespace := ""
+				if i := strings.LastIndex(full, "."); i >= 0 {
+					childNamespace = full[:i]
+				}
+				if fields, ok := v["fields"].([]interface{}); ok {
+					for _, f := range fields {
+						if field, ok := f.(map[string]interface{}); ok {
+							walk(field["type"], childNamespace)
+						}
+					}
+				}
+			case "array":
+				walk(v["items"], namespace)
+			case "map":
+				walk(v["values"], namespace)
+			default:
+				walk(typeName, namespace)
+			}
+		}
+	}
+	walk(schema, "")
+	return defined, used
+}
+
+// ========================
+// Schema evolution
+// ========================
+
+// versionedFileRe matches versioned schema file names such as order.v2,
+// order-v2 or order_2 (without extension)
+var versionedFileRe = regexp.MustCompile(`^(.+?)[._-]v?(\d+)$`)
+
+// schemaFile is a syntactically valid schema found during directory validation
+type schemaFile struct {
+	Path       string
+	Content    string
+	SchemaType string
+}
+
+// EvolutionResult is the compatibility of one version of a schema with an
+// earlier one
+type EvolutionResult struct {
+	Schema     string            `json:"schema"`
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Compatible bool              `json:"compatible"`
+	Issues     []ValidationIssue `json:"issues,omitempty"`
+}
+
+// checkSchemaEvolution groups versioned files (order.v1.avsc, order.v2.avsc,
+// ...) by directory, name and extension, sorts each group by version and
+// checks every version against its predecessor, or against all earlier
+// versions for the *_TRANSITIVE modes. Unversioned files are skipped.
+func checkSchemaEvolution(files []schemaFile, mode string) []EvolutionResult {
+	type version struct {
+		num  int
+		file schemaFile
+	}
+	groups := make(map[string][]version)
+	var names []string
+
+	for _, f := range files {
+		ext := filepath.Ext(f.Path)
+		base := strings.TrimSuffix(filepath.Base(f.Path), ext)
+		m := versionedFileRe.FindStringSubmatch(base)
+		if m == nil {
+			continue
+		}
+		num, _ := strconv.Atoi(m[2])
+		name := filepath.ToSlash(filepath.Join(filepath.Dir(f.Path), m[1])) + ext
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], version{num: num, file: f})
+	}
+	sort.Strings(names)
+
+	transitive := strings.HasSuffix(strings.ToUpper(mode), "_TRANSITIVE")
+
+	var results []EvolutionResult
+	for _, name := range names {
+		versions := groups[name]
+		sort.Slice(versions, func(i, j int) bool { return versions[i].num < versions[j].num })
+
+		for i := 1; i < len(versions); i++ {
+			first := i - 1
+			if transitive {
+				first = 0
+			}
+			for j := first; j < i; j++ {
+				newer, older := versions[i].file, versions[j].file
+				issues := checkCompatibility(newer.Content, older.Content, newer.SchemaType, mode)
+				compatible := true
+				for _, issue := range issues {
+					if issue.Severity == "ERROR" {
+						compatible = false
+						break
+					}
+				}
+				results = append(results, EvolutionResult{
+					Schema:     name,
+					From:       older.Path,
+					To:         newer.Path,
+					Compatible: compatible,
+					Issues:     issues,
+				})
+			}
+		}
+	}
+	return results
+}
+
+// printEvolutionResults displays directory validation results followed by
+// the version transitions, returning an error if anything failed
+func printEvolutionResults(results []ValidationResult, evolution []EvolutionResult) error {
+	printer := output.NewPrinter(outputFormat)
+	if outputFormat != "table" {
+		return printer.Print(map[string]interface{}{
+			"files":     results,
+			"evolution": evolution,
+		})
+	}
+
+	errorCount := 0
+	for _, r := range results {
+		displayValidationResult(r)
+		if !r.Valid {
+			errorCount++
+		}
+	}
+	fmt.Println()
+
+	output.SubHeader("Schema Evolution (%s)", strings.ToUpper(validateCompatibility))
+	if len(evolution) == 0 {
+		output.Info("No versioned schemas found (expected names like order.v1.avsc, order.v2.avsc)")
+	}
+	incompatible := 0
+	for _, e := range evolution {
+		if e.Compatible {
+			output.Success("%s -> %s compatible", e.From, e.To)
+		} else {
+			incompatible++
+			output.Error("%s -> %s NOT compatible", e.From, e.To)
+		}
+		printValidationIssues(e.Issues, "WARN")
+	}
+	fmt.Println()
+
+	if errorCount > 0 {
+		output.Error("%d of %d schemas have errors", errorCount, len(results))
+	}
+	if incompatible > 0 {
+		output.Error("%d of %d version transitions are incompatible", incompatible, len(evolution))
+	}
+	if errorCount == 0 && incompatible == 0 {
+		output.Success("All %d schemas are valid and all %d version transitions are compatible", len(results), len(evolution))
+		return nil
+	}
+	return fmt.Errorf("%d schemas have validation errors, %d incompatible version transitions", errorCount, incompatible)
+}
+
 // ========================
 // Display helpers
 // ========================
@@ -1098,11 +2040,16 @@ func displayValidationResult(result ValidationResult) {
 		output.Error("%s (%s) - invalid", result.File, result.SchemaType)
 	}
 
+	printValidationIssues(result.Issues, "WARNING")
+}
+
+// printValidationIssues prints issues with a colored severity marker and fix
+func printValidationIssues(issues []ValidationIssue, warnLabel string) {
 	red := color.New(color.FgRed).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 
-	for _, issue := range result.Issues {
-		marker := yellow("WARNING")
+	for _, issue := range issues {
+		marker := yellow(warnLabel)
 		if issue.Severity == "ERROR" {
 			marker = red("ERROR")
 		}
@@ -1111,6 +2058,9 @@ func displayValidationResult(result ValidationResult) {
 		if issue.Field != "" {
 			fieldStr = fmt.Sprintf(" [%s]", issue.Field)
 		}
+		if issue.Version > 0 {
+			fieldStr += fmt.Sprintf(" (vs v%d)", issue.Version)
+		}
 
 		fmt.Printf("  %s%s: %s\n", marker, fieldStr, issue.Message)
 		if issue.Fix != "" {
@@ -1120,9 +2070,6 @@ func displayValidationResult(result ValidationResult) {
 }
 
 func displayCompatibilityIssues(issues []ValidationIssue) {
-	red := color.New(color.FgRed).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
-
 	var errors, warnings int
 	for _, issue := range issues {
 		if issue.Severity == "ERROR" {
@@ -1140,20 +2087,5 @@ func displayCompatibilityIssues(issues []ValidationIssue) {
 	fmt.Println()
 
 	output.SubHeader("Issues")
-	for _, issue := range issues {
-		marker := yellow("WARN")
-		if issue.Severity == "ERROR" {
-			marker = red("ERROR")
-		}
-
-		fieldStr := ""
-		if issue.Field != "" {
-			fieldStr = fmt.Sprintf(" [%s]", issue.Field)
-		}
-
-		fmt.Printf("  %s%s: %s\n", marker, fieldStr, issue.Message)
-		if issue.Fix != "" {
-			fmt.Printf("    Fix: %s\n", issue.Fix)
-		}
-	}
+	printValidationIssues(issues, "WARN")
 }
@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/srctl/srctl/internal/client"
 )
 
 func TestValidateAvroSyntaxValid(t *testing.T) {
@@ -483,6 +487,47 @@ message Test {
 	}
 }
 
+func TestValidateFilesParallelOrdering(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	var files []string
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("schema-%02d.avsc", i)
+		content := fmt.Sprintf(`{"type": "record", "name": "S%d", "namespace": "com.example", "fields": [{"name": "id", "type": "string"}]}`, i)
+		if i%7 == 0 {
+			content = `{"type": "record"}`
+		}
+		os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644)
+		files = append(files, filepath.Join(tmpDir, name))
+	}
+	oldFormat := outputFormat
+	outputFormat = "json"
+	defer func() { outputFormat = oldFormat }()
+
+	results, validFiles := validateFilesParallel(tmpDir, files, nil, 8)
+
+	if len(results) != len(files) {
+		t.Fatalf("expected %d results, got %d", len(files), len(results))
+	}
+	for i, r := range results {
+		want := fmt.Sprintf("schema-%02d.avsc", i)
+		if r.File != want {
+			t.Fatalf("result %d: expected %s, got %s", i, want, r.File)
+		}
+		if r.Valid == (i%7 == 0) {
+			t.Errorf("%s: unexpected validity %v", r.File, r.Valid)
+		}
+	}
+	if len(validFiles) != 42 {
+		t.Errorf("expected 42 valid files, got %d", len(validFiles))
+	}
+	for i := 1; i < len(validFiles); i++ {
+		if validFiles[i-1].Path >= validFiles[i].Path {
+			t.Errorf("valid files out of order: %s before %s", validFiles[i-1].Path, validFiles[i].Path)
+		}
+	}
+}
+
 func TestValidateActionableFeedback(t *testing.T) {
 	schema := `{"type": "record"}`
 	result := validateSchemaSyntax(schema, "AVRO", "test.avsc")
@@ -530,3 +575,306 @@ func TestExtractAvroFieldsDeep(t *testing.T) {
 		t.Error("expected 'customer.email' field")
 	}
 }
+
+func writeSplitManifest(t *testing.T, dir string, result *SplitResult) string {
+	t.Helper()
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal manifest: %v", err)
+	}
+	path := filepath.Join(dir, "manifest.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func countErrors(issues []ValidationIssue) int {
+	n := 0
+	for _, issue := range issues {
+		if issue.Severity == "ERROR" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestValidateReferencesManifest(t *testing.T) {
+	schema := `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "id", "type": "string"},
+    {"name": "address", "type": {
+      "type": "record",
+      "name": "Address",
+      "namespace": "com.example",
+      "fields": [{"name": "street", "type": "string"}]
+    }}
+  ]
+}`
+	result, err := splitAvroSchema(schema, 0, "", 0)
+	if err != nil {
+		t.Fatalf("splitAvroSchema failed: %v", err)
+	}
+
+	var root *ExtractedType
+	for i := range result.Types {
+		if result.Types[i].IsRoot {
+			root = &result.Types[i]
+		}
+	}
+
+	t.Run("all references resolve", func(t *testing.T) {
+		refs, err := loadReferenceSet(writeSplitManifest(t, t.TempDir(), result))
+		if err != nil {
+			t.Fatalf("loadReferenceSet failed: %v", err)
+		}
+		if issues := refs.check(root.Schema, "AVRO"); len(issues) != 0 {
+			t.Errorf("expected no issues, got %v", issues)
+		}
+	})
+
+	t.Run("missing referenced type", func(t *testing.T) {
+		partial := *result
+		partial.Types = []ExtractedType{*root}
+		refs, err := loadReferenceSet(writeSplitManifest(t, t.TempDir(), &partial))
+		if err != nil {
+			t.Fatalf("loadReferenceSet failed: %v", err)
+		}
+		issues := refs.check(root.Schema, "AVRO")
+		if countErrors(issues) == 0 {
+			t.Fatalf("expected an error for the missing Address schema, got %v", issues)
+		}
+		if issues[0].Field != "com.example.Address" {
+			t.Errorf("expected missing reference com.example.Address, got %s", issues[0].Field)
+		}
+	})
+
+	t.Run("declared name mismatch", func(t *testing.T) {
+		mismatched := *result
+		mismatched.Types = append([]ExtractedType(nil), result.Types...)
+		for i := range mismatched.Types {
+			if mismatched.Types[i].IsRoot {
+				mismatched.Types[i].References = []string{"com.example.Adress"}
+			}
+		}
+		refs, err := loadReferenceSet(writeSplitManifest(t, t.TempDir(), &mismatched))
+		if err != nil {
+			t.Fatalf("loadReferenceSet failed: %v", err)
+		}
+		// The schema uses an undeclared reference, and the declared one is unused
+		if n := countErrors(refs.check(root.Schema, "AVRO")); n != 2 {
+			t.Errorf("expected 2 errors, got %d", n)
+		}
+	})
+}
+
+func TestValidateReferencesDirectory(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "customer.proto"), []byte(`syntax = "proto3";
+message Customer {
+  string id = 1;
+}`), 0644)
+
+	refs, err := loadReferenceSet(dir)
+	if err != nil {
+		t.Fatalf("loadReferenceSet failed: %v", err)
+	}
+
+	order := `syntax = "proto3";
+import "customer.proto";
+import "google/protobuf/timestamp.proto";
+
+message Order {
+  Customer customer = 1;
+  google.protobuf.Timestamp created_at = 2;
+}`
+	if issues := refs.check(order, "PROTOBUF"); len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+
+	missing := strings.Replace(order, `import "customer.proto";`, `import "customer.proto";
+import "address.proto";`, 1)
+	issues := refs.check(missing, "PROTOBUF")
+	if len(issues) != 1 || issues[0].Field != "address.proto" {
+		t.Errorf("expected a missing address.proto reference, got %v", issues)
+	}
+}
+
+func TestSchemaExpectedReferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		content    string
+		want       []string
+	}{
+		{
+			name:       "avro unqualified name uses enclosing namespace",
+			schemaType: "AVRO",
+			content:    `{"type":"record","name":"Order","namespace":"com.example","fields":[{"name":"a","type":["null","Address"]},{"name":"b","type":{"type":"array","items":"com.other.Item"}}]}`,
+			want:       []string{"com.example.Address", "com.other.Item"},
+		},
+		{
+			name:       "avro types defined inline are not references",
+			schemaType: "AVRO",
+			content:    `{"type":"record","name":"Order","namespace":"com.example","fields":[{"name":"s","type":{"type":"enum","name":"Status","symbols":["A"]}},{"name":"t","type":"Status"}]}`,
+		},
+		{
+			name:       "json schema ignores local refs",
+			schemaType: "JSON",
+			content:    `{"type":"object","properties":{"a":{"$ref":"address.json"},"b":{"$ref":"#/definitions/x"},"c":{"$ref":"item.json#/definitions/y"}}}`,
+			want:       []string{"address.json", "item.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schemaExpectedReferences(tt.content, tt.schemaType)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateCompatibilityAvroEnumSymbols(t *testing.T) {
+	schema := func(symbols, extra string) string {
+		return `{"type": "record", "name": "Order", "namespace": "com.example", "fields": [
+  {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": [` + symbols + `]` + extra + `}},
+  {"name": "previous", "type": ["null", "Status"], "default": null}
+]}`
+	}
+	v1 := schema(`"OPEN", "CLOSED"`, "")
+	v2Removed := schema(`"OPEN"`, "")
+	v2Added := schema(`"OPEN", "CLOSED", "CANCELLED"`, "")
+
+	tests := []struct {
+		name       string
+		newSchema  string
+		oldSchema  string
+		mode       string
+		wantErrors int
+	}{
+		{"symbol removed breaks BACKWARD", v2Removed, v1, "BACKWARD", 2}, // status and previous (by name)
+		{"symbol added is BACKWARD compatible", v2Added, v1, "BACKWARD", 0},
+		{"symbol added breaks FORWARD", v2Added, v1, "FORWARD", 2},
+		{"reader default allows added symbol", v2Added, schema(`"OPEN", "CLOSED"`, `, "default": "OPEN"`), "FORWARD", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checkAvroCompatibility(tt.newSchema, tt.oldSchema, tt.mode)
+			if n := countErrors(issues); n != tt.wantErrors {
+				t.Errorf("expected %d errors, got %d: %v", tt.wantErrors, n, issues)
+			}
+			for _, issue := range issues {
+				if issue.Severity == "ERROR" && !strings.Contains(issue.Message, "Enum symbol") {
+					t.Errorf("unexpected issue: %s", issue.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCompatibilityAvroUnionAndFixed(t *testing.T) {
+	record := func(fieldType string) string {
+		return `{"type": "record", "name": "Event", "namespace": "com.example", "fields": [{"name": "value", "type": ` + fieldType + `}]}`
+	}
+
+	tests := []struct {
+		name       string
+		newType    string
+		oldType    string
+		mode       string
+		wantErrors int
+	}{
+		{"branch removed breaks BACKWARD", `["null", "string"]`, `["null", "string", "int"]`, "BACKWARD", 1},
+		{"branch added is BACKWARD compatible", `["null", "string", "int"]`, `["null", "string"]`, "BACKWARD", 0},
+		{"branch added breaks FORWARD", `["null", "string", "int"]`, `["null", "string"]`, "FORWARD", 1},
+		{"type widened to nullable union", `["null", "string"]`, `"string"`, "BACKWARD", 0},
+		{"nullable union narrowed breaks BACKWARD", `"string"`, `["null", "string"]`, "BACKWARD", 1},
+		{"union branch promotion", `["null", "long"]`, `["null", "int"]`, "BACKWARD", 0},
+		{"int to long breaks FORWARD", `"long"`, `"int"`, "FORWARD", 1},
+		{"array item type changed", `{"type": "array", "items": "string"}`, `{"type": "array", "items": "int"}`, "BACKWARD", 1},
+		{"fixed size changed", `{"type": "fixed", "name": "Hash", "size": 32}`, `{"type": "fixed", "name": "Hash", "size": 16}`, "BACKWARD", 1},
+		{"fixed size unchanged", `{"type": "fixed", "name": "Hash", "size": 16}`, `{"type": "fixed", "name": "Hash", "size": 16}`, "FULL", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checkAvroCompatibility(record(tt.newType), record(tt.oldType), tt.mode)
+			if n := countErrors(issues); n != tt.wantErrors {
+				t.Errorf("expected %d errors, got %d: %v", tt.wantErrors, n, issues)
+			}
+		})
+	}
+}
+
+func TestValidateCompatibilityAvroTopLevelEnum(t *testing.T) {
+	oldSchema := `{"type": "enum", "name": "Status", "namespace": "com.example", "symbols": ["OPEN", "CLOSED"]}`
+	newSchema := `{"type": "enum", "name": "Status", "namespace": "com.example", "symbols": ["OPEN"]}`
+
+	issues := checkAvroCompatibility(newSchema, oldSchema, "BACKWARD")
+	if countErrors(issues) != 1 || !strings.Contains(issues[0].Message, "CLOSED") {
+		t.Errorf("expected removed symbol CLOSED to be reported, got %v", issues)
+	}
+}
+
+func TestCheckSchemaEvolution(t *testing.T) {
+	v1 := `{"type": "record", "name": "Order", "namespace": "com.example", "fields": [{"name": "id", "type": "string"}, {"name": "note", "type": "string"}]}`
+	v2 := `{"type": "record", "name": "Order", "namespace": "com.example", "fields": [{"name": "id", "type": "string"}, {"name": "note", "type": "string"}, {"name": "total", "type": ["null", "double"], "default": null}]}`
+	v10 := `{"type": "record", "name": "Order", "namespace": "com.example", "fields": [{"name": "id", "type": "string"}, {"name": "total", "type": ["null", "double"], "default": null}]}`
+	files := []schemaFile{
+		// Deliberately unsorted; v10 must sort after v2 numerically
+		{Path: "order.v10.avsc", Content: v10, SchemaType: "AVRO"},
+		{Path: "order.v1.avsc", Content: v1, SchemaType: "AVRO"},
+		{Path: "order-v2.avsc", Content: v2, SchemaType: "AVRO"},
+		{Path: "customer.avsc", Content: v1, SchemaType: "AVRO"}, // unversioned
+	}
+
+	results := checkSchemaEvolution(files, "BACKWARD")
+	if len(results) != 2 {
+		t.Fatalf("expected 2 transitions, got %d: %+v", len(results), results)
+	}
+	if results[0].From != "order.v1.avsc" || results[0].To != "order-v2.avsc" || !results[0].Compatible {
+		t.Errorf("expected compatible v1 -> v2, got %+v", results[0])
+	}
+	if results[1].From != "order-v2.avsc" || results[1].To != "order.v10.avsc" || results[1].Compatible {
+		t.Errorf("expected incompatible v2 -> v10 (note removed), got %+v", results[1])
+	}
+
+	transitive := checkSchemaEvolution(files, "BACKWARD_TRANSITIVE")
+	if len(transitive) != 3 {
+		t.Errorf("expected 3 transitions for a transitive mode, got %d", len(transitive))
+	}
+}
+
+func TestCheckAgainstRegistryVersionsTransitive(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.AddSubject("orders-value", []client.Schema{
+		{Subject: "orders-value", Version: 1, ID: 1, Schema: `{"type": "record", "name": "Order", "namespace": "com.example", "fields": [{"name": "id", "type": "string"}, {"name": "legacy", "type": "string"}]}`},
+		{Subject: "orders-value", Version: 2, ID: 2, Schema: `{"type": "record", "name": "Order", "namespace": "com.example", "fields": [{"name": "id", "type": "string"}]}`},
+	})
+	newSchema := `{"type": "record", "name": "Order", "namespace": "com.example", "fields": [{"name": "id", "type": "string"}]}`
+
+	// Non-transitive: only the latest version, which matches
+	issues, checked, err := checkAgainstRegistryVersions(mock, "orders-value", newSchema, "AVRO", "BACKWARD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checked) != 1 || checked[0] != 2 || len(issues) != 0 {
+		t.Errorf("expected a clean check against v2 only, got versions %v, issues %v", checked, issues)
+	}
+
+	// Transitive: v1 still has 'legacy', so its removal is reported against v1
+	issues, checked, err = checkAgainstRegistryVersions(mock, "orders-value", newSchema, "AVRO", "BACKWARD_TRANSITIVE")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checked) != 2 {
+		t.Errorf("expected both versions checked, got %v", checked)
+	}
+	if len(issues) != 1 || issues[0].Field != "legacy" || issues[0].Version != 1 {
+		t.Errorf("expected one issue for 'legacy' against v1, got %+v", issues)
+	}
+}
@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/srctl/srctl/internal/client"
+	"github.com/srctl/srctl/internal/output"
+)
+
+var (
+	watchInterval time.Duration
+	watchWorkers  int
+)
+
+var watchCmd = &cobra.Command{
+	Use:     "watch [subject]",
+	Short:   "Poll the registry and print schema and config changes as they happen",
+	GroupID: groupSchema,
+	Long: `Poll a subject, or every subject, on an interval and print a line for each
+change since the previous poll:
+
+  +  a new version was registered (with its schema ID and type)
+  -  a version, or a whole subject, was deleted
+  ~  a compatibility setting changed (subject-level, or global)
+
+The first poll is the baseline and prints nothing. A subject that doesn't exist
+yet can be watched; its first registration shows up as a new version. Polling
+errors are reported and retried on the next interval. Press Ctrl-C to stop.
+
+With -o json each change is one JSON object per line, for piping into other
+tools.
+
+Examples:
+  # Watch one subject every 5 seconds
+  srctl watch orders-value
+
+  # Watch every subject in a context every 30 seconds
+  srctl watch --context .dev --interval 30s
+
+  # Stream changes as JSON lines
+  srctl watch -o json | jq .`,
+	// Not marked read-only: every poll must reach the registry, not the read cache
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Second, "Time between polls")
+	watchCmd.Flags().IntVar(&watchWorkers, "workers", 10, "Number of parallel workers when watching every subject")
+	rootCmd.AddCommand(watchCmd)
+}
+
+// Watch event types
+const (
+	watchVersionAdded   = "version-added"
+	watchVersionDeleted = "version-deleted"
+	watchSubjectDeleted = "subject-deleted"
+	watchConfigChanged  = "config-changed"
+)
+
+// WatchEvent is one change detected between two polls. Subject is empty for
+// a global config change.
+type WatchEvent struct {
+	Time       time.Time `json:"time" yaml:"time"`
+	Type       string    `json:"type" yaml:"type"`
+	Subject    string    `json:"subject,omitempty" yaml:"subject,omitempty"`
+	Version    int       `json:"version,omitempty" yaml:"version,omitempty"`
+	SchemaID   int       `json:"schemaId,omitempty" yaml:"schemaId,omitempty"`
+	SchemaType string    `json:"schemaType,omitempty" yaml:"schemaType,omitempty"`
+	From       string    `json:"from,omitempty" yaml:"from,omitempty"`
+	To         string    `json:"to,omitempty" yaml:"to,omitempty"`
+}
+
+// watchState is what one poll saw
+type watchState struct {
+	GlobalCompatibility string
+	Subjects            map[string]watchedSubject
+}
+
+// watchedSubject is a subject's live versions and its subject-level
+// compatibility override ("" when it has none)
+type watchedSubject struct {
+	Versions      []int
+	Compatibility string
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if watchInterval <= 0 {
+		return fmt.Errorf("invalid --interval %s: must be positive", watchInterval)
+	}
+	subject := ""
+	if len(args) == 1 {
+		subject = args[0]
+	}
+
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if outputFormat == "table" {
+		target := "all subjects"
+		if subject != "" {
+			target = subject
+		}
+		output.Info("Watching %s every %s (Ctrl-C to stop)", target, watchInterval)
+	}
+
+	polls, changes, err := watchRegistry(ctx, c, subject, watchInterval, watchWorkers, printWatchEvent)
+	if err != nil {
+		return err
+	}
+	if outputFormat == "table" {
+		fmt.Println()
+		output.Info("Stopped after %d poll(s); %d change(s) seen", polls, changes)
+	}
+	return nil
+}
+
+// watchRegistry polls until ctx is cancelled, calling emit for each change.
+// It fails only if the baseline poll fails; later errors are reported and the
+// previous state kept, so a blip doesn't show up as deletions.
+func watchRegistry(ctx context.Context, c client.SchemaRegistryClientInterface, subject string, interval time.Duration, workers int, emit func(WatchEvent)) (polls, changes int, err error) {
+	prev, err := pollWatchState(c, subject, workers)
+	if err != nil {
+		return 0, 0, err
+	}
+	polls = 1
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return polls, changes, nil
+		case <-ticker.C:
+		}
+
+		cur, err := pollWatchState(c, subject, workers)
+		polls++
+		if err != nil {
+			output.Warning("Poll failed, retrying in %s: %v", interval, err)
+			continue
+		}
+		events := diffWatchStates(prev, cur, time.Now())
+		describeNewVersions(c, events)
+		for _, ev := range events {
+			emit(ev)
+		}
+		changes += len(events)
+		prev = cur
+	}
+}
+
+// pollWatchState reads the global compatibility and, for the subject (or all
+// subjects when subject is ""), its live versions and compatibility override
+func pollWatchState(c client.SchemaRegistryClientInterface, subject string, workers int) (*watchState, error) {
+	state := &watchState{Subjects: make(map[string]watchedSubject)}
+
+	global, err := c.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get global config: %w", err)
+	}
+	state.GlobalCompatibility = compatibilityOf(global)
+
+	subjects := []string{subject}
+	if subject == "" {
+		if subjects, err = c.GetSubjects(false); err != nil {
+			return nil, fmt.Errorf("failed to get subjects: %w", err)
+		}
+	}
+
+	jobs := make(chan string, len(subjects))
+	for _, s := range subjects {
+		jobs <- s
+	}
+	close(jobs)
+
+	var mu sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	for i := 0; i < clampWorkers(workers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for subj := range jobs {
+				ws, found, err := pollWatchedSubject(c, subj)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				if found {
+					state.Subjects[subj] = ws
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return state, nil
+}
+
+// pollWatchedSubject reads one subject; found is false when it doesn't exist
+// (or has no live versions)
+func pollWatchedSubject(c client.SchemaRegistryClientInterface, subject string) (watchedSubject, bool, error) {
+	versions, err := c.GetVersions(subject, false)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return watchedSubject{}, false, nil
+		}
+		return watchedSubject{}, false, fmt.Errorf("failed to get versions for %s: %w", subject, err)
+	}
+	if len(versions) == 0 {
+		return watchedSubject{}, false, nil
+	}
+
+	cfg, err := c.GetSubjectConfig(subject, false)
+	if err != nil && !client.IsNotFound(err) {
+		return watchedSubject{}, false, fmt.Errorf("failed to get config for %s: %w", subject, err)
+	}
+	return watchedSubject{Versions: versions, Compatibility: compatibilityOf(cfg)}, true, nil
+}
+
+// diffWatchStates lists the changes from prev to cur: global config first,
+// then by subject and version
+func diffWatchStates(prev, cur *watchState, now time.Time) []WatchEvent {
+	var events []WatchEvent
+	if prev.GlobalCompatibility != cur.GlobalCompatibility {
+		events = append(events, WatchEvent{Time: now, Type: watchConfigChanged, From: prev.GlobalCompatibility, To: cur.GlobalCompatibility})
+	}
+
+	names := make(map[string]bool)
+	for s := range prev.Subjects {
+		names[s] = true
+	}
+	for s := range cur.Subjects {
+		names[s] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for s := range names {
+		sorted = append(sorted, s)
+	}
+	sort.Strings(sorted)
+
+	for _, subj := range sorted {
+		before, existed := prev.Subjects[subj]
+		after, exists := cur.Subjects[subj]
+		if !exists {
+			events = append(events, WatchEvent{Time: now, Type: watchSubjectDeleted, Subject: subj})
+			continue
+		}
+
+		old := make(map[int]bool, len(before.Versions))
+		for _, v := range before.Versions {
+			old[v] = true
+		}
+		live := make(map[int]bool, len(after.Versions))
+		for _, v := range after.Versions {
+			live[v] = true
+			if !old[v] {
+				events = append(events, WatchEvent{Time: now, Type: watchVersionAdded, Subject: subj, Version: v})
+			}
+		}
+		for _, v := range before.Versions {
+			if !live[v] {
+				events = append(events, WatchEvent{Time: now, Type: watchVersionDeleted, Subject: subj, Version: v})
+			}
+		}
+
+		if existed && before.Compatibility != after.Compatibility {
+			events = append(events, WatchEvent{Time: now, Type: watchConfigChanged, Subject: subj, From: before.Compatibility, To: after.Compatibility})
+		}
+	}
+	return events
+}
+
+// describeNewVersions fills in the schema ID and type of each added version;
+// a version deleted again before the lookup keeps them empty
+func describeNewVersions(c client.SchemaRegistryClientInterface, events []WatchEvent) {
+	for i := range events {
+		if events[i].Type != watchVersionAdded {
+			continue
+		}
+		schema, err := c.GetSchema(events[i].Subject, strconv.Itoa(events[i].Version))
+		if err != nil {
+			continue
+		}
+		events[i].SchemaID = schema.ID
+		events[i].SchemaType = schema.SchemaType
+		if events[i].SchemaType == "" {
+			events[i].SchemaType = "AVRO"
+		}
+	}
+}
+
+func compatibilityOf(cfg *client.Config) string {
+	if cfg == nil {
+		return ""
+	}
+	if cfg.CompatibilityLevel != "" {
+		return cfg.CompatibilityLevel
+	}
+	return cfg.Compatibility
+}
+
+// printWatchEvent prints one change: a JSON line with -o json, otherwise a
+// timestamped line such as "12:04:05 + orders-value v3 (ID 105, AVRO)"
+func printWatchEvent(ev WatchEvent) {
+	switch outputFormat {
+	case "table", "plain":
+		fmt.Printf("%s %s\n", ev.Time.Format("15:04:05"), formatWatchEvent(ev))
+	case "json":
+		line, err := json.Marshal(ev)
+		if err != nil {
+			output.Error("Failed to encode event: %v", err)
+			return
+		}
+		fmt.Println(string(line))
+	default:
+		if err := output.NewPrinter(outputFormat).Print(ev); err != nil {
+			output.Error("Failed to print event: %v", err)
+		}
+	}
+}
+
+func formatWatchEvent(ev WatchEvent) string {
+	switch ev.Type {
+	case watchVersionAdded:
+		if ev.SchemaID == 0 {
+			return fmt.Sprintf("+ %s v%d", ev.Subject, ev.Version)
+		}
+		return fmt.Sprintf("+ %s v%d (ID %d, %s)", ev.Subject, ev.Version, ev.SchemaID, ev.SchemaType)
+	case watchVersionDeleted:
+		return fmt.Sprintf("- %s v%d", ev.Subject, ev.Version)
+	case watchSubjectDeleted:
+		return fmt.Sprintf("- %s (subject deleted)", ev.Subject)
+	case watchConfigChanged:
+		target := "global"
+		if ev.Subject != "" {
+			target = ev.Subject
+		}
+		return fmt.Sprintf("~ %s compatibility %s -> %s", target, orNone(ev.From), orNone(ev.To))
+	}
+	return ev.Type
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/srctl/srctl/internal/client"
+)
+
+func TestWatchDetectsChanges(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders-value", 2)
+	addTestSubject(mock, "users-value", 1)
+
+	prev, err := pollWatchState(mock, "", 4)
+	if err != nil {
+		t.Fatalf("baseline poll: %v", err)
+	}
+	if len(prev.Subjects) != 2 || prev.GlobalCompatibility != "BACKWARD" {
+		t.Fatalf("unexpected baseline: %+v", prev)
+	}
+
+	// New version, deleted subject, new subject and config changes
+	mock.Subjects["orders-value"] = append(mock.Subjects["orders-value"], client.Schema{
+		Subject: "orders-value", Version: 3, ID: 200, Schema: `{"type":"string"}`, SchemaType: "AVRO",
+	})
+	delete(mock.Subjects, "users-value")
+	addTestSubject(mock, "payments-value", 1)
+	mock.SubjectConfigs["orders-value"] = &client.Config{CompatibilityLevel: "FULL"}
+	mock.GlobalConfig = &client.Config{CompatibilityLevel: "NONE"}
+
+	cur, err := pollWatchState(mock, "", 4)
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	now := time.Now()
+	events := diffWatchStates(prev, cur, now)
+	describeNewVersions(mock, events)
+
+	want := []WatchEvent{
+		{Type: watchConfigChanged, From: "BACKWARD", To: "NONE"},
+		{Type: watchVersionAdded, Subject: "orders-value", Version: 3, SchemaID: 200, SchemaType: "AVRO"},
+		{Type: watchConfigChanged, Subject: "orders-value", From: "", To: "FULL"},
+		{Type: watchVersionAdded, Subject: "payments-value", Version: 1, SchemaID: 100, SchemaType: "AVRO"},
+		{Type: watchSubjectDeleted, Subject: "users-value"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		w.Time = now
+		if events[i] != w {
+			t.Errorf("event %d = %+v, want %+v", i, events[i], w)
+		}
+	}
+}
+
+func TestWatchSingleSubject(t *testing.T) {
+	mock := client.NewMockClient()
+
+	// A subject that doesn't exist yet is watched as empty
+	prev, err := pollWatchState(mock, "orders-value", 1)
+	if err != nil {
+		t.Fatalf("baseline poll: %v", err)
+	}
+	if len(prev.Subjects) != 0 {
+		t.Fatalf("expected no subjects, got %+v", prev.Subjects)
+	}
+
+	addTestSubject(mock, "orders-value", 2)
+	addTestSubject(mock, "other-value", 1)
+	cur, err := pollWatchState(mock, "orders-value", 1)
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	events := diffWatchStates(prev, cur, time.Now())
+	if len(events) != 2 || events[0].Version != 1 || events[1].Version != 2 {
+		t.Fatalf("expected versions 1 and 2 of orders-value only, got %+v", events)
+	}
+
+	// Deleting one version
+	mock.Subjects["orders-value"] = mock.Subjects["orders-value"][1:]
+	next, err := pollWatchState(mock, "orders-value", 1)
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	events = diffWatchStates(cur, next, time.Now())
+	if len(events) != 1 || events[0].Type != watchVersionDeleted || events[0].Version != 1 {
+		t.Errorf("expected version 1 deleted, got %+v", events)
+	}
+}
+
+func TestWatchRegistryStopsOnCancel(t *testing.T) {
+	mock := client.NewMockClient()
+	addTestSubject(mock, "orders-value", 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var events []WatchEvent
+	polls, changes, err := watchRegistry(ctx, mock, "", 10*time.Millisecond, 1, func(ev WatchEvent) {
+		events = append(events, ev)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls < 2 {
+		t.Errorf("expected several polls, got %d", polls)
+	}
+	if changes != 0 || len(events) != 0 {
+		t.Errorf("expected no changes on an idle registry, got %+v", events)
+	}
+
+	// A failing baseline poll is an error
+	mock.ConfigError = context.DeadlineExceeded
+	if _, _, err := watchRegistry(context.Background(), mock, "", time.Second, 1, func(WatchEvent) {}); err == nil {
+		t.Error("expected error when the baseline poll fails")
+	}
+}
+
+func TestFormatWatchEvent(t *testing.T) {
+	tests := []struct {
+		ev   WatchEvent
+		want string
+	}{
+		{WatchEvent{Type: watchVersionAdded, Subject: "a", Version: 3, SchemaID: 7, SchemaType: "JSON"}, "+ a v3 (ID 7, JSON)"},
+		{WatchEvent{Type: watchVersionAdded, Subject: "a", Version: 3}, "+ a v3"},
+		{WatchEvent{Type: watchVersionDeleted, Subject: "a", Version: 2}, "- a v2"},
+		{WatchEvent{Type: watchSubjectDeleted, Subject: "a"}, "- a (subject deleted)"},
+		{WatchEvent{Type: watchConfigChanged, From: "BACKWARD", To: "NONE"}, "~ global compatibility BACKWARD -> NONE"},
+		{WatchEvent{Type: watchConfigChanged, Subject: "a", To: "FULL"}, "~ a compatibility (none) -> FULL"},
+	}
+	for _, tt := range tests {
+		if got := formatWatchEvent(tt.ev); got != tt.want {
+			t.Errorf("formatWatchEvent(%+v) = %q, want %q", tt.ev, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// responseCache memoizes successful GET responses by URL for the lifetime of
+// a client. Any other request clears it, so reads made after a write through
+// the same client never see stale data.
+type responseCache struct {
+	mu      sync.RWMutex
+	entries map[string][]byte
+	hits    int64
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string][]byte)}
+}
+
+func (rc *responseCache) get(url string) ([]byte, bool) {
+	rc.mu.RLock()
+	body, ok := rc.entries[url]
+	rc.mu.RUnlock()
+	if ok {
+		atomic.AddInt64(&rc.hits, 1)
+	}
+	return body, ok
+}
+
+func (rc *responseCache) put(url string, body []byte) {
+	rc.mu.Lock()
+	rc.entries[url] = body
+	rc.mu.Unlock()
+}
+
+func (rc *responseCache) clear() {
+	rc.mu.Lock()
+	rc.entries = make(map[string][]byte)
+	rc.mu.Unlock()
+}
+
+// EnableCache turns on in-memory caching of GET responses. Copies made with
+// WithContext share the cache; URLs include the context, so entries don't mix.
+func (c *SchemaRegistryClient) EnableCache() {
+	c.cache = newResponseCache()
+}
+
+// CacheHits returns how many requests were served from the response cache
+func (c *SchemaRegistryClient) CacheHits() int64 {
+	if c.cache == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&c.cache.hits)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -25,19 +26,76 @@ func truncateBody(b []byte) string {
 	return string(b[:maxLen]) + "... (truncated)"
 }
 
+// DefaultPageSize is the number of subjects or schemas requested per page
+const DefaultPageSize = 1000
+
 // SchemaRegistryClient is the main client for interacting with Schema Registry
 type SchemaRegistryClient struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	Auth       *AuthConfig
 	Context    string // Default context (empty for default context ".")
+
+	// Retry policy for transient failures (see shouldRetry). MaxRetries of 0
+	// disables retries.
+	MaxRetries   int
+	RetryBackoff time.Duration
+
+	// Normalize asks the registry to normalize schemas on registration, so
+	// formatting differences don't produce new schema IDs
+	Normalize bool
+
+	// PageSize is the offset/limit page size for subject and schema listings;
+	// 0 fetches each listing in a single request
+	PageSize int
+
+	// limiter caps the request rate; nil means unlimited
+	limiter *RateLimiter
+
+	// sleep waits between retries; replaced in tests
+	sleep func(time.Duration)
+
+	// tokens caches OAuth2 bearer tokens; nil when not using OAuth. Shared by
+	// copies made with WithContext so all of them reuse the same token.
+	tokens *tokenSource
+
+	// cache holds GET responses when enabled with EnableCache; nil otherwise
+	cache *responseCache
+
+	// logger receives request logs when enabled with EnableRequestLog; nil
+	// otherwise. logBodies adds request and response bodies.
+	logger    *slog.Logger
+	logBodies bool
 }
 
-// AuthConfig holds authentication configuration
+// AuthConfig holds authentication configuration.
+// When TokenURL is set the client authenticates with an OAuth2 bearer token
+// obtained via the client credentials grant; otherwise Username/Password are
+// sent as HTTP basic auth.
 type AuthConfig struct {
 	Username string
 	Password string
-	// Add more auth methods as needed (OAuth, mTLS, etc.)
+
+	// OAuth2 client credentials
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scope        string
+
+	// Mutual TLS: PEM-encoded client certificate and key, plus an optional
+	// CA bundle for verifying the registry's certificate
+	ClientCertFile string
+	ClientKeyFile  string
+	CACertFile     string
+
+	// InsecureSkipVerify disables verification of the registry's certificate,
+	// for development registries with self-signed certificates
+	InsecureSkipVerify bool
+}
+
+// UsesOAuth reports whether the config selects OAuth2 bearer-token auth
+func (a *AuthConfig) UsesOAuth() bool {
+	return a != nil && a.TokenURL != ""
 }
 
 // Schema represents a schema in the registry
@@ -51,6 +109,9 @@ type Schema struct {
 	Metadata   *SchemaMetadata   `json:"metadata,omitempty"`
 	RuleSet    *SchemaRuleSet    `json:"ruleSet,omitempty"`
 	Deleted    bool              `json:"deleted,omitempty"`
+	// Timestamp is the version's creation time in epoch milliseconds; only
+	// returned by registries that track it
+	Timestamp int64 `json:"ts,omitempty"`
 }
 
 // SchemaMetadata represents data contract metadata
@@ -120,10 +181,11 @@ func NewClient(baseURL string, auth *AuthConfig) *SchemaRegistryClient {
 			timeout = time.Duration(secs) * time.Second
 		}
 	}
-	return &SchemaRegistryClient{
+	c := &SchemaRegistryClient{
 		BaseURL: strings.TrimRight(baseURL, "/"),
 		HTTPClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: newTransport(),
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				// Prevent forwarding credentials to a different host on redirect
 				if len(via) > 0 && req.URL.Host != via[0].URL.Host {
@@ -135,9 +197,17 @@ func NewClient(baseURL string, auth *AuthConfig) *SchemaRegistryClient {
 				return nil
 			},
 		},
-		Auth:    auth,
-		Context: "",
+		Auth:         auth,
+		Context:      "",
+		MaxRetries:   DefaultMaxRetries,
+		RetryBackoff: DefaultRetryBackoff,
+		PageSize:     DefaultPageSize,
+		sleep:        time.Sleep,
+	}
+	if auth.UsesOAuth() {
+		c.tokens = newTokenSource(auth)
 	}
+	return c
 }
 
 // WithContext returns a copy of the client with a specific context
@@ -149,61 +219,187 @@ func (c *SchemaRegistryClient) WithContext(ctx string) *SchemaRegistryClient {
 
 // buildURL constructs the URL with optional context prefix
 func (c *SchemaRegistryClient) buildURL(path string) string {
-	if c.Context != "" && c.Context != "." {
+	return c.contextURL(c.Context, path)
+}
+
+func (c *SchemaRegistryClient) contextURL(ctx, path string) string {
+	if ctx != "" && ctx != "." {
 		// Context-aware URL: /contexts/{context}/...
-		return fmt.Sprintf("%s/contexts/%s%s", c.BaseURL, url.PathEscape(c.Context), path)
+		return fmt.Sprintf("%s/contexts/%s%s", c.BaseURL, url.PathEscape(ctx), path)
 	}
 	return c.BaseURL + path
 }
 
-// doRequest performs an HTTP request with authentication
+// subjectURL builds the URL for a subject-scoped path whose first verb is the
+// subject, e.g. subjectURL("/subjects/%s/versions/%d", subject, version).
+// The subject is path-escaped; further arguments are used as given. A
+// context-qualified subject (":.ctx:name") is sent to its own context's URL,
+// whatever the client's context, so any subject can be addressed by its full
+// name.
+func (c *SchemaRegistryClient) subjectURL(format, subject string, args ...interface{}) string {
+	ctx := c.Context
+	if qctx, name, ok := SplitQualifiedSubject(subject); ok {
+		ctx, subject = qctx, name
+	}
+	return c.contextURL(ctx, fmt.Sprintf(format, append([]interface{}{url.PathEscape(subject)}, args...)...))
+}
+
+// SplitQualifiedSubject splits a context-qualified subject (":.ctx:name")
+// into its context and name; ok is false for an unqualified subject
+func SplitQualifiedSubject(subject string) (ctx, name string, ok bool) {
+	if !strings.HasPrefix(subject, ":.") {
+		return "", subject, false
+	}
+	i := strings.Index(subject[1:], ":")
+	if i < 0 {
+		return "", subject, false
+	}
+	return subject[1 : i+1], subject[i+2:], true
+}
+
+// qualifiedSubject returns the subject in the registry's context-qualified
+// form (":.ctx:subject") when a context is set. Used where the context can't
+// be carried by the URL, such as catalog entity names.
+func (c *SchemaRegistryClient) qualifiedSubject(subject string) string {
+	if c.Context == "" || c.Context == "." || strings.HasPrefix(subject, ":.") {
+		return subject
+	}
+	return fmt.Sprintf(":%s:%s", c.Context, subject)
+}
+
+// doRequest performs an HTTP request with authentication, retrying transient
+// failures according to the client's retry policy (see shouldRetry). With the
+// response cache enabled, successful GETs are served from memory and any other
+// method invalidates the cache.
 func (c *SchemaRegistryClient) doRequest(method, urlPath string, body interface{}) ([]byte, int, error) {
-	var reqBody io.Reader
+	if c.cache != nil {
+		if method != http.MethodGet {
+			c.cache.clear()
+		} else if cached, ok := c.cache.get(urlPath); ok {
+			if c.logEnabled(slog.LevelDebug) {
+				c.logger.Debug("served from cache", "method", method, "url", redactURL(urlPath))
+			}
+			return cached, http.StatusOK, nil
+		}
+	}
+
+	var jsonBytes []byte
 	if body != nil {
-		jsonBytes, err := json.Marshal(body)
+		var err error
+		jsonBytes, err = json.Marshal(body)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		respBody, statusCode, header, err := c.attemptRequest(method, urlPath, jsonBytes)
+		if attempt >= c.MaxRetries || !shouldRetry(method, statusCode, err) {
+			if c.cache != nil && method == http.MethodGet && err == nil && statusCode == http.StatusOK {
+				c.cache.put(urlPath, respBody)
+			}
+			return respBody, statusCode, err
+		}
+
+		sleep := c.sleep
+		if sleep == nil {
+			sleep = time.Sleep
+		}
+		delay := c.retryDelay(attempt, header)
+		if c.logEnabled(slog.LevelInfo) {
+			reason := fmt.Sprintf("status %d", statusCode)
+			if err != nil {
+				reason = err.Error()
+			}
+			c.logger.Info("retrying request", "method", method, "url", redactURL(urlPath),
+				"retry", attempt+1, "max_retries", c.MaxRetries, "reason", reason, "delay", delay)
+		}
+		sleep(delay)
+	}
+}
+
+// attemptRequest performs one logical request, transparently refreshing an
+// OAuth token that the registry rejected.
+func (c *SchemaRegistryClient) attemptRequest(method, urlPath string, jsonBytes []byte) ([]byte, int, http.Header, error) {
+	if c.tokens == nil {
+		return c.sendRequest(method, urlPath, jsonBytes, "")
+	}
+
+	token, err := c.tokens.Token(c.HTTPClient)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	respBody, statusCode, header, err := c.sendRequest(method, urlPath, jsonBytes, token)
+	if err == nil && statusCode == http.StatusUnauthorized {
+		// The token may have been revoked or expired early; fetch a fresh one
+		// and try once more before surfacing the 401.
+		c.tokens.Invalidate(token)
+		if token, err = c.tokens.Token(c.HTTPClient); err != nil {
+			return nil, 0, nil, err
+		}
+		respBody, statusCode, header, err = c.sendRequest(method, urlPath, jsonBytes, token)
+	}
+	return respBody, statusCode, header, err
+}
+
+// sendRequest issues a single HTTP request. A non-empty bearerToken is sent
+// as the Authorization header; otherwise basic auth is applied if configured.
+func (c *SchemaRegistryClient) sendRequest(method, urlPath string, jsonBytes []byte, bearerToken string) ([]byte, int, http.Header, error) {
+	var reqBody io.Reader
+	if jsonBytes != nil {
 		reqBody = bytes.NewReader(jsonBytes)
 	}
 
 	req, err := http.NewRequest(method, urlPath, reqBody)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
 	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json")
 	req.Header.Set("Confluent-Accept-Unknown-Properties", "true")
 
-	if c.Auth != nil && c.Auth.Username != "" {
+	auth := "none"
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+		auth = "bearer"
+	} else if c.Auth != nil && c.Auth.Username != "" {
 		req.SetBasicAuth(c.Auth.Username, c.Auth.Password)
+		auth = "basic"
+	}
+
+	if c.limiter != nil {
+		c.limiter.Wait()
 	}
 
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("request failed: %w", err)
+		c.logRequest(method, urlPath, auth, jsonBytes, nil, 0, time.Since(start), err)
+		return nil, 0, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	const maxResponseSize = 50 * 1024 * 1024 // 50 MB
 	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	c.logRequest(method, urlPath, auth, jsonBytes, respBody, resp.StatusCode, time.Since(start), err)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return respBody, resp.StatusCode, nil
+	return respBody, resp.StatusCode, resp.Header, nil
 }
 
 // GetContexts returns all contexts in the registry
 func (c *SchemaRegistryClient) GetContexts() ([]string, error) {
+	// Contexts are listed registry-wide, regardless of c.Context
 	respBody, statusCode, err := c.doRequest("GET", c.BaseURL+"/contexts", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get contexts: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get contexts", statusCode, respBody)
 	}
 
 	var contexts []string
@@ -214,33 +410,88 @@ func (c *SchemaRegistryClient) GetContexts() ([]string, error) {
 	return contexts, nil
 }
 
-// GetSubjects returns all subjects, optionally filtered by prefix and including deleted
+// getPaged fetches a list endpoint in pages of c.PageSize using the
+// offset/limit query parameters, or in a single request when PageSize is 0.
+// Paging stops at a short page or one that adds nothing new, which also copes
+// with registries that ignore offset/limit and return everything every time.
+func getPaged[T any](c *SchemaRegistryClient, op, baseURL string, params url.Values, key func(T) string) ([]T, error) {
+	var all []T
+	seen := make(map[string]bool)
+
+	for offset := 0; ; offset += c.PageSize {
+		query := url.Values{}
+		for k, v := range params {
+			query[k] = v
+		}
+		if c.PageSize > 0 {
+			query.Set("offset", strconv.Itoa(offset))
+			query.Set("limit", strconv.Itoa(c.PageSize))
+		}
+		urlPath := baseURL
+		if len(query) > 0 {
+			urlPath += "?" + query.Encode()
+		}
+
+		respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
+		if err != nil {
+			return nil, err
+		}
+		if statusCode != http.StatusOK {
+			return nil, newAPIError(op, statusCode, respBody)
+		}
+
+		var page []T
+		if err := json.Unmarshal(respBody, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse %s response: %w", op, err)
+		}
+
+		added := 0
+		for _, item := range page {
+			k := key(item)
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			all = append(all, item)
+			added++
+		}
+
+		if c.PageSize <= 0 || added == 0 || len(page) < c.PageSize {
+			return all, nil
+		}
+	}
+}
+
+// GetSubjects returns all subjects, optionally including deleted ones. Large
+// registries are fetched in pages of PageSize.
 func (c *SchemaRegistryClient) GetSubjects(includeDeleted bool) ([]string, error) {
-	urlPath := c.buildURL("/subjects")
+	return c.GetSubjectsWithPrefix("", includeDeleted)
+}
+
+// GetSubjectsWithPrefix returns the subjects starting with prefix, filtered by
+// the registry (subjectPrefix). The match is case-sensitive; an empty prefix
+// returns every subject.
+func (c *SchemaRegistryClient) GetSubjectsWithPrefix(prefix string, includeDeleted bool) ([]string, error) {
+	params := url.Values{}
+	if prefix != "" {
+		params.Set("subjectPrefix", prefix)
+	}
 	if includeDeleted {
-		urlPath += "?deleted=true"
+		params.Set("deleted", "true")
 	}
-
-	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
+	subjects, err := getPaged(c, "get subjects", c.buildURL("/subjects"), params, func(s string) string { return s })
 	if err != nil {
 		return nil, err
 	}
-
-	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get subjects: %s (status %d)", truncateBody(respBody), statusCode)
-	}
-
-	var subjects []string
-	if err := json.Unmarshal(respBody, &subjects); err != nil {
-		return nil, fmt.Errorf("failed to parse subjects response: %w", err)
+	if subjects == nil {
+		subjects = []string{}
 	}
-
 	return subjects, nil
 }
 
 // GetVersions returns all versions for a subject
 func (c *SchemaRegistryClient) GetVersions(subject string, includeDeleted bool) ([]int, error) {
-	urlPath := c.buildURL(fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject)))
+	urlPath := c.subjectURL("/subjects/%s/versions", subject)
 	if includeDeleted {
 		urlPath += "?deleted=true"
 	}
@@ -251,7 +502,7 @@ func (c *SchemaRegistryClient) GetVersions(subject string, includeDeleted bool)
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get versions: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get versions", statusCode, respBody)
 	}
 
 	var versions []int
@@ -272,7 +523,7 @@ func (c *SchemaRegistryClient) GetSchema(subject string, version string) (*Schem
 
 // GetSchemaWithDeleted returns a schema, optionally including deleted schemas
 func (c *SchemaRegistryClient) GetSchemaWithDeleted(subject string, version string, includeDeleted bool) (*Schema, error) {
-	urlPath := c.buildURL(fmt.Sprintf("/subjects/%s/versions/%s", url.PathEscape(subject), url.PathEscape(version)))
+	urlPath := c.subjectURL("/subjects/%s/versions/%s", subject, url.PathEscape(version))
 	if includeDeleted {
 		urlPath += "?deleted=true"
 	}
@@ -283,7 +534,7 @@ func (c *SchemaRegistryClient) GetSchemaWithDeleted(subject string, version stri
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get schema: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get schema", statusCode, respBody)
 	}
 
 	var schema Schema
@@ -294,9 +545,43 @@ func (c *SchemaRegistryClient) GetSchemaWithDeleted(subject string, version stri
 	return &schema, nil
 }
 
+// SchemaVersionInfo identifies a schema version without its content
+type SchemaVersionInfo struct {
+	Subject    string `json:"subject"`
+	Version    int    `json:"version"`
+	ID         int    `json:"id"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+// GetLatestSchemaMetadata returns the subject, version, ID and type of the
+// latest version. The registry has no metadata-only endpoint, so the schema
+// body is still transferred, but it is skipped when decoding and never kept.
+func (c *SchemaRegistryClient) GetLatestSchemaMetadata(subject string) (*SchemaVersionInfo, error) {
+	urlPath := c.subjectURL("/subjects/%s/versions/latest", subject)
+
+	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, newAPIError("get schema metadata", statusCode, respBody)
+	}
+
+	var info SchemaVersionInfo
+	if err := json.Unmarshal(respBody, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse schema response: %w", err)
+	}
+	if info.SchemaType == "" {
+		info.SchemaType = "AVRO"
+	}
+
+	return &info, nil
+}
+
 // GetSchemaByID returns a schema by its global ID
 func (c *SchemaRegistryClient) GetSchemaByID(id int) (*Schema, error) {
-	urlPath := fmt.Sprintf("%s/schemas/ids/%d", c.BaseURL, id)
+	urlPath := c.buildURL(fmt.Sprintf("/schemas/ids/%d", id))
 
 	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
 	if err != nil {
@@ -304,7 +589,7 @@ func (c *SchemaRegistryClient) GetSchemaByID(id int) (*Schema, error) {
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get schema by ID: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get schema by ID", statusCode, respBody)
 	}
 
 	var schema Schema
@@ -318,7 +603,7 @@ func (c *SchemaRegistryClient) GetSchemaByID(id int) (*Schema, error) {
 
 // GetSchemaReferencedBy returns schema IDs that reference the given subject/version
 func (c *SchemaRegistryClient) GetSchemaReferencedBy(subject string, version int) ([]int, error) {
-	urlPath := c.buildURL(fmt.Sprintf("/subjects/%s/versions/%d/referencedby", url.PathEscape(subject), version))
+	urlPath := c.subjectURL("/subjects/%s/versions/%d/referencedby", subject, version)
 
 	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
 	if err != nil {
@@ -332,7 +617,7 @@ func (c *SchemaRegistryClient) GetSchemaReferencedBy(subject string, version int
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get referencedby: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get referencedby", statusCode, respBody)
 	}
 
 	var schemaIDs []int
@@ -345,7 +630,7 @@ func (c *SchemaRegistryClient) GetSchemaReferencedBy(subject string, version int
 
 // GetSchemaSubjectVersionsByID returns all subjects/versions that use a schema ID
 func (c *SchemaRegistryClient) GetSchemaSubjectVersionsByID(id int) ([]SubjectVersion, error) {
-	urlPath := fmt.Sprintf("%s/schemas/ids/%d/versions", c.BaseURL, id)
+	urlPath := c.buildURL(fmt.Sprintf("/schemas/ids/%d/versions", id))
 
 	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
 	if err != nil {
@@ -353,7 +638,7 @@ func (c *SchemaRegistryClient) GetSchemaSubjectVersionsByID(id int) ([]SubjectVe
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get schema versions by ID: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get schema versions by ID", statusCode, respBody)
 	}
 
 	var subjectVersions []SubjectVersion
@@ -366,7 +651,10 @@ func (c *SchemaRegistryClient) GetSchemaSubjectVersionsByID(id int) ([]SubjectVe
 
 // RegisterSchema registers a new schema under a subject
 func (c *SchemaRegistryClient) RegisterSchema(subject string, schema *Schema) (int, error) {
-	urlPath := c.buildURL(fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject)))
+	urlPath := c.subjectURL("/subjects/%s/versions", subject)
+	if c.Normalize {
+		urlPath += "?normalize=true"
+	}
 
 	reqBody := map[string]interface{}{
 		"schema": schema.Schema,
@@ -383,9 +671,13 @@ func (c *SchemaRegistryClient) RegisterSchema(subject string, schema *Schema) (i
 	if schema.RuleSet != nil {
 		reqBody["ruleSet"] = schema.RuleSet
 	}
-	// Include ID for IMPORT mode (requires registry to be in IMPORT mode)
+	// Include ID for IMPORT mode (requires registry to be in IMPORT mode),
+	// and the version too when one is given, so it is kept
 	if schema.ID > 0 {
 		reqBody["id"] = schema.ID
+		if schema.Version > 0 {
+			reqBody["version"] = schema.Version
+		}
 	}
 
 	respBody, statusCode, err := c.doRequest("POST", urlPath, reqBody)
@@ -394,7 +686,7 @@ func (c *SchemaRegistryClient) RegisterSchema(subject string, schema *Schema) (i
 	}
 
 	if statusCode != http.StatusOK {
-		return 0, fmt.Errorf("failed to register schema: %s (status %d)", truncateBody(respBody), statusCode)
+		return 0, newAPIError("register schema", statusCode, respBody)
 	}
 
 	var result struct {
@@ -409,7 +701,7 @@ func (c *SchemaRegistryClient) RegisterSchema(subject string, schema *Schema) (i
 
 // DeleteSubject deletes a subject (soft delete by default)
 func (c *SchemaRegistryClient) DeleteSubject(subject string, permanent bool) ([]int, error) {
-	urlPath := c.buildURL(fmt.Sprintf("/subjects/%s", url.PathEscape(subject)))
+	urlPath := c.subjectURL("/subjects/%s", subject)
 	if permanent {
 		urlPath += "?permanent=true"
 	}
@@ -420,7 +712,7 @@ func (c *SchemaRegistryClient) DeleteSubject(subject string, permanent bool) ([]
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to delete subject: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("delete subject", statusCode, respBody)
 	}
 
 	var versions []int
@@ -433,7 +725,7 @@ func (c *SchemaRegistryClient) DeleteSubject(subject string, permanent bool) ([]
 
 // DeleteVersion deletes a specific version (soft delete by default)
 func (c *SchemaRegistryClient) DeleteVersion(subject string, version string, permanent bool) (int, error) {
-	urlPath := c.buildURL(fmt.Sprintf("/subjects/%s/versions/%s", url.PathEscape(subject), url.PathEscape(version)))
+	urlPath := c.subjectURL("/subjects/%s/versions/%s", subject, url.PathEscape(version))
 	if permanent {
 		urlPath += "?permanent=true"
 	}
@@ -444,7 +736,7 @@ func (c *SchemaRegistryClient) DeleteVersion(subject string, version string, per
 	}
 
 	if statusCode != http.StatusOK {
-		return 0, fmt.Errorf("failed to delete version: %s (status %d)", truncateBody(respBody), statusCode)
+		return 0, newAPIError("delete version", statusCode, respBody)
 	}
 
 	var deletedVersion int
@@ -465,7 +757,7 @@ func (c *SchemaRegistryClient) GetConfig() (*Config, error) {
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get config: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get config", statusCode, respBody)
 	}
 
 	var config Config
@@ -481,7 +773,7 @@ func (c *SchemaRegistryClient) GetConfig() (*Config, error) {
 // override and falls back to the global config. Callers receive (nil, nil)
 // to signal "use global".
 func (c *SchemaRegistryClient) GetSubjectConfig(subject string, defaultToGlobal bool) (*Config, error) {
-	urlPath := c.buildURL(fmt.Sprintf("/config/%s", url.PathEscape(subject)))
+	urlPath := c.subjectURL("/config/%s", subject)
 	if defaultToGlobal {
 		urlPath += "?defaultToGlobal=true"
 	}
@@ -497,7 +789,7 @@ func (c *SchemaRegistryClient) GetSubjectConfig(subject string, defaultToGlobal
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get subject config: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get subject config", statusCode, respBody)
 	}
 
 	var config Config
@@ -519,7 +811,7 @@ func (c *SchemaRegistryClient) SetConfig(compatibility string) error {
 	}
 
 	if statusCode != http.StatusOK {
-		return fmt.Errorf("failed to set config: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("set config", statusCode, respBody)
 	}
 
 	return nil
@@ -527,7 +819,7 @@ func (c *SchemaRegistryClient) SetConfig(compatibility string) error {
 
 // SetSubjectConfig sets the compatibility configuration for a subject
 func (c *SchemaRegistryClient) SetSubjectConfig(subject string, compatibility string) error {
-	urlPath := c.buildURL(fmt.Sprintf("/config/%s", url.PathEscape(subject)))
+	urlPath := c.subjectURL("/config/%s", subject)
 	body := map[string]string{"compatibility": compatibility}
 
 	respBody, statusCode, err := c.doRequest("PUT", urlPath, body)
@@ -536,7 +828,24 @@ func (c *SchemaRegistryClient) SetSubjectConfig(subject string, compatibility st
 	}
 
 	if statusCode != http.StatusOK {
-		return fmt.Errorf("failed to set subject config: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("set subject config", statusCode, respBody)
+	}
+
+	return nil
+}
+
+// DeleteSubjectConfig removes a subject's compatibility override so it falls
+// back to the global level
+func (c *SchemaRegistryClient) DeleteSubjectConfig(subject string) error {
+	urlPath := c.subjectURL("/config/%s", subject)
+
+	respBody, statusCode, err := c.doRequest("DELETE", urlPath, nil)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK {
+		return newAPIError("delete subject config", statusCode, respBody)
 	}
 
 	return nil
@@ -552,7 +861,7 @@ func (c *SchemaRegistryClient) GetMode() (*Mode, error) {
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get mode: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get mode", statusCode, respBody)
 	}
 
 	var mode Mode
@@ -568,7 +877,7 @@ func (c *SchemaRegistryClient) GetMode() (*Mode, error) {
 // falls back to the global mode. Callers receive (nil, nil) to signal
 // "use global".
 func (c *SchemaRegistryClient) GetSubjectMode(subject string, defaultToGlobal bool) (*Mode, error) {
-	urlPath := c.buildURL(fmt.Sprintf("/mode/%s", url.PathEscape(subject)))
+	urlPath := c.subjectURL("/mode/%s", subject)
 	if defaultToGlobal {
 		urlPath += "?defaultToGlobal=true"
 	}
@@ -584,7 +893,7 @@ func (c *SchemaRegistryClient) GetSubjectMode(subject string, defaultToGlobal bo
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get subject mode: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get subject mode", statusCode, respBody)
 	}
 
 	var mode Mode
@@ -606,7 +915,7 @@ func (c *SchemaRegistryClient) SetMode(mode string) error {
 	}
 
 	if statusCode != http.StatusOK {
-		return fmt.Errorf("failed to set mode: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("set mode", statusCode, respBody)
 	}
 
 	return nil
@@ -614,7 +923,7 @@ func (c *SchemaRegistryClient) SetMode(mode string) error {
 
 // SetSubjectMode sets the mode for a subject
 func (c *SchemaRegistryClient) SetSubjectMode(subject string, mode string) error {
-	urlPath := c.buildURL(fmt.Sprintf("/mode/%s", url.PathEscape(subject)))
+	urlPath := c.subjectURL("/mode/%s", subject)
 	body := map[string]string{"mode": mode}
 
 	respBody, statusCode, err := c.doRequest("PUT", urlPath, body)
@@ -623,7 +932,24 @@ func (c *SchemaRegistryClient) SetSubjectMode(subject string, mode string) error
 	}
 
 	if statusCode != http.StatusOK {
-		return fmt.Errorf("failed to set subject mode: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("set subject mode", statusCode, respBody)
+	}
+
+	return nil
+}
+
+// DeleteSubjectMode removes a subject's mode override so it falls back to the
+// global mode
+func (c *SchemaRegistryClient) DeleteSubjectMode(subject string) error {
+	urlPath := c.subjectURL("/mode/%s", subject)
+
+	respBody, statusCode, err := c.doRequest("DELETE", urlPath, nil)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK {
+		return newAPIError("delete subject mode", statusCode, respBody)
 	}
 
 	return nil
@@ -631,7 +957,7 @@ func (c *SchemaRegistryClient) SetSubjectMode(subject string, mode string) error
 
 // CheckCompatibility checks if a schema is compatible with the latest version
 func (c *SchemaRegistryClient) CheckCompatibility(subject string, schema *Schema, version string) (bool, error) {
-	urlPath := c.buildURL(fmt.Sprintf("/compatibility/subjects/%s/versions/%s", url.PathEscape(subject), url.PathEscape(version)))
+	urlPath := c.subjectURL("/compatibility/subjects/%s/versions/%s", subject, url.PathEscape(version))
 
 	reqBody := map[string]interface{}{
 		"schema": schema.Schema,
@@ -649,7 +975,7 @@ func (c *SchemaRegistryClient) CheckCompatibility(subject string, schema *Schema
 	}
 
 	if statusCode != http.StatusOK {
-		return false, fmt.Errorf("failed to check compatibility: %s (status %d)", truncateBody(respBody), statusCode)
+		return false, newAPIError("check compatibility", statusCode, respBody)
 	}
 
 	var result struct {
@@ -664,10 +990,29 @@ func (c *SchemaRegistryClient) CheckCompatibility(subject string, schema *Schema
 
 // GetAllSchemas returns all schemas in the registry (for stats)
 func (c *SchemaRegistryClient) GetAllSchemas(includeDeleted bool) ([]Schema, error) {
-	urlPath := c.BaseURL + "/schemas"
+	params := url.Values{}
 	if includeDeleted {
-		urlPath += "?deleted=true"
+		params.Set("deleted", "true")
+	}
+	return getPaged(c, "get all schemas", c.buildURL("/schemas"), params, func(s Schema) string {
+		return fmt.Sprintf("%s:%d", s.Subject, s.Version)
+	})
+}
+
+// GetSchemasPage returns one page of schemas from the bulk /schemas endpoint,
+// optionally limited to subjects starting with subjectPrefix. Registries that
+// ignore offset/limit return everything on the first page.
+func (c *SchemaRegistryClient) GetSchemasPage(subjectPrefix string, includeDeleted bool, offset, limit int) ([]Schema, error) {
+	params := url.Values{}
+	if subjectPrefix != "" {
+		params.Set("subjectPrefix", subjectPrefix)
 	}
+	if includeDeleted {
+		params.Set("deleted", "true")
+	}
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("limit", strconv.Itoa(limit))
+	urlPath := c.buildURL("/schemas") + "?" + params.Encode()
 
 	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
 	if err != nil {
@@ -675,7 +1020,7 @@ func (c *SchemaRegistryClient) GetAllSchemas(includeDeleted bool) ([]Schema, err
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get all schemas: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get schemas", statusCode, respBody)
 	}
 
 	var schemas []Schema
@@ -688,6 +1033,7 @@ func (c *SchemaRegistryClient) GetAllSchemas(includeDeleted bool) ([]Schema, err
 
 // GetSchemaTypes returns all registered schema types
 func (c *SchemaRegistryClient) GetSchemaTypes() ([]string, error) {
+	// Schema types are registry-wide, not per context
 	urlPath := c.BaseURL + "/schemas/types"
 
 	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
@@ -696,7 +1042,7 @@ func (c *SchemaRegistryClient) GetSchemaTypes() ([]string, error) {
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get schema types: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get schema types", statusCode, respBody)
 	}
 
 	var types []string
@@ -721,7 +1067,8 @@ type TagAssignment struct {
 	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
-// GetTags returns all tag definitions
+// GetTags returns all tag definitions. Tag definitions are registry-wide;
+// assignments are scoped to the client's context (see qualifiedSubject).
 func (c *SchemaRegistryClient) GetTags() ([]Tag, error) {
 	urlPath := c.BaseURL + "/catalog/v1/types/tagdefs"
 
@@ -731,7 +1078,7 @@ func (c *SchemaRegistryClient) GetTags() ([]Tag, error) {
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get tags: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get tags", statusCode, respBody)
 	}
 
 	var tags []Tag
@@ -752,7 +1099,7 @@ func (c *SchemaRegistryClient) CreateTag(tag *Tag) error {
 	}
 
 	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
-		return fmt.Errorf("failed to create tag: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("create tag", statusCode, respBody)
 	}
 
 	return nil
@@ -768,7 +1115,7 @@ func (c *SchemaRegistryClient) DeleteTag(tagName string) error {
 	}
 
 	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete tag: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("delete tag", statusCode, respBody)
 	}
 
 	return nil
@@ -776,7 +1123,7 @@ func (c *SchemaRegistryClient) DeleteTag(tagName string) error {
 
 // GetSubjectTags returns tags assigned to a subject
 func (c *SchemaRegistryClient) GetSubjectTags(subject string) ([]TagAssignment, error) {
-	qualifiedName := fmt.Sprintf("lsrc:%s", subject)
+	qualifiedName := fmt.Sprintf("lsrc:%s", c.qualifiedSubject(subject))
 	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/sr_subject/name/%s/tags", c.BaseURL, url.PathEscape(qualifiedName))
 
 	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
@@ -790,7 +1137,7 @@ func (c *SchemaRegistryClient) GetSubjectTags(subject string) ([]TagAssignment,
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get subject tags: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get subject tags", statusCode, respBody)
 	}
 
 	var tags []TagAssignment
@@ -801,14 +1148,12 @@ func (c *SchemaRegistryClient) GetSubjectTags(subject string) ([]TagAssignment,
 	return tags, nil
 }
 
-// AssignTagToSubject assigns a tag to a subject
-func (c *SchemaRegistryClient) AssignTagToSubject(subject, tagName string) error {
-	qualifiedName := fmt.Sprintf("lsrc:%s", subject)
+// AssignTagToSubject assigns a tag, with optional attributes, to a subject
+func (c *SchemaRegistryClient) AssignTagToSubject(subject, tagName string, attributes map[string]string) error {
+	qualifiedName := fmt.Sprintf("lsrc:%s", c.qualifiedSubject(subject))
 	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/sr_subject/name/%s/tags", c.BaseURL, url.PathEscape(qualifiedName))
 
-	body := []map[string]string{
-		{"typeName": tagName},
-	}
+	body := tagAssignmentBody(tagName, attributes)
 
 	respBody, statusCode, err := c.doRequest("POST", urlPath, body)
 	if err != nil {
@@ -816,15 +1161,25 @@ func (c *SchemaRegistryClient) AssignTagToSubject(subject, tagName string) error
 	}
 
 	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
-		return fmt.Errorf("failed to assign tag: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("assign tag", statusCode, respBody)
 	}
 
 	return nil
 }
 
+// tagAssignmentBody is the request body assigning one tag; attributes are
+// only sent when there are some
+func tagAssignmentBody(tagName string, attributes map[string]string) []map[string]interface{} {
+	tag := map[string]interface{}{"typeName": tagName}
+	if len(attributes) > 0 {
+		tag["attributes"] = attributes
+	}
+	return []map[string]interface{}{tag}
+}
+
 // RemoveTagFromSubject removes a tag from a subject
 func (c *SchemaRegistryClient) RemoveTagFromSubject(subject, tagName string) error {
-	qualifiedName := fmt.Sprintf("lsrc:%s", subject)
+	qualifiedName := fmt.Sprintf("lsrc:%s", c.qualifiedSubject(subject))
 	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/sr_subject/name/%s/tags/%s", c.BaseURL, url.PathEscape(qualifiedName), url.PathEscape(tagName))
 
 	respBody, statusCode, err := c.doRequest("DELETE", urlPath, nil)
@@ -833,7 +1188,7 @@ func (c *SchemaRegistryClient) RemoveTagFromSubject(subject, tagName string) err
 	}
 
 	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to remove tag: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("remove tag", statusCode, respBody)
 	}
 
 	return nil
@@ -841,7 +1196,7 @@ func (c *SchemaRegistryClient) RemoveTagFromSubject(subject, tagName string) err
 
 // GetSchemaTags returns tags assigned to a specific schema version
 func (c *SchemaRegistryClient) GetSchemaTags(subject string, version int) ([]TagAssignment, error) {
-	qualifiedName := fmt.Sprintf("lsrc:%s:%d", subject, version)
+	qualifiedName := fmt.Sprintf("lsrc:%s:%d", c.qualifiedSubject(subject), version)
 	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/sr_schema/name/%s/tags", c.BaseURL, url.PathEscape(qualifiedName))
 
 	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
@@ -855,7 +1210,7 @@ func (c *SchemaRegistryClient) GetSchemaTags(subject string, version int) ([]Tag
 	}
 
 	if statusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get schema tags: %s (status %d)", truncateBody(respBody), statusCode)
+		return nil, newAPIError("get schema tags", statusCode, respBody)
 	}
 
 	var tags []TagAssignment
@@ -866,14 +1221,12 @@ func (c *SchemaRegistryClient) GetSchemaTags(subject string, version int) ([]Tag
 	return tags, nil
 }
 
-// AssignTagToSchema assigns a tag to a specific schema version
-func (c *SchemaRegistryClient) AssignTagToSchema(subject string, version int, tagName string) error {
-	qualifiedName := fmt.Sprintf("lsrc:%s:%d", subject, version)
+// AssignTagToSchema assigns a tag, with optional attributes, to a specific schema version
+func (c *SchemaRegistryClient) AssignTagToSchema(subject string, version int, tagName string, attributes map[string]string) error {
+	qualifiedName := fmt.Sprintf("lsrc:%s:%d", c.qualifiedSubject(subject), version)
 	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/sr_schema/name/%s/tags", c.BaseURL, url.PathEscape(qualifiedName))
 
-	body := []map[string]string{
-		{"typeName": tagName},
-	}
+	body := tagAssignmentBody(tagName, attributes)
 
 	respBody, statusCode, err := c.doRequest("POST", urlPath, body)
 	if err != nil {
@@ -881,7 +1234,7 @@ func (c *SchemaRegistryClient) AssignTagToSchema(subject string, version int, ta
 	}
 
 	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
-		return fmt.Errorf("failed to assign tag to schema: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("assign tag to schema", statusCode, respBody)
 	}
 
 	return nil
@@ -889,7 +1242,7 @@ func (c *SchemaRegistryClient) AssignTagToSchema(subject string, version int, ta
 
 // RemoveTagFromSchema removes a tag from a specific schema version
 func (c *SchemaRegistryClient) RemoveTagFromSchema(subject string, version int, tagName string) error {
-	qualifiedName := fmt.Sprintf("lsrc:%s:%d", subject, version)
+	qualifiedName := fmt.Sprintf("lsrc:%s:%d", c.qualifiedSubject(subject), version)
 	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/sr_schema/name/%s/tags/%s", c.BaseURL, url.PathEscape(qualifiedName), url.PathEscape(tagName))
 
 	respBody, statusCode, err := c.doRequest("DELETE", urlPath, nil)
@@ -898,7 +1251,242 @@ func (c *SchemaRegistryClient) RemoveTagFromSchema(subject string, version int,
 	}
 
 	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to remove tag from schema: %s (status %d)", truncateBody(respBody), statusCode)
+		return newAPIError("remove tag from schema", statusCode, respBody)
+	}
+
+	return nil
+}
+
+// GetTopicTags returns tags assigned to a Kafka topic. topic is the catalog's
+// qualified name for the kafka_topic entity, i.e. "<kafka-cluster-id>:<topic>".
+func (c *SchemaRegistryClient) GetTopicTags(topic string) ([]TagAssignment, error) {
+	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/kafka_topic/name/%s/tags", c.BaseURL, url.PathEscape(topic))
+
+	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNotFound {
+		// List endpoint: 404 means no tags are assigned (empty list).
+		return []TagAssignment{}, nil
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, newAPIError("get topic tags", statusCode, respBody)
+	}
+
+	var tags []TagAssignment
+	if err := json.Unmarshal(respBody, &tags); err != nil {
+		return nil, fmt.Errorf("failed to parse topic tags response: %w", err)
+	}
+
+	return tags, nil
+}
+
+// AssignTagToTopic assigns a tag, with optional attributes, to a Kafka topic
+// (see GetTopicTags for the name)
+func (c *SchemaRegistryClient) AssignTagToTopic(topic, tagName string, attributes map[string]string) error {
+	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/kafka_topic/name/%s/tags", c.BaseURL, url.PathEscape(topic))
+
+	body := tagAssignmentBody(tagName, attributes)
+
+	respBody, statusCode, err := c.doRequest("POST", urlPath, body)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
+		return newAPIError("assign tag to topic", statusCode, respBody)
+	}
+
+	return nil
+}
+
+// RemoveTagFromTopic removes a tag from a Kafka topic (see GetTopicTags for the name)
+func (c *SchemaRegistryClient) RemoveTagFromTopic(topic, tagName string) error {
+	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/kafka_topic/name/%s/tags/%s", c.BaseURL, url.PathEscape(topic), url.PathEscape(tagName))
+
+	respBody, statusCode, err := c.doRequest("DELETE", urlPath, nil)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		return newAPIError("remove tag from topic", statusCode, respBody)
+	}
+
+	return nil
+}
+
+// BusinessMetadataDef is a catalog business metadata definition: a named set
+// of attributes that can be attached to subjects and schemas
+type BusinessMetadataDef struct {
+	Name          string                         `json:"name"`
+	Description   string                         `json:"description,omitempty"`
+	AttributeDefs []BusinessMetadataAttributeDef `json:"attributeDefs,omitempty"`
+}
+
+// BusinessMetadataAttributeDef defines one attribute of a business metadata definition
+type BusinessMetadataAttributeDef struct {
+	Name        string            `json:"name"`
+	TypeName    string            `json:"typeName"`
+	IsOptional  bool              `json:"isOptional,omitempty"`
+	Cardinality string            `json:"cardinality,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+}
+
+// BusinessMetadata is business metadata attached to a catalog entity
+type BusinessMetadata struct {
+	TypeName   string            `json:"typeName"`
+	EntityType string            `json:"entityType,omitempty"`
+	EntityName string            `json:"entityName,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// GetBusinessMetadataDefs returns all business metadata definitions. Like tag
+// definitions they are registry-wide.
+func (c *SchemaRegistryClient) GetBusinessMetadataDefs() ([]BusinessMetadataDef, error) {
+	urlPath := c.BaseURL + "/catalog/v1/types/businessmetadatadefs"
+
+	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, newAPIError("get business metadata definitions", statusCode, respBody)
+	}
+
+	var defs []BusinessMetadataDef
+	if err := json.Unmarshal(respBody, &defs); err != nil {
+		return nil, fmt.Errorf("failed to parse business metadata definitions response: %w", err)
+	}
+
+	return defs, nil
+}
+
+// CreateBusinessMetadataDef creates a business metadata definition
+func (c *SchemaRegistryClient) CreateBusinessMetadataDef(def *BusinessMetadataDef) error {
+	urlPath := c.BaseURL + "/catalog/v1/types/businessmetadatadefs"
+
+	respBody, statusCode, err := c.doRequest("POST", urlPath, []BusinessMetadataDef{*def})
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
+		return newAPIError("create business metadata definition", statusCode, respBody)
+	}
+
+	return nil
+}
+
+// DeleteBusinessMetadataDef deletes a business metadata definition
+func (c *SchemaRegistryClient) DeleteBusinessMetadataDef(name string) error {
+	urlPath := fmt.Sprintf("%s/catalog/v1/types/businessmetadatadefs/%s", c.BaseURL, url.PathEscape(name))
+
+	respBody, statusCode, err := c.doRequest("DELETE", urlPath, nil)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		return newAPIError("delete business metadata definition", statusCode, respBody)
+	}
+
+	return nil
+}
+
+// GetSubjectBusinessMetadata returns the business metadata attached to a subject
+func (c *SchemaRegistryClient) GetSubjectBusinessMetadata(subject string) ([]BusinessMetadata, error) {
+	return c.getBusinessMetadata("sr_subject", fmt.Sprintf("lsrc:%s", c.qualifiedSubject(subject)))
+}
+
+// AssignBusinessMetadataToSubject attaches business metadata to a subject
+func (c *SchemaRegistryClient) AssignBusinessMetadataToSubject(subject, name string, attributes map[string]string) error {
+	return c.assignBusinessMetadata("sr_subject", fmt.Sprintf("lsrc:%s", c.qualifiedSubject(subject)), name, attributes)
+}
+
+// RemoveBusinessMetadataFromSubject detaches business metadata from a subject
+func (c *SchemaRegistryClient) RemoveBusinessMetadataFromSubject(subject, name string) error {
+	return c.removeBusinessMetadata("sr_subject", fmt.Sprintf("lsrc:%s", c.qualifiedSubject(subject)), name)
+}
+
+// GetSchemaBusinessMetadata returns the business metadata attached to a schema version
+func (c *SchemaRegistryClient) GetSchemaBusinessMetadata(subject string, version int) ([]BusinessMetadata, error) {
+	return c.getBusinessMetadata("sr_schema", fmt.Sprintf("lsrc:%s:%d", c.qualifiedSubject(subject), version))
+}
+
+// AssignBusinessMetadataToSchema attaches business metadata to a schema version
+func (c *SchemaRegistryClient) AssignBusinessMetadataToSchema(subject string, version int, name string, attributes map[string]string) error {
+	return c.assignBusinessMetadata("sr_schema", fmt.Sprintf("lsrc:%s:%d", c.qualifiedSubject(subject), version), name, attributes)
+}
+
+// RemoveBusinessMetadataFromSchema detaches business metadata from a schema version
+func (c *SchemaRegistryClient) RemoveBusinessMetadataFromSchema(subject string, version int, name string) error {
+	return c.removeBusinessMetadata("sr_schema", fmt.Sprintf("lsrc:%s:%d", c.qualifiedSubject(subject), version), name)
+}
+
+func (c *SchemaRegistryClient) getBusinessMetadata(entityType, entityName string) ([]BusinessMetadata, error) {
+	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/%s/name/%s/businessmetadata", c.BaseURL, entityType, url.PathEscape(entityName))
+
+	respBody, statusCode, err := c.doRequest("GET", urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusNotFound {
+		// List endpoint: 404 means nothing is attached (empty list).
+		return []BusinessMetadata{}, nil
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, newAPIError("get business metadata", statusCode, respBody)
+	}
+
+	var metadata []BusinessMetadata
+	if err := json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse business metadata response: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// assignBusinessMetadata attaches business metadata through the bulk entity
+// endpoint, which (unlike tags) names the entity in the body
+func (c *SchemaRegistryClient) assignBusinessMetadata(entityType, entityName, name string, attributes map[string]string) error {
+	urlPath := c.BaseURL + "/catalog/v1/entity/businessmetadata"
+
+	body := []BusinessMetadata{{
+		TypeName:   name,
+		EntityType: entityType,
+		EntityName: entityName,
+		Attributes: attributes,
+	}}
+
+	respBody, statusCode, err := c.doRequest("POST", urlPath, body)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK && statusCode != http.StatusCreated {
+		return newAPIError("assign business metadata", statusCode, respBody)
+	}
+
+	return nil
+}
+
+func (c *SchemaRegistryClient) removeBusinessMetadata(entityType, entityName, name string) error {
+	urlPath := fmt.Sprintf("%s/catalog/v1/entity/type/%s/name/%s/businessmetadata/%s", c.BaseURL, entityType, url.PathEscape(entityName), url.PathEscape(name))
+
+	respBody, statusCode, err := c.doRequest("DELETE", urlPath, nil)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK && statusCode != http.StatusNoContent {
+		return newAPIError("remove business metadata", statusCode, respBody)
 	}
 
 	return nil
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Schema Registry error codes, returned as error_code in error responses
+const (
+	ErrCodeSubjectNotFound                = 40401
+	ErrCodeVersionNotFound                = 40402
+	ErrCodeSchemaNotFound                 = 40403
+	ErrCodeSubjectSoftDeleted             = 40404
+	ErrCodeSubjectNotSoftDeleted          = 40405
+	ErrCodeVersionSoftDeleted             = 40406
+	ErrCodeVersionNotSoftDeleted          = 40407
+	ErrCodeSubjectCompatibilityNotDefined = 40408
+	ErrCodeSubjectModeNotDefined          = 40409
+	ErrCodeIncompatibleSchema             = 409
+	ErrCodeInvalidSchema                  = 42201
+	ErrCodeInvalidVersion                 = 42202
+	ErrCodeInvalidCompatibility           = 42203
+	ErrCodeInvalidMode                    = 42204
+	ErrCodeOperationNotPermitted          = 42205
+	ErrCodeReferenceExists                = 42206
+	ErrCodeIDDoesNotMatch                 = 42207
+)
+
+// APIError is a non-success response from the registry. StatusCode is the
+// HTTP status; ErrorCode and Message come from the JSON error body when the
+// registry sends one.
+type APIError struct {
+	Op         string // what was attempted, e.g. "delete subject"
+	StatusCode int
+	ErrorCode  int
+	Message    string
+	Body       string // raw response body, truncated
+}
+
+func (e *APIError) Error() string {
+	detail := e.Body
+	if detail == "" {
+		detail = e.Message
+	}
+	if e.Op == "" {
+		return fmt.Sprintf("%s (status %d)", detail, e.StatusCode)
+	}
+	return fmt.Sprintf("failed to %s: %s (status %d)", e.Op, detail, e.StatusCode)
+}
+
+// newAPIError builds an APIError from a response, parsing the registry's
+// {"error_code": ..., "message": ...} body when present
+func newAPIError(op string, statusCode int, body []byte) *APIError {
+	e := &APIError{Op: op, StatusCode: statusCode, Body: truncateBody(body)}
+	var parsed struct {
+		ErrorCode int    `json:"error_code"`
+		Message   string `json:"message"`
+	}
+	if json.Unmarshal(body, &parsed) == nil {
+		e.ErrorCode = parsed.ErrorCode
+		e.Message = parsed.Message
+	}
+	if e.Message == "" {
+		e.Message = e.Body
+	}
+	return e
+}
+
+// AsAPIError returns the APIError in err's chain, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// HasErrorCode reports whether err is an APIError with one of the codes
+func HasErrorCode(err error, codes ...int) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.ErrorCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// HasStatus reports whether err is an APIError with one of the HTTP statuses
+func HasStatus(err error, statuses ...int) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	for _, status := range statuses {
+		if apiErr.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether the registry answered 404
+func IsNotFound(err error) bool {
+	return HasStatus(err, http.StatusNotFound)
+}
+
+// IsSubjectNotFound reports whether the subject doesn't exist (40401)
+func IsSubjectNotFound(err error) bool {
+	return HasErrorCode(err, ErrCodeSubjectNotFound)
+}
+
+// IsVersionNotFound reports whether the subject exists but the version doesn't
+// (40402)
+func IsVersionNotFound(err error) bool {
+	return HasErrorCode(err, ErrCodeVersionNotFound)
+}
+
+// IsSchemaNotFound reports whether no schema has the requested ID (40403)
+func IsSchemaNotFound(err error) bool {
+	return HasErrorCode(err, ErrCodeSchemaNotFound)
+}
+
+// IsIncompatible reports whether a registration was rejected because the
+// schema breaks the subject's compatibility rules (409)
+func IsIncompatible(err error) bool {
+	return HasErrorCode(err, ErrCodeIncompatibleSchema)
+}
+
+// IsSoftDeleted reports whether a soft delete failed because the subject or
+// version is already soft-deleted
+func IsSoftDeleted(err error) bool {
+	return HasErrorCode(err, ErrCodeSubjectSoftDeleted, ErrCodeVersionSoftDeleted)
+}
+
+// IsConflict reports whether the registry answered 409 for something other
+// than an incompatible schema, e.g. a tag that already exists
+func IsConflict(err error) bool {
+	return HasStatus(err, http.StatusConflict) && !IsIncompatible(err)
+}
@@ -5,17 +5,20 @@ package client
 type SchemaRegistryClientInterface interface {
 	// Subjects
 	GetSubjects(includeDeleted bool) ([]string, error)
+	GetSubjectsWithPrefix(prefix string, includeDeleted bool) ([]string, error)
 	GetVersions(subject string, includeDeleted bool) ([]int, error)
 
 	// Schemas
 	GetSchema(subject string, version string) (*Schema, error)
 	GetSchemaWithDeleted(subject string, version string, includeDeleted bool) (*Schema, error)
 	GetSchemaByID(id int) (*Schema, error)
+	GetLatestSchemaMetadata(subject string) (*SchemaVersionInfo, error)
 	GetSchemaSubjectVersionsByID(id int) ([]SubjectVersion, error)
 	GetSchemaReferencedBy(subject string, version int) ([]int, error)
 	RegisterSchema(subject string, schema *Schema) (int, error)
 	CheckCompatibility(subject string, schema *Schema, version string) (bool, error)
 	GetAllSchemas(includeDeleted bool) ([]Schema, error)
+	GetSchemasPage(subjectPrefix string, includeDeleted bool, offset, limit int) ([]Schema, error)
 	GetSchemaTypes() ([]string, error)
 
 	// Subjects operations
@@ -27,12 +30,14 @@ type SchemaRegistryClientInterface interface {
 	GetSubjectConfig(subject string, defaultToGlobal bool) (*Config, error)
 	SetConfig(compatibility string) error
 	SetSubjectConfig(subject string, compatibility string) error
+	DeleteSubjectConfig(subject string) error
 
 	// Mode
 	GetMode() (*Mode, error)
 	GetSubjectMode(subject string, defaultToGlobal bool) (*Mode, error)
 	SetMode(mode string) error
 	SetSubjectMode(subject string, mode string) error
+	DeleteSubjectMode(subject string) error
 
 	// Contexts
 	GetContexts() ([]string, error)
@@ -42,11 +47,25 @@ type SchemaRegistryClientInterface interface {
 	CreateTag(tag *Tag) error
 	DeleteTag(name string) error
 	GetSubjectTags(subject string) ([]TagAssignment, error)
-	AssignTagToSubject(subject, tagName string) error
+	AssignTagToSubject(subject, tagName string, attributes map[string]string) error
 	RemoveTagFromSubject(subject, tagName string) error
 	GetSchemaTags(subject string, version int) ([]TagAssignment, error)
-	AssignTagToSchema(subject string, version int, tagName string) error
+	AssignTagToSchema(subject string, version int, tagName string, attributes map[string]string) error
 	RemoveTagFromSchema(subject string, version int, tagName string) error
+	GetTopicTags(topic string) ([]TagAssignment, error)
+	AssignTagToTopic(topic, tagName string, attributes map[string]string) error
+	RemoveTagFromTopic(topic, tagName string) error
+
+	// Business metadata
+	GetBusinessMetadataDefs() ([]BusinessMetadataDef, error)
+	CreateBusinessMetadataDef(def *BusinessMetadataDef) error
+	DeleteBusinessMetadataDef(name string) error
+	GetSubjectBusinessMetadata(subject string) ([]BusinessMetadata, error)
+	AssignBusinessMetadataToSubject(subject, name string, attributes map[string]string) error
+	RemoveBusinessMetadataFromSubject(subject, name string) error
+	GetSchemaBusinessMetadata(subject string, version int) ([]BusinessMetadata, error)
+	AssignBusinessMetadataToSchema(subject string, version int, name string, attributes map[string]string) error
+	RemoveBusinessMetadataFromSchema(subject string, version int, name string) error
 }
 
 // Ensure MockSchemaRegistryClient implements the interface
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// EnableRequestLog logs every HTTP request the client sends to l at debug
+// level: method, URL, status, duration and the kind of auth used. With bodies
+// set, request and response bodies are logged too, truncated, with
+// secret-looking JSON fields redacted. Credentials are never logged.
+// Copies made with WithContext log to the same logger.
+func (c *SchemaRegistryClient) EnableRequestLog(l *slog.Logger, bodies bool) {
+	c.logger = l
+	c.logBodies = bodies
+}
+
+// logEnabled reports whether messages at level would be logged
+func (c *SchemaRegistryClient) logEnabled(level slog.Level) bool {
+	return c.logger != nil && c.logger.Enabled(context.Background(), level)
+}
+
+// logRequest logs one HTTP exchange; status is 0 and err set when no
+// response arrived
+func (c *SchemaRegistryClient) logRequest(method, rawURL, auth string, reqBody, respBody []byte, status int, elapsed time.Duration, err error) {
+	if !c.logEnabled(slog.LevelDebug) {
+		return
+	}
+	attrs := []interface{}{
+		"method", method,
+		"url", redactURL(rawURL),
+		"auth", auth,
+		"duration", elapsed.Round(time.Millisecond),
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err.Error())
+	} else {
+		attrs = append(attrs, "status", status)
+	}
+	if c.logBodies {
+		if len(reqBody) > 0 {
+			attrs = append(attrs, "request_body", redactBody(reqBody))
+		}
+		if len(respBody) > 0 {
+			attrs = append(attrs, "response_body", redactBody(respBody))
+		}
+	}
+	c.logger.Debug("http request", attrs...)
+}
+
+// redactURL hides userinfo and secret-looking query parameters
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "(unparseable URL)"
+	}
+	if q := u.Query(); len(q) > 0 {
+		for key := range q {
+			if isSecretKey(key) {
+				q.Set(key, "REDACTED")
+			}
+		}
+		u.RawQuery = q.Encode()
+	}
+	return u.Redacted()
+}
+
+// redactBody returns a body for logging: JSON with secret-looking fields
+// replaced, or the raw text, cut to the same length as error bodies
+func redactBody(b []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return truncateBody(b)
+	}
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return truncateBody(b)
+	}
+	return truncateBody(redacted)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if isSecretKey(k) {
+				t[k] = "REDACTED"
+			} else {
+				t[k] = redactValue(val)
+			}
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = redactValue(t[i])
+		}
+	}
+	return v
+}
+
+// isSecretKey matches field and parameter names that usually hold
+// credentials, e.g. "password", "client_secret", "basic.auth.user.info"
+func isSecretKey(key string) bool {
+	k := strings.ToLower(key)
+	for _, s := range []string{"password", "secret", "token", "credential", "user.info", "userinfo", "api.key", "apikey", "private"} {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
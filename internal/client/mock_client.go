@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 )
 
@@ -18,7 +19,10 @@ type MockSchemaRegistryClient struct {
 	GlobalMode     *Mode
 	Contexts       []string
 	Tags           []Tag
-	TagAssignments map[string][]TagAssignment // key: "subject" or "subject:version"
+	TagAssignments map[string][]TagAssignment // key: "subject", "subject:version" or "topic:<name>"
+
+	BusinessMetadataDefs []BusinessMetadataDef
+	BusinessMetadata     map[string][]BusinessMetadata // key: "subject" or "subject:version"
 
 	// Error simulation
 	ShouldError      bool
@@ -26,11 +30,15 @@ type MockSchemaRegistryClient struct {
 	GetSubjectsError error
 	GetVersionsError error
 	GetSchemaError   error
+	BulkSchemasError error
 	DeleteError      error
 	RegisterError    error
 	ConfigError      error
 	ModeError        error
 
+	// Incompatible lists subjects CheckCompatibility reports as incompatible
+	Incompatible map[string]bool
+
 	// Call tracking
 	Calls []MockCall
 }
@@ -53,6 +61,9 @@ func NewMockClient() *MockSchemaRegistryClient {
 		Tags:           []Tag{},
 		TagAssignments: make(map[string][]TagAssignment),
 		Calls:          []MockCall{},
+
+		BusinessMetadataDefs: []BusinessMetadataDef{},
+		BusinessMetadata:     make(map[string][]BusinessMetadata),
 	}
 }
 
@@ -119,6 +130,27 @@ func (m *MockSchemaRegistryClient) GetSubjects(includeDeleted bool) ([]string, e
 	return subjects, nil
 }
 
+func (m *MockSchemaRegistryClient) GetSubjectsWithPrefix(prefix string, includeDeleted bool) ([]string, error) {
+	m.RecordCall("GetSubjectsWithPrefix", prefix, includeDeleted)
+	if m.GetSubjectsError != nil {
+		return nil, m.GetSubjectsError
+	}
+	if m.ShouldError {
+		return nil, fmt.Errorf("%s", m.ErrorMessage)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	subjects := make([]string, 0, len(m.Subjects))
+	for subj := range m.Subjects {
+		if strings.HasPrefix(subj, prefix) {
+			subjects = append(subjects, subj)
+		}
+	}
+	return subjects, nil
+}
+
 func (m *MockSchemaRegistryClient) GetVersions(subject string, includeDeleted bool) ([]int, error) {
 	m.RecordCall("GetVersions", subject, includeDeleted)
 	if m.GetVersionsError != nil {
@@ -133,7 +165,7 @@ func (m *MockSchemaRegistryClient) GetVersions(subject string, includeDeleted bo
 
 	schemas, ok := m.Subjects[subject]
 	if !ok {
-		return nil, fmt.Errorf("subject not found: %s", subject)
+		return nil, mockNotFound(ErrCodeSubjectNotFound, "subject not found: %s", subject)
 	}
 
 	versions := make([]int, len(schemas))
@@ -159,12 +191,12 @@ func (m *MockSchemaRegistryClient) GetSchema(subject string, version string) (*S
 
 	schemas, ok := m.Subjects[subject]
 	if !ok {
-		return nil, fmt.Errorf("subject not found: %s", subject)
+		return nil, mockNotFound(ErrCodeSubjectNotFound, "subject not found: %s", subject)
 	}
 
 	if version == "latest" {
 		if len(schemas) == 0 {
-			return nil, fmt.Errorf("no versions found")
+			return nil, mockNotFound(ErrCodeVersionNotFound, "no versions found")
 		}
 		s := schemas[len(schemas)-1]
 		return &s, nil
@@ -178,7 +210,7 @@ func (m *MockSchemaRegistryClient) GetSchema(subject string, version string) (*S
 		}
 	}
 
-	return nil, fmt.Errorf("version not found: %s", version)
+	return nil, mockNotFound(ErrCodeVersionNotFound, "version not found: %s", version)
 }
 
 func (m *MockSchemaRegistryClient) GetSchemaWithDeleted(subject string, version string, includeDeleted bool) (*Schema, error) {
@@ -203,7 +235,31 @@ func (m *MockSchemaRegistryClient) GetSchemaByID(id int) (*Schema, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("schema ID not found: %d", id)
+	return nil, mockNotFound(ErrCodeSchemaNotFound, "schema ID not found: %d", id)
+}
+
+func (m *MockSchemaRegistryClient) GetLatestSchemaMetadata(subject string) (*SchemaVersionInfo, error) {
+	m.RecordCall("GetLatestSchemaMetadata", subject)
+	if m.GetSchemaError != nil {
+		return nil, m.GetSchemaError
+	}
+	if m.ShouldError {
+		return nil, fmt.Errorf("%s", m.ErrorMessage)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	schemas, ok := m.Subjects[subject]
+	if !ok || len(schemas) == 0 {
+		return nil, mockNotFound(ErrCodeSubjectNotFound, "subject not found: %s", subject)
+	}
+	s := schemas[len(schemas)-1]
+	schemaType := s.SchemaType
+	if schemaType == "" {
+		schemaType = "AVRO"
+	}
+	return &SchemaVersionInfo{Subject: subject, Version: s.Version, ID: s.ID, SchemaType: schemaType}, nil
 }
 
 func (m *MockSchemaRegistryClient) GetSchemaReferencedBy(subject string, version int) ([]int, error) {
@@ -211,8 +267,26 @@ func (m *MockSchemaRegistryClient) GetSchemaReferencedBy(subject string, version
 	if m.ShouldError {
 		return nil, fmt.Errorf("%s", m.ErrorMessage)
 	}
-	// By default, return empty (no references)
-	return []int{}, nil
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// IDs of stored schemas that reference subject/version, like the registry's
+	// referencedby endpoint
+	ids := []int{}
+	seen := make(map[int]bool)
+	for _, schemas := range m.Subjects {
+		for _, s := range schemas {
+			for _, ref := range s.References {
+				if ref.Subject == subject && ref.Version == version && !seen[s.ID] {
+					seen[s.ID] = true
+					ids = append(ids, s.ID)
+				}
+			}
+		}
+	}
+	sort.Ints(ids)
+	return ids, nil
 }
 
 func (m *MockSchemaRegistryClient) GetSchemaSubjectVersionsByID(id int) ([]SubjectVersion, error) {
@@ -262,6 +336,14 @@ func (m *MockSchemaRegistryClient) RegisterSchema(subject string, schema *Schema
 	existingSchemas := m.Subjects[subject]
 	newVersion := len(existingSchemas) + 1
 
+	// Like IMPORT mode, keep a given ID and version
+	if schema.ID > 0 {
+		newID = schema.ID
+		if schema.Version > 0 {
+			newVersion = schema.Version
+		}
+	}
+
 	newSchema := Schema{
 		Subject:    subject,
 		Version:    newVersion,
@@ -289,7 +371,7 @@ func (m *MockSchemaRegistryClient) DeleteSubject(subject string, permanent bool)
 
 	schemas, ok := m.Subjects[subject]
 	if !ok {
-		return nil, fmt.Errorf("subject not found: %s", subject)
+		return nil, mockNotFound(ErrCodeSubjectNotFound, "subject not found: %s", subject)
 	}
 
 	versions := make([]int, len(schemas))
@@ -318,7 +400,7 @@ func (m *MockSchemaRegistryClient) DeleteVersion(subject string, version string,
 
 	schemas, ok := m.Subjects[subject]
 	if !ok {
-		return 0, fmt.Errorf("subject not found: %s", subject)
+		return 0, mockNotFound(ErrCodeSubjectNotFound, "subject not found: %s", subject)
 	}
 
 	var ver int
@@ -333,7 +415,7 @@ func (m *MockSchemaRegistryClient) DeleteVersion(subject string, version string,
 		}
 	}
 
-	return 0, fmt.Errorf("version not found: %s", version)
+	return 0, mockNotFound(ErrCodeVersionNotFound, "version not found: %s", version)
 }
 
 func (m *MockSchemaRegistryClient) GetConfig() (*Config, error) {
@@ -398,6 +480,24 @@ func (m *MockSchemaRegistryClient) SetSubjectConfig(subject string, compatibilit
 	return nil
 }
 
+func (m *MockSchemaRegistryClient) DeleteSubjectConfig(subject string) error {
+	m.RecordCall("DeleteSubjectConfig", subject)
+	if m.ConfigError != nil {
+		return m.ConfigError
+	}
+	if m.ShouldError {
+		return fmt.Errorf("%s", m.ErrorMessage)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.SubjectConfigs[subject]; !ok {
+		return mockNotFound(ErrCodeSubjectCompatibilityNotDefined, "subject %s has no compatibility override", subject)
+	}
+	delete(m.SubjectConfigs, subject)
+	return nil
+}
+
 func (m *MockSchemaRegistryClient) GetMode() (*Mode, error) {
 	m.RecordCall("GetMode")
 	if m.ModeError != nil {
@@ -460,12 +560,30 @@ func (m *MockSchemaRegistryClient) SetSubjectMode(subject string, mode string) e
 	return nil
 }
 
+func (m *MockSchemaRegistryClient) DeleteSubjectMode(subject string) error {
+	m.RecordCall("DeleteSubjectMode", subject)
+	if m.ModeError != nil {
+		return m.ModeError
+	}
+	if m.ShouldError {
+		return fmt.Errorf("%s", m.ErrorMessage)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.SubjectModes[subject]; !ok {
+		return mockNotFound(ErrCodeSubjectModeNotDefined, "subject %s has no mode override", subject)
+	}
+	delete(m.SubjectModes, subject)
+	return nil
+}
+
 func (m *MockSchemaRegistryClient) CheckCompatibility(subject string, schema *Schema, version string) (bool, error) {
 	m.RecordCall("CheckCompatibility", subject, schema, version)
 	if m.ShouldError {
 		return false, fmt.Errorf("%s", m.ErrorMessage)
 	}
-	return true, nil
+	return !m.Incompatible[subject], nil
 }
 
 func (m *MockSchemaRegistryClient) GetAllSchemas(includeDeleted bool) ([]Schema, error) {
@@ -484,6 +602,45 @@ func (m *MockSchemaRegistryClient) GetAllSchemas(includeDeleted bool) ([]Schema,
 	return all, nil
 }
 
+func (m *MockSchemaRegistryClient) GetSchemasPage(subjectPrefix string, includeDeleted bool, offset, limit int) ([]Schema, error) {
+	m.RecordCall("GetSchemasPage", subjectPrefix, includeDeleted, offset, limit)
+	if m.BulkSchemasError != nil {
+		return nil, m.BulkSchemasError
+	}
+	if m.ShouldError {
+		return nil, fmt.Errorf("%s", m.ErrorMessage)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var all []Schema
+	for subject, schemas := range m.Subjects {
+		if !strings.HasPrefix(subject, subjectPrefix) {
+			continue
+		}
+		for _, schema := range schemas {
+			schema.Subject = subject
+			all = append(all, schema)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Subject != all[j].Subject {
+			return all[i].Subject < all[j].Subject
+		}
+		return all[i].Version < all[j].Version
+	})
+
+	if offset >= len(all) {
+		return []Schema{}, nil
+	}
+	end := len(all)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return all[offset:end], nil
+}
+
 func (m *MockSchemaRegistryClient) GetSchemaTypes() ([]string, error) {
 	m.RecordCall("GetSchemaTypes")
 	if m.ShouldError {
@@ -535,11 +692,12 @@ func (m *MockSchemaRegistryClient) GetSubjectTags(subject string) ([]TagAssignme
 	return []TagAssignment{}, nil
 }
 
-func (m *MockSchemaRegistryClient) AssignTagToSubject(subject, tagName string) error {
-	m.RecordCall("AssignTagToSubject", subject, tagName)
+func (m *MockSchemaRegistryClient) AssignTagToSubject(subject, tagName string, attributes map[string]string) error {
+	m.RecordCall("AssignTagToSubject", subject, tagName, attributes)
 	if m.ShouldError {
 		return fmt.Errorf("%s", m.ErrorMessage)
 	}
+	m.assignTag(subject, "sr_subject", tagName, attributes)
 	return nil
 }
 
@@ -548,6 +706,7 @@ func (m *MockSchemaRegistryClient) RemoveTagFromSubject(subject, tagName string)
 	if m.ShouldError {
 		return fmt.Errorf("%s", m.ErrorMessage)
 	}
+	m.removeTag(subject, tagName)
 	return nil
 }
 
@@ -567,11 +726,12 @@ func (m *MockSchemaRegistryClient) GetSchemaTags(subject string, version int) ([
 	return []TagAssignment{}, nil
 }
 
-func (m *MockSchemaRegistryClient) AssignTagToSchema(subject string, version int, tagName string) error {
-	m.RecordCall("AssignTagToSchema", subject, version, tagName)
+func (m *MockSchemaRegistryClient) AssignTagToSchema(subject string, version int, tagName string, attributes map[string]string) error {
+	m.RecordCall("AssignTagToSchema", subject, version, tagName, attributes)
 	if m.ShouldError {
 		return fmt.Errorf("%s", m.ErrorMessage)
 	}
+	m.assignTag(fmt.Sprintf("%s:%d", subject, version), "sr_schema", tagName, attributes)
 	return nil
 }
 
@@ -580,9 +740,184 @@ func (m *MockSchemaRegistryClient) RemoveTagFromSchema(subject string, version i
 	if m.ShouldError {
 		return fmt.Errorf("%s", m.ErrorMessage)
 	}
+	m.removeTag(fmt.Sprintf("%s:%d", subject, version), tagName)
+	return nil
+}
+
+func (m *MockSchemaRegistryClient) GetTopicTags(topic string) ([]TagAssignment, error) {
+	m.RecordCall("GetTopicTags", topic)
+	if m.ShouldError {
+		return nil, fmt.Errorf("%s", m.ErrorMessage)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if tags, ok := m.TagAssignments["topic:"+topic]; ok {
+		return tags, nil
+	}
+	return []TagAssignment{}, nil
+}
+
+func (m *MockSchemaRegistryClient) AssignTagToTopic(topic, tagName string, attributes map[string]string) error {
+	m.RecordCall("AssignTagToTopic", topic, tagName, attributes)
+	if m.ShouldError {
+		return fmt.Errorf("%s", m.ErrorMessage)
+	}
+	m.assignTag("topic:"+topic, "kafka_topic", tagName, attributes)
+	return nil
+}
+
+func (m *MockSchemaRegistryClient) RemoveTagFromTopic(topic, tagName string) error {
+	m.RecordCall("RemoveTagFromTopic", topic, tagName)
+	if m.ShouldError {
+		return fmt.Errorf("%s", m.ErrorMessage)
+	}
+	m.removeTag("topic:"+topic, tagName)
+	return nil
+}
+
+// Business metadata methods
+func (m *MockSchemaRegistryClient) GetBusinessMetadataDefs() ([]BusinessMetadataDef, error) {
+	m.RecordCall("GetBusinessMetadataDefs")
+	if m.ShouldError {
+		return nil, fmt.Errorf("%s", m.ErrorMessage)
+	}
+	return m.BusinessMetadataDefs, nil
+}
+
+func (m *MockSchemaRegistryClient) CreateBusinessMetadataDef(def *BusinessMetadataDef) error {
+	m.RecordCall("CreateBusinessMetadataDef", def)
+	if m.ShouldError {
+		return fmt.Errorf("%s", m.ErrorMessage)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.BusinessMetadataDefs = append(m.BusinessMetadataDefs, *def)
+	return nil
+}
+
+func (m *MockSchemaRegistryClient) DeleteBusinessMetadataDef(name string) error {
+	m.RecordCall("DeleteBusinessMetadataDef", name)
+	if m.ShouldError {
+		return fmt.Errorf("%s", m.ErrorMessage)
+	}
+	return nil
+}
+
+func (m *MockSchemaRegistryClient) GetSubjectBusinessMetadata(subject string) ([]BusinessMetadata, error) {
+	m.RecordCall("GetSubjectBusinessMetadata", subject)
+	return m.getBusinessMetadata(subject)
+}
+
+func (m *MockSchemaRegistryClient) AssignBusinessMetadataToSubject(subject, name string, attributes map[string]string) error {
+	m.RecordCall("AssignBusinessMetadataToSubject", subject, name, attributes)
+	return m.assignBusinessMetadata(subject, "sr_subject", name, attributes)
+}
+
+func (m *MockSchemaRegistryClient) RemoveBusinessMetadataFromSubject(subject, name string) error {
+	m.RecordCall("RemoveBusinessMetadataFromSubject", subject, name)
+	return m.removeBusinessMetadata(subject, name)
+}
+
+func (m *MockSchemaRegistryClient) GetSchemaBusinessMetadata(subject string, version int) ([]BusinessMetadata, error) {
+	m.RecordCall("GetSchemaBusinessMetadata", subject, version)
+	return m.getBusinessMetadata(fmt.Sprintf("%s:%d", subject, version))
+}
+
+func (m *MockSchemaRegistryClient) AssignBusinessMetadataToSchema(subject string, version int, name string, attributes map[string]string) error {
+	m.RecordCall("AssignBusinessMetadataToSchema", subject, version, name, attributes)
+	return m.assignBusinessMetadata(fmt.Sprintf("%s:%d", subject, version), "sr_schema", name, attributes)
+}
+
+func (m *MockSchemaRegistryClient) RemoveBusinessMetadataFromSchema(subject string, version int, name string) error {
+	m.RecordCall("RemoveBusinessMetadataFromSchema", subject, version, name)
+	return m.removeBusinessMetadata(fmt.Sprintf("%s:%d", subject, version), name)
+}
+
+func (m *MockSchemaRegistryClient) getBusinessMetadata(key string) ([]BusinessMetadata, error) {
+	if m.ShouldError {
+		return nil, fmt.Errorf("%s", m.ErrorMessage)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if bm, ok := m.BusinessMetadata[key]; ok {
+		return bm, nil
+	}
+	return []BusinessMetadata{}, nil
+}
+
+// assignBusinessMetadata records business metadata under key, replacing the
+// attributes if it is already there
+func (m *MockSchemaRegistryClient) assignBusinessMetadata(key, entityType, name string, attributes map[string]string) error {
+	if m.ShouldError {
+		return fmt.Errorf("%s", m.ErrorMessage)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, bm := range m.BusinessMetadata[key] {
+		if bm.TypeName == name {
+			m.BusinessMetadata[key][i].Attributes = attributes
+			return nil
+		}
+	}
+	m.BusinessMetadata[key] = append(m.BusinessMetadata[key], BusinessMetadata{
+		TypeName:   name,
+		EntityType: entityType,
+		EntityName: key,
+		Attributes: attributes,
+	})
 	return nil
 }
 
+func (m *MockSchemaRegistryClient) removeBusinessMetadata(key, name string) error {
+	if m.ShouldError {
+		return fmt.Errorf("%s", m.ErrorMessage)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var kept []BusinessMetadata
+	for _, bm := range m.BusinessMetadata[key] {
+		if bm.TypeName != name {
+			kept = append(kept, bm)
+		}
+	}
+	m.BusinessMetadata[key] = kept
+	return nil
+}
+
+// assignTag records a tag under key ("subject" or "subject:version"),
+// replacing the attributes if it is already there
+func (m *MockSchemaRegistryClient) assignTag(key, entityType, tagName string, attributes map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, t := range m.TagAssignments[key] {
+		if t.TypeName == tagName {
+			m.TagAssignments[key][i].Attributes = attributes
+			return
+		}
+	}
+	m.TagAssignments[key] = append(m.TagAssignments[key], TagAssignment{
+		TypeName:   tagName,
+		EntityType: entityType,
+		EntityName: key,
+		Attributes: attributes,
+	})
+}
+
+// removeTag drops a tag recorded under key
+func (m *MockSchemaRegistryClient) removeTag(key, tagName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var kept []TagAssignment
+	for _, t := range m.TagAssignments[key] {
+		if t.TypeName != tagName {
+			kept = append(kept, t)
+		}
+	}
+	m.TagAssignments[key] = kept
+}
+
 // WithContext returns a copy of the mock client (for compatibility)
 func (m *MockSchemaRegistryClient) WithContext(ctx string) *MockSchemaRegistryClient {
 	return m
@@ -593,5 +928,10 @@ func NewError(msg string) error {
 	return fmt.Errorf("%s", msg)
 }
 
+// mockNotFound returns a 404 APIError like the registry's
+func mockNotFound(code int, format string, args ...interface{}) error {
+	return &APIError{StatusCode: 404, ErrorCode: code, Message: fmt.Sprintf(format, args...)}
+}
+
 // ErrSubjectNotFound is a common error for testing
 var ErrSubjectNotFound = fmt.Errorf("subject not found")
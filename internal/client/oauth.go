@@ -0,0 +1,139 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRefreshSkew is how long before expiry a cached token is considered
+// stale. Refreshing early keeps long-running bulk operations from sending a
+// token that expires while the request is in flight.
+const tokenRefreshSkew = 60 * time.Second
+
+// tokenSource fetches OAuth2 access tokens using the client credentials grant
+// and caches them until shortly before they expire. It is safe for concurrent
+// use; callers that find a stale token block while a single refresh runs.
+type tokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scope        string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	now    func() time.Time
+}
+
+func newTokenSource(auth *AuthConfig) *tokenSource {
+	return &tokenSource{
+		tokenURL:     auth.TokenURL,
+		clientID:     auth.ClientID,
+		clientSecret: auth.ClientSecret,
+		scope:        auth.Scope,
+		now:          time.Now,
+	}
+}
+
+// Token returns a valid access token, fetching a new one when the cached
+// token is missing or within the refresh window of its expiry.
+func (ts *tokenSource) Token(httpClient *http.Client) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && ts.now().Before(ts.expiry) {
+		return ts.token, nil
+	}
+
+	token, expiresIn, err := ts.fetch(httpClient)
+	if err != nil {
+		return "", err
+	}
+
+	// Refresh ahead of the real expiry. For very short-lived tokens the skew
+	// would swallow the whole lifetime, so fall back to refreshing at half-life.
+	skew := tokenRefreshSkew
+	if expiresIn <= 2*skew {
+		skew = expiresIn / 2
+	}
+
+	ts.token = token
+	ts.expiry = ts.now().Add(expiresIn - skew)
+	return ts.token, nil
+}
+
+// Invalidate drops the cached token if it is still the given (rejected)
+// token, so the next call to Token fetches a new one. Comparing against the
+// rejected token keeps concurrent workers that all saw the same 401 from
+// discarding a replacement another worker already fetched.
+func (ts *tokenSource) Invalidate(rejected string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token != rejected {
+		return
+	}
+	ts.token = ""
+	ts.expiry = time.Time{}
+}
+
+// fetch requests a new token from the token endpoint
+func (ts *tokenSource) fetch(httpClient *http.Client) (string, time.Duration, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if ts.scope != "" {
+		form.Set("scope", ts.scope)
+	}
+
+	req, err := http.NewRequest("POST", ts.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(ts.clientID), url.QueryEscape(ts.clientSecret))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	const maxTokenResponseSize = 1024 * 1024 // 1 MB
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponseSize))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("failed to obtain OAuth token: %s (status %d)", truncateBody(respBody), resp.StatusCode)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", 0, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", 0, fmt.Errorf("token response did not contain an access_token")
+	}
+	if result.TokenType != "" && !strings.EqualFold(result.TokenType, "bearer") {
+		return "", 0, fmt.Errorf("unsupported token type %q (expected Bearer)", result.TokenType)
+	}
+
+	// expires_in is optional per RFC 6749; assume a conservative lifetime when absent.
+	expiresIn := time.Duration(result.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 5 * time.Minute
+	}
+
+	return result.AccessToken, expiresIn, nil
+}
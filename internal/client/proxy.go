@@ -0,0 +1,65 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// newTransport returns a copy of the default transport that takes its proxy
+// from HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+	return t
+}
+
+// transport returns the client's *http.Transport, installing a fresh one when
+// the client has none (or a different RoundTripper)
+func (c *SchemaRegistryClient) transport() *http.Transport {
+	if t, ok := c.HTTPClient.Transport.(*http.Transport); ok {
+		return t
+	}
+	t := newTransport()
+	c.HTTPClient.Transport = t
+	return t
+}
+
+// ConfigureProxy sends every request, including OAuth token requests, through
+// proxyURL instead of the proxy from the environment. NO_PROXY no longer
+// applies. An empty proxyURL keeps the environment settings.
+func (c *SchemaRegistryClient) ConfigureProxy(proxyURL string) error {
+	if proxyURL == "" {
+		return nil
+	}
+	// Don't echo the URL in errors; it may carry proxy credentials
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL: expected scheme://host:port")
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("unsupported proxy scheme '%s': must be http, https or socks5", u.Scheme)
+	}
+	c.transport().Proxy = http.ProxyURL(u)
+	return nil
+}
+
+// ProxyURL returns the proxy used to reach the registry, with any password
+// redacted, or "" for a direct connection
+func (c *SchemaRegistryClient) ProxyURL() string {
+	t, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok || t.Proxy == nil {
+		return ""
+	}
+	req, err := http.NewRequest("GET", c.BaseURL, nil)
+	if err != nil {
+		return ""
+	}
+	u, err := t.Proxy(req)
+	if err != nil || u == nil {
+		return ""
+	}
+	return u.Redacted()
+}
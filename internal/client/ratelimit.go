@@ -0,0 +1,88 @@
+package client
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every goroutine using a client.
+// Tokens refill at rate per second up to a burst of max(1, rate), so short
+// idle periods don't let a burst of workers exceed the configured rate.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	throttled int64 // total time callers spent waiting, in nanoseconds
+	now       func() time.Time
+	sleep     func(time.Duration)
+}
+
+// NewRateLimiter creates a limiter allowing rps requests per second
+func NewRateLimiter(rps float64) *RateLimiter {
+	burst := rps
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rps,
+		burst:  burst,
+		tokens: burst,
+		now:    time.Now,
+		sleep:  time.Sleep,
+	}
+}
+
+// Wait blocks until a request may be sent. Each caller reserves a token
+// under the lock and then sleeps outside it, so waiting workers are released
+// in order at the configured rate.
+func (l *RateLimiter) Wait() {
+	l.mu.Lock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait > 0 {
+		atomic.AddInt64(&l.throttled, int64(wait))
+		l.sleep(wait)
+	}
+}
+
+// Throttled returns the total time callers have spent waiting on the limiter
+func (l *RateLimiter) Throttled() time.Duration {
+	return time.Duration(atomic.LoadInt64(&l.throttled))
+}
+
+// SetRateLimit caps the client at rps requests per second across all
+// goroutines sharing it (including copies made with WithContext after this
+// call). A value of 0 or less removes the limit.
+func (c *SchemaRegistryClient) SetRateLimit(rps float64) {
+	if rps <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = NewRateLimiter(rps)
+}
+
+// ThrottledTime returns how long requests have been delayed by the rate limiter
+func (c *SchemaRegistryClient) ThrottledTime() time.Duration {
+	if c.limiter == nil {
+		return 0
+	}
+	return c.limiter.Throttled()
+}
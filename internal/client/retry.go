@@ -0,0 +1,88 @@
+package client
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a transient failure is retried
+	DefaultMaxRetries = 3
+	// DefaultRetryBackoff is the base delay before the first retry; it doubles
+	// with every subsequent attempt
+	DefaultRetryBackoff = 500 * time.Millisecond
+
+	// maxRetryDelay caps both the exponential backoff and a server-supplied
+	// Retry-After so a misbehaving registry can't stall a run indefinitely
+	maxRetryDelay = 60 * time.Second
+)
+
+// Retry policy:
+//
+//   - GET, PUT and DELETE are idempotent and are retried on 429, 500, 502,
+//     503 and 504 responses as well as on transport errors (connection reset,
+//     timeout, ...).
+//   - POST (schema registration, compatibility checks, tag creation) is only
+//     retried on 429 and 503, where the registry rejected the request before
+//     processing it. A POST that failed mid-flight is never replayed.
+func shouldRetry(method string, statusCode int, err error) bool {
+	idempotent := method != http.MethodPost
+
+	if err != nil {
+		return idempotent
+	}
+
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before retry number attempt (0-based).
+// A Retry-After header, given either in seconds or as an HTTP date, takes
+// precedence over the exponential backoff.
+func (c *SchemaRegistryClient) retryDelay(attempt int, header http.Header) time.Duration {
+	if d, ok := parseRetryAfter(header.Get("Retry-After")); ok {
+		if d > maxRetryDelay {
+			d = maxRetryDelay
+		}
+		return d
+	}
+
+	backoff := c.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	delay := backoff << uint(attempt)
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	// Equal jitter: wait at least half the backoff, plus a random share of
+	// the other half, so parallel workers don't retry in lockstep.
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header value
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
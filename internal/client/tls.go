@@ -0,0 +1,72 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// UsesTLS reports whether the config carries any custom TLS settings
+func (a *AuthConfig) UsesTLS() bool {
+	return a != nil && (a.ClientCertFile != "" || a.ClientKeyFile != "" || a.CACertFile != "" || a.InsecureSkipVerify)
+}
+
+// SkipsVerify reports whether the registry's certificate goes unverified
+func (a *AuthConfig) SkipsVerify() bool {
+	return a != nil && a.InsecureSkipVerify
+}
+
+// UsesClientCert reports whether a client certificate is configured for mutual TLS
+func (a *AuthConfig) UsesClientCert() bool {
+	return a != nil && a.ClientCertFile != ""
+}
+
+// buildTLSConfig loads the certificate files referenced by auth into a *tls.Config
+func buildTLSConfig(auth *AuthConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: auth.InsecureSkipVerify, // #nosec G402 -- user-controlled flag
+	}
+
+	if auth.ClientCertFile != "" || auth.ClientKeyFile != "" {
+		if auth.ClientCertFile == "" || auth.ClientKeyFile == "" {
+			return nil, fmt.Errorf("mutual TLS requires both a client certificate and a client key")
+		}
+		cert, err := tls.LoadX509KeyPair(auth.ClientCertFile, auth.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate %s / key %s: %w", auth.ClientCertFile, auth.ClientKeyFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if auth.CACertFile != "" {
+		caPEM, err := os.ReadFile(auth.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no valid PEM certificates found in CA file %s", auth.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// ConfigureTLS applies the client's TLS settings (client certificate, CA
+// bundle, skipped verification) to its HTTP transport. It is a no-op when none are configured.
+func (c *SchemaRegistryClient) ConfigureTLS() error {
+	if !c.Auth.UsesTLS() {
+		return nil
+	}
+
+	tlsConfig, err := buildTLSConfig(c.Auth)
+	if err != nil {
+		return err
+	}
+
+	c.transport().TLSClientConfig = tlsConfig
+	return nil
+}
@@ -5,51 +5,92 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // KafkaSASLConfig holds SASL authentication for Kafka
 type KafkaSASLConfig struct {
-	Mechanism string `mapstructure:"mechanism"` // PLAIN, SCRAM-SHA-256, SCRAM-SHA-512
-	Username  string `mapstructure:"username"`
-	Password  string `mapstructure:"password"`
+	Mechanism string `mapstructure:"mechanism" yaml:"mechanism,omitempty"` // PLAIN, SCRAM-SHA-256, SCRAM-SHA-512
+	Username  string `mapstructure:"username" yaml:"username,omitempty"`
+	Password  string `mapstructure:"password" yaml:"password,omitempty"`
 }
 
 // KafkaTLSConfig holds TLS settings for Kafka
 type KafkaTLSConfig struct {
-	Enabled    bool `mapstructure:"enabled"`
-	SkipVerify bool `mapstructure:"skip_verify"`
+	Enabled    bool `mapstructure:"enabled" yaml:"enabled,omitempty"`
+	SkipVerify bool `mapstructure:"skip_verify" yaml:"skip_verify,omitempty"`
 }
 
 // KafkaConfig holds Kafka connection settings for a registry
 type KafkaConfig struct {
-	Brokers []string        `mapstructure:"brokers"`
-	SASL    KafkaSASLConfig `mapstructure:"sasl"`
-	TLS     KafkaTLSConfig  `mapstructure:"tls"`
+	Brokers []string        `mapstructure:"brokers" yaml:"brokers,omitempty"`
+	SASL    KafkaSASLConfig `mapstructure:"sasl" yaml:"sasl,omitempty"`
+	TLS     KafkaTLSConfig  `mapstructure:"tls" yaml:"tls,omitempty"`
+}
+
+// OAuthConfig holds OAuth2 client credentials for a registry
+type OAuthConfig struct {
+	TokenURL     string `mapstructure:"token_url" yaml:"token_url,omitempty"`
+	ClientID     string `mapstructure:"client_id" yaml:"client_id,omitempty"`
+	ClientSecret string `mapstructure:"client_secret" yaml:"client_secret,omitempty"`
+	Scope        string `mapstructure:"scope" yaml:"scope,omitempty"`
+}
+
+// RegistryTLSConfig holds client certificate and server verification settings
+type RegistryTLSConfig struct {
+	CertFile string `mapstructure:"cert_file" yaml:"cert_file,omitempty"`
+	KeyFile  string `mapstructure:"key_file" yaml:"key_file,omitempty"`
+	CAFile   string `mapstructure:"ca_file" yaml:"ca_file,omitempty"`
+	// InsecureSkipVerify disables certificate verification; development only
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify" yaml:"insecure_skip_verify,omitempty"`
 }
 
 // Registry represents a configured schema registry
 type Registry struct {
-	Name     string      `mapstructure:"name"`
-	URL      string      `mapstructure:"url"`
-	Username string      `mapstructure:"username"`
-	Password string      `mapstructure:"password"`
-	Context  string      `mapstructure:"context"`
-	Default  bool        `mapstructure:"default"`
-	Kafka    KafkaConfig `mapstructure:"kafka"`
+	Name     string            `mapstructure:"name" yaml:"name"`
+	URL      string            `mapstructure:"url" yaml:"url"`
+	AuthMode string            `mapstructure:"auth_mode" yaml:"auth_mode,omitempty"` // basic (default) or oauth
+	Username string            `mapstructure:"username" yaml:"username,omitempty"`
+	Password string            `mapstructure:"password" yaml:"password,omitempty"`
+	OAuth    OAuthConfig       `mapstructure:"oauth" yaml:"oauth,omitempty"`
+	TLS      RegistryTLSConfig `mapstructure:"tls" yaml:"tls,omitempty"`
+	Timeout  time.Duration     `mapstructure:"timeout" yaml:"timeout,omitempty"` // HTTP request timeout, e.g. 30s
+	Proxy    string            `mapstructure:"proxy" yaml:"proxy,omitempty"`     // overrides HTTP(S)_PROXY
+	Context  string            `mapstructure:"context" yaml:"context,omitempty"`
+	Default  bool              `mapstructure:"default" yaml:"default,omitempty"`
+	Kafka    KafkaConfig       `mapstructure:"kafka" yaml:"kafka,omitempty"`
+}
+
+// Profile bundles registries and a default context that are selected together
+// with --profile or SRCTL_PROFILE
+type Profile struct {
+	Registries     []Registry `mapstructure:"registries" yaml:"registries,omitempty"`
+	DefaultContext string     `mapstructure:"default_context" yaml:"default_context,omitempty"`
 }
 
 // Config represents the application configuration
 type Config struct {
-	Registries     []Registry `mapstructure:"registries"`
-	DefaultOutput  string     `mapstructure:"default_output"`
-	DefaultContext string     `mapstructure:"default_context"`
+	Registries     []Registry         `mapstructure:"registries"`
+	DefaultOutput  string             `mapstructure:"default_output"`
+	DefaultContext string             `mapstructure:"default_context"`
+	Profiles       map[string]Profile `mapstructure:"profiles"`
 }
 
 // Global configuration instance
 var AppConfig Config
 
+// loadedConfigFile is the config file LoadConfig read successfully, if any
+var loadedConfigFile string
+
+// activeProfile is the profile applied by UseProfile, and baseConfig the
+// configuration as loaded before it was applied
+var (
+	activeProfile string
+	baseConfig    Config
+)
+
 // LoadConfig loads configuration from file and environment
 func LoadConfig() error {
 	// Set config file name and type
@@ -100,6 +141,7 @@ func LoadConfig() error {
 	if err := viper.Unmarshal(&AppConfig); err != nil {
 		return fmt.Errorf("unable to decode config: %w", err)
 	}
+	loadedConfigFile = viper.ConfigFileUsed()
 
 	return nil
 }
@@ -128,23 +170,113 @@ func GetRegistry(name string) *Registry {
 	return nil
 }
 
+// UseProfile applies a named profile: its registries replace the top-level
+// ones and its default context replaces default_context. Fields the profile
+// leaves empty keep their top-level values. An empty name restores the
+// top-level configuration.
+func UseProfile(name string) error {
+	if activeProfile != "" {
+		AppConfig = baseConfig
+		activeProfile = ""
+	}
+	if name == "" {
+		return nil
+	}
+	profile, ok := AppConfig.Profiles[name]
+	if !ok {
+		return fmt.Errorf("profile '%s' not found in config", name)
+	}
+	baseConfig = AppConfig
+	activeProfile = name
+	if len(profile.Registries) > 0 {
+		AppConfig.Registries = append([]Registry(nil), profile.Registries...)
+	}
+	if profile.DefaultContext != "" {
+		AppConfig.DefaultContext = profile.DefaultContext
+	}
+	return nil
+}
+
+// ActiveProfile returns the name of the profile in use, or "" for none
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// AddRegistry adds a registry to the configuration; names must be unique.
+// A registry added with Default set becomes the only default.
+func AddRegistry(registry Registry) error {
+	if registry.Name == "" {
+		return fmt.Errorf("registry name is required")
+	}
+	if GetRegistry(registry.Name) != nil {
+		return fmt.Errorf("registry '%s' already exists", registry.Name)
+	}
+	if registry.Default {
+		clearDefault()
+	}
+	AppConfig.Registries = append(AppConfig.Registries, registry)
+	return nil
+}
+
+// RemoveRegistry removes a registry from the configuration by name
+func RemoveRegistry(name string) error {
+	for i := range AppConfig.Registries {
+		if AppConfig.Registries[i].Name == name {
+			AppConfig.Registries = append(AppConfig.Registries[:i], AppConfig.Registries[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("registry '%s' not found in config", name)
+}
+
+// SetDefaultRegistry makes the named registry the only default
+func SetDefaultRegistry(name string) error {
+	r := GetRegistry(name)
+	if r == nil {
+		return fmt.Errorf("registry '%s' not found in config", name)
+	}
+	clearDefault()
+	r.Default = true
+	return nil
+}
+
+func clearDefault() {
+	for i := range AppConfig.Registries {
+		AppConfig.Registries[i].Default = false
+	}
+}
+
+// ConfigFilePath returns the file SaveConfig writes to: the config file that
+// was loaded, or ~/.srctl/srctl.yaml when none was found or it failed to parse
+func ConfigFilePath() (string, error) {
+	if loadedConfigFile != "" {
+		return loadedConfigFile, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".srctl", "srctl.yaml"), nil
+}
+
 // SaveConfig saves the current configuration to file
 func SaveConfig() error {
-	homeDir, err := os.UserHomeDir()
+	configPath, err := ConfigFilePath()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
 
-	configDir := filepath.Join(homeDir, ".srctl")
-	if err := os.MkdirAll(configDir, 0700); err != nil {
+	if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	configPath := filepath.Join(configDir, "srctl.yaml")
-
-	viper.Set("registries", AppConfig.Registries)
-	viper.Set("default_output", AppConfig.DefaultOutput)
-	viper.Set("default_context", AppConfig.DefaultContext)
+	cfg := savedConfig()
+	viper.Set("registries", cfg.Registries)
+	viper.Set("default_output", cfg.DefaultOutput)
+	viper.Set("default_context", cfg.DefaultContext)
+	if len(cfg.Profiles) > 0 {
+		viper.Set("profiles", cfg.Profiles)
+	}
 
 	// Pre-create the target with 0600 and truncate it so the file never exists
 	// with world-readable permissions, even briefly. viper.WriteConfigAs writes
@@ -170,6 +302,28 @@ func SaveConfig() error {
 	return nil
 }
 
+// savedConfig returns the configuration to write. With a profile active,
+// registry changes belong to that profile (or to the top level when the
+// profile defines no registries of its own), and the other top-level
+// settings are written as loaded.
+func savedConfig() Config {
+	if activeProfile == "" {
+		return AppConfig
+	}
+	cfg := baseConfig
+	cfg.Profiles = make(map[string]Profile, len(baseConfig.Profiles))
+	for name, p := range baseConfig.Profiles {
+		cfg.Profiles[name] = p
+	}
+	if profile := cfg.Profiles[activeProfile]; len(profile.Registries) > 0 {
+		profile.Registries = AppConfig.Registries
+		cfg.Profiles[activeProfile] = profile
+	} else {
+		cfg.Registries = AppConfig.Registries
+	}
+	return cfg
+}
+
 // InitConfig creates an initial configuration file
 func InitConfig(registry Registry) error {
 	AppConfig.Registries = append(AppConfig.Registries, registry)
@@ -3,10 +3,12 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/olekukonko/tablewriter"
 	"gopkg.in/yaml.v3"
 )
@@ -24,6 +26,7 @@ const (
 // Printer handles formatted output
 type Printer struct {
 	format Format
+	out    io.Writer // nil for os.Stdout
 }
 
 // NewPrinter creates a new printer with the specified format
@@ -37,6 +40,19 @@ func NewPrinter(format string) *Printer {
 	}
 }
 
+// WithWriter sends the printer's output to w instead of stdout
+func (p *Printer) WithWriter(w io.Writer) *Printer {
+	p.out = w
+	return p
+}
+
+func (p *Printer) writer() io.Writer {
+	if p.out != nil {
+		return p.out
+	}
+	return os.Stdout
+}
+
 // Print outputs data in the configured format
 func (p *Printer) Print(data interface{}) error {
 	switch p.format {
@@ -56,33 +72,59 @@ func (p *Printer) printJSON(data interface{}) error {
 	if err != nil {
 		return err
 	}
-	fmt.Println(string(output))
+	fmt.Fprintln(p.writer(), string(output))
 	return nil
 }
 
+// printYAML goes through JSON so YAML keys follow the json tags, as most
+// output types only have those; field order is kept
 func (p *Printer) printYAML(data interface{}) error {
-	output, err := yaml.Marshal(data)
+	output, err := MarshalYAML(data)
 	if err != nil {
 		return err
 	}
-	fmt.Print(string(output))
+	fmt.Fprint(p.writer(), string(output))
 	return nil
 }
 
+// MarshalYAML marshals data to block-style YAML using its JSON field names
+func MarshalYAML(data interface{}) ([]byte, error) {
+	js, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(js, &doc); err != nil {
+		return nil, err
+	}
+	clearStyle(&doc)
+	return yaml.Marshal(&doc)
+}
+
+// clearStyle drops the flow and quoting styles JSON parses with, so the
+// result reads like hand-written YAML
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
 func (p *Printer) printPlain(data interface{}) error {
+	w := p.writer()
 	switch v := data.(type) {
 	case []string:
 		for _, s := range v {
-			fmt.Println(s)
+			fmt.Fprintln(w, s)
 		}
 	case []int:
 		for _, i := range v {
-			fmt.Println(i)
+			fmt.Fprintln(w, i)
 		}
 	case string:
-		fmt.Println(v)
+		fmt.Fprintln(w, v)
 	default:
-		fmt.Printf("%v\n", v)
+		fmt.Fprintf(w, "%v\n", v)
 	}
 	return nil
 }
@@ -91,7 +133,7 @@ func (p *Printer) printTable(data interface{}) error {
 	// This is a generic table printer, specific commands may implement their own
 	switch v := data.(type) {
 	case []string:
-		table := tablewriter.NewWriter(os.Stdout)
+		table := tablewriter.NewWriter(p.writer())
 		table.SetHeader([]string{"Value"})
 		table.SetBorder(false)
 		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
@@ -104,7 +146,7 @@ func (p *Printer) printTable(data interface{}) error {
 		if len(v) == 0 {
 			return nil
 		}
-		table := tablewriter.NewWriter(os.Stdout)
+		table := tablewriter.NewWriter(p.writer())
 		if len(v) > 0 {
 			table.SetHeader(v[0])
 			for _, row := range v[1:] {
@@ -151,26 +193,38 @@ var (
 // so when stderr is redirected (but stdout is a terminal) the codes above would
 // still leak escape sequences into stderr. These respect stderr's own TTY state.
 var (
-	stderrIsTTY = isTerminal(os.Stderr)
+	stderrColor = colorEnabled(os.Stderr)
 
 	redStderr    = newStderrColorFunc(color.FgRed)
 	yellowStderr = newStderrColorFunc(color.FgYellow)
 )
 
-// isTerminal reports whether f is attached to a character device (a terminal).
-// Uses os.Stat to avoid pulling in an external terminal-detection dependency.
-func isTerminal(f *os.File) bool {
-	fi, err := f.Stat()
-	if err != nil {
+// IsTerminal reports whether f is a terminal. Uses go-isatty, as fatih/color
+// does, since other character devices such as /dev/null are not terminals.
+func IsTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// colorEnabled reports whether color escapes belong in f: it must be a
+// terminal, and NO_COLOR (https://no-color.org) or TERM=dumb turn color off
+// everywhere. This matches how fatih/color decides for stdout.
+func colorEnabled(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
 		return false
 	}
-	return fi.Mode()&os.ModeCharDevice != 0
+	return IsTerminal(f)
+}
+
+// SetStdoutColor decides from f whether the stdout colorizers emit color, for
+// when os.Stdout has been pointed at another file such as stderr
+func SetStdoutColor(f *os.File) {
+	color.NoColor = !colorEnabled(f)
 }
 
 // newStderrColorFunc returns a SprintFunc that colorizes only when stderr is a
-// terminal, otherwise it returns the plain text.
+// terminal and color is not turned off, otherwise it returns the plain text.
 func newStderrColorFunc(attr color.Attribute) func(a ...interface{}) string {
-	if !stderrIsTTY {
+	if !stderrColor {
 		return fmt.Sprint
 	}
 	c := color.New(attr)
@@ -178,6 +232,15 @@ func newStderrColorFunc(attr color.Attribute) func(a ...interface{}) string {
 	return c.SprintFunc()
 }
 
+// quiet suppresses headers, steps and info messages
+var quiet bool
+
+// SetQuiet limits output to results, successes, warnings and errors: Header,
+// Step and Info print nothing while it is on
+func SetQuiet(q bool) {
+	quiet = q
+}
+
 // Success prints a success message
 func Success(format string, args ...interface{}) {
 	fmt.Printf("%s %s\n", Green("✓"), fmt.Sprintf(format, args...))
@@ -195,16 +258,25 @@ func Warning(format string, args ...interface{}) {
 
 // Info prints an info message
 func Info(format string, args ...interface{}) {
+	if quiet {
+		return
+	}
 	fmt.Printf("%s %s\n", Blue("ℹ"), fmt.Sprintf(format, args...))
 }
 
 // Step prints a step message
 func Step(format string, args ...interface{}) {
+	if quiet {
+		return
+	}
 	fmt.Printf("%s %s\n", Cyan("→"), fmt.Sprintf(format, args...))
 }
 
 // Header prints a header
 func Header(format string, args ...interface{}) {
+	if quiet {
+		return
+	}
 	fmt.Printf("\n%s\n", Bold(fmt.Sprintf(format, args...)))
 	fmt.Println(strings.Repeat("─", 50))
 }
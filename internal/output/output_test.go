@@ -1,6 +1,15 @@
 package output
 
-import "testing"
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+)
 
 func TestFormatBytes(t *testing.T) {
 	tests := []struct {
@@ -29,3 +38,90 @@ func TestFormatBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintYAMLUsesJSONNames(t *testing.T) {
+	type item struct {
+		SchemaID int      `json:"schemaId"`
+		Subject  string   `json:"subject"`
+		Version  string   `json:"version"`
+		Tags     []string `json:"tags,omitempty"`
+		Empty    string   `json:"empty,omitempty"`
+		Schema   string   `json:"schema"`
+	}
+	var buf bytes.Buffer
+	err := NewPrinter("yaml").WithWriter(&buf).Print([]item{{
+		SchemaID: 7, Subject: "orders-value", Version: "3", Tags: []string{"pii"}, Schema: "{\n  \"type\": \"string\"\n}",
+	}})
+	if err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+
+	var got []map[string]interface{}
+	if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid YAML %q: %v", buf.String(), err)
+	}
+	want := map[string]interface{}{
+		"schemaId": 7, "subject": "orders-value", "version": "3",
+		"tags": []interface{}{"pii"}, "schema": "{\n  \"type\": \"string\"\n}",
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+	if !strings.HasPrefix(buf.String(), "- schemaId: 7\n  subject: orders-value\n") {
+		t.Errorf("expected block style in field order, got:\n%s", buf.String())
+	}
+}
+
+func TestSetQuiet(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = w
+	SetQuiet(true)
+	defer func() {
+		os.Stdout = saved
+		SetQuiet(false)
+	}()
+
+	Header("Header")
+	Step("Step")
+	Info("Info")
+	Success("Done")
+	w.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); strings.Contains(got, "Header") || strings.Contains(got, "Step") || strings.Contains(got, "Info") || !strings.Contains(got, "Done") {
+		t.Errorf("quiet output = %q, want only the success message", got)
+	}
+}
+
+func TestColorDisabledOffTerminal(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+
+	// A character device that is not a terminal gets no color
+	if IsTerminal(devNull) || colorEnabled(devNull) {
+		t.Errorf("%s should not be treated as a terminal", os.DevNull)
+	}
+
+	saved := color.NoColor
+	defer func() { color.NoColor = saved }()
+	color.NoColor = false
+	SetStdoutColor(devNull)
+	if !color.NoColor || Green("ok") != "ok" {
+		t.Error("stdout colors should be off when stdout points at a non-terminal")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if colorEnabled(os.Stdout) {
+		t.Error("NO_COLOR should turn color off")
+	}
+}
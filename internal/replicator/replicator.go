@@ -3,6 +3,7 @@ package replicator
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
@@ -319,26 +320,29 @@ func (r *Replicator) applyWithRetry(ctx context.Context, event *kafka.SchemaEven
 // isNonRetryableError returns true for errors that won't resolve by retrying
 // (client-side errors like incompatible schema, bad request, etc.)
 func isNonRetryableError(err error) bool {
-	msg := err.Error()
-	lower := strings.ToLower(msg)
-
 	// A missing/unresolved reference also surfaces as 422 (error code 42201),
 	// but it is transient during streaming: the referenced subject may simply
 	// not have been registered on the target yet. Keep these RETRYABLE so the
 	// retry budget can ride out ordering races; only genuine incompatibility
 	// errors should fail fast.
-	if strings.Contains(lower, "reference") {
+	if isMissingReferenceError(err) {
 		return false
 	}
 
 	// 409 Conflict (schema already exists) is handled as success elsewhere.
 	// 422 Unprocessable (incompatible schema, invalid schema) and
 	// 400 Bad Request are genuine client errors that won't resolve on retry.
-	if strings.Contains(msg, "status 422") || strings.Contains(msg, "status 400") {
-		return true
-	}
 	// "not found" errors when deleting non-existent subjects are handled elsewhere
-	return false
+	return client.HasStatus(err, http.StatusUnprocessableEntity, http.StatusBadRequest)
+}
+
+// isMissingReferenceError reports whether a registration failed because a
+// referenced schema can't be resolved. The registry has no dedicated error
+// code for this, so the invalid-schema message is checked.
+func isMissingReferenceError(err error) bool {
+	apiErr, ok := client.AsAPIError(err)
+	return ok && apiErr.ErrorCode == client.ErrCodeInvalidSchema &&
+		strings.Contains(strings.ToLower(apiErr.Message), "reference")
 }
 
 // applyEvent applies a single SchemaEvent to the target registry.
@@ -368,7 +372,7 @@ func (r *Replicator) applySchemaEvent(event *kafka.SchemaEvent) error {
 		_, err := r.cfg.TargetClient.DeleteSubject(event.Subject, permanent)
 		if err != nil {
 			// Subject may not exist on target yet, or target in IMPORT mode -- not an error
-			if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "405") {
+			if client.HasStatus(err, http.StatusNotFound, http.StatusMethodNotAllowed) {
 				return nil
 			}
 			return fmt.Errorf("failed to delete %s: %w", event.Subject, err)
@@ -466,7 +470,7 @@ func (r *Replicator) applyModeEvent(event *kafka.SchemaEvent) error {
 	}
 	if err := r.cfg.TargetClient.SetSubjectMode(event.Subject, event.Mode); err != nil {
 		// Subject may not exist yet
-		if strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) {
 			return nil
 		}
 		return fmt.Errorf("failed to set mode for %s: %w", event.Subject, err)
@@ -482,7 +486,7 @@ func (r *Replicator) applyDeleteEvent(event *kafka.SchemaEvent) error {
 	}
 	_, err := r.cfg.TargetClient.DeleteSubject(event.Subject, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "405") {
+		if client.HasStatus(err, http.StatusNotFound, http.StatusMethodNotAllowed) {
 			return nil
 		}
 		return fmt.Errorf("failed to delete subject %s: %w", event.Subject, err)
@@ -613,7 +617,7 @@ func (r *Replicator) syncSubject(source, target client.SchemaRegistryClientInter
 		if rerr != nil && !isAlreadyExistsError(rerr) {
 			// A missing reference means a subject this one depends on hasn't
 			// been registered yet; signal the caller to retry on a later pass.
-			if !isNonRetryableError(rerr) && strings.Contains(strings.ToLower(rerr.Error()), "reference") {
+			if isMissingReferenceError(rerr) {
 				return true, rerr
 			}
 			output.Warning("Failed to register %s v%d: %v", subj, v, rerr)
@@ -626,21 +630,19 @@ func (r *Replicator) syncSubject(source, target client.SchemaRegistryClientInter
 	return false, nil
 }
 
+// isAlreadyExistsError reports whether the schema is already registered on the
+// target under a different ID (SR error code 42207)
 func isAlreadyExistsError(err error) bool {
-	msg := err.Error()
-	return strings.Contains(msg, "already exists") ||
-		strings.Contains(msg, "already registered")
+	return client.HasErrorCode(err, client.ErrCodeIDDoesNotMatch)
 }
 
 // isExistingSubjectsImportError reports whether the error indicates the target
 // could not be put into IMPORT mode because it already has existing subjects
-// (SR error code 42205 / "found existing subjects"). This happens when the
-// HTTP initial sync already populated the target, or when replication is
-// restarted against an already-populated target.
+// (SR error code 42205). This happens when the HTTP initial sync already
+// populated the target, or when replication is restarted against an
+// already-populated target.
 func isExistingSubjectsImportError(err error) bool {
-	msg := err.Error()
-	return strings.Contains(msg, "found existing subjects") ||
-		strings.Contains(msg, "42205")
+	return client.HasErrorCode(err, client.ErrCodeOperationNotPermitted)
 }
 
 // schemaAlreadyPresent reports whether the schema in the event is already
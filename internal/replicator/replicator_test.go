@@ -395,13 +395,35 @@ func TestStats_Concurrent(t *testing.T) {
 }
 
 func TestIsAlreadyExistsError(t *testing.T) {
-	if !isAlreadyExistsError(fmt.Errorf("schema already exists")) {
-		t.Error("expected true for 'already exists'")
+	if !isAlreadyExistsError(&client.APIError{StatusCode: 422, ErrorCode: client.ErrCodeIDDoesNotMatch}) {
+		t.Error("expected true for error code 42207")
 	}
-	if !isAlreadyExistsError(fmt.Errorf("schema already registered")) {
-		t.Error("expected true for 'already registered'")
+	if !isAlreadyExistsError(fmt.Errorf("register: %w", &client.APIError{StatusCode: 422, ErrorCode: client.ErrCodeIDDoesNotMatch})) {
+		t.Error("expected true for a wrapped 42207")
 	}
-	if isAlreadyExistsError(fmt.Errorf("connection refused")) {
-		t.Error("expected false for 'connection refused'")
+	if isAlreadyExistsError(&client.APIError{StatusCode: 409, ErrorCode: client.ErrCodeIncompatibleSchema}) {
+		t.Error("expected false for an incompatible schema")
+	}
+	if isAlreadyExistsError(fmt.Errorf("schema already registered")) {
+		t.Error("expected false for a plain error")
+	}
+}
+
+func TestIsNonRetryableError(t *testing.T) {
+	missingRef := &client.APIError{StatusCode: 422, ErrorCode: client.ErrCodeInvalidSchema, Message: "Invalid schema: unresolved reference common.Address"}
+	if isNonRetryableError(missingRef) || !isMissingReferenceError(missingRef) {
+		t.Error("expected a missing reference to be retryable")
+	}
+	if !isNonRetryableError(&client.APIError{StatusCode: 422, ErrorCode: client.ErrCodeInvalidSchema, Message: "Invalid schema"}) {
+		t.Error("expected an invalid schema to be non-retryable")
+	}
+	if !isNonRetryableError(&client.APIError{StatusCode: 400}) {
+		t.Error("expected 400 to be non-retryable")
+	}
+	if isNonRetryableError(fmt.Errorf("connection refused")) {
+		t.Error("expected network errors to be retryable")
+	}
+	if !isExistingSubjectsImportError(&client.APIError{StatusCode: 422, ErrorCode: client.ErrCodeOperationNotPermitted}) {
+		t.Error("expected 42205 to be an existing-subjects import error")
 	}
 }